@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"event_manager/internal/models"
+	repository_imple "event_manager/internal/repository"
+	service_imple "event_manager/internal/service"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// migrateEventLocations gắn location_id cho sự kiện chỉ có tên địa điểm dạng chuỗi.
+// Các tên chỉ khác nhau về hoa thường, dấu hoặc khoảng trắng được gộp về một địa điểm.
+func migrateEventLocations(ctx context.Context, db *mongo.Database) error {
+	eventRepo := repository_imple.NewEventMongoRepository(db)
+	locationRepo := repository_imple.NewLocationMongoRepository(db)
+	locations := service_imple.NewLocationService(locationRepo, eventRepo, service_imple.VenueBuffers{})
+
+	events, err := eventRepo.FindAll(ctx)
+	if err != nil {
+		return fmt.Errorf("list events failed: %w", err)
+	}
+
+	linked, skipped := 0, 0
+	merged := map[string]map[string]struct{}{} // location id -> các cách viết gốc
+	for _, e := range events {
+		if e.LocationID != "" {
+			continue
+		}
+		if models.LocationNameKey(e.Location) == "" {
+			skipped++
+			continue
+		}
+
+		loc, err := locations.Resolve(ctx, e.Location)
+		if err != nil {
+			return fmt.Errorf("resolve location %q of event %s failed: %w", e.Location, e.ID, err)
+		}
+		if err := eventRepo.SetLocation(ctx, e.ID, loc.ID, loc.Name); err != nil {
+			return fmt.Errorf("link event %s failed: %w", e.ID, err)
+		}
+
+		if merged[loc.ID] == nil {
+			merged[loc.ID] = map[string]struct{}{}
+		}
+		merged[loc.ID][strings.TrimSpace(e.Location)] = struct{}{}
+		linked++
+	}
+
+	fmt.Printf("   %d sự kiện được gắn địa điểm, %d sự kiện không có địa điểm\n", linked, skipped)
+	for id, variants := range merged {
+		if len(variants) < 2 {
+			continue
+		}
+		names := make([]string, 0, len(variants))
+		for v := range variants {
+			names = append(names, fmt.Sprintf("%q", v))
+		}
+		sort.Strings(names)
+		fmt.Printf("   gộp %s -> %s\n", strings.Join(names, ", "), id)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	repository_imple "event_manager/internal/repository"
+	service_imple "event_manager/internal/service"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// findGuestDuplicates chạy job phát hiện khách trùng (dùng cho cron);
+// cặp đã đánh dấu "không trùng" được giữ nguyên.
+func findGuestDuplicates(ctx context.Context, db *mongo.Database) error {
+	region, err := phoneRegionFromEnv()
+	if err != nil {
+		return err
+	}
+	registrationRepo := repository_imple.NewRegistrationMongoRepository(db)
+	eventRepo := repository_imple.NewEventMongoRepository(db)
+	dedup := service_imple.NewGuestDedupService(
+		repository_imple.NewGuestRepository(db),
+		registrationRepo,
+		repository_imple.NewReviewMongoRepository(db),
+		repository_imple.NewOrderMongoRepository(db),
+		eventRepo,
+		repository_imple.NewCalendarTokenMongoRepository(db),
+		repository_imple.NewGuestDuplicateMongoRepository(db),
+		repository_imple.NewEventSessionMongoRepository(db),
+		repository_imple.NewSessionRegistrationMongoRepository(db),
+		repository_imple.NewMongoTransactor(db.Client()),
+		region,
+	)
+
+	scan, err := dedup.DetectDuplicates(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("   %d khách, %d cặp nghi trùng (%s)\n", scan.Guests, scan.Pairs, scan.FinishedAt.Sub(scan.StartedAt).Round(time.Millisecond))
+	return nil
+}
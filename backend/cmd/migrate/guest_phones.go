@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"event_manager/internal/models"
+	repository_imple "event_manager/internal/repository"
+	utils "event_manager/util"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// migrateGuestPhones chuẩn hoá số điện thoại của khách về dạng E.164 (vùng mặc định lấy từ
+// PHONE_DEFAULT_REGION) và in ra các số không chuẩn hoá được để sửa tay; các số này giữ nguyên.
+func migrateGuestPhones(ctx context.Context, db *mongo.Database) error {
+	region, err := phoneRegionFromEnv()
+	if err != nil {
+		return err
+	}
+	guestRepo := repository_imple.NewGuestRepository(db)
+
+	var total, updated int
+	var invalid []*models.GuestModel
+	err = guestRepo.StreamAll(ctx, func(g *models.GuestModel) error {
+		total++
+		if strings.TrimSpace(g.Phone) == "" {
+			return nil
+		}
+		phone, err := utils.NormalizePhone(g.Phone, region)
+		if err != nil {
+			invalid = append(invalid, g)
+			return nil
+		}
+		if phone == g.Phone && g.SearchPhone == utils.PhoneSearchKey(phone) {
+			return nil
+		}
+		g.Phone = phone
+		if err := guestRepo.Update(ctx, g); err != nil {
+			return fmt.Errorf("update guest %s failed: %w", g.ID, err)
+		}
+		updated++
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("scan guests failed: %w", err)
+	}
+
+	fmt.Printf("   %d khách, %d số đã chuẩn hoá (vùng %s), %d số không hợp lệ\n", total, updated, region, len(invalid))
+	for _, g := range invalid {
+		fmt.Printf("   ⚠️ %s  %-30s %q\n", g.ID, g.FullName, g.Phone)
+	}
+	return nil
+}
+
+func phoneRegionFromEnv() (string, error) {
+	region := os.Getenv("PHONE_DEFAULT_REGION")
+	if region == "" {
+		region = "VN"
+	}
+	return utils.PhoneRegion(region)
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	repository_imple "event_manager/internal/repository"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// migrateGuestSearch ghi lại từng khách để repository tính khoá tìm kiếm,
+// rồi đồng bộ event_ids từ collection "registrations".
+func migrateGuestSearch(ctx context.Context, db *mongo.Database) error {
+	guestRepo := repository_imple.NewGuestRepository(db)
+	registrationRepo := repository_imple.NewRegistrationMongoRepository(db)
+
+	guests, err := guestRepo.FindAll(ctx)
+	if err != nil {
+		return fmt.Errorf("list guests failed: %w", err)
+	}
+
+	linked := 0
+	for _, g := range guests {
+		if err := guestRepo.Update(ctx, g); err != nil {
+			return fmt.Errorf("update guest %s failed: %w", g.ID, err)
+		}
+
+		regs, err := registrationRepo.FindByGuest(ctx, g.ID)
+		if err != nil {
+			return fmt.Errorf("find registrations of guest %s failed: %w", g.ID, err)
+		}
+		for _, reg := range regs {
+			if err := guestRepo.AddEvent(ctx, g.ID, reg.EventID); err != nil {
+				return fmt.Errorf("link guest %s to event %s failed: %w", g.ID, reg.EventID, err)
+			}
+			linked++
+		}
+	}
+
+	fmt.Printf("   %d khách, %d liên kết sự kiện\n", len(guests), linked)
+	return nil
+}
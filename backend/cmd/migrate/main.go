@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	dbmongo "event_manager/infra/db"
+
+	"github.com/joho/godotenv"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// task là một bước chuyển đổi dữ liệu có thể chạy lại nhiều lần (idempotent)
+type task struct {
+	description string
+	run         func(ctx context.Context, db *mongo.Database) error
+}
+
+// dryRun chỉ in những gì tác vụ sẽ thay đổi (với tác vụ hỗ trợ), không ghi DB
+var dryRun = flag.Bool("dry-run", false, "chỉ in thay đổi, không ghi DB (registration-duplicates)")
+
+var tasks = map[string]task{
+	"event-locations": {
+		description: "chuyển địa điểm dạng chuỗi của sự kiện sang bản ghi locations",
+		run:         migrateEventLocations,
+	},
+	"guest-duplicates": {
+		description: "tìm các cặp khách nghi trùng theo email, số điện thoại và họ tên",
+		run:         findGuestDuplicates,
+	},
+	"guest-phones": {
+		description: "chuẩn hoá số điện thoại của khách về dạng E.164, liệt kê số không hợp lệ",
+		run:         migrateGuestPhones,
+	},
+	"guest-search": {
+		description: "tính khoá tìm kiếm và danh sách sự kiện cho khách mời cũ",
+		run:         migrateGuestSearch,
+	},
+	"promote-admin": {
+		description: "cấp quyền admin cho user -user (đặt mật khẩu từ ADMIN_PASSWORD nếu user chưa có)",
+		run:         promoteAdmin,
+	},
+	"registration-duplicates": {
+		description: "gộp đăng ký trùng (cùng sự kiện, cùng khách) rồi tạo unique index",
+		run:         dedupeRegistrations,
+	},
+	"registration-visits": {
+		description: "dựng lượt check-in/check-out của đăng ký cũ từ lịch sử trạng thái",
+		run:         migrateRegistrationVisits,
+	},
+}
+
+// go run ./cmd/migrate -task guest-search
+func main() {
+	if err := godotenv.Load(); err != nil {
+		fmt.Println("⚠️ Không tìm thấy file .env — sử dụng biến môi trường mặc định.")
+	}
+
+	name := flag.String("task", "", "tên tác vụ migration")
+	timeout := flag.Duration("timeout", 30*time.Minute, "thời gian tối đa cho tác vụ")
+	flag.Parse()
+
+	t, ok := tasks[*name]
+	if !ok {
+		printUsage()
+		os.Exit(2)
+	}
+
+	dbURL := os.Getenv("DB_URL")
+	if dbURL == "" {
+		fmt.Println("⚠️ Thiếu biến môi trường DB_URL")
+		os.Exit(1)
+	}
+
+	client, err := dbmongo.ConnectMongoDB(dbURL)
+	if err != nil {
+		fmt.Printf("❌ Kết nối Mongo thất bại: %v\n", err)
+		os.Exit(1)
+	}
+	defer dbmongo.StopMongoDB(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	fmt.Printf("▶️ Chạy %s: %s\n", *name, t.description)
+	if err := t.run(ctx, client.Database("event_manager")); err != nil {
+		fmt.Printf("❌ %s thất bại: %v\n", *name, err)
+		os.Exit(1)
+	}
+	fmt.Printf("✅ %s hoàn tất\n", *name)
+}
+
+func printUsage() {
+	names := make([]string, 0, len(tasks))
+	for name := range tasks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Println("Cách dùng: migrate -task <tên>")
+	for _, name := range names {
+		fmt.Printf("  %-16s %s\n", name, tasks[name].description)
+	}
+}
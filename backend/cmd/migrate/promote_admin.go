@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"event_manager/internal/domain/entity"
+	"event_manager/internal/models"
+	repository_imple "event_manager/internal/repository"
+	utils "event_manager/util"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// minAdminPasswordLen là độ dài tối thiểu của mật khẩu admin đặt qua ADMIN_PASSWORD
+const minAdminPasswordLen = 12
+
+var adminUsername = flag.String("user", "", "username được cấp quyền admin (tác vụ promote-admin)")
+
+// promoteAdmin cấp quyền admin cho user -user. User chưa có thì được tạo với ADMIN_EMAIL và
+// ADMIN_PASSWORD; user cũ chưa có mật khẩu (tạo trước khi có đăng nhập) thì đặt từ ADMIN_PASSWORD.
+// Mật khẩu đọc từ biến môi trường để không lộ trong lịch sử shell.
+func promoteAdmin(ctx context.Context, db *mongo.Database) error {
+	username := strings.TrimSpace(*adminUsername)
+	if username == "" {
+		return errors.New("thiếu -user")
+	}
+	password := os.Getenv("ADMIN_PASSWORD")
+	userRepo := repository_imple.NewUserMongoRepository(db)
+
+	user, err := userRepo.FindByUsername(ctx, username)
+	if err != nil {
+		return fmt.Errorf("find user failed: %w", err)
+	}
+
+	// 🆕 Chưa có user: tạo mới làm admin
+	if user == nil {
+		email := strings.TrimSpace(os.Getenv("ADMIN_EMAIL"))
+		if email == "" {
+			return fmt.Errorf("user %q chưa tồn tại, cần ADMIN_EMAIL và ADMIN_PASSWORD để tạo", username)
+		}
+		hash, err := adminPasswordHash(password)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		user = new(models.UserModel).UserEntityToModel(&entity.User{
+			Username:     username,
+			Email:        email,
+			FullName:     username,
+			PasswordHash: hash,
+			Status:       "active",
+			Role:         string(entity.RoleAdmin),
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		})
+		if err := userRepo.Insert(ctx, user); err != nil {
+			return fmt.Errorf("insert user failed: %w", err)
+		}
+		fmt.Printf("   đã tạo admin %s (%s)\n", username, user.ID.Hex())
+		return nil
+	}
+
+	// ⬆️ User đã có: nâng quyền, kích hoạt lại và đặt mật khẩu nếu còn thiếu
+	if user.PasswordHash == "" {
+		if user.PasswordHash, err = adminPasswordHash(password); err != nil {
+			return fmt.Errorf("user %q chưa có mật khẩu: %w", username, err)
+		}
+	}
+	user.Role = string(entity.RoleAdmin)
+	user.Status = "active"
+	user.UpdatedAt = time.Now()
+	if err := userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("update user failed: %w", err)
+	}
+	fmt.Printf("   %s (%s) đã là admin\n", username, user.ID.Hex())
+	return nil
+}
+
+func adminPasswordHash(password string) (string, error) {
+	if len(password) < minAdminPasswordLen {
+		return "", fmt.Errorf("ADMIN_PASSWORD phải dài ít nhất %d ký tự", minAdminPasswordLen)
+	}
+	hash, err := utils.HashPassword(password)
+	if err != nil {
+		return "", fmt.Errorf("hash password failed: %w", err)
+	}
+	return hash, nil
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"event_manager/internal/domain/entity"
+	"event_manager/internal/models"
+	repository_imple "event_manager/internal/repository"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// dedupeRegistrations giữ lại một đăng ký cho mỗi cặp (sự kiện, khách) rồi tạo unique index.
+// Đăng ký được giữ là đăng ký có trạng thái hạng cao nhất, hoà thì lấy đăng ký tạo sớm nhất.
+// Như khi gộp khách: đơn vé của đăng ký bị bỏ chuyển sang đăng ký được giữ, các phiên đã chọn
+// được huỷ và trả chỗ; đăng ký bị bỏ được lưu (kèm lịch sử) vào "registrations_archive" trước
+// khi xoá. Sự kiện giới hạn mất đăng ký giữ chỗ được đếm lại. Với -dry-run chỉ in thay đổi.
+func dedupeRegistrations(ctx context.Context, db *mongo.Database) error {
+	registrationRepo := repository_imple.NewRegistrationMongoRepository(db)
+	eventRepo := repository_imple.NewEventMongoRepository(db)
+	orderRepo := repository_imple.NewOrderMongoRepository(db)
+	sessionRepo := repository_imple.NewEventSessionMongoRepository(db)
+	sessionRegRepo := repository_imple.NewSessionRegistrationMongoRepository(db)
+
+	var groups, dropped, ordersMoved, sessionsReleased int
+	recount := map[string]struct{}{}
+	err := registrationRepo.StreamDuplicates(ctx, func(regs []*models.RegistrationModel) error {
+		groups++
+		kept := regs[0]
+		for _, r := range regs[1:] {
+			if entity.ParseRegistrationStatus(r.Status).KeepRank() > entity.ParseRegistrationStatus(kept.Status).KeepRank() {
+				kept = r
+			}
+		}
+		for _, r := range regs {
+			if r == kept {
+				continue
+			}
+			fmt.Printf("   🗑️ %s  sự kiện %s  khách %s  %s (giữ %s %s)\n", r.ID.Hex(), r.EventID, r.GuestID, r.Status, kept.ID.Hex(), kept.Status)
+			dropped++
+			if entity.ParseRegistrationStatus(r.Status).HoldsSeat() {
+				recount[r.EventID] = struct{}{}
+			}
+
+			sessionRegs, err := sessionRegRepo.FindByRegistration(ctx, r.ID.Hex())
+			if err != nil {
+				return fmt.Errorf("find session registrations of %s failed: %w", r.ID.Hex(), err)
+			}
+			if *dryRun {
+				sessionsReleased += len(sessionRegs)
+				continue
+			}
+
+			moved, err := orderRepo.ReassignRegistration(ctx, r.ID.Hex(), kept.ID.Hex())
+			if err != nil {
+				return fmt.Errorf("move orders of %s failed: %w", r.ID.Hex(), err)
+			}
+			ordersMoved += int(moved)
+
+			// 🪑 Huỷ phiên và trả chỗ giống sessionSeats.releaseAll
+			now := time.Now()
+			for _, sr := range sessionRegs {
+				ok, err := sessionRegRepo.Cancel(ctx, sr.ID.Hex(), now)
+				if err != nil {
+					return fmt.Errorf("cancel session registration %s failed: %w", sr.ID.Hex(), err)
+				}
+				if !ok {
+					continue
+				}
+				if err := sessionRepo.ReleaseSeat(ctx, sr.SessionID); err != nil {
+					return fmt.Errorf("release session seat %s failed: %w", sr.SessionID, err)
+				}
+				sessionsReleased++
+			}
+
+			if err := registrationRepo.ArchiveDuplicate(ctx, r, kept.ID.Hex()); err != nil {
+				return fmt.Errorf("archive registration %s failed: %w", r.ID.Hex(), err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("scan registrations failed: %w", err)
+	}
+
+	if *dryRun {
+		fmt.Printf("   [dry-run] %d nhóm trùng, %d đăng ký sẽ bị gộp, %d phiên sẽ được trả chỗ, %d sự kiện cần đếm lại chỗ\n", groups, dropped, sessionsReleased, len(recount))
+		return nil
+	}
+
+	// 🪑 Đếm lại chỗ cho sự kiện giới hạn vừa mất đăng ký giữ chỗ
+	var recounted int
+	for eventID := range recount {
+		event, err := eventRepo.FindByID(ctx, eventID)
+		if err != nil {
+			return fmt.Errorf("get event %s failed: %w", eventID, err)
+		}
+		if event == nil || !event.IsLimited() {
+			continue
+		}
+		taken, err := registrationRepo.CountHoldingSeat(ctx, eventID)
+		if err != nil {
+			return fmt.Errorf("count registrations of %s failed: %w", eventID, err)
+		}
+		if err := eventRepo.SetSeatCounter(ctx, eventID, taken); err != nil {
+			return fmt.Errorf("reset seat counter of %s failed: %w", eventID, err)
+		}
+		recounted++
+	}
+	fmt.Printf("   %d nhóm trùng, %d đăng ký đã gộp, %d đơn chuyển đăng ký, %d phiên trả chỗ, %d sự kiện đếm lại chỗ\n", groups, dropped, ordersMoved, sessionsReleased, recounted)
+
+	if err := registrationRepo.EnsureUniqueGuestIndex(ctx); err != nil {
+		return fmt.Errorf("create unique index failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"event_manager/internal/domain/entity"
+	"event_manager/internal/models"
+	repository_imple "event_manager/internal/repository"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// migrateRegistrationVisits dựng lại mảng "visits" của các đăng ký đã tham dự từ lịch sử chuyển
+// trạng thái. Đăng ký cũ không có lịch sử check-in thì không khôi phục được giờ vào, chỉ in ra để biết.
+func migrateRegistrationVisits(ctx context.Context, db *mongo.Database) error {
+	registrationRepo := repository_imple.NewRegistrationMongoRepository(db)
+
+	var total, updated int
+	var missing []*models.RegistrationModel
+	err := registrationRepo.StreamMissingVisits(ctx, func(reg *models.RegistrationModel) error {
+		total++
+		visits := visitsFromHistory(reg.History)
+		if len(visits) == 0 {
+			missing = append(missing, reg)
+			return nil
+		}
+		reg.Visits = visits
+		if err := registrationRepo.Update(ctx, reg); err != nil {
+			return fmt.Errorf("update registration %s failed: %w", reg.ID.Hex(), err)
+		}
+		updated++
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("scan registrations failed: %w", err)
+	}
+
+	fmt.Printf("   %d đăng ký đã tham dự chưa có visits, %d đã khôi phục, %d không có lịch sử check-in\n", total, updated, len(missing))
+	for _, reg := range missing {
+		fmt.Printf("   ⚠️ %s  sự kiện %s  trạng thái %s\n", reg.ID.Hex(), reg.EventID, reg.Status)
+	}
+	return nil
+}
+
+// visitsFromHistory ghép từng lần check-in với lần check-out kế tiếp thành một lượt
+func visitsFromHistory(history []models.RegistrationTransitionModel) []models.RegistrationVisitModel {
+	var visits []models.RegistrationVisitModel
+	for _, h := range history {
+		switch h.To {
+		case string(entity.RegistrationCheckedIn):
+			visits = append(visits, models.RegistrationVisitModel{CheckedInAt: h.At})
+		case string(entity.RegistrationCheckedOut):
+			if n := len(visits); n > 0 && visits[n-1].CheckedOutAt == nil {
+				at := h.At
+				visits[n-1].CheckedOutAt = &at
+			}
+		}
+	}
+	return visits
+}
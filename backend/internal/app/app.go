@@ -1,10 +1,12 @@
 package app
 
 import (
+	"context"
 	dbmongo "event_manager/infra/db"
 	"fmt"
 	"log"
 	"os"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -15,6 +17,8 @@ type App struct {
 	Modules *Modules
 	Router  *gin.Engine
 	Client  *mongo.Client
+
+	stopJobs context.CancelFunc
 }
 
 // ✅ Khởi tạo toàn bộ ứng dụng
@@ -35,6 +39,8 @@ func NewApplication() *App {
 
 	// Khởi tạo Router
 	router := gin.Default()
+	// Cho phép gin.Context đọc các giá trị middleware gắn vào request context
+	router.ContextWithFallback = true
 	RegisterRoutes(router, modules)
 
 	return &App{
@@ -56,12 +62,24 @@ func (a *App) Start() error {
 	}
 
 	addr := fmt.Sprintf("%s:%s", host, port)
+	jobs, stop := context.WithCancel(context.Background())
+	a.stopJobs = stop
+	go a.sweepExpiredOrders(jobs)
+	a.failUnfinishedImports()
+
 	fmt.Printf("🚀 Server đang chạy tại http://%s\n", addr)
 	return a.Router.Run(addr)
 }
 
 // ✅ Dừng app và đóng kết nối DB
 func (a *App) Stop() {
+	if a.stopJobs != nil {
+		a.stopJobs()
+	}
+	// Chờ các job nhập khách lưu trạng thái trước khi ngắt kết nối DB
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	a.Modules.GuestService.StopImports(ctx)
+	cancel()
 	if a.Client == nil {
 		return
 	}
@@ -71,3 +89,43 @@ func (a *App) Stop() {
 		fmt.Println("🛑 Đã ngắt kết nối MongoDB thành công.")
 	}
 }
+
+// failUnfinishedImports đánh dấu thất bại các job nhập khách bị bỏ dở khi tiến trình trước dừng
+func (a *App) failUnfinishedImports() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	n, err := a.Modules.GuestService.FailUnfinishedImports(ctx)
+	if err != nil {
+		log.Println("⚠️ Lỗi khi đóng các job nhập khách dở dang:", err)
+		return
+	}
+	if n > 0 {
+		fmt.Printf("🧹 Đã đánh dấu thất bại %d job nhập khách dở dang.\n", n)
+	}
+}
+
+// sweepExpiredOrders định kỳ trả vé của các đơn hết thời gian giữ mà chưa thanh toán
+func (a *App) sweepExpiredOrders(ctx context.Context) {
+	interval := durationFromEnv("ORDER_SWEEP_INTERVAL")
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := a.Modules.OrderService.ExpireReservations(ctx)
+			if err != nil {
+				log.Println("⚠️ Lỗi khi trả vé của đơn hết hạn:", err)
+			}
+			if n > 0 {
+				fmt.Printf("🎟️ Đã trả vé của %d đơn hết hạn.\n", n)
+			}
+		}
+	}
+}
@@ -2,12 +2,21 @@ package app
 
 import (
     "fmt"
+    "os"
+    "strconv"
+    "strings"
+    "time"
 
     service_interface "event_manager/internal/domain/service"
+    "event_manager/internal/export"
     v1handler "event_manager/internal/handler/v1"
+    "event_manager/internal/ical"
+    "event_manager/internal/middleware"
+    "event_manager/internal/payment"
     repository_imple "event_manager/internal/repository"
     service_imple "event_manager/internal/service"
     "event_manager/internal/storage"
+    utils "event_manager/util"
 
     "go.mongodb.org/mongo-driver/mongo"
 )
@@ -17,13 +26,40 @@ type Modules struct {
     UserService         service_interface.UserService
     GuestService        service_interface.GuestService
     RegistrationService service_interface.RegistrationService
+    AuthService         service_interface.AuthService
+    ReviewService       service_interface.ReviewService
+    LocationService     service_interface.LocationService
+    CalendarService     service_interface.CalendarService
+    GuestDedupService   service_interface.GuestDedupService
+    KioskService        service_interface.KioskService
+    EventSessionService service_interface.EventSessionService
+    ProfileService      service_interface.ProfileService
+    TicketService       service_interface.TicketService
+    OrderService        service_interface.OrderService
+    PaymentProvider     payment.Provider // nil khi chưa cấu hình cổng thanh toán
+    // FakeCheckout bật trang thanh toán giả lập công khai, chỉ dùng khi phát triển
+    FakeCheckout        bool
     MediaStorage        storage.ObjectStorage
 
+	V1AuthHandler         *v1handler.AuthHandler
 	V1EventHandler        *v1handler.EventHandler
 	V1UserHandler         *v1handler.UserHandler
 	V1GuestHandler        *v1handler.GuestHandler
 	V1RegistrationHandler *v1handler.RegistrationHandler
 	V1AnalyticsHandler    *v1handler.AnalyticsHandler
+	V1ReviewHandler       *v1handler.ReviewHandler
+	V1LocationHandler     *v1handler.LocationHandler
+	V1CalendarHandler     *v1handler.CalendarHandler
+	V1KioskHandler        *v1handler.KioskHandler
+	V1EventSessionHandler *v1handler.EventSessionHandler
+	V1ProfileHandler      *v1handler.ProfileHandler
+	V1TicketHandler       *v1handler.TicketHandler
+	V1OrderHandler        *v1handler.OrderHandler
+
+	// Giới hạn tần suất của mỗi thiết bị kiosk
+	KioskLookupLimiter  *middleware.RateLimiter
+	KioskCheckInLimiter *middleware.RateLimiter
+
 	db                    *mongo.Client
 }
 
@@ -31,17 +67,70 @@ func NewModules(db *mongo.Client) *Modules {
 	// Initialize repositories
 	dbSavedata := db.Database("event_manager")
 	eventRepo := repository_imple.NewEventMongoRepository(dbSavedata)
+	eventSeriesRepo := repository_imple.NewEventSeriesMongoRepository(dbSavedata)
 	registrationRepo := repository_imple.NewRegistrationMongoRepository(dbSavedata)
 	guestRepo := repository_imple.NewGuestRepository(dbSavedata)
 	userRepo := repository_imple.NewUserMongoRepository(dbSavedata)
 	aggregateRepo := repository_imple.NewAggregateRepo(dbSavedata)
+	refreshTokenRepo := repository_imple.NewRefreshTokenMongoRepository(dbSavedata)
+	reviewRepo := repository_imple.NewReviewMongoRepository(dbSavedata)
+	locationRepo := repository_imple.NewLocationMongoRepository(dbSavedata)
+	calendarTokenRepo := repository_imple.NewCalendarTokenMongoRepository(dbSavedata)
+	guestImportJobRepo := repository_imple.NewGuestImportJobMongoRepository(dbSavedata)
+	guestDuplicateRepo := repository_imple.NewGuestDuplicateMongoRepository(dbSavedata)
+	checkInSyncRepo := repository_imple.NewCheckInSyncMongoRepository(dbSavedata)
+	kioskSessionRepo := repository_imple.NewKioskSessionMongoRepository(dbSavedata)
+	kioskAuditRepo := repository_imple.NewKioskAuditMongoRepository(dbSavedata)
+	eventSessionRepo := repository_imple.NewEventSessionMongoRepository(dbSavedata)
+	sessionRegistrationRepo := repository_imple.NewSessionRegistrationMongoRepository(dbSavedata)
+	profileRepo := repository_imple.NewProfileMongoRepository(dbSavedata)
+	profileAssignmentRepo := repository_imple.NewProfileAssignmentMongoRepository(dbSavedata)
+	ticketTypeRepo := repository_imple.NewTicketTypeMongoRepository(dbSavedata)
+	orderRepo := repository_imple.NewOrderMongoRepository(dbSavedata)
+	transactor := repository_imple.NewMongoTransactor(db)
+
+	jwtSecret := os.Getenv("JWT_SECRET")
+	if jwtSecret == "" {
+		panic("⚠️ Thiếu biến môi trường JWT_SECRET")
+	}
+	requireStrongSecret("JWT_SECRET", jwtSecret)
+	requireStrongSecret("TICKET_SECRET", os.Getenv("TICKET_SECRET"))
+	requireStrongSecret("PAYMENT_WEBHOOK_SECRET", os.Getenv("PAYMENT_WEBHOOK_SECRET"))
+	// Vé QR ký bằng secret riêng; mặc định suy ra từ JWT_SECRET để vé không dùng được như access token
+	ticketSecret := envOrDefault("TICKET_SECRET", jwtSecret+":ticket")
+	phoneRegion, err := utils.PhoneRegion(envOrDefault("PHONE_DEFAULT_REGION", "VN"))
+	if err != nil {
+		panic(fmt.Errorf("invalid PHONE_DEFAULT_REGION: %w", err))
+	}
+	paymentProvider := paymentProviderFromEnv(jwtSecret)
+	fakeCheckout := boolFromEnv("PAYMENT_FAKE_CHECKOUT")
+	if _, isFake := paymentProvider.(*payment.FakeProvider); fakeCheckout && !isFake {
+		panic("⚠️ PAYMENT_FAKE_CHECKOUT chỉ dùng được với PAYMENT_PROVIDER=fake")
+	}
 
     // Initialize services
-    eventService := service_imple.NewEventService(eventRepo, registrationRepo)
+    locationService := service_imple.NewLocationService(locationRepo, eventRepo, service_imple.VenueBuffers{
+        Setup:    durationFromEnv("VENUE_SETUP_BUFFER"),
+        Teardown: durationFromEnv("VENUE_TEARDOWN_BUFFER"),
+    })
+    eventService := service_imple.NewEventService(eventRepo, registrationRepo, orderRepo, guestRepo, eventSeriesRepo, locationService, transactor)
     userService := service_imple.NewUserService(userRepo)
-    registrationService := service_imple.NewRegistrationService(registrationRepo)
-    guestService := service_imple.NewGuestService(guestRepo, registrationRepo)
+    registrationService := service_imple.NewRegistrationService(registrationRepo, eventRepo, guestRepo, eventSessionRepo, sessionRegistrationRepo, checkInSyncRepo, ticketTypeRepo, ticketSecret)
+    guestService := service_imple.NewGuestService(guestRepo, registrationRepo, eventRepo, ticketTypeRepo, guestImportJobRepo, phoneRegion)
+    kioskService := service_imple.NewKioskService(kioskSessionRepo, kioskAuditRepo, eventRepo, guestRepo, registrationRepo, registrationService)
+    guestDedupService := service_imple.NewGuestDedupService(guestRepo, registrationRepo, reviewRepo, orderRepo, eventRepo, calendarTokenRepo, guestDuplicateRepo, eventSessionRepo, sessionRegistrationRepo, transactor, phoneRegion)
+    eventSessionService := service_imple.NewEventSessionService(eventSessionRepo, sessionRegistrationRepo, eventRepo, registrationRepo, guestRepo)
+    profileService := service_imple.NewProfileService(profileRepo, profileAssignmentRepo, eventRepo, eventSessionRepo)
+    ticketService := service_imple.NewTicketService(ticketTypeRepo, orderRepo, eventRepo)
+    orderService := service_imple.NewOrderService(orderRepo, ticketTypeRepo, eventRepo, registrationRepo, guestRepo, paymentProvider, durationFromEnv("ORDER_HOLD_TTL"))
     aggregateService := service_imple.NewAggregateServiceImpl(aggregateRepo)
+    reviewService := service_imple.NewReviewService(reviewRepo, registrationRepo, eventRepo, guestRepo)
+    calendarService := service_imple.NewCalendarService(calendarTokenRepo, eventRepo, registrationRepo, guestRepo, userRepo)
+    authService := service_imple.NewAuthService(userRepo, refreshTokenRepo, service_imple.AuthConfig{
+        JWTSecret:       jwtSecret,
+        AccessTokenTTL:  durationFromEnv("ACCESS_TOKEN_TTL"),
+        RefreshTokenTTL: durationFromEnv("REFRESH_TOKEN_TTL"),
+    })
 
     mediaStorage, err := storage.NewMinioStorageFromEnv()
     if err != nil {
@@ -49,11 +138,27 @@ func NewModules(db *mongo.Client) *Modules {
     }
 
     // Initialize handlers
-    v1EventHandler := v1handler.NewEventHandler(eventService, mediaStorage)
+    v1AuthHandler := v1handler.NewAuthHandler(authService)
+    v1EventHandler := v1handler.NewEventHandler(eventService, profileService, mediaStorage)
 	v1UserHandler := v1handler.NewUserHandler(userService)
-	v1GuestHandler := v1handler.NewGuestHandler(guestService)
-	v1RegistrationHandler := v1handler.NewRegistrationHandler(registrationService)
+	v1GuestHandler := v1handler.NewGuestHandler(guestService, guestDedupService)
+	v1RegistrationHandler := v1handler.NewRegistrationHandler(registrationService, export.Options{
+		Location: locationFromEnv("EXPORT_TIMEZONE", "Asia/Ho_Chi_Minh"),
+		FontPath: os.Getenv("EXPORT_PDF_FONT"),
+	})
+	v1KioskHandler := v1handler.NewKioskHandler(kioskService)
+	v1EventSessionHandler := v1handler.NewEventSessionHandler(eventSessionService)
+	v1ProfileHandler := v1handler.NewProfileHandler(profileService, mediaStorage)
+	v1TicketHandler := v1handler.NewTicketHandler(ticketService)
+	v1OrderHandler := v1handler.NewOrderHandler(orderService, paymentProvider)
 	v1AnalyticsHandler := v1handler.NewAnalyticsHandler(aggregateService)
+	v1ReviewHandler := v1handler.NewReviewHandler(reviewService)
+	v1LocationHandler := v1handler.NewLocationHandler(locationService)
+	v1CalendarHandler := v1handler.NewCalendarHandler(eventService, calendarService, ical.Options{
+		ProdID:    "-//Event Manager//Events//VI",
+		UIDDomain: envOrDefault("CALENDAR_UID_DOMAIN", "event-manager"),
+		Location:  locationFromEnv("CALENDAR_TIMEZONE", "Asia/Ho_Chi_Minh"),
+	})
 
 	// Return the assembled module container
     return &Modules{
@@ -61,14 +166,123 @@ func NewModules(db *mongo.Client) *Modules {
         UserService:         userService,
         GuestService:        guestService,
         RegistrationService: registrationService,
+        AuthService:         authService,
+        ReviewService:       reviewService,
+        LocationService:     locationService,
+        CalendarService:     calendarService,
+        GuestDedupService:   guestDedupService,
+        KioskService:        kioskService,
+        EventSessionService: eventSessionService,
+        ProfileService:      profileService,
+        TicketService:       ticketService,
+        OrderService:        orderService,
+        PaymentProvider:     paymentProvider,
+        FakeCheckout:        fakeCheckout,
         MediaStorage:        mediaStorage,
 
+		V1AuthHandler:         v1AuthHandler,
 		V1EventHandler:        v1EventHandler,
 		V1UserHandler:         v1UserHandler,
 		V1GuestHandler:        v1GuestHandler,
 		V1RegistrationHandler: v1RegistrationHandler,
 		V1AnalyticsHandler:    v1AnalyticsHandler,
+		V1ReviewHandler:       v1ReviewHandler,
+		V1LocationHandler:     v1LocationHandler,
+		V1CalendarHandler:     v1CalendarHandler,
+		V1KioskHandler:        v1KioskHandler,
+		V1EventSessionHandler: v1EventSessionHandler,
+		V1ProfileHandler:      v1ProfileHandler,
+		V1TicketHandler:       v1TicketHandler,
+		V1OrderHandler:        v1OrderHandler,
+
+		KioskLookupLimiter:  middleware.NewRateLimiter(intFromEnv("KIOSK_LOOKUP_PER_MINUTE", 30), time.Minute),
+		KioskCheckInLimiter: middleware.NewRateLimiter(intFromEnv("KIOSK_CHECKIN_PER_MINUTE", 10), time.Minute),
 
 		db: db,
 	}
 }
+
+// durationFromEnv đọc time.Duration (vd "15m", "720h"); trả về 0 nếu thiếu hoặc sai định dạng
+func durationFromEnv(key string) time.Duration {
+	d, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return 0
+	}
+	return d
+}
+
+// envOrDefault đọc biến môi trường, trả về def nếu thiếu
+func envOrDefault(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+// intFromEnv đọc số nguyên dương, trả về def nếu thiếu hoặc sai định dạng
+func intFromEnv(key string, def int) int {
+	n, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || n <= 0 {
+		return def
+	}
+	return n
+}
+
+// boolFromEnv đọc cờ bật / tắt (vd "true", "1"); thiếu hoặc sai định dạng coi là tắt
+func boolFromEnv(key string) bool {
+	b, err := strconv.ParseBool(os.Getenv(key))
+	return err == nil && b
+}
+
+// minSecretLen là độ dài tối thiểu của secret ký token khi chạy production
+const minSecretLen = 32
+
+// placeholderSecrets là các giá trị mẫu trong .env, ai cũng biết nên không được dùng thật
+var placeholderSecrets = map[string]struct{}{
+	"change-me-in-production": {},
+	"secret":                  {},
+	"changeme":                {},
+}
+
+// requireStrongSecret dừng app khi APP_ENV=production mà secret còn là giá trị mẫu hoặc quá ngắn:
+// TICKET_SECRET và secret webhook mặc định suy ra từ JWT_SECRET nên lộ một là giả mạo được cả ba.
+// Secret để trống (dùng giá trị suy ra) thì bỏ qua.
+func requireStrongSecret(key, value string) {
+	if value == "" || !strings.EqualFold(os.Getenv("APP_ENV"), "production") {
+		return
+	}
+	if _, ok := placeholderSecrets[strings.ToLower(strings.TrimSpace(value))]; ok {
+		panic(fmt.Sprintf("⚠️ %s đang là giá trị mẫu, đặt secret ngẫu nhiên trước khi chạy production", key))
+	}
+	if len(value) < minSecretLen {
+		panic(fmt.Sprintf("⚠️ %s phải dài ít nhất %d ký tự khi APP_ENV=production", key, minSecretLen))
+	}
+}
+
+// locationFromEnv đọc múi giờ IANA (vd "Asia/Ho_Chi_Minh"); sai tên thì panic để lộ lỗi cấu hình sớm
+func locationFromEnv(key, def string) *time.Location {
+	loc, err := time.LoadLocation(envOrDefault(key, def))
+	if err != nil {
+		panic(fmt.Errorf("invalid %s: %w", key, err))
+	}
+	return loc
+}
+
+// paymentProviderFromEnv chọn cổng thanh toán theo PAYMENT_PROVIDER; để trống thì chỉ bán được vé miễn phí.
+// Cổng giả lập "fake" không thu tiền thật nên không được bật khi APP_ENV=production
+func paymentProviderFromEnv(jwtSecret string) payment.Provider {
+	switch name := os.Getenv("PAYMENT_PROVIDER"); name {
+	case "":
+		return nil
+	case "fake":
+		if strings.EqualFold(os.Getenv("APP_ENV"), "production") {
+			panic("⚠️ PAYMENT_PROVIDER=fake không được dùng khi APP_ENV=production")
+		}
+		return payment.NewFakeProvider(
+			envOrDefault("PAYMENT_WEBHOOK_SECRET", jwtSecret+":payment"),
+			envOrDefault("PAYMENT_CHECKOUT_BASE_URL", "http://localhost:8080/api/v1/payments/fake"),
+		)
+	default:
+		panic(fmt.Errorf("unsupported PAYMENT_PROVIDER %q", name))
+	}
+}
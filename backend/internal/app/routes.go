@@ -1,61 +1,192 @@
 package app
 
-import "github.com/gin-gonic/gin"
+import (
+	"event_manager/internal/domain/entity"
+	"event_manager/internal/middleware"
+	utils "event_manager/util"
+
+	"github.com/gin-gonic/gin"
+)
 
 func RegisterRoutes(r *gin.Engine, m *Modules) {
+	requireAuth := middleware.RequireAuth(m.AuthService)
+	can := middleware.RequirePermission
+	requireKiosk := middleware.RequireKiosk(m.KioskService)
+	perKiosk := func(c *gin.Context) string { return utils.KioskFromContext(c.Request.Context()).ID }
+
 	api := r.Group("/api")
 	{
 		// api version 1 using handler version 1
 		v1 := api.Group("/v1")
 		{
-			events := v1.Group("/events")
+			// public: đăng ký / đăng nhập không cần token
+			auth := v1.Group("/auth")
+			{
+				auth.POST("/register", m.V1UserHandler.CreateUser)
+				auth.POST("/login", m.V1AuthHandler.Login)
+				auth.POST("/refresh", m.V1AuthHandler.Refresh)
+				auth.POST("/logout", m.V1AuthHandler.Logout)
+				auth.GET("/me", requireAuth, m.V1AuthHandler.Me)
+			}
+
+			events := v1.Group("/events", requireAuth)
+			{
+				events.POST("/", can(entity.PermEventWrite), m.V1EventHandler.CreateEvent)
+				events.GET("/", can(entity.PermEventRead), m.V1EventHandler.ListEvents)
+				events.POST("/import/ical", can(entity.PermEventWrite), m.V1CalendarHandler.ImportEvents)
+				events.GET("/:id", can(entity.PermEventRead), m.V1EventHandler.GetEventByID)
+				events.GET("/:id/statistics", can(entity.PermEventRead), m.V1EventHandler.GetStatistics)
+				events.GET("/:id/occurrences", can(entity.PermEventRead), m.V1EventHandler.ListOccurrences)
+				events.GET("/:id/ical", can(entity.PermEventRead), m.V1CalendarHandler.ExportEvent)
+				events.GET("/:id/guests/export", can(entity.PermGuestRead), m.V1RegistrationHandler.ExportGuests)
+				events.PATCH("/auto-update", can(entity.PermEventWrite), m.V1EventHandler.AutoUpdateStatus)
+				events.PUT("/:id", can(entity.PermEventWrite), m.V1EventHandler.UpdateEvent)
+				events.DELETE("/:id", can(entity.PermEventWrite), m.V1EventHandler.DeleteEvent)
+				events.PUT("/:id/organizers", can(entity.PermEventWrite), m.V1EventHandler.SetOrganizers)
+
+				events.GET("/:id/reviews", can(entity.PermReviewRead), m.V1ReviewHandler.List)
+				events.POST("/:id/reviews", can(entity.PermReviewWrite), m.V1ReviewHandler.Create)
+				events.GET("/:id/reviews/:reviewId", can(entity.PermReviewRead), m.V1ReviewHandler.GetByID)
+				events.PUT("/:id/reviews/:reviewId", can(entity.PermReviewWrite), m.V1ReviewHandler.Update)
+				events.DELETE("/:id/reviews/:reviewId", can(entity.PermReviewWrite), m.V1ReviewHandler.Delete)
+				events.GET("/:id/sessions", can(entity.PermEventRead), m.V1EventSessionHandler.List)
+				events.POST("/:id/sessions", can(entity.PermEventWrite), m.V1EventSessionHandler.Create)
+				events.GET("/:id/sessions/:sessionId", can(entity.PermEventRead), m.V1EventSessionHandler.GetByID)
+				events.PUT("/:id/sessions/:sessionId", can(entity.PermEventWrite), m.V1EventSessionHandler.Update)
+				events.DELETE("/:id/sessions/:sessionId", can(entity.PermEventWrite), m.V1EventSessionHandler.Delete)
+				events.GET("/:id/sessions/:sessionId/registrations", can(entity.PermRegistrationRead), m.V1EventSessionHandler.ListRegistrations)
+				events.POST("/:id/sessions/:sessionId/registrations", can(entity.PermRegistrationWrite), m.V1EventSessionHandler.Register)
+				events.DELETE("/:id/sessions/:sessionId/registrations/:registrationId", can(entity.PermRegistrationWrite), m.V1EventSessionHandler.CancelRegistration)
+				events.PUT("/:id/sessions/:sessionId/registrations/:registrationId/check-in", can(entity.PermCheckIn), m.V1EventSessionHandler.CheckIn)
+				events.GET("/:id/profiles", can(entity.PermProfileRead), m.V1ProfileHandler.ListByEvent)
+				events.POST("/:id/profiles", can(entity.PermEventWrite), m.V1ProfileHandler.Assign)
+				events.DELETE("/:id/profiles/:profileId", can(entity.PermEventWrite), m.V1ProfileHandler.Unassign)
+				events.POST("/:id/kiosks", can(entity.PermCheckIn), m.V1KioskHandler.CreateSession)
+				events.GET("/:id/kiosks", can(entity.PermCheckIn), m.V1KioskHandler.ListSessions)
+				events.DELETE("/:id/kiosks/:kioskId", can(entity.PermCheckIn), m.V1KioskHandler.RevokeSession)
+				events.GET("/:id/kiosks/:kioskId/audit", can(entity.PermCheckIn), m.V1KioskHandler.Audit)
+				events.GET("/:id/ticket-types", can(entity.PermEventRead), m.V1TicketHandler.List)
+				events.POST("/:id/ticket-types", can(entity.PermEventWrite), m.V1TicketHandler.Create)
+				events.GET("/:id/ticket-types/:ticketTypeId", can(entity.PermEventRead), m.V1TicketHandler.GetByID)
+				events.PUT("/:id/ticket-types/:ticketTypeId", can(entity.PermEventWrite), m.V1TicketHandler.Update)
+				events.DELETE("/:id/ticket-types/:ticketTypeId", can(entity.PermEventWrite), m.V1TicketHandler.Delete)
+				events.GET("/:id/orders", can(entity.PermRegistrationRead), m.V1OrderHandler.List)
+				events.POST("/:id/orders", can(entity.PermRegistrationWrite), m.V1OrderHandler.Create)
+				events.GET("/:id/orders/:orderId", can(entity.PermRegistrationRead), m.V1OrderHandler.GetByID)
+				events.DELETE("/:id/orders/:orderId", can(entity.PermRegistrationWrite), m.V1OrderHandler.Cancel)
+			}
+
+			// feed .ics: ứng dụng lịch không gửi được Authorization, token trong URL là thông tin xác thực
+			calendars := v1.Group("/calendars")
+			{
+				calendars.GET("/:token", m.V1CalendarHandler.Feed)
+				calendars.POST("/token", requireAuth, m.V1CalendarHandler.IssueMyToken)
+				calendars.DELETE("/token", requireAuth, m.V1CalendarHandler.RevokeMyToken)
+			}
+
+			locations := v1.Group("/locations", requireAuth)
 			{
-				events.POST("/", m.V1EventHandler.CreateEvent)
-				events.GET("/", m.V1EventHandler.ListEvents)
-				events.GET("/:id", m.V1EventHandler.GetEventByID)
-				events.GET("/:id/statistics", m.V1EventHandler.GetStatistics)
-				events.PATCH("/auto-update", m.V1EventHandler.AutoUpdateStatus)
-				events.PUT("/:id", m.V1EventHandler.UpdateEvent)
-				events.DELETE("/:id", m.V1EventHandler.DeleteEvent)
+				locations.POST("/", can(entity.PermLocationWrite), m.V1LocationHandler.Create)
+				locations.GET("/", can(entity.PermLocationRead), m.V1LocationHandler.List)
+				locations.GET("/:id", can(entity.PermLocationRead), m.V1LocationHandler.GetByID)
+				locations.GET("/:id/capacity", can(entity.PermLocationRead), m.V1LocationHandler.CheckCapacity)
+				locations.GET("/:id/availability", can(entity.PermLocationRead), m.V1LocationHandler.Availability)
+				locations.PUT("/:id", can(entity.PermLocationWrite), m.V1LocationHandler.Update)
+				locations.DELETE("/:id", can(entity.PermLocationWrite), m.V1LocationHandler.Delete)
 			}
 
-			users := v1.Group("/users")
+			profiles := v1.Group("/profiles", requireAuth)
 			{
-				users.POST("/", m.V1UserHandler.CreateUser)
-				users.PUT("/:id", m.V1UserHandler.UpdateProfile)
+				profiles.POST("/", can(entity.PermProfileWrite), m.V1ProfileHandler.Create)
+				profiles.GET("/", can(entity.PermProfileRead), m.V1ProfileHandler.List)
+				profiles.GET("/:id", can(entity.PermProfileRead), m.V1ProfileHandler.GetByID)
+				profiles.PUT("/:id", can(entity.PermProfileWrite), m.V1ProfileHandler.Update)
+				profiles.POST("/:id/photo", can(entity.PermProfileWrite), m.V1ProfileHandler.UploadPhoto)
+				profiles.DELETE("/:id", can(entity.PermProfileWrite), m.V1ProfileHandler.Delete)
+			}
+
+			users := v1.Group("/users", requireAuth)
+			{
+				users.POST("/", can(entity.PermUserManage), m.V1UserHandler.CreateUser)
+				users.PUT("/:id", middleware.RequireSelfOrPermission("id", entity.PermUserManage), m.V1UserHandler.UpdateProfile)
+				users.PUT("/:id/role", can(entity.PermUserManage), m.V1UserHandler.ChangeRole)
 				// users.PUT("/:id/activate", m.V1UserHandler.ActivateUser)
 				// users.PUT("/:id/deactivate", m.V1UserHandler.DeactivateUser)
-				users.GET("/", m.V1UserHandler.ListUsers)
-				users.GET("/:id", m.V1UserHandler.GetUserByID)
+				users.GET("/", can(entity.PermUserManage), m.V1UserHandler.ListUsers)
+				users.GET("/:id", middleware.RequireSelfOrPermission("id", entity.PermUserManage), m.V1UserHandler.GetUserByID)
+			}
+
+			guests := v1.Group("/guests", requireAuth)
+			{
+				guests.POST("/", can(entity.PermGuestWrite), m.V1GuestHandler.CreateGuest)
+				guests.PUT("/:id", can(entity.PermGuestWrite), m.V1GuestHandler.UpdateGuest)
+				guests.DELETE("/:id", can(entity.PermGuestWrite), m.V1GuestHandler.DeleteGuest)
+				guests.GET("/", can(entity.PermGuestRead), m.V1GuestHandler.ListGuests)
+				guests.GET("/search", can(entity.PermGuestRead), m.V1GuestHandler.FindGuestByContact)
+				guests.POST("/import", can(entity.PermGuestWrite), m.V1GuestHandler.ImportGuests)
+				guests.GET("/import/:job_id", can(entity.PermGuestWrite), m.V1GuestHandler.GetImportJob)
+				guests.GET("/duplicates", can(entity.PermGuestRead), m.V1GuestHandler.ListDuplicates)
+				guests.POST("/duplicates/scan", can(entity.PermGuestWrite), m.V1GuestHandler.ScanDuplicates)
+				guests.POST("/duplicates/:id/dismiss", can(entity.PermGuestWrite), m.V1GuestHandler.DismissDuplicate)
+				guests.POST("/merge", can(entity.PermGuestWrite), m.V1GuestHandler.MergeGuests)
+				guests.GET("/:id", can(entity.PermGuestRead), m.V1GuestHandler.GetGuestByID)
+				guests.GET("/:id/events", can(entity.PermGuestRead), m.V1GuestHandler.ListGuestEvents)
+				guests.POST("/:id/calendar-token", can(entity.PermGuestWrite), m.V1CalendarHandler.IssueGuestToken)
+				guests.DELETE("/:id/calendar-token", can(entity.PermGuestWrite), m.V1CalendarHandler.RevokeGuestToken)
+			}
+
+			registrations := v1.Group("/registrations", requireAuth)
+			{
+				registrations.POST("/", can(entity.PermRegistrationWrite), m.V1RegistrationHandler.Register)
+				registrations.GET("/", can(entity.PermRegistrationRead), m.V1RegistrationHandler.List)
+				registrations.GET("/:id", can(entity.PermRegistrationRead), m.V1RegistrationHandler.GetByID)
+				registrations.GET("/:id/history", can(entity.PermRegistrationRead), m.V1RegistrationHandler.History)
+				registrations.PUT("/:id/status", can(entity.PermRegistrationWrite), m.V1RegistrationHandler.ChangeStatus)
+				registrations.PUT("/:id/check-in", can(entity.PermCheckIn), m.V1RegistrationHandler.CheckIn)
+				registrations.PUT("/:id/check-out", can(entity.PermCheckIn), m.V1RegistrationHandler.CheckOut)
+				registrations.PUT("/:id/cancel", can(entity.PermRegistrationWrite), m.V1RegistrationHandler.Cancel)
+				registrations.GET("/:id/ticket.png", can(entity.PermRegistrationRead), m.V1RegistrationHandler.Ticket)
+				registrations.POST("/:id/ticket/reissue", can(entity.PermRegistrationWrite), m.V1RegistrationHandler.ReissueTicket)
+				registrations.GET("/:id/sessions", can(entity.PermRegistrationRead), m.V1EventSessionHandler.Schedule)
+			}
+
+			// thiết bị kiosk tự check-in, xác thực bằng token kiosk thay cho user
+			kiosk := v1.Group("/kiosk", requireKiosk)
+			{
+				kiosk.GET("/session", m.V1KioskHandler.Session)
+				kiosk.GET("/guests", middleware.RateLimit(m.KioskLookupLimiter, perKiosk, m.V1KioskHandler.RateLimited), m.V1KioskHandler.LookupGuests)
+				kiosk.POST("/check-in", middleware.RateLimit(m.KioskCheckInLimiter, perKiosk, m.V1KioskHandler.RateLimited), m.V1KioskHandler.CheckIn)
 			}
 
-			guests := v1.Group("/guests")
+			// cổng thanh toán gọi về không kèm token user, webhook được xác thực bằng chữ ký
+			payments := v1.Group("/payments")
 			{
-				guests.POST("/", m.V1GuestHandler.CreateGuest)
-				guests.PUT("/:id", m.V1GuestHandler.UpdateGuest)
-				guests.DELETE("/:id", m.V1GuestHandler.DeleteGuest)
-				guests.GET("/", m.V1GuestHandler.ListGuests)
-				guests.GET("/search", m.V1GuestHandler.FindGuestByContact)
-				guests.GET("/:id", m.V1GuestHandler.GetGuestByID)
+				payments.POST("/webhook", m.V1OrderHandler.Webhook)
+				// trang thanh toán giả lập ai có payment ID cũng gọi được: chỉ bật khi phát triển
+				if m.FakeCheckout {
+					payments.POST("/fake/:paymentId", m.V1OrderHandler.FakeCheckout)
+				}
 			}
 
-			registrations := v1.Group("/registrations")
+			checkIn := v1.Group("/check-in", requireAuth, can(entity.PermCheckIn))
 			{
-				registrations.POST("/", m.V1RegistrationHandler.Register)
-				registrations.GET("/", m.V1RegistrationHandler.List)
-				registrations.GET("/:id", m.V1RegistrationHandler.GetByID)
-				registrations.PUT("/:id/check-in", m.V1RegistrationHandler.CheckIn)
-				registrations.PUT("/:id/cancel", m.V1RegistrationHandler.Cancel)
+				checkIn.POST("/scan", m.V1RegistrationHandler.ScanTicket)
+				checkIn.POST("/sync", m.V1RegistrationHandler.SyncCheckIns)
 			}
 
-			analytics := v1.Group("/analytics")
+			analytics := v1.Group("/analytics", requireAuth, can(entity.PermAnalyticsRead))
 			{
 				analytics.GET("/events", m.V1AnalyticsHandler.GetGuestStatsByEvent)
 				analytics.GET("/event-types", m.V1AnalyticsHandler.GetGuestStatsByEventType)
 				analytics.GET("/events/:id/guests", m.V1AnalyticsHandler.GetGuestCountByEvent)
+				analytics.GET("/events/:id/occupancy", m.V1AnalyticsHandler.GetOccupancyByEvent)
+				analytics.GET("/events/:id/dwell-time", m.V1AnalyticsHandler.GetDwellTimeByEvent)
+				analytics.GET("/events/:id/sessions", m.V1AnalyticsHandler.GetGuestStatsBySession)
+				analytics.GET("/sessions/:id/guests", m.V1AnalyticsHandler.GetGuestCountBySession)
 				analytics.GET("/events/top", m.V1AnalyticsHandler.GetTopEventsByGuests)
 				analytics.GET("/locations", m.V1AnalyticsHandler.GetGuestStatsByLocation)
 				analytics.GET("/participation", m.V1AnalyticsHandler.GetParticipationTrend)
+				analytics.GET("/occupancy", m.V1AnalyticsHandler.GetLiveOccupancy)
 			}
 		}
 	}
@@ -20,6 +20,7 @@ type EventTypeGuestStat struct {
 
 // LocationGuestStat represents guest counts grouped by event location.
 type LocationGuestStat struct {
+	LocationID  string // Rỗng với sự kiện cũ chưa gắn địa điểm
 	Location    string
 	TotalGuests int
 	CheckedIn   int
@@ -31,3 +32,36 @@ type ParticipationTrendPoint struct {
 	TotalGuests int
 	CheckedIn   int
 }
+
+// EventOccupancy is the live headcount of an event, derived from check-in/check-out status.
+type EventOccupancy struct {
+	EventID    string
+	EventName  string
+	Capacity   int // Sức chứa tối đa, 0 nếu không giới hạn
+	OnSite     int // Đang ở trong địa điểm (đã check-in, chưa check-out)
+	CheckedOut int
+	Attended   int // Đã check-in ít nhất một lần
+}
+
+// EventDwellTime summarizes how long guests stayed at an event. Only completed visits
+// (checked in and out) are counted.
+type EventDwellTime struct {
+	EventID     string
+	Guests      int
+	Visits      int
+	AvgVisit    time.Duration
+	AvgPerGuest time.Duration // Tổng thời gian các lượt của một khách, lấy trung bình
+}
+
+// SessionGuestStat represents registration and attendance counts of one session of an event.
+type SessionGuestStat struct {
+	SessionID   string
+	EventID     string
+	Title       string
+	Room        string
+	StartTime   time.Time
+	EndTime     time.Time
+	Capacity    int
+	TotalGuests int
+	CheckedIn   int
+}
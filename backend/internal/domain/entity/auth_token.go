@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+// RefreshToken là phiên đăng nhập dài hạn, chỉ lưu hash của token
+type RefreshToken struct {
+	ID        string
+	UserID    string
+	TokenHash string
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
+
+// TokenPair là cặp token trả về khi đăng nhập / refresh
+type TokenPair struct {
+	AccessToken      string
+	AccessExpiresAt  time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+}
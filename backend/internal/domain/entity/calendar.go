@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+// Chủ thể của lịch đăng ký (subscription feed)
+const (
+	CalendarSubjectUser  = "user"  // các sự kiện user là chủ / đồng tổ chức
+	CalendarSubjectGuest = "guest" // các sự kiện khách mời đã đăng ký (chưa huỷ)
+)
+
+// CalendarToken cho phép ứng dụng lịch đọc feed .ics mà không cần đăng nhập; chỉ lưu hash
+type CalendarToken struct {
+	ID          string
+	SubjectType string
+	SubjectID   string
+	TokenHash   string
+	IssuedBy    string // user đã cấp token; token hết hiệu lực khi user này bị khoá
+	CreatedAt   time.Time
+	RevokedAt   *time.Time
+}
+
+// CalendarFeed là dữ liệu của một feed .ics
+type CalendarFeed struct {
+	Name   string
+	Events []*Event
+}
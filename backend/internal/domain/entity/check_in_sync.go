@@ -0,0 +1,54 @@
+package entity
+
+import "time"
+
+// MaxCheckInSyncOps giới hạn số thao tác trong một lần đồng bộ
+const MaxCheckInSyncOps = 500
+
+// CheckInSyncAction là thao tác được ghi nhận khi thiết bị check-in mất mạng
+type CheckInSyncAction string
+
+const (
+	CheckInSyncCheckIn CheckInSyncAction = "check_in"
+	CheckInSyncCancel  CheckInSyncAction = "cancel"
+)
+
+// Kết quả áp dụng một thao tác offline
+const (
+	CheckInSyncApplied   = "applied"   // đã áp dụng lên đăng ký
+	CheckInSyncNoop      = "noop"      // đăng ký đã ở trạng thái đích (vd check-in ở thiết bị khác)
+	CheckInSyncConflict  = "conflict"  // mâu thuẫn với trạng thái trên server, giữ trạng thái server
+	CheckInSyncRejected  = "rejected"  // thao tác không hợp lệ (không tìm thấy, không có quyền, ...)
+	CheckInSyncError     = "error"     // lỗi tạm thời, thiết bị gửi lại thao tác sau
+	CheckInSyncDuplicate = "duplicate" // thao tác đã được đồng bộ trước đó, trả lại kết quả cũ
+)
+
+// Mã mâu thuẫn, kèm quy tắc xử lý cố định
+const (
+	// Đăng ký đã bị huỷ trên server: huỷ là trạng thái cuối, check-in offline bị bỏ
+	CheckInConflictCancelled = "cancelled_on_server"
+	// Khách đã check-in (ở server hoặc thao tác trước đó): có mặt thắng huỷ, lệnh huỷ offline bị bỏ
+	CheckInConflictCheckedIn = "checked_in_on_server"
+	// Đăng ký đang chờ và sự kiện đã hết chỗ: không check-in được
+	CheckInConflictEventFull = "event_full"
+)
+
+// CheckInSyncOp là một thao tác thiết bị ghi lại khi offline. OpID do thiết bị sinh, duy nhất trên thiết bị.
+type CheckInSyncOp struct {
+	OpID           string
+	RegistrationID string
+	Action         CheckInSyncAction
+	ClientTime     time.Time // thời điểm thao tác trên thiết bị, dùng để sắp thứ tự áp dụng
+}
+
+// CheckInSyncResult là kết quả của một thao tác
+type CheckInSyncResult struct {
+	OpID           string
+	RegistrationID string
+	Action         CheckInSyncAction
+	Result         string
+	Conflict       string             // mã mâu thuẫn khi Result = conflict
+	Status         RegistrationStatus // trạng thái đăng ký trên server sau khi xử lý
+	Message        string
+	SyncedAt       time.Time
+}
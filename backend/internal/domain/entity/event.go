@@ -2,18 +2,38 @@ package entity
 
 import "time"
 
+// Loại sự kiện có giới hạn số khách (MaxGuests)
+const EventTypeLimited = "Sự kiện giới hạn"
+
+// Trạng thái sự kiện, được suy ra từ thời gian bắt đầu / kết thúc
+const (
+	EventStatusUpcoming = "Sắp diễn ra"
+	EventStatusOngoing  = "Đang diễn ra"
+	EventStatusEnded    = "Đã kết thúc"
+	EventStatusAll      = "Tất cả" // giá trị lọc: không giới hạn trạng thái
+)
+
 // Event đại diện cho một sự kiện trong hệ thống
 type Event struct {
-	ID          string    // UUID hoặc ObjectID
-	Name        string    // Tên sự kiện
-	Description string    // Mô tả chi tiết sự kiện
-	Type        string    // Loại sự kiện: "Sự kiện mở" | "Sự kiện giới hạn" | "Sự kiện riêng tư"
-	Status      string    // "Sắp diễn ra" | "Đang diễn ra" | "Đã kết thúc"
-	Location    string    // Địa điểm tổ chức (thay vì LocationID cho dễ hiển thị)
-	MaxGuests   uint      // Giới hạn khách (0 nếu là sự kiện mở)
-	StartDate   time.Time // Thời gian bắt đầu
-	EndDate     time.Time // Thời gian kết thúc
-	ImageURLs   []string  // Đường dẫn ảnh nơi tổ chức (nếu có)
-	CreatedAt   time.Time // Ngày tạo sự kiện
-	UpdatedAt   time.Time // Ngày cập nhật cuối cùng
+	ID              string        // UUID hoặc ObjectID
+	Name            string        // Tên sự kiện
+	Description     string        // Mô tả chi tiết sự kiện
+	Type            string        // Loại sự kiện: "Sự kiện mở" | "Sự kiện giới hạn" | "Sự kiện riêng tư"
+	Status          string        // "Sắp diễn ra" | "Đang diễn ra" | "Đã kết thúc"
+	LocationID      string        // Địa điểm tổ chức (ID trong collection "locations")
+	Location        string        // Tên địa điểm, lưu kèm để hiển thị không cần tra cứu
+	MaxGuests       uint          // Giới hạn khách (0 nếu là sự kiện mở)
+	SeatsTaken      int           // Số chỗ đã được giữ (đăng ký chưa huỷ, không tính waitlist)
+	StartDate       time.Time     // Thời gian bắt đầu
+	EndDate         time.Time     // Thời gian kết thúc
+	ImageURLs       []string      // Đường dẫn ảnh nơi tổ chức (nếu có)
+	OwnerID         string        // User sở hữu sự kiện (người tạo)
+	CoOrganizers    []CoOrganizer // Đồng tổ chức cùng quyền trên sự kiện
+	SeriesID        string        // Chuỗi sự kiện lặp lại chứa buổi này ("" nếu là sự kiện đơn)
+	OccurrenceStart time.Time     // Thời điểm bắt đầu gốc của buổi theo quy tắc lặp
+	Recurrence      *Recurrence   // Chỉ dùng khi tạo/cập nhật: quy tắc lặp, lưu ở EventSeries
+	ICalUID         string        // UID của VEVENT khi sự kiện được nhập từ file .ics (khoá chống trùng)
+	AllowOverlap    bool          // Chỉ dùng khi tạo/cập nhật: chấp nhận trùng lịch địa điểm, không lưu
+	CreatedAt       time.Time     // Ngày tạo sự kiện
+	UpdatedAt       time.Time     // Ngày cập nhật cuối cùng
 }
@@ -0,0 +1,51 @@
+package entity
+
+import "time"
+
+// ImportedEvent là một VEVENT đọc từ file .ics, trước khi ghi thành Event
+type ImportedEvent struct {
+	UID         string
+	Name        string
+	Description string
+	Location    string
+	StartDate   time.Time
+	EndDate     time.Time
+	Recurrence  *Recurrence
+	ParseError  string // VEVENT không nhập được (thiếu UID, RRULE không hỗ trợ, ...)
+}
+
+// ImportOptions điều khiển việc nhập sự kiện
+type ImportOptions struct {
+	DryRun          bool   // chỉ báo cáo sẽ tạo / cập nhật gì, không ghi DB
+	Type            string // loại sự kiện gán cho các sự kiện mới
+	DefaultLocation string // dùng khi VEVENT không có LOCATION
+	AllowOverlap    bool   // chấp nhận trùng lịch địa điểm
+}
+
+// Kết quả xử lý từng VEVENT
+const (
+	ImportActionCreate    = "create"
+	ImportActionUpdate    = "update"
+	ImportActionUnchanged = "unchanged"
+	ImportActionError     = "error"
+)
+
+// ImportItem là kết quả của một VEVENT
+type ImportItem struct {
+	UID         string
+	Name        string
+	Action      string
+	EventID     string // sự kiện (buổi đầu tiên nếu là chuỗi) đã có / vừa tạo
+	Occurrences int    // số buổi nếu có quy tắc lặp
+	Error       string
+}
+
+// ImportReport tổng hợp kết quả nhập
+type ImportReport struct {
+	DryRun    bool
+	Created   int
+	Updated   int
+	Unchanged int
+	Failed    int
+	Items     []ImportItem
+}
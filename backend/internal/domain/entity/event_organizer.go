@@ -0,0 +1,26 @@
+package entity
+
+// EventPermission là quyền của một người tổ chức trên một sự kiện cụ thể
+type EventPermission string
+
+const (
+	EventPermEdit                EventPermission = "edit"                 // sửa thông tin sự kiện
+	EventPermManageGuests        EventPermission = "manage_guests"        // thêm / sửa / xoá khách mời
+	EventPermManageRegistrations EventPermission = "manage_registrations" // tạo / huỷ đăng ký
+	EventPermCheckIn             EventPermission = "check_in"             // check-in khách
+)
+
+// IsValid cho biết quyền có được định nghĩa hay không
+func (p EventPermission) IsValid() bool {
+	switch p {
+	case EventPermEdit, EventPermManageGuests, EventPermManageRegistrations, EventPermCheckIn:
+		return true
+	}
+	return false
+}
+
+// CoOrganizer là user đồng tổ chức sự kiện với danh sách quyền được chủ sự kiện cấp
+type CoOrganizer struct {
+	UserID      string
+	Permissions []EventPermission
+}
@@ -0,0 +1,64 @@
+package entity
+
+import "time"
+
+// Các trường được phép sắp xếp khi liệt kê sự kiện
+const (
+	EventSortStartDate = "start_date"
+	EventSortEndDate   = "end_date"
+	EventSortName      = "name"
+	EventSortCreatedAt = "created_at"
+)
+
+// Giới hạn kích thước trang khi liệt kê sự kiện
+const (
+	DefaultEventPageSize = 20
+	MaxEventPageSize     = 100
+)
+
+// EventQuery mô tả điều kiện lọc, sắp xếp và phân trang khi liệt kê sự kiện
+type EventQuery struct {
+	Status      string    // "Sắp diễn ra" | "Đang diễn ra" | "Đã kết thúc" (rỗng hoặc "Tất cả" = không lọc)
+	Type        string    // Loại sự kiện
+	LocationID  string    // ID địa điểm
+	Location    string    // Tên địa điểm (khớp chính xác)
+	From        time.Time // Bắt đầu không sớm hơn From
+	To          time.Time // Kết thúc không muộn hơn To
+	Text        string    // Tìm theo từ khoá trong tên / mô tả / địa điểm
+	OrganizerID string    // Chỉ lấy sự kiện user là chủ hoặc đồng tổ chức
+	SortBy      string    // Một trong các EventSort*, mặc định start_date
+	SortDesc    bool      // Sắp xếp giảm dần
+	Page        int       // Trang (bắt đầu từ 1), bỏ qua khi có Cursor
+	PageSize    int       // Số sự kiện mỗi trang
+	Cursor      string    // Con trỏ trang kế tiếp trả về từ lần gọi trước
+}
+
+// Normalize điền giá trị mặc định và giới hạn phân trang
+func (q *EventQuery) Normalize() {
+	switch q.SortBy {
+	case EventSortStartDate, EventSortEndDate, EventSortName, EventSortCreatedAt:
+	default:
+		q.SortBy = EventSortStartDate
+	}
+	if q.Status == EventStatusAll {
+		q.Status = ""
+	}
+	if q.Page < 1 {
+		q.Page = 1
+	}
+	if q.PageSize <= 0 {
+		q.PageSize = DefaultEventPageSize
+	}
+	if q.PageSize > MaxEventPageSize {
+		q.PageSize = MaxEventPageSize
+	}
+}
+
+// EventPage là một trang kết quả liệt kê sự kiện
+type EventPage struct {
+	Events     []*Event
+	Total      int64  // Tổng số sự kiện khớp điều kiện lọc (không tính phân trang)
+	Page       int    // Trang hiện tại (0 khi phân trang bằng cursor)
+	PageSize   int    // Kích thước trang
+	NextCursor string // Rỗng khi không còn trang tiếp theo
+}
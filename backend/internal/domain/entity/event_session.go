@@ -0,0 +1,57 @@
+package entity
+
+import "time"
+
+// EventSession là một phiên trong chương trình (agenda) của sự kiện; các phiên có thể diễn ra song song ở nhiều phòng
+type EventSession struct {
+	ID          string
+	EventID     string
+	Title       string
+	Description string
+	Room        string
+	Speakers    []string
+	StartTime   time.Time
+	EndTime     time.Time
+	Capacity    int // 0 = không giới hạn
+	SeatsTaken  int // Số đăng ký phiên đang hoạt động
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// IsLimited cho biết phiên có giới hạn số khách hay không
+func (s *EventSession) IsLimited() bool {
+	return s.Capacity > 0
+}
+
+// Overlaps cho biết hai phiên có giao nhau về thời gian hay không (phiên liền kề không tính là trùng)
+func (s *EventSession) Overlaps(other *EventSession) bool {
+	return s.StartTime.Before(other.EndTime) && other.StartTime.Before(s.EndTime)
+}
+
+// SessionRegistrationStatus là trạng thái đăng ký tham dự một phiên
+type SessionRegistrationStatus string
+
+const (
+	SessionRegistered SessionRegistrationStatus = "registered"
+	SessionCheckedIn  SessionRegistrationStatus = "checked_in"
+	SessionCancelled  SessionRegistrationStatus = "cancelled"
+)
+
+// SessionRegistration là đăng ký phiên của khách, gắn với đăng ký sự kiện của khách đó
+type SessionRegistration struct {
+	ID             string
+	SessionID      string
+	EventID        string
+	RegistrationID string
+	GuestID        string // Chỉ dùng để hiển thị, lấy từ đăng ký sự kiện
+	GuestName      string // Chỉ dùng để hiển thị
+	Status         SessionRegistrationStatus
+	CreatedAt      time.Time
+	CheckedInAt    *time.Time
+	CancelledAt    *time.Time
+}
+
+// IsActive cho biết đăng ký phiên còn hiệu lực (chưa huỷ)
+func (r *SessionRegistration) IsActive() bool {
+	return r.Status != SessionCancelled
+}
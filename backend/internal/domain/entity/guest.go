@@ -1,5 +1,7 @@
 package entity
 
+import "time"
+
 // Khách mời (Guest)
 type Guest struct {
 	ID       string
@@ -7,3 +9,16 @@ type Guest struct {
 	Email    string
 	Phone    string
 }
+
+// Sự kiện mà khách tham gia, kèm đăng ký tương ứng
+type GuestEvent struct {
+	Event        Event
+	Registration Registration
+}
+
+// Khách của một sự kiện kèm đăng ký, dùng cho danh sách khách / xuất file
+type EventGuest struct {
+	Guest        Guest
+	Registration Registration
+	CheckedInAt  *time.Time // lần check-in gần nhất, nil nếu chưa check-in
+}
@@ -0,0 +1,76 @@
+package entity
+
+import "time"
+
+// Dấu hiệu trùng của một cặp khách
+const (
+	DuplicateByEmail = "email" // email trùng sau khi chuẩn hoá
+	DuplicateByPhone = "phone" // số điện thoại trùng sau khi chuẩn hoá
+	DuplicateByName  = "name"  // họ tên gần giống và không có liên hệ mâu thuẫn
+)
+
+// GuestDuplicate là một cặp khách nghi trùng do job phát hiện
+type GuestDuplicate struct {
+	ID             string
+	Guests         [2]Guest
+	Reasons        []string
+	NameSimilarity float64 // 0..1, so khớp họ tên sau khi bỏ dấu
+	Score          float64 // 0..1, càng cao càng chắc là cùng một người
+	Dismissed      bool    // đã được xác nhận không trùng, giữ qua các lần quét
+	DetectedAt     time.Time
+}
+
+// GuestDuplicateQuery lọc và phân trang danh sách cặp nghi trùng
+type GuestDuplicateQuery struct {
+	Reason           string // chỉ lấy cặp có dấu hiệu này (rỗng = tất cả)
+	MinScore         float64
+	IncludeDismissed bool
+	Page             int
+	PageSize         int
+}
+
+// Normalize điền giá trị mặc định và giới hạn phân trang
+func (q *GuestDuplicateQuery) Normalize() {
+	switch q.Reason {
+	case DuplicateByEmail, DuplicateByPhone, DuplicateByName:
+	default:
+		q.Reason = ""
+	}
+	if q.Page < 1 {
+		q.Page = 1
+	}
+	if q.PageSize <= 0 {
+		q.PageSize = DefaultGuestPageSize
+	}
+	if q.PageSize > MaxGuestPageSize {
+		q.PageSize = MaxGuestPageSize
+	}
+}
+
+// GuestDuplicatePage là một trang cặp nghi trùng
+type GuestDuplicatePage struct {
+	Items    []*GuestDuplicate
+	Total    int64
+	Page     int
+	PageSize int
+}
+
+// GuestDuplicateScan tóm tắt một lần chạy job phát hiện trùng
+type GuestDuplicateScan struct {
+	ID         string
+	Guests     int // số khách đã quét
+	Pairs      int // số cặp nghi trùng tìm được
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+// GuestMergeResult tóm tắt một lần gộp khách
+type GuestMergeResult struct {
+	Survivor             Guest
+	MergedIDs            []string
+	RegistrationsMoved   int // đăng ký chuyển sang khách giữ lại
+	RegistrationsDropped int // đăng ký bị bỏ vì khách giữ lại đã có đăng ký cùng sự kiện
+	ReviewsMoved         int
+	ReviewsDropped       int
+	OrdersMoved          int // đơn mua vé chuyển sang khách giữ lại
+}
@@ -0,0 +1,69 @@
+package entity
+
+import "time"
+
+// MaxGuestImportRows giới hạn số dòng dữ liệu mỗi lần nhập (báo cáo từng dòng nằm trong một document)
+const MaxGuestImportRows = 20000
+
+// Trạng thái của job nhập khách
+type GuestImportStatus string
+
+const (
+	GuestImportPending   GuestImportStatus = "pending"
+	GuestImportRunning   GuestImportStatus = "running"
+	GuestImportCompleted GuestImportStatus = "completed"
+	GuestImportFailed    GuestImportStatus = "failed"
+)
+
+// Kết quả xử lý từng dòng
+const (
+	GuestImportCreated = "created" // tạo khách mới và đăng ký vào sự kiện
+	GuestImportReused  = "reused"  // khách đã có (tìm theo email / SĐT) được đăng ký vào sự kiện
+	GuestImportError   = "error"
+)
+
+// GuestImportColumns ánh xạ tiêu đề cột trong file sang trường của khách; để trống thì tự nhận diện
+type GuestImportColumns struct {
+	FullName string
+	Email    string
+	Phone    string
+}
+
+// GuestImportRequest mô tả một lần nhập khách từ file CSV / XLSX
+type GuestImportRequest struct {
+	EventID  string
+	FileName string
+	Format   string // csv | xlsx
+	Columns  GuestImportColumns
+}
+
+// GuestImportRow là kết quả của một dòng dữ liệu (Row đánh số như trong file, dòng tiêu đề là 1)
+type GuestImportRow struct {
+	Row      int
+	FullName string
+	Email    string
+	Phone    string
+	Action   string
+	GuestID  string
+	Error    string
+}
+
+// GuestImportJob là tiến độ và báo cáo của một lần nhập; ID rỗng khi nhập đồng bộ (không lưu job)
+type GuestImportJob struct {
+	ID         string
+	EventID    string
+	FileName   string
+	Format     string
+	Status     GuestImportStatus
+	Total      int // số dòng dữ liệu, 0 khi chưa đếm xong
+	Processed  int
+	Created    int
+	Reused     int
+	Failed     int
+	Rows       []GuestImportRow
+	Error      string // lỗi làm dừng cả job (file hỏng, thiếu cột, ...)
+	CreatedBy  string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	FinishedAt *time.Time
+}
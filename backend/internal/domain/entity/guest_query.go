@@ -0,0 +1,60 @@
+package entity
+
+// Trường dùng để tìm khách mời
+const (
+	GuestFieldAll   = ""
+	GuestFieldName  = "name"
+	GuestFieldEmail = "email"
+	GuestFieldPhone = "phone"
+)
+
+// Kiểu so khớp từ khoá
+const (
+	GuestMatchPrefix   = "prefix"
+	GuestMatchContains = "contains"
+)
+
+// Giới hạn kích thước trang khi tìm khách mời
+const (
+	DefaultGuestPageSize = 20
+	MaxGuestPageSize     = 100
+)
+
+// GuestQuery mô tả điều kiện tìm kiếm và phân trang khách mời
+type GuestQuery struct {
+	EventID  string // Chỉ lấy khách đã đăng ký sự kiện này (rỗng = tất cả)
+	Keyword  string // Từ khoá, không phân biệt hoa thường / dấu
+	Field    string // Một trong các GuestField*, mặc định tìm trên cả tên, email, số điện thoại
+	Match    string // GuestMatchPrefix (mặc định) hoặc GuestMatchContains
+	Page     int    // Trang, bắt đầu từ 1
+	PageSize int    // Số khách mỗi trang
+}
+
+// Normalize điền giá trị mặc định và giới hạn phân trang
+func (q *GuestQuery) Normalize() {
+	switch q.Field {
+	case GuestFieldName, GuestFieldEmail, GuestFieldPhone:
+	default:
+		q.Field = GuestFieldAll
+	}
+	if q.Match != GuestMatchContains {
+		q.Match = GuestMatchPrefix
+	}
+	if q.Page < 1 {
+		q.Page = 1
+	}
+	if q.PageSize <= 0 {
+		q.PageSize = DefaultGuestPageSize
+	}
+	if q.PageSize > MaxGuestPageSize {
+		q.PageSize = MaxGuestPageSize
+	}
+}
+
+// GuestPage là một trang kết quả tìm khách mời
+type GuestPage struct {
+	Guests   []*Guest
+	Total    int64
+	Page     int
+	PageSize int
+}
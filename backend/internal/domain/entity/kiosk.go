@@ -0,0 +1,75 @@
+package entity
+
+import "time"
+
+// Giới hạn của phiên kiosk tự check-in
+const (
+	DefaultKioskTTL       = 24 * time.Hour
+	MaxKioskTTL           = 7 * 24 * time.Hour
+	MinKioskQueryLength   = 3 // số ký tự tối thiểu khi khách tự tìm tên / email / SĐT
+	MaxKioskMatches       = 5 // số kết quả tối đa; nhiều hơn thì khách phải gõ thêm
+	DefaultKioskAuditSize = 100
+	MaxKioskAuditSize     = 1000
+)
+
+// KioskSession là một thiết bị kiosk được cấp token để khách tự check-in tại một sự kiện
+type KioskSession struct {
+	ID         string
+	EventID    string
+	Name       string // nhãn thiết bị, vd "Sảnh A - máy 1"
+	CreatedBy  string
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+	RevokedAt  *time.Time
+	LastSeenAt *time.Time
+}
+
+// IsActive cho biết token của phiên còn dùng được tại thời điểm now
+func (k *KioskSession) IsActive(now time.Time) bool {
+	return k.RevokedAt == nil && now.Before(k.ExpiresAt)
+}
+
+// KioskGuestMatch là một khách tìm được trên kiosk; liên hệ đã được che bớt
+type KioskGuestMatch struct {
+	RegistrationID string
+	Name           string
+	Email          string
+	Phone          string
+	Status         RegistrationStatus
+	CheckedIn      bool
+}
+
+// Các thao tác được ghi vào nhật ký kiosk
+const (
+	KioskAuditLookup      = "lookup"
+	KioskAuditCheckIn     = "check_in"
+	KioskAuditRateLimited = "rate_limited"
+)
+
+// Kết quả của thao tác trong nhật ký kiosk
+const (
+	KioskResultOK       = "ok"
+	KioskResultRejected = "rejected"
+	KioskResultError    = "error"
+)
+
+// KioskAuditEntry là một dòng nhật ký thao tác của thiết bị kiosk
+type KioskAuditEntry struct {
+	ID             string
+	KioskID        string
+	EventID        string
+	Action         string
+	Query          string // từ khoá tìm kiếm (với lookup)
+	Matches        int    // số kết quả trả về (với lookup)
+	RegistrationID string // đăng ký được check-in (với check_in)
+	Result         string
+	Message        string
+	ClientIP       string
+	At             time.Time
+}
+
+// KioskLookupResult là kết quả tìm khách trên kiosk; Total lớn hơn số kết quả thì khách cần gõ thêm
+type KioskLookupResult struct {
+	Matches []*KioskGuestMatch
+	Total   int64
+}
@@ -5,6 +5,11 @@ type Location struct {
 	ID          string
 	Name        string
 	Address     string
-	Capacity    int
+	Capacity    int // Sức chứa tối đa, 0 = không giới hạn
 	Description string
 }
+
+// Fits cho biết địa điểm có chứa được expected khách hay không
+func (l *Location) Fits(expected int) bool {
+	return l.Capacity <= 0 || expected <= l.Capacity
+}
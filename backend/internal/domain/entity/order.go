@@ -0,0 +1,43 @@
+package entity
+
+import "time"
+
+// OrderStatus là trạng thái của một đơn mua vé
+type OrderStatus string
+
+const (
+	OrderPending   OrderStatus = "pending"    // Đang giữ vé, chờ thanh toán
+	OrderPaid      OrderStatus = "paid"       // Đã thanh toán, đăng ký đã được xác nhận
+	OrderFailed    OrderStatus = "failed"     // Thanh toán thất bại, vé đã được trả
+	OrderExpired   OrderStatus = "expired"    // Hết thời gian giữ vé, vé đã được trả
+	OrderCancelled OrderStatus = "cancelled"  // Huỷ trước khi thanh toán, vé đã được trả
+	OrderRefundDue OrderStatus = "refund_due" // Nhận tiền nhưng không thể xác nhận đăng ký, cần hoàn tiền
+)
+
+// IsOpen cho biết đơn còn đang giữ vé hay không
+func (s OrderStatus) IsOpen() bool {
+	return s == OrderPending
+}
+
+// Order là đơn mua một vé cho một khách. Thanh toán thành công thì đăng ký của khách được tạo
+// ở trạng thái confirmed với ID trùng ID của đơn.
+type Order struct {
+	ID             string
+	EventID        string
+	TicketTypeID   string
+	TicketName     string
+	GuestID        string
+	RegistrationID string // Có sau khi thanh toán thành công
+	Amount         int64
+	Currency       string
+	EarlyBird      bool
+	Status         OrderStatus
+	Reason         string // Lý do thất bại / huỷ
+	Provider       string
+	PaymentID      string // ID giao dịch phía cổng thanh toán
+	CheckoutURL    string
+	ExpiresAt      time.Time
+	PaidAt         *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
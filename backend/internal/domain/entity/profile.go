@@ -0,0 +1,77 @@
+package entity
+
+import "time"
+
+// ProfileKind phân loại hồ sơ: diễn giả hoặc nhà tài trợ
+type ProfileKind string
+
+const (
+	ProfileSpeaker ProfileKind = "speaker"
+	ProfileSponsor ProfileKind = "sponsor"
+)
+
+// IsValid cho biết loại hồ sơ có được hỗ trợ hay không
+func (k ProfileKind) IsValid() bool {
+	return k == ProfileSpeaker || k == ProfileSponsor
+}
+
+// SponsorTier là hạng tài trợ, chỉ áp dụng cho hồ sơ nhà tài trợ
+type SponsorTier string
+
+const (
+	SponsorPlatinum SponsorTier = "platinum"
+	SponsorGold     SponsorTier = "gold"
+	SponsorSilver   SponsorTier = "silver"
+	SponsorBronze   SponsorTier = "bronze"
+	SponsorPartner  SponsorTier = "partner"
+)
+
+// sponsorTierRank là thứ tự hiển thị nhà tài trợ, hạng cao trước
+var sponsorTierRank = map[SponsorTier]int{
+	SponsorPlatinum: 0,
+	SponsorGold:     1,
+	SponsorSilver:   2,
+	SponsorBronze:   3,
+	SponsorPartner:  4,
+}
+
+// IsValid cho biết hạng tài trợ có được hỗ trợ hay không
+func (t SponsorTier) IsValid() bool {
+	_, ok := sponsorTierRank[t]
+	return ok
+}
+
+// Rank trả về thứ tự hiển thị của hạng (nhỏ hơn = hạng cao hơn); hạng lạ xếp cuối
+func (t SponsorTier) Rank() int {
+	if rank, ok := sponsorTierRank[t]; ok {
+		return rank
+	}
+	return len(sponsorTierRank)
+}
+
+// ProfileLink là một liên kết hiển thị trên hồ sơ (website, LinkedIn, ...)
+type ProfileLink struct {
+	Label string
+	URL   string
+}
+
+// Profile là hồ sơ diễn giả / nhà tài trợ, dùng lại được cho nhiều sự kiện
+type Profile struct {
+	ID        string
+	Kind      ProfileKind
+	Name      string
+	Headline  string // Chức danh / công ty của diễn giả, khẩu hiệu của nhà tài trợ
+	Bio       string
+	PhotoURL  string
+	Links     []ProfileLink
+	Tier      SponsorTier // Chỉ có ở nhà tài trợ
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// EventProfile là hồ sơ được gắn vào sự kiện: gắn trực tiếp với sự kiện và/hoặc với một số phiên
+type EventProfile struct {
+	Profile    *Profile
+	OnEvent    bool     // Gắn ở cấp sự kiện
+	SessionIDs []string // Các phiên của sự kiện có gắn hồ sơ
+}
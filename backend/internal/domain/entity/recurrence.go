@@ -0,0 +1,386 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Tần suất lặp (FREQ) được hỗ trợ
+type Frequency string
+
+const (
+	FreqDaily   Frequency = "DAILY"
+	FreqWeekly  Frequency = "WEEKLY"
+	FreqMonthly Frequency = "MONTHLY"
+	FreqYearly  Frequency = "YEARLY"
+)
+
+// Giới hạn khi sinh lịch: chuỗi không có COUNT / UNTIL chỉ được tạo trước một năm
+const (
+	MaxSeriesOccurrences = 500
+	RecurrenceHorizon    = 365 * 24 * time.Hour
+)
+
+// Phạm vi áp dụng khi sửa / xoá một buổi của chuỗi sự kiện
+type SeriesScope string
+
+const (
+	SeriesScopeThis      SeriesScope = "this"      // chỉ buổi này
+	SeriesScopeFollowing SeriesScope = "following" // buổi này và các buổi sau
+	SeriesScopeAll       SeriesScope = "all"       // toàn bộ chuỗi
+)
+
+// IsValid kiểm tra phạm vi có được hỗ trợ không
+func (s SeriesScope) IsValid() bool {
+	switch s {
+	case SeriesScopeThis, SeriesScopeFollowing, SeriesScopeAll:
+		return true
+	}
+	return false
+}
+
+// WeekdayNum là một phần tử BYDAY, ví dụ "MO", "2TU", "-1FR" (Ordinal = 0 nghĩa là mọi thứ đó)
+type WeekdayNum struct {
+	Ordinal int
+	Day     time.Weekday
+}
+
+// Recurrence là tập con RRULE (RFC 5545): FREQ, INTERVAL, BYDAY, COUNT, UNTIL kèm danh sách EXDATE
+type Recurrence struct {
+	Freq     Frequency
+	Interval int
+	ByDay    []WeekdayNum
+	Count    int       // 0 = không giới hạn số buổi
+	Until    time.Time // zero = không có ngày kết thúc
+	ExDates  []time.Time
+}
+
+var weekdayCodes = map[string]time.Weekday{
+	"MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday, "TH": time.Thursday,
+	"FR": time.Friday, "SA": time.Saturday, "SU": time.Sunday,
+}
+
+var weekdayNames = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// ParseRRule đọc chuỗi RRULE, chấp nhận cả tiền tố "RRULE:"
+func ParseRRule(raw string) (*Recurrence, error) {
+	raw = strings.TrimSpace(raw)
+	raw = strings.TrimPrefix(strings.TrimPrefix(raw, "RRULE:"), "rrule:")
+	if raw == "" {
+		return nil, errors.New("empty RRULE")
+	}
+
+	r := &Recurrence{Interval: 1}
+	for _, part := range strings.Split(raw, ";") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid RRULE part %q", part)
+		}
+		key = strings.ToUpper(strings.TrimSpace(key))
+		value = strings.ToUpper(strings.TrimSpace(value))
+
+		switch key {
+		case "FREQ":
+			r.Freq = Frequency(value)
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid INTERVAL %q", value)
+			}
+			r.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid COUNT %q", value)
+			}
+			r.Count = n
+		case "UNTIL":
+			t, err := parseRRuleTime(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid UNTIL %q", value)
+			}
+			r.Until = t
+		case "BYDAY":
+			for _, code := range strings.Split(value, ",") {
+				wd, err := parseWeekdayNum(code)
+				if err != nil {
+					return nil, err
+				}
+				r.ByDay = append(r.ByDay, wd)
+			}
+		case "WKST":
+			if value != "MO" {
+				return nil, errors.New("only WKST=MO is supported")
+			}
+		default:
+			return nil, fmt.Errorf("unsupported RRULE part %s", key)
+		}
+	}
+
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Validate kiểm tra các tổ hợp thuộc tính được hỗ trợ
+func (r *Recurrence) Validate() error {
+	switch r.Freq {
+	case FreqDaily, FreqWeekly, FreqMonthly, FreqYearly:
+	case "":
+		return errors.New("FREQ is required")
+	default:
+		return fmt.Errorf("unsupported FREQ %s", r.Freq)
+	}
+	if r.Interval < 1 {
+		return errors.New("INTERVAL must be positive")
+	}
+	if r.Count > 0 && !r.Until.IsZero() {
+		return errors.New("COUNT and UNTIL must not be used together")
+	}
+	if r.Freq == FreqYearly && len(r.ByDay) > 0 {
+		return errors.New("BYDAY is not supported with FREQ=YEARLY")
+	}
+	for _, wd := range r.ByDay {
+		if wd.Ordinal != 0 && r.Freq != FreqMonthly {
+			return errors.New("numbered BYDAY (e.g. 2TU) is only supported with FREQ=MONTHLY")
+		}
+	}
+	return nil
+}
+
+// String trả về RRULE (không gồm EXDATE), UNTIL luôn ở dạng UTC
+func (r Recurrence) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		codes := make([]string, 0, len(r.ByDay))
+		for _, wd := range r.ByDay {
+			code := weekdayNames[wd.Day]
+			if wd.Ordinal != 0 {
+				code = strconv.Itoa(wd.Ordinal) + code
+			}
+			codes = append(codes, code)
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Expand sinh thời điểm bắt đầu các buổi từ dtstart (luôn là buổi đầu tiên), theo thứ tự tăng dần.
+// COUNT tính trên các buổi trước khi loại EXDATE; dừng khi vượt UNTIL, horizon hoặc đủ limit buổi.
+func (r Recurrence) Expand(dtstart time.Time, limit int, horizon time.Time) []time.Time {
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+	excluded := make(map[int64]struct{}, len(r.ExDates))
+	for _, t := range r.ExDates {
+		excluded[t.UnixNano()] = struct{}{}
+	}
+
+	var (
+		result    []time.Time
+		generated int
+	)
+	// emit trả về false khi đã đủ điều kiện dừng
+	emit := func(t time.Time) bool {
+		if !r.Until.IsZero() && t.After(r.Until) {
+			return false
+		}
+		if !horizon.IsZero() && t.After(horizon) {
+			return false
+		}
+		generated++
+		if r.Count > 0 && generated > r.Count {
+			return false
+		}
+		if _, ok := excluded[t.UnixNano()]; !ok {
+			result = append(result, t)
+		}
+		return limit <= 0 || len(result) < limit
+	}
+
+	if !emit(dtstart) {
+		return result
+	}
+
+	// Số chu kỳ tối đa đủ phủ mọi giới hạn (chặn vòng lặp khi rule không sinh được buổi nào)
+	const maxPeriods = 10000
+	for k := 0; k < maxPeriods; k++ {
+		periodStart, candidates := r.period(dtstart, k*interval)
+		if !r.Until.IsZero() && periodStart.After(r.Until) {
+			break
+		}
+		if !horizon.IsZero() && periodStart.After(horizon) {
+			break
+		}
+		for _, t := range candidates {
+			if !t.After(dtstart) {
+				continue
+			}
+			if !emit(t) {
+				return result
+			}
+		}
+	}
+	return result
+}
+
+// period trả về mốc đầu chu kỳ thứ n (tính theo đơn vị FREQ) và các buổi trong chu kỳ đó,
+// giữ nguyên giờ trong ngày và múi giờ của dtstart.
+func (r Recurrence) period(dtstart time.Time, n int) (time.Time, []time.Time) {
+	y, m, d := dtstart.Date()
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, dtstart.Hour(), dtstart.Minute(), dtstart.Second(), dtstart.Nanosecond(), dtstart.Location())
+	}
+
+	switch r.Freq {
+	case FreqDaily:
+		day := at(y, m, d+n)
+		if len(r.ByDay) > 0 && !r.matchesWeekday(day.Weekday()) {
+			return day, nil
+		}
+		return day, []time.Time{day}
+
+	case FreqWeekly:
+		// Tuần bắt đầu từ thứ Hai (WKST=MO)
+		offset := (int(dtstart.Weekday()) + 6) % 7
+		weekStart := at(y, m, d-offset+7*n)
+		days := r.ByDay
+		if len(days) == 0 {
+			days = []WeekdayNum{{Day: dtstart.Weekday()}}
+		}
+		out := make([]time.Time, 0, len(days))
+		for _, wd := range days {
+			wy, wm, wdd := weekStart.Date()
+			out = append(out, at(wy, wm, wdd+(int(wd.Day)+6)%7))
+		}
+		sortTimes(out)
+		return weekStart, dedupeTimes(out)
+
+	case FreqMonthly:
+		first := at(y, m+time.Month(n), 1)
+		fy, fm, _ := first.Date()
+		daysIn := at(fy, fm+1, 0).Day()
+		if len(r.ByDay) == 0 {
+			if d > daysIn {
+				return first, nil // tháng không có ngày này (vd. 31) thì bỏ qua
+			}
+			return first, []time.Time{at(fy, fm, d)}
+		}
+		var out []time.Time
+		for _, wd := range r.ByDay {
+			var matches []time.Time
+			for day := 1; day <= daysIn; day++ {
+				t := at(fy, fm, day)
+				if t.Weekday() == wd.Day {
+					matches = append(matches, t)
+				}
+			}
+			switch {
+			case wd.Ordinal == 0:
+				out = append(out, matches...)
+			case wd.Ordinal > 0 && wd.Ordinal <= len(matches):
+				out = append(out, matches[wd.Ordinal-1])
+			case wd.Ordinal < 0 && -wd.Ordinal <= len(matches):
+				out = append(out, matches[len(matches)+wd.Ordinal])
+			}
+		}
+		sortTimes(out)
+		return first, dedupeTimes(out)
+
+	case FreqYearly:
+		year := y + n
+		t := at(year, m, d)
+		if t.Month() != m {
+			return t, nil // 29/02 ở năm không nhuận
+		}
+		return t, []time.Time{t}
+	}
+	return dtstart, nil
+}
+
+func (r Recurrence) matchesWeekday(day time.Weekday) bool {
+	for _, wd := range r.ByDay {
+		if wd.Day == day {
+			return true
+		}
+	}
+	return false
+}
+
+func parseWeekdayNum(code string) (WeekdayNum, error) {
+	code = strings.TrimSpace(code)
+	if len(code) < 2 {
+		return WeekdayNum{}, fmt.Errorf("invalid BYDAY %q", code)
+	}
+	day, ok := weekdayCodes[code[len(code)-2:]]
+	if !ok {
+		return WeekdayNum{}, fmt.Errorf("invalid BYDAY %q", code)
+	}
+	wd := WeekdayNum{Day: day}
+	if prefix := code[:len(code)-2]; prefix != "" {
+		n, err := strconv.Atoi(strings.TrimPrefix(prefix, "+"))
+		if err != nil || n == 0 || n > 5 || n < -5 {
+			return WeekdayNum{}, fmt.Errorf("invalid BYDAY %q", code)
+		}
+		wd.Ordinal = n
+	}
+	return wd, nil
+}
+
+// parseRRuleTime đọc UNTIL dạng UTC ("...Z"), giờ địa phương hoặc chỉ ngày (tính hết ngày đó)
+func parseRRuleTime(value string) (time.Time, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("20060102T150405", value, time.Local); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("20060102", value, time.Local)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.Add(24*time.Hour - time.Second), nil
+}
+
+func sortTimes(list []time.Time) {
+	sort.Slice(list, func(i, j int) bool { return list[i].Before(list[j]) })
+}
+
+func dedupeTimes(list []time.Time) []time.Time {
+	out := list[:0]
+	for i, t := range list {
+		if i > 0 && t.Equal(list[i-1]) {
+			continue
+		}
+		out = append(out, t)
+	}
+	return out
+}
+
+// EventSeries là một chuỗi sự kiện lặp lại; mỗi buổi là một Event riêng (có đăng ký riêng)
+// tham chiếu SeriesID và giữ OccurrenceStart là thời điểm gốc theo quy tắc.
+type EventSeries struct {
+	ID         string
+	DTStart    time.Time
+	Recurrence Recurrence
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
@@ -0,0 +1,155 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRRule(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    string // Recurrence.String() sau khi parse
+		wantErr bool
+	}{
+		{"RRULE:FREQ=WEEKLY;BYDAY=MO,WE;COUNT=4", "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=4", false},
+		{"freq=daily;interval=2", "FREQ=DAILY;INTERVAL=2", false},
+		{"FREQ=MONTHLY;BYDAY=-1FR;UNTIL=20251231T000000Z", "FREQ=MONTHLY;BYDAY=-1FR;UNTIL=20251231T000000Z", false},
+		{"FREQ=YEARLY;WKST=MO", "FREQ=YEARLY", false},
+		{"", "", true},
+		{"INTERVAL=2", "", true},
+		{"FREQ=HOURLY", "", true},
+		{"FREQ=DAILY;INTERVAL=0", "", true},
+		{"FREQ=DAILY;COUNT=2;UNTIL=20250101", "", true},
+		{"FREQ=WEEKLY;BYDAY=2TU", "", true},
+		{"FREQ=YEARLY;BYDAY=MO", "", true},
+		{"FREQ=MONTHLY;BYDAY=6MO", "", true},
+		{"FREQ=WEEKLY;WKST=SU", "", true},
+		{"FREQ=WEEKLY;BYMONTH=1", "", true},
+	}
+	for _, tt := range tests {
+		r, err := ParseRRule(tt.raw)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseRRule(%q): expected an error, got %s", tt.raw, r)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseRRule(%q): %v", tt.raw, err)
+			continue
+		}
+		if got := r.String(); got != tt.want {
+			t.Errorf("ParseRRule(%q).String() = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestRecurrenceExpand(t *testing.T) {
+	loc := time.FixedZone("ICT", 7*3600)
+	at := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 9, 30, 0, 0, loc) }
+	// 2025-01-06 là thứ Hai
+	monday := at(2025, time.January, 6)
+
+	tests := []struct {
+		name    string
+		rule    string
+		exDates []time.Time
+		dtstart time.Time
+		limit   int
+		horizon time.Time
+		want    []time.Time
+	}{
+		{
+			name:    "daily count",
+			rule:    "FREQ=DAILY;COUNT=3",
+			dtstart: monday,
+			want:    []time.Time{monday, at(2025, 1, 7), at(2025, 1, 8)},
+		},
+		{
+			name:    "daily interval until",
+			rule:    "FREQ=DAILY;INTERVAL=2;UNTIL=20250111T000000Z",
+			dtstart: monday,
+			want:    []time.Time{monday, at(2025, 1, 8), at(2025, 1, 10)},
+		},
+		{
+			name:    "weekly byday",
+			rule:    "FREQ=WEEKLY;BYDAY=MO,TH;COUNT=4",
+			dtstart: monday,
+			want:    []time.Time{monday, at(2025, 1, 9), at(2025, 1, 13), at(2025, 1, 16)},
+		},
+		{
+			name:    "weekly every other week",
+			rule:    "FREQ=WEEKLY;INTERVAL=2;COUNT=3",
+			dtstart: monday,
+			want:    []time.Time{monday, at(2025, 1, 20), at(2025, 2, 3)},
+		},
+		{
+			name:    "dtstart off the byday set is still the first occurrence",
+			rule:    "FREQ=WEEKLY;BYDAY=FR;COUNT=3",
+			dtstart: monday,
+			want:    []time.Time{monday, at(2025, 1, 10), at(2025, 1, 17)},
+		},
+		{
+			name:    "monthly skips months without the day",
+			rule:    "FREQ=MONTHLY;COUNT=3",
+			dtstart: at(2025, 1, 31),
+			want:    []time.Time{at(2025, 1, 31), at(2025, 3, 31), at(2025, 5, 31)},
+		},
+		{
+			name:    "monthly second tuesday",
+			rule:    "FREQ=MONTHLY;BYDAY=2TU;COUNT=3",
+			dtstart: at(2025, 1, 14),
+			want:    []time.Time{at(2025, 1, 14), at(2025, 2, 11), at(2025, 3, 11)},
+		},
+		{
+			name:    "monthly last friday",
+			rule:    "FREQ=MONTHLY;BYDAY=-1FR;COUNT=3",
+			dtstart: at(2025, 1, 31),
+			want:    []time.Time{at(2025, 1, 31), at(2025, 2, 28), at(2025, 3, 28)},
+		},
+		{
+			name:    "yearly leap day",
+			rule:    "FREQ=YEARLY;COUNT=2",
+			dtstart: at(2024, 2, 29),
+			want:    []time.Time{at(2024, 2, 29), at(2028, 2, 29)},
+		},
+		{
+			name:    "exdate counts toward count",
+			rule:    "FREQ=DAILY;COUNT=3",
+			exDates: []time.Time{at(2025, 1, 7)},
+			dtstart: monday,
+			want:    []time.Time{monday, at(2025, 1, 8)},
+		},
+		{
+			name:    "limit",
+			rule:    "FREQ=DAILY",
+			dtstart: monday,
+			limit:   2,
+			want:    []time.Time{monday, at(2025, 1, 7)},
+		},
+		{
+			name:    "horizon",
+			rule:    "FREQ=WEEKLY",
+			dtstart: monday,
+			horizon: at(2025, 1, 20),
+			want:    []time.Time{monday, at(2025, 1, 13), at(2025, 1, 20)},
+		},
+	}
+	for _, tt := range tests {
+		r, err := ParseRRule(tt.rule)
+		if err != nil {
+			t.Fatalf("%s: ParseRRule: %v", tt.name, err)
+		}
+		r.ExDates = tt.exDates
+		got := r.Expand(tt.dtstart, tt.limit, tt.horizon)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if !got[i].Equal(tt.want[i]) {
+				t.Errorf("%s: occurrence %d = %v, want %v", tt.name, i, got[i], tt.want[i])
+			}
+		}
+	}
+}
@@ -2,12 +2,107 @@ package entity
 
 import "time"
 
+// RegistrationStatus là trạng thái của một đăng ký tham dự
+type RegistrationStatus string
+
+const (
+	RegistrationPending    RegistrationStatus = "pending"
+	RegistrationConfirmed  RegistrationStatus = "confirmed"
+	RegistrationWaitlisted RegistrationStatus = "waitlisted"
+	RegistrationCheckedIn  RegistrationStatus = "checked_in"
+	RegistrationCheckedOut RegistrationStatus = "checked_out"
+	RegistrationCancelled  RegistrationStatus = "cancelled"
+	RegistrationNoShow     RegistrationStatus = "no_show"
+)
+
+// registrationTransitions liệt kê các bước chuyển trạng thái hợp lệ
+var registrationTransitions = map[RegistrationStatus][]RegistrationStatus{
+	RegistrationPending:    {RegistrationConfirmed, RegistrationWaitlisted, RegistrationCheckedIn, RegistrationCancelled, RegistrationNoShow},
+	RegistrationConfirmed:  {RegistrationCheckedIn, RegistrationCancelled, RegistrationNoShow},
+	RegistrationWaitlisted: {RegistrationPending, RegistrationConfirmed, RegistrationCancelled},
+	RegistrationCheckedIn:  {RegistrationCheckedOut},
+	RegistrationCheckedOut: {RegistrationCheckedIn},
+	RegistrationNoShow:     {RegistrationCheckedIn},
+	RegistrationCancelled:  {},
+}
+
+// registrationKeepRank xếp hạng trạng thái khi phải chọn một trong hai đăng ký của cùng khách
+// cho cùng sự kiện: giữ đăng ký có hạng cao hơn
+var registrationKeepRank = map[RegistrationStatus]int{
+	RegistrationCheckedOut: 6,
+	RegistrationCheckedIn:  5,
+	RegistrationConfirmed:  4,
+	RegistrationPending:    3,
+	RegistrationNoShow:     2,
+	RegistrationWaitlisted: 1,
+	RegistrationCancelled:  0,
+}
+
+// ParseRegistrationStatus chuẩn hoá trạng thái lưu trong DB; dữ liệu cũ để trống được coi là pending
+func ParseRegistrationStatus(value string) RegistrationStatus {
+	if value == "" {
+		return RegistrationPending
+	}
+	return RegistrationStatus(value)
+}
+
+// IsValid cho biết trạng thái có được định nghĩa hay không
+func (s RegistrationStatus) IsValid() bool {
+	_, ok := registrationTransitions[s]
+	return ok
+}
+
+// CanTransitionTo kiểm tra có được chuyển từ s sang next hay không
+func (s RegistrationStatus) CanTransitionTo(next RegistrationStatus) bool {
+	for _, allowed := range registrationTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// KeepRank trả về thứ hạng của trạng thái khi gộp đăng ký trùng (cao hơn được giữ lại)
+func (s RegistrationStatus) KeepRank() int {
+	return registrationKeepRank[s]
+}
+
+// HoldsSeat cho biết đăng ký ở trạng thái này có chiếm chỗ của sự kiện giới hạn hay không
+func (s RegistrationStatus) HoldsSeat() bool {
+	return s != RegistrationCancelled && s != RegistrationWaitlisted
+}
+
+// RegistrationTransition là một lần chuyển trạng thái được ghi lại
+type RegistrationTransition struct {
+	From    RegistrationStatus
+	To      RegistrationStatus
+	ActorID string // user ID, hoặc "system" với thao tác tự động
+	Reason  string
+	At      time.Time
+}
+
 // Đăng ký tham dự (Registration)
 type Registration struct {
 	ID        string
 	EventID   string
 	GuestID   string
-	Status    string
+	Status    RegistrationStatus
 	CreatedAt time.Time
 	CheckedIn bool
+	History   []RegistrationTransition
+	Visits    []RegistrationVisit
+}
+
+// RegistrationVisit là một lượt vào/ra của khách; CheckedOutAt nil khi khách vẫn đang ở trong
+type RegistrationVisit struct {
+	CheckedInAt  time.Time
+	CheckedOutAt *time.Time
+}
+
+// Duration trả về thời gian ở lại của lượt đã check-out, 0 nếu khách chưa ra
+func (v RegistrationVisit) Duration() time.Duration {
+	if v.CheckedOutAt == nil {
+		return 0
+	}
+	return v.CheckedOutAt.Sub(v.CheckedInAt)
 }
@@ -0,0 +1,64 @@
+package entity
+
+import "testing"
+
+func TestRegistrationStatusCanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from, to RegistrationStatus
+		want     bool
+	}{
+		{RegistrationPending, RegistrationConfirmed, true},
+		{RegistrationPending, RegistrationWaitlisted, true},
+		{RegistrationPending, RegistrationCheckedIn, true},
+		{RegistrationPending, RegistrationCheckedOut, false},
+		{RegistrationConfirmed, RegistrationCheckedIn, true},
+		{RegistrationConfirmed, RegistrationWaitlisted, false},
+		{RegistrationConfirmed, RegistrationPending, false},
+		{RegistrationWaitlisted, RegistrationPending, true},
+		{RegistrationWaitlisted, RegistrationCheckedIn, false},
+		{RegistrationCheckedIn, RegistrationCheckedOut, true},
+		{RegistrationCheckedIn, RegistrationCancelled, false},
+		{RegistrationCheckedOut, RegistrationCheckedIn, true},
+		{RegistrationNoShow, RegistrationCheckedIn, true},
+		{RegistrationNoShow, RegistrationConfirmed, false},
+		{RegistrationCancelled, RegistrationPending, false},
+		{RegistrationCancelled, RegistrationCancelled, false},
+		{RegistrationStatus("unknown"), RegistrationPending, false},
+	}
+	for _, tt := range tests {
+		if got := tt.from.CanTransitionTo(tt.to); got != tt.want {
+			t.Errorf("%s -> %s: got %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestRegistrationStatusKeepRank(t *testing.T) {
+	// Từ hạng cao xuống thấp: đăng ký đứng trước luôn được giữ khi gộp
+	order := []RegistrationStatus{
+		RegistrationCheckedOut,
+		RegistrationCheckedIn,
+		RegistrationConfirmed,
+		RegistrationPending,
+		RegistrationNoShow,
+		RegistrationWaitlisted,
+		RegistrationCancelled,
+	}
+	for i := 1; i < len(order); i++ {
+		if order[i-1].KeepRank() <= order[i].KeepRank() {
+			t.Errorf("%s (%d) should outrank %s (%d)", order[i-1], order[i-1].KeepRank(), order[i], order[i].KeepRank())
+		}
+	}
+
+	tests := []struct {
+		value string
+		want  int
+	}{
+		{"", RegistrationPending.KeepRank()},
+		{"bogus", 0},
+	}
+	for _, tt := range tests {
+		if got := ParseRegistrationStatus(tt.value).KeepRank(); got != tt.want {
+			t.Errorf("KeepRank(%q) = %d, want %d", tt.value, got, tt.want)
+		}
+	}
+}
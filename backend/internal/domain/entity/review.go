@@ -2,12 +2,27 @@ package entity
 
 import "time"
 
+// Thang điểm đánh giá
+const (
+	MinReviewRating = 1.0
+	MaxReviewRating = 5.0
+)
+
 // Đánh giá sau sự kiện
 type Review struct {
 	ID        string
 	EventID   string
 	GuestID   string
+	GuestName string // Chỉ dùng để hiển thị, không lưu cùng review
 	Rating    float64
 	Comment   string
 	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ReviewSummary là danh sách đánh giá của một sự kiện kèm điểm trung bình
+type ReviewSummary struct {
+	Reviews       []*Review
+	AverageRating float64
+	Count         int
 }
@@ -0,0 +1,88 @@
+package entity
+
+// Role là vai trò của user trong hệ thống (lưu ở User.Role)
+type Role string
+
+const (
+	RoleAdmin        Role = "admin"
+	RoleOrganizer    Role = "organizer"
+	RoleCheckInStaff Role = "checkin_staff"
+	RoleViewer       Role = "viewer"
+)
+
+// Permission là một quyền thao tác trên tài nguyên
+type Permission string
+
+const (
+	PermEventRead         Permission = "event:read"
+	PermEventWrite        Permission = "event:write"
+	PermGuestRead         Permission = "guest:read"
+	PermGuestWrite        Permission = "guest:write"
+	PermRegistrationRead  Permission = "registration:read"
+	PermRegistrationWrite Permission = "registration:write"
+	PermCheckIn           Permission = "registration:check_in"
+	PermLocationRead      Permission = "location:read"
+	PermLocationWrite     Permission = "location:write"
+	PermProfileRead       Permission = "profile:read"
+	PermProfileWrite      Permission = "profile:write"
+	PermReviewRead        Permission = "review:read"
+	PermReviewWrite       Permission = "review:write"
+	PermAnalyticsRead     Permission = "analytics:read"
+	PermUserManage        Permission = "user:manage"
+)
+
+// rolePermissions là ma trận phân quyền; admin có toàn quyền nên không liệt kê
+var rolePermissions = map[Role][]Permission{
+	RoleOrganizer: {
+		PermEventRead, PermEventWrite,
+		PermGuestRead, PermGuestWrite,
+		PermRegistrationRead, PermRegistrationWrite, PermCheckIn,
+		PermLocationRead, PermLocationWrite,
+		PermProfileRead, PermProfileWrite,
+		PermReviewRead, PermReviewWrite,
+		PermAnalyticsRead,
+	},
+	RoleCheckInStaff: {
+		PermCheckIn,
+	},
+	RoleViewer: {
+		PermEventRead,
+		PermGuestRead,
+		PermRegistrationRead,
+		PermLocationRead,
+		PermProfileRead,
+		PermReviewRead,
+		PermAnalyticsRead,
+	},
+}
+
+// ParseRole chuẩn hoá role lưu trong DB; role cũ/không hợp lệ (vd "user") được coi là viewer
+func ParseRole(value string) Role {
+	role := Role(value)
+	if role.IsValid() {
+		return role
+	}
+	return RoleViewer
+}
+
+// IsValid cho biết role có nằm trong danh sách role được định nghĩa hay không
+func (r Role) IsValid() bool {
+	switch r {
+	case RoleAdmin, RoleOrganizer, RoleCheckInStaff, RoleViewer:
+		return true
+	}
+	return false
+}
+
+// Can kiểm tra role có quyền p hay không
+func (r Role) Can(p Permission) bool {
+	if r == RoleAdmin {
+		return true
+	}
+	for _, granted := range rolePermissions[r] {
+		if granted == p {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package entity
+
+import "time"
+
+// TicketType là một loại vé bán cho sự kiện (vd "Thường", "VIP", "Sinh viên").
+// Giá tính theo đơn vị nhỏ nhất của tiền tệ (VND: đồng, USD: cent).
+type TicketType struct {
+	ID             string
+	EventID        string
+	Name           string
+	Description    string
+	Currency       string // Mã ISO 4217, vd "VND"
+	Price          int64
+	EarlyBirdPrice int64      // Giá ưu đãi khi mua trước EarlyBirdUntil
+	EarlyBirdUntil *time.Time // nil = không có giá ưu đãi
+	Quantity       int        // 0 = không giới hạn
+	Sold           int        // Số vé đã thanh toán
+	Reserved       int        // Số vé đang được giữ bởi đơn chưa thanh toán
+	SaleStart      *time.Time // nil = mở bán ngay
+	SaleEnd        *time.Time // nil = bán đến khi hết vé
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// IsLimited cho biết loại vé có giới hạn số lượng hay không
+func (t *TicketType) IsLimited() bool {
+	return t.Quantity > 0
+}
+
+// Remaining trả về số vé còn có thể đặt; -1 nếu không giới hạn
+func (t *TicketType) Remaining() int {
+	if !t.IsLimited() {
+		return -1
+	}
+	if left := t.Quantity - t.Sold - t.Reserved; left > 0 {
+		return left
+	}
+	return 0
+}
+
+// OnSale cho biết loại vé có đang trong thời gian mở bán tại thời điểm at hay không
+func (t *TicketType) OnSale(at time.Time) bool {
+	if t.SaleStart != nil && at.Before(*t.SaleStart) {
+		return false
+	}
+	if t.SaleEnd != nil && !at.Before(*t.SaleEnd) {
+		return false
+	}
+	return true
+}
+
+// IsEarlyBird cho biết giá ưu đãi có áp dụng tại thời điểm at hay không
+func (t *TicketType) IsEarlyBird(at time.Time) bool {
+	return t.EarlyBirdUntil != nil && at.Before(*t.EarlyBirdUntil)
+}
+
+// PriceAt trả về giá vé tại thời điểm at
+func (t *TicketType) PriceAt(at time.Time) int64 {
+	if t.IsEarlyBird(at) {
+		return t.EarlyBirdPrice
+	}
+	return t.Price
+}
@@ -5,12 +5,13 @@ import (
 )
 
 type User struct {
-	ID        string
-	Username  string
-	Email     string
-	FullName  string
-	Status    string
-	Role      string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID           string
+	Username     string
+	Email        string
+	FullName     string
+	PasswordHash string
+	Status       string
+	Role         string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
 }
@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+// TimeSlot là một khoảng thời gian [From, To)
+type TimeSlot struct {
+	From time.Time
+	To   time.Time
+}
+
+// VenueBooking là một sự kiện chiếm địa điểm; Blocked* đã cộng thời gian setup / teardown
+type VenueBooking struct {
+	EventID     string
+	EventName   string
+	StartDate   time.Time
+	EndDate     time.Time
+	BlockedFrom time.Time
+	BlockedTo   time.Time
+}
+
+// VenueAvailability là lịch bận / rảnh của địa điểm trong khoảng [From, To)
+type VenueAvailability struct {
+	LocationID     string
+	From           time.Time
+	To             time.Time
+	SetupBuffer    time.Duration
+	TeardownBuffer time.Duration
+	Busy           []VenueBooking
+	Free           []TimeSlot
+}
@@ -28,4 +28,19 @@ type AggregateRepo interface {
 
 	// AggregateGuestStatsByEvent returns attendance statistics for a single event.
 	AggregateGuestStatsByEvent(ctx context.Context, eventID string) (*models.EventStatModel, error)
+
+	// AggregateLiveOccupancy returns the headcount of every event that has guests on site, busiest first.
+	AggregateLiveOccupancy(ctx context.Context) ([]*models.EventOccupancyAggregation, error)
+
+	// AggregateOccupancyByEvent returns the headcount of a single event.
+	AggregateOccupancyByEvent(ctx context.Context, eventID string) (*models.EventOccupancyAggregation, error)
+
+	// AggregateDwellTimeByEvent returns average visit and per-guest stay durations of a single event.
+	AggregateDwellTimeByEvent(ctx context.Context, eventID string) (*models.EventDwellTimeAggregation, error)
+
+	// AggregateGuestStatsBySession returns registration and attendance counts for every session of an event, by start time.
+	AggregateGuestStatsBySession(ctx context.Context, eventID string) ([]*models.SessionGuestAggregation, error)
+
+	// AggregateSessionStat returns registration and attendance counts of a single session, nil when it does not exist.
+	AggregateSessionStat(ctx context.Context, sessionID string) (*models.SessionGuestAggregation, error)
 }
@@ -0,0 +1,21 @@
+package repository_interface
+
+import (
+	"context"
+
+	"event_manager/internal/models"
+)
+
+type CalendarTokenRepository interface {
+	// Insert stores a new calendar token.
+	Insert(ctx context.Context, m *models.CalendarTokenModel) error
+
+	// FindByHash fetches a calendar token by the SHA256 hash of its raw value.
+	FindByHash(ctx context.Context, tokenHash string) (*models.CalendarTokenModel, error)
+
+	// RevokeBySubject revokes every active token of the subject.
+	RevokeBySubject(ctx context.Context, subjectType, subjectID string) error
+
+	// ReassignSubject moves every token of one subject to another subject of the same type.
+	ReassignSubject(ctx context.Context, subjectType, fromID, toID string) error
+}
@@ -0,0 +1,15 @@
+package repository_interface
+
+import (
+	"context"
+
+	"event_manager/internal/models"
+)
+
+type CheckInSyncRepository interface {
+	// FindByIDs returns the already synced operations among the given keys.
+	FindByIDs(ctx context.Context, ids []string) ([]*models.CheckInSyncOpModel, error)
+
+	// Insert records the outcome of an operation; recording the same key twice is not an error.
+	Insert(ctx context.Context, m *models.CheckInSyncOpModel) error
+}
@@ -0,0 +1,11 @@
+package repository_interface
+
+import "errors"
+
+var (
+	// ErrDuplicate được trả về khi thao tác ghi vi phạm ràng buộc unique.
+	ErrDuplicate = errors.New("duplicate key")
+
+	// ErrInvalidCursor được trả về khi con trỏ phân trang không giải mã được.
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
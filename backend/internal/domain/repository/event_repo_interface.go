@@ -2,14 +2,62 @@ package repository_interface
 
 import (
 	"context"
+	"time"
+
+	"event_manager/internal/domain/entity"
 	"event_manager/internal/models"
 )
 
 type EventRepository interface {
 	Insert(ctx context.Context, m *models.EventModel) error
+	// InsertMany thêm nhiều sự kiện (các buổi của một chuỗi) theo thứ tự
+	InsertMany(ctx context.Context, list []*models.EventModel) error
 	Update(ctx context.Context, m *models.EventModel) error
 	Delete(ctx context.Context, id string) error
 	FindByID(ctx context.Context, id string) (*models.EventModel, error)
 	FindAll(ctx context.Context) ([]*models.EventModel, error)
+	FindByIDs(ctx context.Context, ids []string) ([]*models.EventModel, error)
+
+	// FindByQuery runs filtering, sorting and pagination in Mongo. It returns one page of events,
+	// the total number of matching events and the cursor of the next page ("" on the last page).
+	FindByQuery(ctx context.Context, q entity.EventQuery) ([]*models.EventModel, int64, string, error)
+
 	FindUpcoming(ctx context.Context) ([]*models.EventModel, error)
+	FindByOrganizer(ctx context.Context, userID string) ([]*models.EventModel, error)
+
+	// FindBySeries lists the occurrences of a recurring series ordered by occurrence start.
+	FindBySeries(ctx context.Context, seriesID string) ([]*models.EventModel, error)
+
+	// FindByICalUID lists events imported from the VEVENT with this UID (all occurrences of a series),
+	// ordered by start date.
+	FindByICalUID(ctx context.Context, uid string) ([]*models.EventModel, error)
+
+	// SetOccurrence moves the event into a series and sets its original occurrence start.
+	SetOccurrence(ctx context.Context, id, seriesID string, occurrenceStart time.Time) error
+
+	// CountByLocation counts events referencing the location.
+	CountByLocation(ctx context.Context, locationID string) (int64, error)
+
+	// FindByLocationBetween lists events of the location overlapping [from, to), ordered by start date.
+	FindByLocationBetween(ctx context.Context, locationID string, from, to time.Time) ([]*models.EventModel, error)
+
+	// SetLocation links the event to a location and stores its display name.
+	SetLocation(ctx context.Context, id, locationID, name string) error
+
+	// RenameLocation refreshes the display name on every event of the location.
+	RenameLocation(ctx context.Context, locationID, name string) error
+
+	UpdateOrganizers(ctx context.Context, id string, ownerID string, coOrganizers []models.CoOrganizerModel) error
+
+	// InitSeatCounter sets seats_taken for events created before capacity tracking existed (no-op otherwise).
+	InitSeatCounter(ctx context.Context, id string, taken int) error
+
+	// SetSeatCounter overwrites seats_taken, e.g. when an open event becomes limited.
+	SetSeatCounter(ctx context.Context, id string, taken int) error
+
+	// ReserveSeat atomically takes one seat if seats_taken < max_guests; returns false when the event is full.
+	ReserveSeat(ctx context.Context, id string) (bool, error)
+
+	// ReleaseSeat atomically gives back one seat.
+	ReleaseSeat(ctx context.Context, id string) error
 }
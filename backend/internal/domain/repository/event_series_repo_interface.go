@@ -0,0 +1,13 @@
+package repository_interface
+
+import (
+	"context"
+	"event_manager/internal/models"
+)
+
+type EventSeriesRepository interface {
+	Insert(ctx context.Context, m *models.EventSeriesModel) error
+	Update(ctx context.Context, m *models.EventSeriesModel) error
+	Delete(ctx context.Context, id string) error
+	FindByID(ctx context.Context, id string) (*models.EventSeriesModel, error)
+}
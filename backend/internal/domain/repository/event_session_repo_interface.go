@@ -0,0 +1,62 @@
+package repository_interface
+
+import (
+	"context"
+	"time"
+
+	"event_manager/internal/models"
+)
+
+type EventSessionRepository interface {
+	// Insert stores a new session of an event.
+	Insert(ctx context.Context, m *models.EventSessionModel) error
+
+	// Update persists the editable fields of a session; the seat counter is left untouched.
+	Update(ctx context.Context, m *models.EventSessionModel) error
+
+	// Delete removes a session by identifier.
+	Delete(ctx context.Context, id string) error
+
+	// FindByID fetches a session by identifier, nil when none exists.
+	FindByID(ctx context.Context, id string) (*models.EventSessionModel, error)
+
+	// FindByIDs fetches the sessions with the given identifiers; unknown identifiers are skipped.
+	FindByIDs(ctx context.Context, ids []string) ([]*models.EventSessionModel, error)
+
+	// FindByEvent lists the sessions of an event ordered by start time (the agenda).
+	FindByEvent(ctx context.Context, eventID string) ([]*models.EventSessionModel, error)
+
+	// ReserveSeat atomically takes one seat if the session is unlimited or seats_taken < capacity;
+	// returns false when the session is full.
+	ReserveSeat(ctx context.Context, id string) (bool, error)
+
+	// ReleaseSeat atomically gives back one seat.
+	ReleaseSeat(ctx context.Context, id string) error
+}
+
+type SessionRegistrationRepository interface {
+	// Insert stores a new session registration. Returns ErrDuplicate when the event registration
+	// already has a record for the session, including a cancelled one.
+	Insert(ctx context.Context, m *models.SessionRegistrationModel) error
+
+	// FindBySessionAndRegistration fetches the record of an event registration for a session, nil when none exists.
+	FindBySessionAndRegistration(ctx context.Context, sessionID, registrationID string) (*models.SessionRegistrationModel, error)
+
+	// FindBySession lists the active (not cancelled) registrations of a session, oldest first.
+	FindBySession(ctx context.Context, sessionID string) ([]*models.SessionRegistrationModel, error)
+
+	// FindByRegistration lists the active session registrations of an event registration.
+	FindByRegistration(ctx context.Context, registrationID string) ([]*models.SessionRegistrationModel, error)
+
+	// CountActiveBySession counts the registrations of a session that are not cancelled.
+	CountActiveBySession(ctx context.Context, sessionID string) (int, error)
+
+	// Reactivate moves a cancelled record back to "registered"; returns false when it is no longer cancelled.
+	Reactivate(ctx context.Context, id string, at time.Time) (bool, error)
+
+	// Cancel marks an active record as cancelled; returns false when it was already cancelled.
+	Cancel(ctx context.Context, id string, at time.Time) (bool, error)
+
+	// CheckIn marks a "registered" record as checked in; returns false when it is not "registered".
+	CheckIn(ctx context.Context, id string, at time.Time) (bool, error)
+}
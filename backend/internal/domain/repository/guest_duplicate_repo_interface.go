@@ -0,0 +1,25 @@
+package repository_interface
+
+import (
+	"context"
+
+	"event_manager/internal/domain/entity"
+	"event_manager/internal/models"
+)
+
+type GuestDuplicateRepository interface {
+	// UpsertMany stores the candidate pairs of a scan, keeping the dismissed flag of known pairs.
+	UpsertMany(ctx context.Context, pairs []*models.GuestDuplicateModel) error
+
+	// DeleteStale removes pairs that were not found again by the given scan.
+	DeleteStale(ctx context.Context, scanID string) error
+
+	// Search returns one page of pairs, highest score first, and the total number of matches.
+	Search(ctx context.Context, q entity.GuestDuplicateQuery) ([]*models.GuestDuplicateModel, int64, error)
+
+	// SetDismissed marks a pair as (not) a duplicate; returns false when the pair does not exist.
+	SetDismissed(ctx context.Context, pairID string, dismissed bool) (bool, error)
+
+	// DeleteByGuests removes every pair involving one of the guests.
+	DeleteByGuests(ctx context.Context, guestIDs []string) error
+}
@@ -0,0 +1,22 @@
+package repository_interface
+
+import (
+	"context"
+
+	"event_manager/internal/models"
+)
+
+type GuestImportJobRepository interface {
+	// Insert stores a new import job.
+	Insert(ctx context.Context, m *models.GuestImportJobModel) error
+
+	// FindByID fetches an import job with its row report.
+	FindByID(ctx context.Context, jobID string) (*models.GuestImportJobModel, error)
+
+	// SaveProgress writes the status and counters of the job and appends the given rows to its report.
+	SaveProgress(ctx context.Context, m *models.GuestImportJobModel, rows []models.GuestImportRowModel) error
+
+	// FailUnfinished marks every pending or running job as failed with the given reason and returns
+	// how many were updated.
+	FailUnfinished(ctx context.Context, reason string) (int64, error)
+}
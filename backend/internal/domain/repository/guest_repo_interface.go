@@ -2,6 +2,7 @@ package repository_interface
 
 import (
 	"context"
+	"event_manager/internal/domain/entity"
 	"event_manager/internal/models"
 )
 
@@ -18,9 +19,30 @@ type GuestRepository interface {
 	// FindByID fetches a guest model by identifier.
 	FindByID(ctx context.Context, guestID string) (*models.GuestModel, error)
 
+	// FindByIDs fetches the guests with the given identifiers.
+	FindByIDs(ctx context.Context, guestIDs []string) ([]*models.GuestModel, error)
+
 	// FindAll lists all guest models.
 	FindAll(ctx context.Context) ([]*models.GuestModel, error)
 
 	// FindByEmail locates a guest by email address.
 	FindByEmail(ctx context.Context, email string) (*models.GuestModel, error)
+
+	// FindByPhone locates a guest by phone number in E.164 form.
+	FindByPhone(ctx context.Context, phone string) (*models.GuestModel, error)
+
+	// Search returns one page of guests matching the query and the total number of matches.
+	Search(ctx context.Context, q entity.GuestQuery) ([]*models.GuestModel, int64, error)
+
+	// AddEvent records that the guest is registered to the event (idempotent).
+	AddEvent(ctx context.Context, guestID, eventID string) error
+
+	// RemoveEvent drops the event from the guest's registered events (idempotent).
+	RemoveEvent(ctx context.Context, guestID, eventID string) error
+
+	// RemoveEventFromAll drops the event from every guest, e.g. when the event is deleted.
+	RemoveEventFromAll(ctx context.Context, eventID string) error
+
+	// StreamAll passes every guest to fn one at a time; an error from fn stops the iteration.
+	StreamAll(ctx context.Context, fn func(*models.GuestModel) error) error
 }
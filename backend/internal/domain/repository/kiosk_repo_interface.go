@@ -0,0 +1,36 @@
+package repository_interface
+
+import (
+	"context"
+	"time"
+
+	"event_manager/internal/models"
+)
+
+type KioskSessionRepository interface {
+	// Insert stores a new kiosk session.
+	Insert(ctx context.Context, m *models.KioskSessionModel) error
+
+	// FindByID fetches a kiosk session by identifier.
+	FindByID(ctx context.Context, id string) (*models.KioskSessionModel, error)
+
+	// FindByHash fetches a kiosk session by the SHA256 hash of its raw token.
+	FindByHash(ctx context.Context, tokenHash string) (*models.KioskSessionModel, error)
+
+	// FindByEvent lists the kiosk sessions of an event, newest first.
+	FindByEvent(ctx context.Context, eventID string) ([]*models.KioskSessionModel, error)
+
+	// Revoke marks the session as revoked; revoking twice keeps the first time.
+	Revoke(ctx context.Context, id string, at time.Time) error
+
+	// Touch records the last time the kiosk used its token.
+	Touch(ctx context.Context, id string, at time.Time) error
+}
+
+type KioskAuditRepository interface {
+	// Insert appends an entry to the audit trail.
+	Insert(ctx context.Context, m *models.KioskAuditModel) error
+
+	// FindByKiosk lists the latest entries of a kiosk, newest first.
+	FindByKiosk(ctx context.Context, kioskID string, limit int) ([]*models.KioskAuditModel, error)
+}
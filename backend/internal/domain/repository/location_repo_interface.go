@@ -0,0 +1,22 @@
+package repository_interface
+
+import (
+	"context"
+	"event_manager/internal/models"
+)
+
+type LocationRepository interface {
+	// Insert trả về ErrDuplicate khi đã có địa điểm trùng tên (sau chuẩn hoá)
+	Insert(ctx context.Context, m *models.LocationModel) error
+	Update(ctx context.Context, m *models.LocationModel) error
+	Delete(ctx context.Context, id string) error
+	FindByID(ctx context.Context, id string) (*models.LocationModel, error)
+	FindAll(ctx context.Context) ([]*models.LocationModel, error)
+
+	// FindByNameKey tìm địa điểm theo tên đã chuẩn hoá (models.LocationNameKey)
+	FindByNameKey(ctx context.Context, nameKey string) (*models.LocationModel, error)
+
+	// LockBookings ghi vào bản ghi địa điểm để hai transaction cùng đặt lịch địa điểm xung đột ghi
+	// (write conflict) và transaction sau được chạy lại, nhìn thấy lịch vừa đặt
+	LockBookings(ctx context.Context, id string) error
+}
@@ -0,0 +1,45 @@
+package repository_interface
+
+import (
+	"context"
+
+	"event_manager/internal/models"
+)
+
+type ProfileRepository interface {
+	// Insert stores a new speaker or sponsor profile.
+	Insert(ctx context.Context, m *models.ProfileModel) error
+
+	// Update persists the editable fields of a profile; kind and photo are left untouched.
+	Update(ctx context.Context, m *models.ProfileModel) error
+
+	// SetPhoto replaces the photo URL of a profile.
+	SetPhoto(ctx context.Context, id, photoURL string) error
+
+	// Delete removes a profile by identifier.
+	Delete(ctx context.Context, id string) error
+
+	// FindByID fetches a profile by identifier, nil when none exists.
+	FindByID(ctx context.Context, id string) (*models.ProfileModel, error)
+
+	// FindByIDs fetches the profiles with the given identifiers; unknown identifiers are skipped.
+	FindByIDs(ctx context.Context, ids []string) ([]*models.ProfileModel, error)
+
+	// FindAll lists profiles ordered by name; an empty kind lists every kind.
+	FindAll(ctx context.Context, kind string) ([]*models.ProfileModel, error)
+}
+
+type ProfileAssignmentRepository interface {
+	// Insert links a profile to an event or one of its sessions. Returns ErrDuplicate when the
+	// link already exists.
+	Insert(ctx context.Context, m *models.ProfileAssignmentModel) error
+
+	// Delete removes a link; returns false when it did not exist.
+	Delete(ctx context.Context, eventID, sessionID, profileID string) (bool, error)
+
+	// DeleteByProfile removes every link of a profile.
+	DeleteByProfile(ctx context.Context, profileID string) error
+
+	// FindByEvent lists the links of an event and its sessions, oldest first.
+	FindByEvent(ctx context.Context, eventID string) ([]*models.ProfileAssignmentModel, error)
+}
@@ -0,0 +1,21 @@
+package repository_interface
+
+import (
+	"context"
+
+	"event_manager/internal/models"
+)
+
+type RefreshTokenRepository interface {
+	// Insert stores a new refresh token model.
+	Insert(ctx context.Context, model *models.RefreshTokenModel) error
+
+	// FindByHash fetches a refresh token by the SHA256 hash of its raw value.
+	FindByHash(ctx context.Context, tokenHash string) (*models.RefreshTokenModel, error)
+
+	// Revoke marks a single refresh token as revoked; returns false if it was already revoked.
+	Revoke(ctx context.Context, id string) (bool, error)
+
+	// RevokeAllByUser revokes every active refresh token of the user.
+	RevokeAllByUser(ctx context.Context, userID string) error
+}
@@ -2,12 +2,14 @@ package repository_interface
 
 import (
 	"context"
+	"time"
 
 	"event_manager/internal/models"
 )
 
 type RegistrationRepository interface {
-	// Insert stores a new registration model.
+	// Insert stores a new registration model. Returns ErrDuplicate when the guest is already
+	// registered to the event.
 	Insert(ctx context.Context, model *models.RegistrationModel) error
 
 	// Update persists registration changes.
@@ -19,9 +21,57 @@ type RegistrationRepository interface {
 	// FindByID fetches a registration model by identifier.
 	FindByID(ctx context.Context, registrationID string) (*models.RegistrationModel, error)
 
+	// FindByIDs fetches the registrations with the given identifiers; unknown identifiers are skipped.
+	FindByIDs(ctx context.Context, registrationIDs []string) ([]*models.RegistrationModel, error)
+
 	// FindByEvent lists registration models by event identifier.
 	FindByEvent(ctx context.Context, eventID string) ([]*models.RegistrationModel, error)
 
 	// FindByGuest lists registration models by guest identifier.
 	FindByGuest(ctx context.Context, guestID string) ([]*models.RegistrationModel, error)
+
+	// FindByEventAndGuest fetches the registration of a guest for an event, nil when none exists.
+	FindByEventAndGuest(ctx context.Context, eventID, guestID string) (*models.RegistrationModel, error)
+
+	// CountHoldingSeat counts registrations of the event that occupy a seat (not cancelled, not waitlisted).
+	CountHoldingSeat(ctx context.Context, eventID string) (int, error)
+
+	// Transition atomically moves the registration from status "from" to entry.To and appends entry
+	// to its history; returns false when the current status is no longer "from". Checking in opens a
+	// new entry in "visits" and checking out closes the open one.
+	Transition(ctx context.Context, registrationID, from string, checkedIn bool, entry models.RegistrationTransitionModel) (bool, error)
+
+	// AddKioskConfirmFailure atomically counts one failed kiosk confirmation and returns the new total.
+	AddKioskConfirmFailure(ctx context.Context, registrationID string) (int, error)
+
+	// BumpTicketVersion atomically increments the ticket version of a registration and records when the
+	// new ticket was issued; returns the updated registration, nil when none exists.
+	BumpTicketVersion(ctx context.Context, registrationID string, at time.Time) (*models.RegistrationModel, error)
+
+	// PromoteOldestWaitlisted atomically moves the oldest waitlisted registration of the event to entry.To.
+	// Returns nil when the waitlist is empty.
+	PromoteOldestWaitlisted(ctx context.Context, eventID string, entry models.RegistrationTransitionModel) (*models.RegistrationModel, error)
+
+	// StreamGuestsByEvent joins the registrations of an event with their guests, ordered by guest
+	// name, and passes them to fn one at a time; an error from fn stops the iteration.
+	StreamGuestsByEvent(ctx context.Context, eventID string, fn func(*models.EventGuestModel) error) error
+
+	// ReassignGuest moves every registration of fromGuestID to toGuestID and returns how many moved.
+	ReassignGuest(ctx context.Context, fromGuestID, toGuestID string) (int64, error)
+
+	// StreamDuplicates passes every group of registrations that share (event_id, guest_id) to fn,
+	// oldest first; an error from fn stops the iteration.
+	StreamDuplicates(ctx context.Context, fn func([]*models.RegistrationModel) error) error
+
+	// ArchiveDuplicate copies m, with its history, into the archive collection marked as merged into
+	// keptID, then deletes it from registrations. Safe to re-run.
+	ArchiveDuplicate(ctx context.Context, m *models.RegistrationModel, keptID string) error
+
+	// EnsureUniqueGuestIndex creates the unique (event_id, guest_id) index; it fails while
+	// duplicate registrations still exist.
+	EnsureUniqueGuestIndex(ctx context.Context) error
+
+	// StreamMissingVisits passes every attended registration that has no "visits" array yet to fn,
+	// one at a time; an error from fn stops the iteration.
+	StreamMissingVisits(ctx context.Context, fn func(*models.RegistrationModel) error) error
 }
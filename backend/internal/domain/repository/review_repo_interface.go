@@ -6,6 +6,7 @@ import (
 )
 
 type ReviewRepository interface {
+	// Insert trả về ErrDuplicate khi khách đã đánh giá sự kiện này
 	Insert(ctx context.Context, m *models.ReviewModel) error
 	Update(ctx context.Context, m *models.ReviewModel) error
 	Delete(ctx context.Context, id string) error
@@ -13,4 +14,6 @@ type ReviewRepository interface {
 	FindByEvent(ctx context.Context, eventID string) ([]*models.ReviewModel, error)
 	FindByGuest(ctx context.Context, guestID string) ([]*models.ReviewModel, error)
 	AverageRating(ctx context.Context, eventID string) (float64, error)
+	// ReassignGuest chuyển mọi đánh giá của fromGuestID sang toGuestID, trả về số đánh giá đã chuyển
+	ReassignGuest(ctx context.Context, fromGuestID, toGuestID string) (int64, error)
 }
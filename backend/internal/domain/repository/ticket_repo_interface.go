@@ -0,0 +1,76 @@
+package repository_interface
+
+import (
+	"context"
+	"time"
+
+	"event_manager/internal/models"
+)
+
+type TicketTypeRepository interface {
+	// Insert stores a new ticket type of an event.
+	Insert(ctx context.Context, m *models.TicketTypeModel) error
+
+	// Update persists the editable fields of a ticket type; the sold / reserved counters are left
+	// untouched. Returns false when the new quantity is below the tickets already sold or reserved.
+	Update(ctx context.Context, m *models.TicketTypeModel) (bool, error)
+
+	// Delete removes a ticket type by identifier.
+	Delete(ctx context.Context, id string) error
+
+	// FindByID fetches a ticket type by identifier, nil when none exists.
+	FindByID(ctx context.Context, id string) (*models.TicketTypeModel, error)
+
+	// FindByEvent lists the ticket types of an event ordered by price.
+	FindByEvent(ctx context.Context, eventID string) ([]*models.TicketTypeModel, error)
+
+	// HasPaidTypes reports whether the event sells at least one ticket type with a non-zero price.
+	HasPaidTypes(ctx context.Context, eventID string) (bool, error)
+
+	// Reserve atomically holds one ticket if the type is unlimited or sold + reserved < quantity;
+	// returns false when it is sold out.
+	Reserve(ctx context.Context, id string) (bool, error)
+
+	// Release atomically gives back one held ticket.
+	Release(ctx context.Context, id string) error
+
+	// ConfirmSale atomically turns one held ticket into a sold one.
+	ConfirmSale(ctx context.Context, id string) error
+}
+
+type OrderRepository interface {
+	// Insert stores a new order. Returns ErrDuplicate when the guest already has a pending order
+	// for the event.
+	Insert(ctx context.Context, m *models.OrderModel) error
+
+	// SetPayment records the payment created at the provider for a pending order.
+	SetPayment(ctx context.Context, id, provider, paymentID, checkoutURL string) error
+
+	// Transition atomically moves the order from status "from" to m.Status, together with reason,
+	// registration and paid time; returns false when the current status is no longer "from".
+	Transition(ctx context.Context, m *models.OrderModel, from string) (bool, error)
+
+	// FindByID fetches an order by identifier, nil when none exists.
+	FindByID(ctx context.Context, id string) (*models.OrderModel, error)
+
+	// FindByPayment fetches the order of a provider payment, nil when none exists.
+	FindByPayment(ctx context.Context, provider, paymentID string) (*models.OrderModel, error)
+
+	// FindByEvent lists the orders of an event, newest first; an empty status lists every status.
+	FindByEvent(ctx context.Context, eventID, status string) ([]*models.OrderModel, error)
+
+	// FindExpired lists up to limit pending orders whose hold ended before now, oldest first;
+	// an empty ticketTypeID lists every ticket type.
+	FindExpired(ctx context.Context, ticketTypeID string, now time.Time, limit int) ([]*models.OrderModel, error)
+
+	// CountByTicketType counts the orders placed for a ticket type, whatever their status.
+	CountByTicketType(ctx context.Context, ticketTypeID string) (int, error)
+
+	// ReassignGuest moves every order of fromGuestID to toGuestID and returns how many moved.
+	// Returns ErrDuplicate when both guests have a pending order for the same event.
+	ReassignGuest(ctx context.Context, fromGuestID, toGuestID string) (int64, error)
+
+	// ReassignRegistration points every order of fromRegistrationID at toRegistrationID and returns
+	// how many moved.
+	ReassignRegistration(ctx context.Context, fromRegistrationID, toRegistrationID string) (int64, error)
+}
@@ -0,0 +1,10 @@
+package repository_interface
+
+import "context"
+
+// Transactor runs fn inside a database transaction. Repository calls made with the ctx passed
+// to fn take part in the transaction; it is committed when fn returns nil and aborted otherwise.
+// fn may be retried on transient errors, so it must not keep state between attempts.
+type Transactor interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
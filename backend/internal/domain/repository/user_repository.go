@@ -18,4 +18,8 @@ type UserRepository interface {
 	FindByUsername(ctx context.Context, username string) (*models.UserModel, error)
 
 	List(ctx context.Context, limit, offset int) ([]*models.UserModel, error)
+
+	Count(ctx context.Context) (int64, error)
+
+	CountByRole(ctx context.Context, role string) (int64, error)
 }
@@ -28,4 +28,19 @@ type AggregateService interface {
 
 	// GuestStatsSummaryByEvent returns attendance statistics for the specified event.
 	GuestStatsSummaryByEvent(ctx context.Context, eventID string) (*entity.EventStat, error)
+
+	// LiveOccupancy returns the headcount of every event that currently has guests on site.
+	LiveOccupancy(ctx context.Context) ([]*entity.EventOccupancy, error)
+
+	// OccupancyByEvent returns the headcount of the specified event.
+	OccupancyByEvent(ctx context.Context, eventID string) (*entity.EventOccupancy, error)
+
+	// DwellTimeByEvent returns how long guests stayed at the specified event on average.
+	DwellTimeByEvent(ctx context.Context, eventID string) (*entity.EventDwellTime, error)
+
+	// GuestStatsBySession returns aggregated guest statistics for every session of the specified event.
+	GuestStatsBySession(ctx context.Context, eventID string) ([]*entity.SessionGuestStat, error)
+
+	// GuestStatsSummaryBySession returns attendance statistics for the specified session.
+	GuestStatsSummaryBySession(ctx context.Context, sessionID string) (*entity.SessionGuestStat, error)
 }
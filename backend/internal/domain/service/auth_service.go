@@ -0,0 +1,22 @@
+package service_interface
+
+import (
+	"context"
+
+	"event_manager/internal/domain/entity"
+)
+
+// AuthService xử lý đăng nhập, cấp lại token và xác thực access token
+type AuthService interface {
+	// Login kiểm tra username/password và cấp cặp access + refresh token.
+	Login(ctx context.Context, username, password string) (*entity.TokenPair, error)
+
+	// Refresh xoay vòng refresh token: token cũ bị thu hồi, trả về cặp token mới.
+	Refresh(ctx context.Context, refreshToken string) (*entity.TokenPair, error)
+
+	// Logout thu hồi refresh token của phiên hiện tại.
+	Logout(ctx context.Context, refreshToken string) error
+
+	// Authenticate xác thực access token và trả về user đang hoạt động.
+	Authenticate(ctx context.Context, accessToken string) (*entity.User, error)
+}
@@ -0,0 +1,19 @@
+package service_interface
+
+import (
+	"context"
+
+	"event_manager/internal/domain/entity"
+)
+
+// CalendarService cấp token và dựng dữ liệu cho feed lịch .ics
+type CalendarService interface {
+	// IssueToken thu hồi token cũ và cấp token feed mới cho chủ thể (user hoặc khách mời).
+	IssueToken(ctx context.Context, subjectType, subjectID string) (string, error)
+
+	// RevokeTokens thu hồi mọi token feed của chủ thể.
+	RevokeTokens(ctx context.Context, subjectType, subjectID string) error
+
+	// Feed trả về các sự kiện của chủ thể sở hữu token; token sai / đã thu hồi trả ErrInvalidToken.
+	Feed(ctx context.Context, token string) (*entity.CalendarFeed, error)
+}
@@ -0,0 +1,72 @@
+package service_interface
+
+import (
+	"errors"
+	"fmt"
+
+	"event_manager/internal/domain/entity"
+)
+
+// Các lỗi nghiệp vụ dùng chung để handler map sang HTTP status phù hợp.
+var (
+	ErrInvalidCredentials = errors.New("invalid username or password")
+	ErrInvalidToken       = errors.New("invalid or expired token")
+	ErrUserInactive       = errors.New("user is inactive")
+	ErrForbidden          = errors.New("permission denied")
+	ErrNotFound           = errors.New("not found")
+	ErrInvalidTransition  = errors.New("invalid registration status transition")
+	ErrStatusConflict     = errors.New("registration status changed concurrently, please retry")
+	ErrEventFull          = errors.New("event is full")
+	ErrAlreadyRegistered  = errors.New("guest is already registered to this event")
+	ErrInvalidRole        = errors.New("invalid role")
+	ErrInvalidQuery       = errors.New("invalid query")
+	ErrInvalidRating      = errors.New("rating must be between 1 and 5")
+	ErrNotCheckedIn       = errors.New("only guests who checked in can review this event")
+	ErrAlreadyReviewed    = errors.New("guest has already reviewed this event")
+	ErrDuplicateLocation  = errors.New("a location with the same name already exists")
+	ErrLocationInUse      = errors.New("location is used by existing events")
+	ErrExceedsCapacity    = errors.New("max guests exceeds location capacity")
+	ErrBelowSeatsTaken    = errors.New("max guests is below the seats already taken")
+	ErrVenueConflict      = errors.New("location is already booked for this time")
+	ErrInvalidRecurrence  = errors.New("invalid recurrence")
+	ErrOccurrenceInUse    = errors.New("occurrences removed by this change already have registrations or orders")
+	ErrEventInUse         = errors.New("event already has registrations or orders")
+	ErrInvalidImportFile  = errors.New("invalid import file")
+	ErrInvalidMerge       = errors.New("invalid guest merge")
+	ErrScanInProgress     = errors.New("a duplicate scan is already running")
+	ErrInvalidPhone       = errors.New("invalid phone number")
+	ErrInvalidTicket      = errors.New("invalid ticket")
+	ErrNoTicket           = errors.New("registration has no valid ticket")
+	ErrAlreadyCheckedIn   = errors.New("guest is already checked in")
+	ErrInvalidSync        = errors.New("invalid check-in sync batch")
+	ErrConfirmMismatch    = errors.New("confirmation does not match the guest")
+	ErrKioskLocked        = errors.New("too many failed confirmations, please check in at the front desk")
+	ErrInvalidSession     = errors.New("invalid session")
+	ErrSessionFull        = errors.New("session is full")
+	ErrSessionConflict    = errors.New("session overlaps another session")
+	ErrSessionInUse       = errors.New("session has active registrations")
+	ErrNotAttending       = errors.New("event registration is cancelled or waitlisted")
+	ErrInvalidProfile     = errors.New("invalid profile")
+	ErrInvalidTicketType  = errors.New("invalid ticket type")
+	ErrTicketTypeInUse    = errors.New("ticket type already has orders")
+	ErrNotOnSale          = errors.New("ticket type is not on sale")
+	ErrSoldOut            = errors.New("ticket type is sold out")
+	ErrOrderPending       = errors.New("guest already has an unpaid order for this event")
+	ErrOrderClosed        = errors.New("order is no longer pending")
+	ErrInvalidWebhook     = errors.New("invalid payment webhook")
+	ErrPaymentUnavailable = errors.New("payment provider is not configured")
+	ErrPaymentRequired    = errors.New("event sells paid tickets, registrations are confirmed through ticket orders")
+)
+
+// VenueConflictError liệt kê các sự kiện trùng lịch tại cùng địa điểm; errors.Is(err, ErrVenueConflict) == true.
+type VenueConflictError struct {
+	Conflicts []entity.VenueBooking
+}
+
+func (e *VenueConflictError) Error() string {
+	return fmt.Sprintf("%s (%d overlapping events)", ErrVenueConflict.Error(), len(e.Conflicts))
+}
+
+func (e *VenueConflictError) Unwrap() error {
+	return ErrVenueConflict
+}
@@ -2,7 +2,6 @@ package service_interface
 
 import (
 	"context"
-	"time"
 
 	"event_manager/internal/domain/entity"
 )
@@ -18,11 +17,29 @@ type EventService interface {
 	// Xoá sự kiện
 	Delete(ctx context.Context, eventID string) error
 
+	// Kiểm tra user có quyền sửa sự kiện, để handler từ chối trước khi tải ảnh lên
+	AuthorizeEdit(ctx context.Context, eventID string) error
+
+	// Sửa một buổi của chuỗi lặp theo phạm vi: chỉ buổi này / buổi này và các buổi sau / toàn bộ chuỗi
+	UpdateSeries(ctx context.Context, e *entity.Event, scope entity.SeriesScope) error
+
+	// Xoá một buổi của chuỗi lặp theo phạm vi
+	DeleteSeries(ctx context.Context, eventID string, scope entity.SeriesScope) error
+
+	// Nhập sự kiện từ file .ics: chống trùng theo UID, DryRun chỉ báo cáo sẽ tạo / cập nhật gì
+	ImportEvents(ctx context.Context, items []entity.ImportedEvent, opts entity.ImportOptions) (*entity.ImportReport, error)
+
+	// Lấy quy tắc lặp và các buổi của chuỗi chứa sự kiện (series = nil nếu là sự kiện đơn)
+	ListOccurrences(ctx context.Context, eventID string) (*entity.EventSeries, []*entity.Event, error)
+
 	// Lấy thông tin 1 sự kiện
 	GetByID(ctx context.Context, eventID string) (*entity.Event, error)
 
-	// Lấy danh sách sự kiện (có thể filter theo trạng thái / ngày)
-	List(ctx context.Context, status string, from, to time.Time) ([]*entity.Event, error)
+	// Lấy một trang sự kiện theo điều kiện lọc / sắp xếp / phân trang
+	List(ctx context.Context, q entity.EventQuery) (*entity.EventPage, error)
+
+	// Đổi chủ sự kiện và danh sách đồng tổ chức (chỉ chủ sự kiện hoặc admin)
+	SetOrganizers(ctx context.Context, eventID, ownerID string, coOrganizers []entity.CoOrganizer) error
 
 	// Cập nhật trạng thái sự kiện dựa vào thời gian
 	AutoUpdateStatus(ctx context.Context) error
@@ -0,0 +1,41 @@
+package service_interface
+
+import (
+	"context"
+
+	"event_manager/internal/domain/entity"
+)
+
+// EventSessionService quản lý chương trình (các phiên) của sự kiện và đăng ký / check-in theo phiên
+type EventSessionService interface {
+	// Create thêm một phiên vào chương trình; phiên phải nằm trong thời gian của sự kiện
+	// và không trùng giờ với phiên khác cùng phòng.
+	Create(ctx context.Context, session *entity.EventSession) error
+
+	// Update sửa thông tin phiên; sức chứa không được nhỏ hơn số khách đã đăng ký.
+	Update(ctx context.Context, session *entity.EventSession) error
+
+	// Delete xoá một phiên chưa có đăng ký còn hiệu lực.
+	Delete(ctx context.Context, eventID, sessionID string) error
+
+	// GetByID trả về một phiên thuộc sự kiện.
+	GetByID(ctx context.Context, eventID, sessionID string) (*entity.EventSession, error)
+
+	// ListByEvent trả về chương trình của sự kiện, sắp theo giờ bắt đầu.
+	ListByEvent(ctx context.Context, eventID string) ([]*entity.EventSession, error)
+
+	// Register đăng ký phiên cho một đăng ký sự kiện đang giữ chỗ; gọi lại khi đã đăng ký thì trả về bản ghi hiện có.
+	Register(ctx context.Context, eventID, sessionID, registrationID string) (*entity.SessionRegistration, error)
+
+	// CancelRegistration huỷ đăng ký phiên và trả chỗ cho phiên.
+	CancelRegistration(ctx context.Context, eventID, sessionID, registrationID string) (*entity.SessionRegistration, error)
+
+	// CheckIn ghi nhận khách có mặt tại phiên.
+	CheckIn(ctx context.Context, eventID, sessionID, registrationID string) (*entity.SessionRegistration, error)
+
+	// ListRegistrations trả về các đăng ký còn hiệu lực của phiên, kèm tên khách.
+	ListRegistrations(ctx context.Context, eventID, sessionID string) ([]*entity.SessionRegistration, error)
+
+	// ScheduleByRegistration trả về các phiên khách đã đăng ký, sắp theo giờ bắt đầu.
+	ScheduleByRegistration(ctx context.Context, registrationID string) ([]*entity.EventSession, error)
+}
@@ -0,0 +1,26 @@
+package service_interface
+
+import (
+	"context"
+
+	"event_manager/internal/domain/entity"
+)
+
+// GuestDedupService finds guests that are likely the same person and merges them.
+type GuestDedupService interface {
+	// DetectDuplicates scans every guest and replaces the stored candidate pairs with the result.
+	DetectDuplicates(ctx context.Context) (*entity.GuestDuplicateScan, error)
+
+	// StartScan runs DetectDuplicates in the background; ErrScanInProgress if one is running.
+	StartScan(ctx context.Context) error
+
+	// ListDuplicates returns one page of candidate pairs, highest score first.
+	ListDuplicates(ctx context.Context, q entity.GuestDuplicateQuery) (*entity.GuestDuplicatePage, error)
+
+	// Dismiss marks a candidate pair as two different people so later scans keep it hidden.
+	Dismiss(ctx context.Context, pairID string) error
+
+	// Merge moves the registrations, reviews and calendar tokens of the duplicates onto the
+	// survivor and deletes the duplicates, all in one transaction.
+	Merge(ctx context.Context, survivorID string, duplicateIDs []string) (*entity.GuestMergeResult, error)
+}
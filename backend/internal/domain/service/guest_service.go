@@ -2,16 +2,18 @@ package service_interface
 
 import (
 	"context"
+	"io"
 
 	"event_manager/internal/domain/entity"
 )
 
 // GuestService defines business operations for guest entities.
 type GuestService interface {
-	// Create persists a new guest in the domain.
+	// Create persists a new guest in the domain and registers them to the event.
 	Create(ctx context.Context, guest *entity.Guest, eventID string) error
 
-	// Update modifies an existing guest.
+	// Update modifies an existing guest; a non-empty eventID adds a registration to that event
+	// while keeping the guest's other registrations.
 	Update(ctx context.Context, guest *entity.Guest, eventID string) error
 
 	// Delete removes a guest by identifier.
@@ -20,9 +22,30 @@ type GuestService interface {
 	// GetByID returns a guest by identifier.
 	GetByID(ctx context.Context, guestID string) (*entity.Guest, error)
 
-	// List returns guests matching the optional keyword.
-	List(ctx context.Context, keyword string) ([]*entity.Guest, error)
+	// Search returns one page of guests matching the query, optionally limited to an event.
+	Search(ctx context.Context, q entity.GuestQuery) (*entity.GuestPage, error)
+
+	// ListEvents returns every event the guest is registered to, with the matching registration.
+	ListEvents(ctx context.Context, guestID string) ([]*entity.GuestEvent, error)
 
 	// FindByContact locates a guest using email and/or phone.
 	FindByContact(ctx context.Context, email, phone string) (*entity.Guest, error)
+
+	// ImportGuests streams a CSV or XLSX file, creating or reusing guests and registering them
+	// to the event, and returns the per-row report.
+	ImportGuests(ctx context.Context, req entity.GuestImportRequest, r io.Reader) (*entity.GuestImportJob, error)
+
+	// StartImport runs the same import as a background job and returns it in pending state.
+	StartImport(ctx context.Context, req entity.GuestImportRequest, r io.Reader) (*entity.GuestImportJob, error)
+
+	// GetImportJob returns the progress and report of a background import.
+	GetImportJob(ctx context.Context, jobID string) (*entity.GuestImportJob, error)
+
+	// FailUnfinishedImports marks background imports left pending or running by a previous process
+	// as failed; call it on startup.
+	FailUnfinishedImports(ctx context.Context) (int64, error)
+
+	// StopImports cancels the running background imports and waits until they have saved their
+	// final state or ctx is done; no new import can start afterwards.
+	StopImports(ctx context.Context)
 }
@@ -0,0 +1,39 @@
+package service_interface
+
+import (
+	"context"
+	"time"
+
+	"event_manager/internal/domain/entity"
+)
+
+// KioskService quản lý thiết bị kiosk và cho khách tự tìm đăng ký, tự check-in.
+// Các thao tác của kiosk lấy phiên từ utils.KioskFromContext.
+type KioskService interface {
+	// CreateSession cấp token cho một kiosk của sự kiện; token thô chỉ được trả về một lần.
+	CreateSession(ctx context.Context, eventID, name string, ttl time.Duration) (*entity.KioskSession, string, error)
+
+	// ListSessions liệt kê các kiosk của sự kiện.
+	ListSessions(ctx context.Context, eventID string) ([]*entity.KioskSession, error)
+
+	// RevokeSession thu hồi token của kiosk ngay lập tức.
+	RevokeSession(ctx context.Context, eventID, kioskID string) error
+
+	// Audit trả về nhật ký thao tác mới nhất của kiosk.
+	Audit(ctx context.Context, eventID, kioskID string, limit int) ([]*entity.KioskAuditEntry, error)
+
+	// Authenticate tìm phiên kiosk còn hiệu lực theo token; token sai / hết hạn / đã thu hồi trả ErrInvalidToken.
+	Authenticate(ctx context.Context, token string) (*entity.KioskSession, error)
+
+	// Event trả về sự kiện mà kiosk đang phục vụ.
+	Event(ctx context.Context) (*entity.Event, error)
+
+	// LookupGuests tìm khách của sự kiện theo tiền tố tên, email hoặc SĐT; liên hệ được che bớt.
+	LookupGuests(ctx context.Context, query, clientIP string) (*entity.KioskLookupResult, error)
+
+	// CheckIn cho khách tự check-in sau khi xác nhận bằng email hoặc 4 số cuối SĐT.
+	CheckIn(ctx context.Context, registrationID, confirm, clientIP string) (*entity.KioskGuestMatch, error)
+
+	// RecordRateLimited ghi nhật ký khi kiosk bị chặn vì gửi quá nhiều yêu cầu.
+	RecordRateLimited(ctx context.Context, action, clientIP string)
+}
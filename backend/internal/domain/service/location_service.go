@@ -2,6 +2,7 @@ package service_interface
 
 import (
 	"context"
+	"time"
 
 	"event_manager/internal/domain/entity"
 )
@@ -18,6 +19,18 @@ type LocationService interface {
 
 	List(ctx context.Context) ([]*entity.Location, error)
 
+	// Tìm địa điểm theo tên (không phân biệt hoa thường / dấu), tạo mới nếu chưa có
+	Resolve(ctx context.Context, name string) (*entity.Location, error)
+
+	// Các sự kiện khác chiếm địa điểm trong [start, end), đã tính thời gian setup / teardown
+	FindConflicts(ctx context.Context, locationID string, start, end time.Time, excludeEventID string) ([]entity.VenueBooking, error)
+
+	// Khoá lịch đặt của địa điểm đến hết transaction hiện tại, gọi trước FindConflicts khi sắp ghi sự kiện
+	LockBookings(ctx context.Context, locationID string) error
+
+	// Lịch bận / rảnh của địa điểm trong [from, to)
+	Availability(ctx context.Context, locationID string, from, to time.Time) (*entity.VenueAvailability, error)
+
 	// Kiểm tra sức chứa khi thêm khách
 	CheckCapacity(ctx context.Context, locationID string, expected int) (bool, error)
 }
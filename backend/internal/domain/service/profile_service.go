@@ -0,0 +1,38 @@
+package service_interface
+
+import (
+	"context"
+
+	"event_manager/internal/domain/entity"
+)
+
+// ProfileService quản lý hồ sơ diễn giả / nhà tài trợ và việc gắn chúng vào sự kiện, phiên
+type ProfileService interface {
+	// Create thêm hồ sơ; nhà tài trợ phải có hạng tài trợ, diễn giả thì không.
+	Create(ctx context.Context, profile *entity.Profile) error
+
+	// Update sửa thông tin hồ sơ; loại hồ sơ và ảnh không đổi.
+	Update(ctx context.Context, profile *entity.Profile) error
+
+	// Delete xoá hồ sơ và gỡ nó khỏi mọi sự kiện, phiên.
+	Delete(ctx context.Context, profileID string) error
+
+	// GetByID trả về một hồ sơ.
+	GetByID(ctx context.Context, profileID string) (*entity.Profile, error)
+
+	// List trả về các hồ sơ sắp theo tên; kind rỗng lấy mọi loại.
+	List(ctx context.Context, kind entity.ProfileKind) ([]*entity.Profile, error)
+
+	// SetPhoto gán ảnh (đã tải lên kho lưu trữ) cho hồ sơ.
+	SetPhoto(ctx context.Context, profileID, photoURL string) (*entity.Profile, error)
+
+	// Assign gắn hồ sơ vào sự kiện, hoặc vào một phiên của sự kiện khi sessionID khác rỗng; gọi lại không lỗi.
+	Assign(ctx context.Context, eventID, sessionID, profileID string) error
+
+	// Unassign gỡ hồ sơ khỏi sự kiện hoặc phiên.
+	Unassign(ctx context.Context, eventID, sessionID, profileID string) error
+
+	// ListByEvent trả về các hồ sơ gắn với sự kiện hoặc các phiên của nó:
+	// nhà tài trợ theo hạng rồi tên, diễn giả theo tên.
+	ListByEvent(ctx context.Context, eventID string) ([]*entity.EventProfile, error)
+}
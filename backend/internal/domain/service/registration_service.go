@@ -14,9 +14,18 @@ type RegistrationService interface {
 	// CheckIn marks a registration as attended and returns the updated entity.
 	CheckIn(ctx context.Context, registrationID string) (*entity.Registration, error)
 
+	// CheckOut records that a checked-in guest left; checking in again starts a new visit.
+	CheckOut(ctx context.Context, registrationID string) (*entity.Registration, error)
+
 	// Cancel revokes a registration and returns the updated entity.
 	Cancel(ctx context.Context, registrationID string) (*entity.Registration, error)
 
+	// ChangeStatus applies an allowed status transition, recording actor and reason.
+	ChangeStatus(ctx context.Context, registrationID string, status entity.RegistrationStatus, reason string) (*entity.Registration, error)
+
+	// History returns the status transitions of a registration, oldest first.
+	History(ctx context.Context, registrationID string) ([]entity.RegistrationTransition, error)
+
 	// GetByID fetches a registration by identifier.
 	GetByID(ctx context.Context, registrationID string) (*entity.Registration, error)
 
@@ -25,4 +34,29 @@ type RegistrationService interface {
 
 	// ListByGuest returns registrations created by the given guest.
 	ListByGuest(ctx context.Context, guestID string) ([]*entity.Registration, error)
+
+	// Ticket returns the signed ticket token of a registration, to be rendered as a QR code.
+	Ticket(ctx context.Context, registrationID string) (string, error)
+
+	// ReissueTicket invalidates the registration's current ticket and returns a newly signed one.
+	ReissueTicket(ctx context.Context, registrationID string) (string, error)
+
+	// ScanTicket verifies a ticket token for the event being checked in and checks the guest in.
+	ScanTicket(ctx context.Context, token, eventID string) (*entity.EventGuest, error)
+
+	// SyncCheckIns applies check-in and cancel operations recorded offline by a device, once per
+	// operation ID, and reports the outcome of each, including conflicts with the server state.
+	SyncCheckIns(ctx context.Context, deviceID string, ops []entity.CheckInSyncOp) ([]entity.CheckInSyncResult, error)
+
+	// ExportGuests streams the guests of an event, with their registration, into w.
+	ExportGuests(ctx context.Context, eventID string, w EventGuestWriter) error
+}
+
+// EventGuestWriter receives the guest list of an event one row at a time.
+type EventGuestWriter interface {
+	// Begin is called once the caller is authorized, before the first row.
+	Begin(event *entity.Event) error
+
+	// Write receives the next guest, ordered by name.
+	Write(guest *entity.EventGuest) error
 }
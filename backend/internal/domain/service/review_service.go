@@ -0,0 +1,25 @@
+package service_interface
+
+import (
+	"context"
+
+	"event_manager/internal/domain/entity"
+)
+
+// ReviewService quản lý đánh giá sau sự kiện
+type ReviewService interface {
+	// Create ghi nhận đánh giá của một khách đã check-in; mỗi khách một đánh giá cho mỗi sự kiện.
+	Create(ctx context.Context, review *entity.Review) error
+
+	// Update sửa điểm và nhận xét của một đánh giá thuộc sự kiện.
+	Update(ctx context.Context, review *entity.Review) error
+
+	// Delete xoá một đánh giá thuộc sự kiện.
+	Delete(ctx context.Context, eventID, reviewID string) error
+
+	// GetByID trả về một đánh giá thuộc sự kiện.
+	GetByID(ctx context.Context, eventID, reviewID string) (*entity.Review, error)
+
+	// ListByEvent trả về các đánh giá của sự kiện (mới nhất trước) kèm điểm trung bình.
+	ListByEvent(ctx context.Context, eventID string) (*entity.ReviewSummary, error)
+}
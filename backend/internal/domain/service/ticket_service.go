@@ -0,0 +1,49 @@
+package service_interface
+
+import (
+	"context"
+	"net/http"
+
+	"event_manager/internal/domain/entity"
+)
+
+// TicketService quản lý các loại vé và giá vé của sự kiện
+type TicketService interface {
+	// CreateType thêm loại vé cho sự kiện.
+	CreateType(ctx context.Context, ticketType *entity.TicketType) error
+
+	// UpdateType sửa loại vé; số lượng không được nhỏ hơn số vé đã bán và đang giữ.
+	UpdateType(ctx context.Context, ticketType *entity.TicketType) error
+
+	// DeleteType xoá loại vé chưa có đơn nào.
+	DeleteType(ctx context.Context, eventID, ticketTypeID string) error
+
+	// GetType trả về một loại vé thuộc sự kiện.
+	GetType(ctx context.Context, eventID, ticketTypeID string) (*entity.TicketType, error)
+
+	// ListTypes trả về các loại vé của sự kiện, rẻ nhất trước.
+	ListTypes(ctx context.Context, eventID string) ([]*entity.TicketType, error)
+}
+
+// OrderService xử lý đơn mua vé: giữ vé, thanh toán qua cổng thanh toán và xác nhận đăng ký
+type OrderService interface {
+	// PlaceOrder giữ một vé (và một chỗ nếu sự kiện giới hạn) cho khách rồi tạo giao dịch ở cổng
+	// thanh toán; vé miễn phí được xác nhận ngay. Đăng ký chỉ được tạo khi thanh toán thành công.
+	PlaceOrder(ctx context.Context, eventID, ticketTypeID, guestID string) (*entity.Order, error)
+
+	// Cancel huỷ đơn chưa thanh toán và trả vé.
+	Cancel(ctx context.Context, eventID, orderID string) (*entity.Order, error)
+
+	// GetByID trả về một đơn thuộc sự kiện.
+	GetByID(ctx context.Context, eventID, orderID string) (*entity.Order, error)
+
+	// ListByEvent trả về các đơn của sự kiện, mới nhất trước; status rỗng lấy mọi trạng thái.
+	ListByEvent(ctx context.Context, eventID string, status entity.OrderStatus) ([]*entity.Order, error)
+
+	// HandleWebhook xác thực và áp dụng kết quả thanh toán do cổng thanh toán gửi về; gọi lại nhiều
+	// lần với cùng kết quả không có tác dụng phụ.
+	HandleWebhook(ctx context.Context, header http.Header, body []byte) (*entity.Order, error)
+
+	// ExpireReservations trả vé của các đơn hết thời gian giữ mà chưa thanh toán; trả về số đơn đã hết hạn.
+	ExpireReservations(ctx context.Context) (int, error)
+}
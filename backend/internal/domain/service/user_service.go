@@ -8,7 +8,7 @@ import (
 
 // 🧩 UserService định nghĩa nghiệp vụ cao hơn repository
 type UserService interface {
-	CreateUser(ctx context.Context, username, email, fullName string) error
+	CreateUser(ctx context.Context, username, email, fullName, password string) error
 
 	UpdateProfile(ctx context.Context, id string, fullName, email string) error
 
@@ -19,4 +19,7 @@ type UserService interface {
 	GetUserByID(ctx context.Context, id string) (*entity.User, error)
 
 	ListUsers(ctx context.Context, limit, offset int) ([]*entity.User, error)
+
+	// ChangeRole đổi vai trò của user (chỉ admin được gọi)
+	ChangeRole(ctx context.Context, id string, role entity.Role) error
 }
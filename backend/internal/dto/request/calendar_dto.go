@@ -0,0 +1,35 @@
+package dto
+
+// CalendarTokenResponse returns a new calendar feed token and the subscription URL built from it.
+type CalendarTokenResponse struct {
+	Token string `json:"token"`
+	URL   string `json:"url"`
+}
+
+// EventImportRequest carries options of POST /events/import/ical (multipart form or query string).
+type EventImportRequest struct {
+	DryRun          *bool  `form:"dry_run"` // default true: report only, nothing is written
+	Type            string `form:"type"`    // type of newly created events, default "Sự kiện mở"
+	DefaultLocation string `form:"default_location"`
+	AllowOverlap    bool   `form:"allow_overlap"`
+}
+
+// EventImportItemResponse is the outcome of one VEVENT.
+type EventImportItemResponse struct {
+	UID         string `json:"uid"`
+	Name        string `json:"name"`
+	Action      string `json:"action"` // create | update | unchanged | error
+	EventID     string `json:"event_id,omitempty"`
+	Occurrences int    `json:"occurrences,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// EventImportResponse summarises an import run.
+type EventImportResponse struct {
+	DryRun    bool                      `json:"dry_run"`
+	Created   int                       `json:"created"`
+	Updated   int                       `json:"updated"`
+	Unchanged int                       `json:"unchanged"`
+	Failed    int                       `json:"failed"`
+	Items     []EventImportItemResponse `json:"items"`
+}
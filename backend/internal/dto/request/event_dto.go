@@ -8,41 +8,64 @@ import (
 // 📥 EventCreateRequest
 // ======================================
 type EventCreateRequest struct {
-	Name        string    `form:"name" binding:"required"`
-	Location    string    `form:"location" binding:"required"`
-	Type        string    `form:"type" binding:"required"` // "Sự kiện mở" | "Sự kiện giới hạn"
-	Status      string    `form:"status"`                  // mặc định: "Sắp diễn ra"
-	Description string    `form:"description"`             // mô tả sự kiện (tùy chọn)
-	MaxGuests   uint      `form:"max_guests"`              // chỉ cần nếu Type = "Sự kiện giới hạn"
-	StartDate   time.Time `form:"start_date" time_format:"2006-01-02T15:04:05Z07:00" binding:"required"`
-	EndDate     time.Time `form:"end_date"   time_format:"2006-01-02T15:04:05Z07:00" binding:"required"`
+	Name         string      `form:"name" binding:"required"`
+	LocationID   string      `form:"location_id"`                                    // ưu tiên nếu có
+	Location     string      `form:"location" binding:"required_without=LocationID"` // tên địa điểm, tự tìm/tạo theo tên
+	Type         string      `form:"type" binding:"required"`                        // "Sự kiện mở" | "Sự kiện giới hạn"
+	Status       string      `form:"status"`                                         // mặc định: "Sắp diễn ra"
+	Description  string      `form:"description"`                                    // mô tả sự kiện (tùy chọn)
+	MaxGuests    uint        `form:"max_guests"`                                     // chỉ cần nếu Type = "Sự kiện giới hạn"
+	StartDate    time.Time   `form:"start_date" time_format:"2006-01-02T15:04:05Z07:00" binding:"required"`
+	EndDate      time.Time   `form:"end_date"   time_format:"2006-01-02T15:04:05Z07:00" binding:"required"`
+	AllowOverlap bool        `form:"allow_overlap"`                                   // true = chấp nhận trùng lịch địa điểm
+	Recurrence   string      `form:"recurrence"`                                      // RRULE, vd. "FREQ=WEEKLY;BYDAY=TU;COUNT=10"
+	ExDates      []time.Time `form:"exdates" time_format:"2006-01-02T15:04:05Z07:00"` // các buổi bỏ qua (EXDATE)
 }
 
 // ======================================
 // 📥 EventUpdateRequest
 // ======================================
 type EventUpdateRequest struct {
-	Name        string     `form:"name" binding:"required"`
-	Description string     `form:"description"`
-	Type        string     `form:"type" binding:"required"`
-	Status      string     `form:"status"`
-	Location    string     `form:"location" binding:"required"`
-	MaxGuests   uint       `form:"max_guests"`
-	StartDate   *time.Time `form:"start_date" time_format:"2006-01-02T15:04:05Z07:00"`
-	EndDate     *time.Time `form:"end_date"   time_format:"2006-01-02T15:04:05Z07:00"`
-	ImageURLs   []string   `form:"images_url"`
+	Name         string      `form:"name" binding:"required"`
+	Description  string      `form:"description"`
+	Type         string      `form:"type" binding:"required"`
+	Status       string      `form:"status"`
+	LocationID   string      `form:"location_id"`
+	Location     string      `form:"location"` // bỏ trống cả hai để giữ địa điểm cũ
+	MaxGuests    uint        `form:"max_guests"`
+	StartDate    *time.Time  `form:"start_date" time_format:"2006-01-02T15:04:05Z07:00"`
+	EndDate      *time.Time  `form:"end_date"   time_format:"2006-01-02T15:04:05Z07:00"`
+	ImageURLs    []string    `form:"images_url"`
+	AllowOverlap bool        `form:"allow_overlap"` // true = chấp nhận trùng lịch địa điểm
+	Recurrence   string      `form:"recurrence"`    // chỉ áp dụng khi scope = following | all
+	ExDates      []time.Time `form:"exdates" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// ======================================
+// 📥 EventOrganizersRequest - PUT /events/:id/organizers
+// ======================================
+type CoOrganizerRequest struct {
+	UserID      string   `json:"user_id" binding:"required"`
+	Permissions []string `json:"permissions"` // "edit" | "manage_guests" | "manage_registrations" | "check_in"
+}
+
+type EventOrganizersRequest struct {
+	OwnerID      string               `json:"owner_id"` // bỏ trống để giữ nguyên chủ sự kiện
+	CoOrganizers []CoOrganizerRequest `json:"co_organizers"`
 }
 
 // ======================================
 // 📤 EventResponse
 // ======================================
 type EventResponse struct {
-	ID        string    `json:"id"`
-	Name      string    `json:"name"`
-	Status    string    `json:"status"`
-	StartDate time.Time `json:"start_date"`
-	EndDate   time.Time `json:"end_date"`
-	Location  string    `json:"location"`
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	Status     string    `json:"status"`
+	StartDate  time.Time `json:"start_date"`
+	EndDate    time.Time `json:"end_date"`
+	LocationID string    `json:"location_id,omitempty"`
+	Location   string    `json:"location"`
+	SeriesID   string    `json:"series_id,omitempty"`
 }
 
 // EventDetailResponse - dùng cho GET /events/:id
@@ -52,11 +75,36 @@ type EventDetailResponse struct {
 	Description string    `json:"description"`
 	Type        string    `json:"type"`
 	Status      string    `json:"status"`
+	LocationID  string    `json:"location_id,omitempty"`
 	Location    string    `json:"location"`
 	MaxGuests   int       `json:"max_guests"`
+	SeatsTaken  int       `json:"seats_taken"`
 	StartDate   time.Time `json:"start_date"`
 	EndDate     time.Time `json:"end_date"`
 	ImageURLs   []string  `json:"image_urls"`
+
+	SeriesID        string     `json:"series_id,omitempty"`
+	OccurrenceStart *time.Time `json:"occurrence_start,omitempty"`
+
+	OwnerID      string                `json:"owner_id"`
+	CoOrganizers []CoOrganizerResponse `json:"co_organizers"`
+
+	Speakers []EventProfileResponse `json:"speakers"`
+	Sponsors []EventProfileResponse `json:"sponsors"` // Theo hạng tài trợ, hạng cao trước
+}
+
+// EventOccurrencesResponse - dùng cho GET /events/:id/occurrences
+type EventOccurrencesResponse struct {
+	SeriesID    string          `json:"series_id,omitempty"`
+	RRule       string          `json:"rrule,omitempty"`
+	DTStart     *time.Time      `json:"dtstart,omitempty"`
+	ExDates     []time.Time     `json:"exdates,omitempty"`
+	Occurrences []EventResponse `json:"occurrences"`
+}
+
+type CoOrganizerResponse struct {
+	UserID      string   `json:"user_id"`
+	Permissions []string `json:"permissions"`
 }
 
 // ======================================
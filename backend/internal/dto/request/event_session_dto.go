@@ -0,0 +1,49 @@
+package dto
+
+import "time"
+
+// EventSessionRequest carries payload to create or replace a session of an event's agenda.
+type EventSessionRequest struct {
+	Title       string    `json:"title" binding:"required"`
+	Description string    `json:"description"`
+	Room        string    `json:"room"`
+	Speakers    []string  `json:"speakers"`
+	StartTime   time.Time `json:"start_time" binding:"required"`
+	EndTime     time.Time `json:"end_time" binding:"required"`
+	Capacity    int       `json:"capacity" binding:"min=0"` // 0 means unlimited
+}
+
+// EventSessionResponse represents a session returned to clients.
+type EventSessionResponse struct {
+	ID          string    `json:"id"`
+	EventID     string    `json:"event_id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description,omitempty"`
+	Room        string    `json:"room,omitempty"`
+	Speakers    []string  `json:"speakers"`
+	StartTime   time.Time `json:"start_time"`
+	EndTime     time.Time `json:"end_time"`
+	Capacity    int       `json:"capacity"`
+	SeatsTaken  int       `json:"seats_taken"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at,omitempty"`
+}
+
+// SessionRegistrationRequest carries the event registration that signs up for a session.
+type SessionRegistrationRequest struct {
+	RegistrationID string `json:"registration_id" binding:"required"`
+}
+
+// SessionRegistrationResponse represents a guest's registration for a session.
+type SessionRegistrationResponse struct {
+	ID             string     `json:"id"`
+	SessionID      string     `json:"session_id"`
+	EventID        string     `json:"event_id"`
+	RegistrationID string     `json:"registration_id"`
+	GuestID        string     `json:"guest_id,omitempty"`
+	GuestName      string     `json:"guest_name,omitempty"`
+	Status         string     `json:"status"`
+	CreatedAt      time.Time  `json:"created_at"`
+	CheckedInAt    *time.Time `json:"checked_in_at,omitempty"`
+	CancelledAt    *time.Time `json:"cancelled_at,omitempty"`
+}
@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 // GuestCreateRequest carries payload to create a guest.
 type GuestCreateRequest struct {
 	EventID  string `json:"event_id" binding:"required"`
@@ -23,3 +25,80 @@ type GuestResponse struct {
 	Email    string `json:"email"`
 	Phone    string `json:"phone"`
 }
+
+// GuestEventResponse represents an event the guest is registered to.
+type GuestEventResponse struct {
+	Event              EventResponse `json:"event"`
+	RegistrationID     string        `json:"registration_id"`
+	RegistrationStatus string        `json:"registration_status"`
+	CheckedIn          bool          `json:"checked_in"`
+	RegisteredAt       time.Time     `json:"registered_at"`
+}
+
+// GuestImportRequest carries the form fields of POST /guests/import (the file itself is field "file").
+type GuestImportRequest struct {
+	EventID        string `form:"event_id" binding:"required"`
+	Format         string `form:"format"` // csv | xlsx, default from the file extension
+	Async          bool   `form:"async"`  // force a background job even for small files
+	FullNameColumn string `form:"full_name_column"`
+	EmailColumn    string `form:"email_column"`
+	PhoneColumn    string `form:"phone_column"`
+}
+
+// GuestImportRowResponse is the outcome of one row of the file.
+type GuestImportRowResponse struct {
+	Row      int    `json:"row"`
+	FullName string `json:"full_name,omitempty"`
+	Email    string `json:"email,omitempty"`
+	Phone    string `json:"phone,omitempty"`
+	Action   string `json:"action"` // created | reused | error
+	GuestID  string `json:"guest_id,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// GuestImportJobResponse reports the progress and per-row result of a guest import.
+type GuestImportJobResponse struct {
+	ID         string                   `json:"id,omitempty"`
+	EventID    string                   `json:"event_id"`
+	FileName   string                   `json:"file_name"`
+	Status     string                   `json:"status"`
+	Total      int                      `json:"total"`
+	Processed  int                      `json:"processed"`
+	Progress   float64                  `json:"progress"` // percent of rows processed
+	Created    int                      `json:"created"`
+	Reused     int                      `json:"reused"`
+	Failed     int                      `json:"failed"`
+	Error      string                   `json:"error,omitempty"`
+	Rows       []GuestImportRowResponse `json:"rows"`
+	CreatedAt  time.Time                `json:"created_at"`
+	UpdatedAt  time.Time                `json:"updated_at"`
+	FinishedAt *time.Time               `json:"finished_at,omitempty"`
+}
+
+// GuestDuplicateResponse is a pair of guests suspected to be the same person.
+type GuestDuplicateResponse struct {
+	ID             string          `json:"id"`
+	Guests         []GuestResponse `json:"guests"`
+	Reasons        []string        `json:"reasons"` // email | phone | name
+	NameSimilarity float64         `json:"name_similarity"`
+	Score          float64         `json:"score"`
+	Dismissed      bool            `json:"dismissed"`
+	DetectedAt     time.Time       `json:"detected_at"`
+}
+
+// GuestMergeRequest merges duplicate guests into the survivor.
+type GuestMergeRequest struct {
+	SurvivorID   string   `json:"survivor_id" binding:"required"`
+	DuplicateIDs []string `json:"duplicate_ids" binding:"required,min=1"`
+}
+
+// GuestMergeResponse summarizes a merge.
+type GuestMergeResponse struct {
+	Survivor             GuestResponse `json:"survivor"`
+	MergedIDs            []string      `json:"merged_ids"`
+	RegistrationsMoved   int           `json:"registrations_moved"`
+	RegistrationsDropped int           `json:"registrations_dropped"`
+	ReviewsMoved         int           `json:"reviews_moved"`
+	ReviewsDropped       int           `json:"reviews_dropped"`
+	OrdersMoved          int           `json:"orders_moved"`
+}
@@ -0,0 +1,52 @@
+package dto
+
+import "time"
+
+// KioskCreateRequest registers a kiosk device for an event.
+type KioskCreateRequest struct {
+	Name     string `json:"name" binding:"required,max=100"`
+	TTLHours int    `json:"ttl_hours" binding:"omitempty,min=1,max=168"` // default 24h
+}
+
+// KioskSessionResponse describes a kiosk device; Token is only returned when the kiosk is created.
+type KioskSessionResponse struct {
+	ID         string     `json:"id"`
+	EventID    string     `json:"event_id"`
+	Name       string     `json:"name"`
+	Token      string     `json:"token,omitempty"`
+	Active     bool       `json:"active"`
+	CreatedBy  string     `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	LastSeenAt *time.Time `json:"last_seen_at,omitempty"`
+}
+
+// KioskAuditResponse is one entry of a kiosk audit trail.
+type KioskAuditResponse struct {
+	ID             string    `json:"id"`
+	Action         string    `json:"action"` // lookup | check_in | rate_limited
+	Query          string    `json:"query,omitempty"`
+	Matches        int       `json:"matches,omitempty"`
+	RegistrationID string    `json:"registration_id,omitempty"`
+	Result         string    `json:"result"` // ok | rejected | error
+	Message        string    `json:"message,omitempty"`
+	ClientIP       string    `json:"client_ip,omitempty"`
+	At             time.Time `json:"at"`
+}
+
+// KioskGuestResponse is a guest found on a kiosk, with contacts masked.
+type KioskGuestResponse struct {
+	RegistrationID string `json:"registration_id"`
+	Name           string `json:"name"`
+	Email          string `json:"email,omitempty"`
+	Phone          string `json:"phone,omitempty"`
+	Status         string `json:"status"`
+	CheckedIn      bool   `json:"checked_in"`
+}
+
+// KioskCheckInRequest is sent when a guest confirms their own check-in.
+type KioskCheckInRequest struct {
+	RegistrationID string `json:"registration_id" binding:"required"`
+	Confirm        string `json:"confirm" binding:"required"` // guest email or last 4 digits of their phone
+}
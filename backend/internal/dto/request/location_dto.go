@@ -0,0 +1,55 @@
+package dto
+
+import "time"
+
+// LocationRequest carries payload to create or update a location.
+type LocationRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Address     string `json:"address"`
+	Capacity    int    `json:"capacity" binding:"min=0"` // 0 = unlimited
+	Description string `json:"description"`
+}
+
+// LocationResponse represents a location returned to clients.
+type LocationResponse struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Address     string `json:"address"`
+	Capacity    int    `json:"capacity"`
+	Description string `json:"description"`
+}
+
+// LocationCapacityResponse reports whether a location fits the expected number of guests.
+type LocationCapacityResponse struct {
+	LocationID string `json:"location_id"`
+	Capacity   int    `json:"capacity"`
+	Expected   int    `json:"expected"`
+	Fits       bool   `json:"fits"`
+}
+
+// VenueBookingResponse is an event occupying a location; blocked_* include setup/teardown buffers.
+type VenueBookingResponse struct {
+	EventID     string    `json:"event_id"`
+	EventName   string    `json:"event_name"`
+	StartDate   time.Time `json:"start_date"`
+	EndDate     time.Time `json:"end_date"`
+	BlockedFrom time.Time `json:"blocked_from"`
+	BlockedTo   time.Time `json:"blocked_to"`
+}
+
+// TimeSlotResponse is a free interval [from, to).
+type TimeSlotResponse struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// VenueAvailabilityResponse lists busy and free slots of a location within a window.
+type VenueAvailabilityResponse struct {
+	LocationID            string                 `json:"location_id"`
+	From                  time.Time              `json:"from"`
+	To                    time.Time              `json:"to"`
+	SetupBufferMinutes    int                    `json:"setup_buffer_minutes"`
+	TeardownBufferMinutes int                    `json:"teardown_buffer_minutes"`
+	Busy                  []VenueBookingResponse `json:"busy"`
+	Free                  []TimeSlotResponse     `json:"free"`
+}
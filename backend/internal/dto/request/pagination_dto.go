@@ -0,0 +1,9 @@
+package dto
+
+// PageMeta describes pagination state of a list response.
+type PageMeta struct {
+	Total      int64  `json:"total"`
+	Page       int    `json:"page,omitempty"`
+	PageSize   int    `json:"page_size"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
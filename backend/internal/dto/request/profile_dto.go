@@ -0,0 +1,47 @@
+package dto
+
+import "time"
+
+// ProfileLinkPayload is a labelled link shown on a profile.
+type ProfileLinkPayload struct {
+	Label string `json:"label"`
+	URL   string `json:"url" binding:"required"`
+}
+
+// ProfileRequest carries payload to create or replace a speaker or sponsor profile.
+// Kind cannot be changed after creation; tier is required for sponsors only.
+type ProfileRequest struct {
+	Kind     string               `json:"kind" binding:"required,oneof=speaker sponsor"`
+	Name     string               `json:"name" binding:"required"`
+	Headline string               `json:"headline"`
+	Bio      string               `json:"bio"`
+	Links    []ProfileLinkPayload `json:"links" binding:"dive"`
+	Tier     string               `json:"tier"`
+}
+
+// ProfileResponse represents a profile returned to clients.
+type ProfileResponse struct {
+	ID        string               `json:"id"`
+	Kind      string               `json:"kind"`
+	Name      string               `json:"name"`
+	Headline  string               `json:"headline,omitempty"`
+	Bio       string               `json:"bio,omitempty"`
+	PhotoURL  string               `json:"photo_url,omitempty"`
+	Links     []ProfileLinkPayload `json:"links"`
+	Tier      string               `json:"tier,omitempty"`
+	CreatedAt time.Time            `json:"created_at"`
+	UpdatedAt time.Time            `json:"updated_at,omitempty"`
+}
+
+// ProfileAssignmentRequest links a profile to an event, or to one of its sessions when session_id is set.
+type ProfileAssignmentRequest struct {
+	ProfileID string `json:"profile_id" binding:"required"`
+	SessionID string `json:"session_id"`
+}
+
+// EventProfileResponse is a profile linked to an event and/or some of its sessions.
+type EventProfileResponse struct {
+	ProfileResponse
+	OnEvent    bool     `json:"on_event"`
+	SessionIDs []string `json:"session_ids"`
+}
@@ -9,6 +9,12 @@ type RegistrationCreateRequest struct {
 	Status  string `json:"status"`
 }
 
+// RegistrationStatusRequest carries payload to change a registration status.
+type RegistrationStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=pending confirmed waitlisted checked_in checked_out cancelled no_show"`
+	Reason string `json:"reason"`
+}
+
 // RegistrationResponse represents registration data returned to clients.
 type RegistrationResponse struct {
 	ID        string    `json:"id"`
@@ -17,4 +23,60 @@ type RegistrationResponse struct {
 	Status    string    `json:"status"`
 	CreatedAt time.Time `json:"created_at"`
 	CheckedIn bool      `json:"checked_in"`
+	// Visits lists every check-in/check-out cycle, oldest first
+	Visits []RegistrationVisitResponse `json:"visits,omitempty"`
+}
+
+// RegistrationVisitResponse is one check-in/check-out cycle; checked_out_at is absent while the guest is inside.
+type RegistrationVisitResponse struct {
+	CheckedInAt  time.Time  `json:"checked_in_at"`
+	CheckedOutAt *time.Time `json:"checked_out_at,omitempty"`
+}
+
+// RegistrationTransitionResponse represents one entry of a registration's status history.
+type RegistrationTransitionResponse struct {
+	From    string    `json:"from"`
+	To      string    `json:"to"`
+	ActorID string    `json:"actor_id"`
+	Reason  string    `json:"reason,omitempty"`
+	At      time.Time `json:"at"`
+}
+
+// CheckInScanRequest carries a scanned ticket token and the event being checked in.
+type CheckInScanRequest struct {
+	Token   string `json:"token" binding:"required"`
+	EventID string `json:"event_id" binding:"required"`
+}
+
+// CheckInScanResponse shows staff who was checked in.
+type CheckInScanResponse struct {
+	Registration RegistrationResponse `json:"registration"`
+	Guest        GuestResponse        `json:"guest"`
+	CheckedInAt  *time.Time           `json:"checked_in_at,omitempty"`
+}
+
+// CheckInSyncRequest carries the operations a check-in device recorded while offline.
+type CheckInSyncRequest struct {
+	DeviceID   string                 `json:"device_id" binding:"required"`
+	Operations []CheckInSyncOpRequest `json:"operations" binding:"required,min=1,max=500"`
+}
+
+// CheckInSyncOpRequest is one offline operation; invalid operations are rejected one by one.
+type CheckInSyncOpRequest struct {
+	OpID           string    `json:"op_id"` // unique per device, used to apply the operation only once
+	RegistrationID string    `json:"registration_id"`
+	Action         string    `json:"action"` // check_in | cancel
+	ClientTime     time.Time `json:"client_time"`
+}
+
+// CheckInSyncResultResponse reports the outcome of one offline operation.
+type CheckInSyncResultResponse struct {
+	OpID           string    `json:"op_id"`
+	RegistrationID string    `json:"registration_id"`
+	Action         string    `json:"action"`
+	Result         string    `json:"result"`             // applied | noop | conflict | rejected | error | duplicate
+	Conflict       string    `json:"conflict,omitempty"` // cancelled_on_server | checked_in_on_server | event_full
+	Status         string    `json:"status,omitempty"`   // registration status on the server
+	Message        string    `json:"message,omitempty"`
+	SyncedAt       time.Time `json:"synced_at"`
 }
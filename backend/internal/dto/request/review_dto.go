@@ -0,0 +1,35 @@
+package dto
+
+import "time"
+
+// ReviewCreateRequest carries payload to submit a review for an event.
+type ReviewCreateRequest struct {
+	GuestID string  `json:"guest_id" binding:"required"`
+	Rating  float64 `json:"rating" binding:"required,min=1,max=5"`
+	Comment string  `json:"comment"`
+}
+
+// ReviewUpdateRequest carries payload to edit a review.
+type ReviewUpdateRequest struct {
+	Rating  float64 `json:"rating" binding:"required,min=1,max=5"`
+	Comment string  `json:"comment"`
+}
+
+// ReviewResponse represents a review returned to clients.
+type ReviewResponse struct {
+	ID        string    `json:"id"`
+	EventID   string    `json:"event_id"`
+	GuestID   string    `json:"guest_id"`
+	GuestName string    `json:"guest_name"`
+	Rating    float64   `json:"rating"`
+	Comment   string    `json:"comment"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+// ReviewSummaryResponse lists an event's reviews with the average rating.
+type ReviewSummaryResponse struct {
+	AverageRating float64          `json:"average_rating"`
+	Count         int              `json:"count"`
+	Reviews       []ReviewResponse `json:"reviews"`
+}
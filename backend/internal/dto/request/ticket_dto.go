@@ -0,0 +1,69 @@
+package dto
+
+import "time"
+
+// TicketTypeRequest carries payload to create or replace a ticket type of an event.
+// Prices are in the currency's minor unit; early_bird_price applies until early_bird_until.
+type TicketTypeRequest struct {
+	Name           string     `json:"name" binding:"required"`
+	Description    string     `json:"description"`
+	Currency       string     `json:"currency" binding:"required,len=3"`
+	Price          int64      `json:"price" binding:"min=0"`
+	EarlyBirdPrice int64      `json:"early_bird_price" binding:"min=0"`
+	EarlyBirdUntil *time.Time `json:"early_bird_until"`
+	Quantity       int        `json:"quantity" binding:"min=0"` // 0 means unlimited
+	SaleStart      *time.Time `json:"sale_start"`
+	SaleEnd        *time.Time `json:"sale_end"`
+}
+
+// TicketTypeResponse represents a ticket type returned to clients.
+// CurrentPrice is the price charged right now, taking early-bird pricing into account.
+type TicketTypeResponse struct {
+	ID             string     `json:"id"`
+	EventID        string     `json:"event_id"`
+	Name           string     `json:"name"`
+	Description    string     `json:"description,omitempty"`
+	Currency       string     `json:"currency"`
+	Price          int64      `json:"price"`
+	EarlyBirdPrice int64      `json:"early_bird_price,omitempty"`
+	EarlyBirdUntil *time.Time `json:"early_bird_until,omitempty"`
+	CurrentPrice   int64      `json:"current_price"`
+	EarlyBird      bool       `json:"early_bird"`
+	Quantity       int        `json:"quantity"`
+	Sold           int        `json:"sold"`
+	Reserved       int        `json:"reserved"`
+	Remaining      *int       `json:"remaining"` // null when unlimited
+	OnSale         bool       `json:"on_sale"`
+	SaleStart      *time.Time `json:"sale_start,omitempty"`
+	SaleEnd        *time.Time `json:"sale_end,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at,omitempty"`
+}
+
+// OrderRequest carries payload to buy one ticket of a ticket type for a guest.
+type OrderRequest struct {
+	TicketTypeID string `json:"ticket_type_id" binding:"required"`
+	GuestID      string `json:"guest_id" binding:"required"`
+}
+
+// OrderResponse represents a ticket order returned to clients.
+type OrderResponse struct {
+	ID             string     `json:"id"`
+	EventID        string     `json:"event_id"`
+	TicketTypeID   string     `json:"ticket_type_id"`
+	TicketName     string     `json:"ticket_name"`
+	GuestID        string     `json:"guest_id"`
+	RegistrationID string     `json:"registration_id,omitempty"`
+	Amount         int64      `json:"amount"`
+	Currency       string     `json:"currency"`
+	EarlyBird      bool       `json:"early_bird"`
+	Status         string     `json:"status"`
+	Reason         string     `json:"reason,omitempty"`
+	Provider       string     `json:"provider,omitempty"`
+	PaymentID      string     `json:"payment_id,omitempty"`
+	CheckoutURL    string     `json:"checkout_url,omitempty"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	PaidAt         *time.Time `json:"paid_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at,omitempty"`
+}
@@ -15,6 +15,7 @@ type CreateUserRequest struct {
 	Username string `json:"username" binding:"required"`
 	Email    string `json:"email" binding:"required,email"`
 	FullName string `json:"full_name" binding:"required"`
+	Password string `json:"password" binding:"required,min=8"`
 }
 
 // 📥 Dùng khi cập nhật thông tin user
@@ -23,6 +24,11 @@ type UpdateUserRequest struct {
 	Email    string `json:"email" binding:"required,email"`
 }
 
+// 📥 Dùng khi admin đổi vai trò user
+type ChangeRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=admin organizer checkin_staff viewer"`
+}
+
 //
 // =======================================================
 // 🟣 RESPONSE DTOs
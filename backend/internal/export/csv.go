@@ -0,0 +1,47 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+
+	"event_manager/internal/domain/entity"
+)
+
+// csvFlushEvery is the number of rows buffered before they are pushed to the client.
+const csvFlushEvery = 200
+
+type csvWriter struct {
+	out   io.Writer
+	w     *csv.Writer
+	opts  Options
+	count int
+}
+
+func newCSVWriter(w io.Writer, opts Options) *csvWriter {
+	return &csvWriter{out: w, w: csv.NewWriter(w), opts: opts}
+}
+
+func (c *csvWriter) Begin(*entity.Event) error {
+	// BOM so Excel opens the UTF-8 file with Vietnamese characters intact
+	if _, err := c.out.Write([]byte{0xEF, 0xBB, 0xBF}); err != nil {
+		return err
+	}
+	return c.w.Write(guestListHeader)
+}
+
+func (c *csvWriter) Write(g *entity.EventGuest) error {
+	c.count++
+	if err := c.w.Write(guestListRow(c.count, g, c.opts.Location)); err != nil {
+		return err
+	}
+	if c.count%csvFlushEvery == 0 {
+		c.w.Flush()
+		return c.w.Error()
+	}
+	return nil
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
@@ -0,0 +1,111 @@
+// Package export renders the guest list of an event as CSV, XLSX or PDF, one row at a time.
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"event_manager/internal/domain/entity"
+)
+
+// Format is the output file type.
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+	FormatPDF  Format = "pdf"
+)
+
+// ErrUnsupportedFormat is returned for formats other than csv, xlsx and pdf.
+var ErrUnsupportedFormat = errors.New("unsupported export format, expected csv, xlsx or pdf")
+
+// MaxPDFRows caps the PDF guest list: fpdf keeps every page in memory until Output, so longer
+// lists must be exported as CSV or XLSX.
+const MaxPDFRows = 5000
+
+// ErrTooManyRows is returned by the PDF writer once the list grows past MaxPDFRows.
+var ErrTooManyRows = fmt.Errorf("guest list has more than %d rows, export it as csv or xlsx instead", MaxPDFRows)
+
+// ParseFormat validates a format name (case-insensitive).
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(strings.TrimSpace(s))); f {
+	case FormatCSV, FormatXLSX, FormatPDF:
+		return f, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+// ContentType is the MIME type of the format.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case FormatPDF:
+		return "application/pdf"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
+
+// Options controls how values are rendered.
+type Options struct {
+	Location *time.Location // time zone of the time columns
+	FontPath string         // TTF font with Vietnamese glyphs for PDF; without it text is transliterated to ASCII
+}
+
+// GuestListWriter receives the event first, then its guests; Close completes the file.
+type GuestListWriter interface {
+	Begin(event *entity.Event) error
+	Write(guest *entity.EventGuest) error
+	Close() error
+}
+
+// NewGuestListWriter returns a writer for the format. CSV rows go to w as they arrive; XLSX rows are
+// spooled by the stream writer of excelize and written to w on Close. The PDF document is built in
+// memory and written on Close, so it is limited to MaxPDFRows rows.
+func NewGuestListWriter(format Format, w io.Writer, opts Options) (GuestListWriter, error) {
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w, opts), nil
+	case FormatXLSX:
+		return newXLSXWriter(w, opts)
+	case FormatPDF:
+		return newPDFWriter(w, opts)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+// guestListHeader is shared by every format.
+var guestListHeader = []string{"#", "Full name", "Email", "Phone", "Status", "Checked in at", "Registered at"}
+
+const timeLayout = "02/01/2006 15:04"
+
+// guestListRow formats one guest as text cells, in the order of guestListHeader.
+func guestListRow(index int, g *entity.EventGuest, loc *time.Location) []string {
+	return []string{
+		strconv.Itoa(index),
+		g.Guest.FullName,
+		g.Guest.Email,
+		g.Guest.Phone,
+		string(g.Registration.Status),
+		formatTime(g.CheckedInAt, loc),
+		formatTime(&g.Registration.CreatedAt, loc),
+	}
+}
+
+func formatTime(t *time.Time, loc *time.Location) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.In(loc).Format(timeLayout)
+}
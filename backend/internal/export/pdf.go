@@ -0,0 +1,138 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+
+	"event_manager/internal/domain/entity"
+
+	"github.com/go-pdf/fpdf"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	pdfFont      = "guestlist"
+	pdfFontSize  = 9
+	pdfRowHeight = 6
+)
+
+// pdfColumns are the column widths in mm on landscape A4 (277 mm between the margins).
+var pdfColumns = []float64{10, 62, 66, 32, 25, 41, 41}
+
+type pdfWriter struct {
+	out   io.Writer
+	pdf   *fpdf.Fpdf
+	opts  Options
+	font  string
+	text  func(string) string
+	count int
+}
+
+func newPDFWriter(w io.Writer, opts Options) (*pdfWriter, error) {
+	pdf := fpdf.New("L", "mm", "A4", "")
+	pdf.SetMargins(10, 10, 10)
+	pdf.SetAutoPageBreak(false, 10)
+	pdf.AliasNbPages("")
+
+	p := &pdfWriter{out: w, pdf: pdf, opts: opts, font: "Helvetica", text: asciiText}
+	if opts.FontPath != "" {
+		// fpdf resolves font files relative to its font directory, so the file is loaded here
+		font, err := os.ReadFile(opts.FontPath)
+		if err != nil {
+			return nil, fmt.Errorf("load pdf font: %w", err)
+		}
+		pdf.AddUTF8FontFromBytes(pdfFont, "", font)
+		pdf.AddUTF8FontFromBytes(pdfFont, "B", font)
+		if err := pdf.Error(); err != nil {
+			return nil, fmt.Errorf("load pdf font: %w", err)
+		}
+		p.font, p.text = pdfFont, func(s string) string { return s }
+	}
+	return p, nil
+}
+
+func (p *pdfWriter) Begin(event *entity.Event) error {
+	p.pdf.SetFooterFunc(func() {
+		p.pdf.SetY(-10)
+		p.pdf.SetFont(p.font, "", 8)
+		printed := time.Now().In(p.opts.Location).Format(timeLayout)
+		p.pdf.CellFormat(0, 5, p.text(fmt.Sprintf("Printed %s - page %d/{nb}", printed, p.pdf.PageNo())), "", 0, "R", false, 0, "")
+	})
+
+	p.pdf.AddPage()
+	p.pdf.SetFont(p.font, "B", 14)
+	p.pdf.CellFormat(0, 8, p.text(event.Name), "", 1, "L", false, 0, "")
+	p.pdf.SetFont(p.font, "", 10)
+	when := fmt.Sprintf("%s - %s", event.StartDate.In(p.opts.Location).Format(timeLayout), event.EndDate.In(p.opts.Location).Format(timeLayout))
+	p.pdf.CellFormat(0, 6, p.text(when+"   "+event.Location), "", 1, "L", false, 0, "")
+	p.pdf.Ln(2)
+	p.header()
+	return p.pdf.Error()
+}
+
+func (p *pdfWriter) header() {
+	p.pdf.SetFont(p.font, "B", pdfFontSize)
+	p.pdf.SetFillColor(230, 230, 230)
+	for i, h := range guestListHeader {
+		p.pdf.CellFormat(pdfColumns[i], pdfRowHeight+1, p.text(h), "1", 0, "L", true, 0, "")
+	}
+	p.pdf.Ln(-1)
+	p.pdf.SetFont(p.font, "", pdfFontSize)
+}
+
+func (p *pdfWriter) Write(g *entity.EventGuest) error {
+	// Page breaks are handled here so the table header is repeated on every page
+	if p.count >= MaxPDFRows {
+		return ErrTooManyRows
+	}
+	_, pageHeight := p.pdf.GetPageSize()
+	if p.pdf.GetY()+pdfRowHeight > pageHeight-15 {
+		p.pdf.AddPage()
+		p.header()
+	}
+
+	p.count++
+	for i, value := range guestListRow(p.count, g, p.opts.Location) {
+		p.pdf.CellFormat(pdfColumns[i], pdfRowHeight, p.fit(p.text(value), pdfColumns[i]-2), "1", 0, "L", false, 0, "")
+	}
+	p.pdf.Ln(-1)
+	return p.pdf.Error()
+}
+
+// fit shortens s with "..." so it stays inside a cell of the given width.
+func (p *pdfWriter) fit(s string, width float64) string {
+	if p.pdf.GetStringWidth(s) <= width {
+		return s
+	}
+	r := []rune(s)
+	for len(r) > 0 && p.pdf.GetStringWidth(string(r)+"...") > width {
+		r = r[:len(r)-1]
+	}
+	return string(r) + "..."
+}
+
+func (p *pdfWriter) Close() error {
+	return p.pdf.Output(p.out)
+}
+
+// asciiText drops Vietnamese diacritics for the built-in PDF fonts, which only cover Latin-1.
+func asciiText(s string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	folded, _, err := transform.String(t, s)
+	if err != nil {
+		folded = s
+	}
+	folded = strings.NewReplacer("đ", "d", "Đ", "D").Replace(folded)
+	return strings.Map(func(r rune) rune {
+		if r > unicode.MaxASCII {
+			return '?'
+		}
+		return r
+	}, folded)
+}
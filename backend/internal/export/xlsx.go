@@ -0,0 +1,94 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"event_manager/internal/domain/entity"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const xlsxSheet = "Guests"
+
+type xlsxWriter struct {
+	out       io.Writer
+	file      *excelize.File
+	sw        *excelize.StreamWriter
+	opts      Options
+	dateStyle int
+	count     int
+}
+
+func newXLSXWriter(w io.Writer, opts Options) (*xlsxWriter, error) {
+	f := excelize.NewFile()
+	if err := f.SetSheetName("Sheet1", xlsxSheet); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	layout := "dd/mm/yyyy hh:mm"
+	dateStyle, err := f.NewStyle(&excelize.Style{CustomNumFmt: &layout})
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	sw, err := f.NewStreamWriter(xlsxSheet)
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return &xlsxWriter{out: w, file: f, sw: sw, opts: opts, dateStyle: dateStyle}, nil
+}
+
+func (x *xlsxWriter) Begin(*entity.Event) error {
+	// column widths and the frozen header must be set before the first row
+	widths := []float64{6, 30, 32, 16, 14, 18, 18}
+	for i, width := range widths {
+		if err := x.sw.SetColWidth(i+1, i+1, width); err != nil {
+			return err
+		}
+	}
+	if err := x.sw.SetPanes(&excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"}); err != nil {
+		return err
+	}
+
+	header := make([]interface{}, len(guestListHeader))
+	for i, h := range guestListHeader {
+		header[i] = h
+	}
+	return x.sw.SetRow("A1", header)
+}
+
+func (x *xlsxWriter) Write(g *entity.EventGuest) error {
+	x.count++
+	row := []interface{}{
+		x.count,
+		g.Guest.FullName,
+		g.Guest.Email,
+		g.Guest.Phone,
+		string(g.Registration.Status),
+		x.timeCell(g.CheckedInAt),
+		x.timeCell(&g.Registration.CreatedAt),
+	}
+	return x.sw.SetRow(fmt.Sprintf("A%d", x.count+1), row)
+}
+
+// timeCell writes the local wall-clock time, since Excel dates carry no time zone.
+func (x *xlsxWriter) timeCell(t *time.Time) interface{} {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	local := t.In(x.opts.Location)
+	wall := time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), local.Minute(), local.Second(), 0, time.UTC)
+	return excelize.Cell{StyleID: x.dateStyle, Value: wall}
+}
+
+func (x *xlsxWriter) Close() error {
+	defer x.file.Close()
+	if err := x.sw.Flush(); err != nil {
+		return err
+	}
+	_, err := x.file.WriteTo(x.out)
+	return err
+}
@@ -135,6 +135,7 @@ func (h *AnalyticsHandler) GetGuestStatsByLocation(c *gin.Context) {
 			continue
 		}
 		response = append(response, gin.H{
+			"location_id":  stat.LocationID,
 			"location":     stat.Location,
 			"total_guests": stat.TotalGuests,
 			"checked_in":   stat.CheckedIn,
@@ -226,6 +227,148 @@ func (h *AnalyticsHandler) GetTopEventsByGuests(c *gin.Context) {
 	})
 }
 
+// GetLiveOccupancy returns events that currently have guests on site, busiest first.
+func (h *AnalyticsHandler) GetLiveOccupancy(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	stats, err := h.aggregateSvc.LiveOccupancy(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := make([]gin.H, 0, len(stats))
+	for _, stat := range stats {
+		if stat == nil {
+			continue
+		}
+		response = append(response, occupancyResponse(stat))
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+// GetOccupancyByEvent returns the live headcount of an event.
+func (h *AnalyticsHandler) GetOccupancyByEvent(c *gin.Context) {
+	eventID := c.Param("id")
+	if strings.TrimSpace(eventID) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "event id is required"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	stat, err := h.aggregateSvc.OccupancyByEvent(ctx, eventID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": occupancyResponse(stat)})
+}
+
+// GetDwellTimeByEvent returns average stay durations of an event, in seconds.
+func (h *AnalyticsHandler) GetDwellTimeByEvent(c *gin.Context) {
+	eventID := c.Param("id")
+	if strings.TrimSpace(eventID) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "event id is required"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	stat, err := h.aggregateSvc.DwellTimeByEvent(ctx, eventID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{
+		"event_id":              stat.EventID,
+		"guests":                stat.Guests,
+		"visits":                stat.Visits,
+		"avg_visit_seconds":     int64(stat.AvgVisit.Seconds()),
+		"avg_per_guest_seconds": int64(stat.AvgPerGuest.Seconds()),
+	}})
+}
+
+// GetGuestStatsBySession returns guest counts for every session of an event.
+func (h *AnalyticsHandler) GetGuestStatsBySession(c *gin.Context) {
+	eventID := c.Param("id")
+	if strings.TrimSpace(eventID) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "event id is required"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	stats, err := h.aggregateSvc.GuestStatsBySession(ctx, eventID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := make([]gin.H, 0, len(stats))
+	for _, stat := range stats {
+		if stat == nil {
+			continue
+		}
+		response = append(response, sessionStatResponse(stat))
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+// GetGuestCountBySession returns registration and attendance counts of a session.
+func (h *AnalyticsHandler) GetGuestCountBySession(c *gin.Context) {
+	sessionID := c.Param("id")
+	if strings.TrimSpace(sessionID) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "session id is required"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	stat, err := h.aggregateSvc.GuestStatsSummaryBySession(ctx, sessionID)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": sessionStatResponse(stat)})
+}
+
+func sessionStatResponse(stat *entity.SessionGuestStat) gin.H {
+	return gin.H{
+		"session_id":   stat.SessionID,
+		"event_id":     stat.EventID,
+		"title":        stat.Title,
+		"room":         stat.Room,
+		"start_time":   stat.StartTime,
+		"end_time":     stat.EndTime,
+		"capacity":     stat.Capacity,
+		"total_guests": stat.TotalGuests,
+		"checked_in":   stat.CheckedIn,
+		"absent":       stat.TotalGuests - stat.CheckedIn,
+	}
+}
+
+func occupancyResponse(stat *entity.EventOccupancy) gin.H {
+	return gin.H{
+		"event_id":    stat.EventID,
+		"event_name":  stat.EventName,
+		"capacity":    stat.Capacity,
+		"on_site":     stat.OnSite,
+		"checked_out": stat.CheckedOut,
+		"attended":    stat.Attended,
+	}
+}
+
 func parseTime(value string) (time.Time, error) {
 	if strings.TrimSpace(value) == "" {
 		return time.Time{}, nil
@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"event_manager/internal/domain/entity"
+	service_interface "event_manager/internal/domain/service"
+	dto "event_manager/internal/dto/request"
+	"event_manager/internal/ical"
+	utils "event_manager/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	calendarContentType = "text/calendar; charset=utf-8"
+	maxICalImportSize   = 5 << 20
+)
+
+// 📅 CalendarHandler xuất sự kiện ra iCalendar và phục vụ lịch đăng ký (subscription feed)
+type CalendarHandler struct {
+	events   service_interface.EventService
+	calendar service_interface.CalendarService
+	opts     ical.Options
+}
+
+// ✅ Khởi tạo handler; opts gồm PRODID, domain của UID và múi giờ
+func NewCalendarHandler(events service_interface.EventService, calendar service_interface.CalendarService, opts ical.Options) *CalendarHandler {
+	return &CalendarHandler{events: events, calendar: calendar, opts: opts}
+}
+
+// GET /events/:id/ical — tải một sự kiện dạng .ics
+func (h *CalendarHandler) ExportEvent(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	event, err := h.events.GetByID(ctx, strings.TrimSpace(c.Param("id")))
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+	if event == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "event not found"})
+		return
+	}
+
+	opts := h.opts
+	opts.Name = event.Name
+	h.render(c, fmt.Sprintf("event-%s.ics", event.ID), []*entity.Event{event}, opts)
+}
+
+// POST /events/import/ical — file .ics gửi qua field multipart "file" hoặc nguyên body;
+// dry_run mặc định true để client xem trước kết quả rồi mới áp dụng
+func (h *CalendarHandler) ImportEvents(c *gin.Context) {
+	var req dto.EventImportRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var body io.Reader
+	if file, err := c.FormFile("file"); err == nil {
+		if file.Size > maxICalImportSize {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "file is too large"})
+			return
+		}
+		f, err := file.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		defer f.Close()
+		body = f
+	} else {
+		body = http.MaxBytesReader(c.Writer, c.Request.Body, maxICalImportSize)
+	}
+
+	items, err := ical.Parse(body, h.opts.Location)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, 60*time.Second)
+	defer cancel()
+
+	report, err := h.events.ImportEvents(ctx, items, entity.ImportOptions{
+		DryRun:          req.DryRun == nil || *req.DryRun,
+		Type:            strings.TrimSpace(req.Type),
+		DefaultLocation: req.DefaultLocation,
+		AllowOverlap:    req.AllowOverlap,
+	})
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := dto.EventImportResponse{
+		DryRun:    report.DryRun,
+		Created:   report.Created,
+		Updated:   report.Updated,
+		Unchanged: report.Unchanged,
+		Failed:    report.Failed,
+		Items:     make([]dto.EventImportItemResponse, 0, len(report.Items)),
+	}
+	for _, it := range report.Items {
+		resp.Items = append(resp.Items, dto.EventImportItemResponse{
+			UID:         it.UID,
+			Name:        it.Name,
+			Action:      it.Action,
+			EventID:     it.EventID,
+			Occurrences: it.Occurrences,
+			Error:       it.Error,
+		})
+	}
+	c.JSON(http.StatusOK, gin.H{"data": resp})
+}
+
+// GET /calendars/:token.ics — công khai, token chính là thông tin xác thực
+func (h *CalendarHandler) Feed(c *gin.Context) {
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+
+	ctx, cancel := context.WithTimeout(c, 10*time.Second)
+	defer cancel()
+
+	feed, err := h.calendar.Feed(ctx, token)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	opts := h.opts
+	opts.Name = feed.Name
+	h.render(c, "calendar.ics", feed.Events, opts)
+}
+
+// POST /calendars/token — feed các sự kiện người dùng hiện tại tổ chức
+func (h *CalendarHandler) IssueMyToken(c *gin.Context) {
+	user := utils.UserFromContext(c.Request.Context())
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	h.issue(c, entity.CalendarSubjectUser, user.ID)
+}
+
+// DELETE /calendars/token
+func (h *CalendarHandler) RevokeMyToken(c *gin.Context) {
+	user := utils.UserFromContext(c.Request.Context())
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	h.revoke(c, entity.CalendarSubjectUser, user.ID)
+}
+
+// POST /guests/:id/calendar-token — feed các sự kiện khách đã đăng ký
+func (h *CalendarHandler) IssueGuestToken(c *gin.Context) {
+	h.issue(c, entity.CalendarSubjectGuest, c.Param("id"))
+}
+
+// DELETE /guests/:id/calendar-token
+func (h *CalendarHandler) RevokeGuestToken(c *gin.Context) {
+	h.revoke(c, entity.CalendarSubjectGuest, c.Param("id"))
+}
+
+func (h *CalendarHandler) issue(c *gin.Context, subjectType, subjectID string) {
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	token, err := h.calendar.IssueToken(ctx, subjectType, subjectID)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": dto.CalendarTokenResponse{
+		Token: token,
+		URL:   feedURL(c, token),
+	}})
+}
+
+func (h *CalendarHandler) revoke(c *gin.Context, subjectType, subjectID string) {
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	if err := h.calendar.RevokeTokens(ctx, subjectType, subjectID); err != nil {
+		c.JSON(statusFromError(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "calendar tokens revoked"})
+}
+
+func (h *CalendarHandler) render(c *gin.Context, filename string, events []*entity.Event, opts ical.Options) {
+	var buf bytes.Buffer
+	if err := ical.Write(&buf, events, opts); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="%s"`, filename))
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Data(http.StatusOK, calendarContentType, buf.Bytes())
+}
+
+// 🔗 feedURL dựng URL đăng ký đầy đủ từ request hiện tại
+func feedURL(c *gin.Context, token string) string {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	if proto := c.GetHeader("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return fmt.Sprintf("%s://%s/api/v1/calendars/%s.ics", scheme, c.Request.Host, token)
+}
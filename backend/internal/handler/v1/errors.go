@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	service_interface "event_manager/internal/domain/service"
+)
+
+// statusFromError map lỗi nghiệp vụ dùng chung sang HTTP status, còn lại trả về fallback.
+func statusFromError(err error, fallback int) int {
+	switch {
+	case errors.Is(err, service_interface.ErrInvalidQuery),
+		errors.Is(err, service_interface.ErrInvalidRating),
+		errors.Is(err, service_interface.ErrExceedsCapacity),
+		errors.Is(err, service_interface.ErrInvalidRecurrence),
+		errors.Is(err, service_interface.ErrInvalidImportFile),
+		errors.Is(err, service_interface.ErrInvalidMerge),
+		errors.Is(err, service_interface.ErrInvalidPhone),
+		errors.Is(err, service_interface.ErrInvalidTicket),
+		errors.Is(err, service_interface.ErrInvalidSync),
+		errors.Is(err, service_interface.ErrInvalidSession),
+		errors.Is(err, service_interface.ErrInvalidProfile),
+		errors.Is(err, service_interface.ErrInvalidTicketType),
+		errors.Is(err, service_interface.ErrInvalidWebhook):
+		return http.StatusBadRequest
+	case errors.Is(err, service_interface.ErrPaymentRequired):
+		return http.StatusPaymentRequired
+	case errors.Is(err, service_interface.ErrPaymentUnavailable):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, service_interface.ErrInvalidCredentials),
+		errors.Is(err, service_interface.ErrInvalidToken):
+		return http.StatusUnauthorized
+	case errors.Is(err, service_interface.ErrForbidden),
+		errors.Is(err, service_interface.ErrNotCheckedIn),
+		errors.Is(err, service_interface.ErrConfirmMismatch),
+		errors.Is(err, service_interface.ErrKioskLocked),
+		errors.Is(err, service_interface.ErrUserInactive):
+		return http.StatusForbidden
+	case errors.Is(err, service_interface.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service_interface.ErrInvalidTransition),
+		errors.Is(err, service_interface.ErrStatusConflict),
+		errors.Is(err, service_interface.ErrEventFull),
+		errors.Is(err, service_interface.ErrBelowSeatsTaken),
+		errors.Is(err, service_interface.ErrAlreadyRegistered),
+		errors.Is(err, service_interface.ErrAlreadyReviewed),
+		errors.Is(err, service_interface.ErrDuplicateLocation),
+		errors.Is(err, service_interface.ErrLocationInUse),
+		errors.Is(err, service_interface.ErrVenueConflict),
+		errors.Is(err, service_interface.ErrOccurrenceInUse),
+		errors.Is(err, service_interface.ErrEventInUse),
+		errors.Is(err, service_interface.ErrScanInProgress),
+		errors.Is(err, service_interface.ErrNoTicket),
+		errors.Is(err, service_interface.ErrAlreadyCheckedIn),
+		errors.Is(err, service_interface.ErrSessionFull),
+		errors.Is(err, service_interface.ErrSessionConflict),
+		errors.Is(err, service_interface.ErrSessionInUse),
+		errors.Is(err, service_interface.ErrNotAttending),
+		errors.Is(err, service_interface.ErrTicketTypeInUse),
+		errors.Is(err, service_interface.ErrNotOnSale),
+		errors.Is(err, service_interface.ErrSoldOut),
+		errors.Is(err, service_interface.ErrOrderPending),
+		errors.Is(err, service_interface.ErrOrderClosed):
+		return http.StatusConflict
+	default:
+		return fallback
+	}
+}
@@ -2,11 +2,13 @@ package handler
 
 import (
     "context"
+    "errors"
     "fmt"
     "mime"
     "mime/multipart"
     "net/http"
     "path/filepath"
+    "strconv"
     "strings"
     "time"
 
@@ -14,6 +16,7 @@ import (
 	service_interface "event_manager/internal/domain/service"
 	requestx "event_manager/internal/dto/request"
 	"event_manager/internal/storage"
+	utils "event_manager/util"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -23,15 +26,17 @@ import (
 // 🧩 EventHandler
 // =========================================
 type EventHandler struct {
-	service service_interface.EventService
-	storage storage.ObjectStorage
+	service  service_interface.EventService
+	profiles service_interface.ProfileService
+	storage  storage.ObjectStorage
 }
 
 // ✅ Khởi tạo handler
-func NewEventHandler(s service_interface.EventService, st storage.ObjectStorage) *EventHandler {
+func NewEventHandler(s service_interface.EventService, profiles service_interface.ProfileService, st storage.ObjectStorage) *EventHandler {
 	return &EventHandler{
-		service: s,
-		storage: st,
+		service:  s,
+		profiles: profiles,
+		storage:  st,
 	}
 }
 
@@ -56,6 +61,13 @@ func (h *EventHandler) CreateEvent(c *gin.Context) {
 		return
 	}
 
+	// 🔁 Quy tắc lặp (nếu có)
+	recurrence, err := recurrenceFromForm(req.Recurrence, req.ExDates)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	// Generate ID upfront for storage paths
 	eventID := uuid.New().String()
 
@@ -81,23 +93,26 @@ func (h *EventHandler) CreateEvent(c *gin.Context) {
 
 	// 🧱 Tạo entity từ DTO
 	event := &entity.Event{
-		ID:          eventID,
-		Name:        req.Name,
-		Description: req.Description,
-		Type:        req.Type,
-		Status:      "Sắp diễn ra",
-		Location:    req.Location,
-		MaxGuests:   req.MaxGuests,
-		StartDate:   req.StartDate,
-		EndDate:     req.EndDate,
-		ImageURLs:   imagePaths, // 🖼️ Danh sách nhiều ảnh
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		ID:           eventID,
+		Name:         req.Name,
+		Description:  req.Description,
+		Type:         req.Type,
+		Status:       "Sắp diễn ra",
+		LocationID:   strings.TrimSpace(req.LocationID),
+		Location:     strings.TrimSpace(req.Location),
+		MaxGuests:    req.MaxGuests,
+		StartDate:    req.StartDate,
+		EndDate:      req.EndDate,
+		ImageURLs:    imagePaths, // 🖼️ Danh sách nhiều ảnh
+		Recurrence:   recurrence,
+		AllowOverlap: req.AllowOverlap,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
 	}
 
 	// 💾 Gọi service lưu DB
 	if err := h.service.Create(c, event); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(statusFromError(err, http.StatusInternalServerError), eventErrorBody(err))
 		return
 	}
 	c.Header("Content-Type", "application/json")
@@ -124,6 +139,17 @@ func (h *EventHandler) UpdateEvent(c *gin.Context) {
 		})
 		return
 	}
+	// 🔁 Phạm vi sửa với sự kiện lặp: this (mặc định) | following | all
+	scope := entity.SeriesScope(c.DefaultQuery("scope", string(entity.SeriesScopeThis)))
+	if !scope.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "scope phải là this, following hoặc all"})
+		return
+	}
+	recurrence, err := recurrenceFromForm(req.Recurrence, req.ExDates)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	// ⚙️ Bước 3: Nhận danh sách ảnh mới (nếu có)
 	form, err := c.MultipartForm()
@@ -133,7 +159,12 @@ func (h *EventHandler) UpdateEvent(c *gin.Context) {
 	}
 
 	imagePaths := []string{}
-	if form != nil && form.File != nil {
+	if form != nil && form.File != nil && len(form.File["images"]) > 0 {
+		// 🔐 Kiểm tra quyền trước khi tải ảnh, tránh để lại file của người không có quyền sửa
+		if err := h.service.AuthorizeEdit(c, id); err != nil {
+			c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+			return
+		}
 		files := form.File["images"] // FE gửi dưới key "images"
 		uploaded, err := h.uploadEventImages(c.Request.Context(), id, files)
 		if err != nil {
@@ -149,15 +180,18 @@ func (h *EventHandler) UpdateEvent(c *gin.Context) {
 
 	// ⚙️ Bước 4: Tạo entity để cập nhật
 	event := &entity.Event{
-		ID:          id,
-		Name:        req.Name,
-		Description: req.Description,
-		Type:        req.Type,
-		Status:      req.Status,
-		Location:    req.Location,
-		MaxGuests:   req.MaxGuests,
-		ImageURLs:   imagePaths,
-		UpdatedAt:   time.Now(),
+		ID:           id,
+		Name:         req.Name,
+		Description:  req.Description,
+		Type:         req.Type,
+		Status:       req.Status,
+		LocationID:   strings.TrimSpace(req.LocationID),
+		Location:     strings.TrimSpace(req.Location),
+		MaxGuests:    req.MaxGuests,
+		ImageURLs:    imagePaths,
+		Recurrence:   recurrence,
+		AllowOverlap: req.AllowOverlap,
+		UpdatedAt:    time.Now(),
 	}
 	// 👉 Chỉ cập nhật nếu client có truyền thời gian
 	if req.StartDate != nil {
@@ -168,8 +202,8 @@ func (h *EventHandler) UpdateEvent(c *gin.Context) {
 	}
 
 	// ⚙️ Bước 5: Cập nhật trong service
-	if err := h.service.Update(c, event); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.service.UpdateSeries(c, event, scope); err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), eventErrorBody(err))
 		return
 	}
 
@@ -196,18 +230,7 @@ func (h *EventHandler) uploadEventImages(ctx context.Context, eventID string, fi
 			return nil, fmt.Errorf("không thể mở file %s: %w", file.Filename, err)
 		}
 
-		contentType := file.Header.Get("Content-Type")
-		if contentType == "" {
-			if ext := filepath.Ext(file.Filename); ext != "" {
-				if ct := mime.TypeByExtension(ext); ct != "" {
-					contentType = ct
-				}
-			}
-		}
-		if contentType == "" {
-			contentType = "application/octet-stream"
-		}
-
+		contentType := fileContentType(file)
 		objectName := fmt.Sprintf("events/%s/%d_%s", eventID, time.Now().UnixNano(), sanitizeFilename(file.Filename))
 		url, uploadErr := h.storage.Upload(ctx, objectName, reader, file.Size, contentType)
 		reader.Close()
@@ -221,6 +244,20 @@ func (h *EventHandler) uploadEventImages(ctx context.Context, eventID string, fi
 	return results, nil
 }
 
+// fileContentType lấy Content-Type của file tải lên, đoán theo đuôi file nếu client không gửi
+func fileContentType(file *multipart.FileHeader) string {
+	contentType := file.Header.Get("Content-Type")
+	if contentType == "" {
+		if ext := filepath.Ext(file.Filename); ext != "" {
+			contentType = mime.TypeByExtension(ext)
+		}
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return contentType
+}
+
 func sanitizeFilename(name string) string {
     base := filepath.Base(name)
     base = strings.ReplaceAll(base, " ", "_")
@@ -230,6 +267,32 @@ func sanitizeFilename(name string) string {
     return base
 }
 
+// eventErrorBody trả về body lỗi; nếu trùng lịch địa điểm thì kèm danh sách sự kiện xung đột.
+func eventErrorBody(err error) gin.H {
+	var conflict *service_interface.VenueConflictError
+	if !errors.As(err, &conflict) {
+		return gin.H{"error": err.Error()}
+	}
+	conflicts := make([]requestx.VenueBookingResponse, 0, len(conflict.Conflicts))
+	for _, b := range conflict.Conflicts {
+		conflicts = append(conflicts, toVenueBookingResponse(b))
+	}
+	return gin.H{"error": err.Error(), "conflicts": conflicts}
+}
+
+// recurrenceFromForm đọc RRULE + EXDATE từ form; trả về nil nếu không có quy tắc lặp
+func recurrenceFromForm(rrule string, exdates []time.Time) (*entity.Recurrence, error) {
+	if strings.TrimSpace(rrule) == "" {
+		return nil, nil
+	}
+	rule, err := entity.ParseRRule(rrule)
+	if err != nil {
+		return nil, fmt.Errorf("recurrence không hợp lệ: %w", err)
+	}
+	rule.ExDates = exdates
+	return rule, nil
+}
+
 func mergeImageURLs(existing []string, uploaded []string) []string {
 	total := make([]string, 0, len(existing)+len(uploaded))
 	seen := map[string]struct{}{}
@@ -252,13 +315,85 @@ func mergeImageURLs(existing []string, uploaded []string) []string {
 // DELETE /events/:id
 func (h *EventHandler) DeleteEvent(c *gin.Context) {
 	id := c.Param("id")
-	if err := h.service.Delete(c, id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	scope := entity.SeriesScope(c.DefaultQuery("scope", string(entity.SeriesScopeThis)))
+	if !scope.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "scope phải là this, following hoặc all"})
+		return
+	}
+	if err := h.service.DeleteSeries(c, id, scope); err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "Đã xoá sự kiện"})
 }
 
+// GET /events/:id/occurrences
+func (h *EventHandler) ListOccurrences(c *gin.Context) {
+	series, events, err := h.service.ListOccurrences(c, c.Param("id"))
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := requestx.EventOccurrencesResponse{
+		Occurrences: make([]requestx.EventResponse, 0, len(events)),
+	}
+	if series != nil {
+		resp.SeriesID = series.ID
+		resp.RRule = series.Recurrence.String()
+		resp.DTStart = &series.DTStart
+		resp.ExDates = series.Recurrence.ExDates
+	}
+	for _, e := range events {
+		resp.Occurrences = append(resp.Occurrences, requestx.EventResponse{
+			ID:         e.ID,
+			Name:       e.Name,
+			Status:     e.Status,
+			StartDate:  e.StartDate,
+			EndDate:    e.EndDate,
+			LocationID: e.LocationID,
+			Location:   e.Location,
+			SeriesID:   e.SeriesID,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": resp})
+}
+
+// PUT /events/:id/organizers
+func (h *EventHandler) SetOrganizers(c *gin.Context) {
+	id := c.Param("id")
+
+	var req requestx.EventOrganizersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	coOrganizers := make([]entity.CoOrganizer, 0, len(req.CoOrganizers))
+	for _, co := range req.CoOrganizers {
+		perms := make([]entity.EventPermission, 0, len(co.Permissions))
+		for _, p := range co.Permissions {
+			perm := entity.EventPermission(strings.TrimSpace(p))
+			if !perm.IsValid() {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Quyền không hợp lệ: %s", p)})
+				return
+			}
+			perms = append(perms, perm)
+		}
+		coOrganizers = append(coOrganizers, entity.CoOrganizer{
+			UserID:      strings.TrimSpace(co.UserID),
+			Permissions: perms,
+		})
+	}
+
+	if err := h.service.SetOrganizers(c, id, req.OwnerID, coOrganizers); err != nil {
+		c.JSON(statusFromError(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Đã cập nhật người tổ chức"})
+}
+
 // GET /events/:id
 func (h *EventHandler) GetEventByID(c *gin.Context) {
 	id := c.Param("id")
@@ -280,62 +415,125 @@ func (h *EventHandler) GetEventByID(c *gin.Context) {
 		Description: event.Description,
 		Type:        event.Type,
 		Status:      event.Status,
+		LocationID:  event.LocationID,
 		Location:    event.Location,
 		MaxGuests:   int(event.MaxGuests),
+		SeatsTaken:  event.SeatsTaken,
 		StartDate:   event.StartDate,
 		EndDate:     event.EndDate,
 		ImageURLs:   event.ImageURLs,
+		SeriesID:    event.SeriesID,
+		OwnerID:     event.OwnerID,
+	}
+	if !event.OccurrenceStart.IsZero() {
+		resp.OccurrenceStart = &event.OccurrenceStart
+	}
+	resp.CoOrganizers = make([]requestx.CoOrganizerResponse, 0, len(event.CoOrganizers))
+	for _, co := range event.CoOrganizers {
+		perms := make([]string, 0, len(co.Permissions))
+		for _, p := range co.Permissions {
+			perms = append(perms, string(p))
+		}
+		resp.CoOrganizers = append(resp.CoOrganizers, requestx.CoOrganizerResponse{
+			UserID:      co.UserID,
+			Permissions: perms,
+		})
 	}
 
+	// Diễn giả / nhà tài trợ gắn với sự kiện và các phiên
+	profiles, err := h.profiles.ListByEvent(c, event.ID)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+	resp.Speakers, resp.Sponsors = toEventProfileResponses(profiles)
+
 	c.JSON(http.StatusOK, gin.H{
 		"data": resp,
 	})
 }
 
-// GET /events?status=Sắp diễn ra&from=2025-10-01&to=2025-12-31
+// GET /events?status=Sắp diễn ra&type=&location_id=&location=&from=2025-10-01&to=2025-12-31&q=&sort=start_date&order=desc&page=1&page_size=20&cursor=&mine=true
 func (h *EventHandler) ListEvents(c *gin.Context) {
-	status := c.Query("status")
-	fromStr := c.Query("from")
-	toStr := c.Query("to")
+	q := entity.EventQuery{
+		Status:     c.Query("status"),
+		Type:       strings.TrimSpace(c.Query("type")),
+		LocationID: strings.TrimSpace(c.Query("location_id")),
+		Location:   strings.TrimSpace(c.Query("location")),
+		Text:       strings.TrimSpace(c.Query("q")),
+		SortBy:     c.DefaultQuery("sort", entity.EventSortStartDate),
+		SortDesc:   strings.EqualFold(c.Query("order"), "desc"),
+		Cursor:     strings.TrimSpace(c.Query("cursor")),
+	}
+
+	// 👤 mine=true: chỉ lấy sự kiện user hiện tại là chủ hoặc đồng tổ chức
+	if c.Query("mine") == "true" {
+		if user := utils.UserFromContext(c); user != nil {
+			q.OrganizerID = user.ID
+		}
+	}
 
-	var from, to time.Time
 	var err error
-	if fromStr != "" {
-		from, err = time.Parse("2006-01-02", fromStr)
+	if fromStr := c.Query("from"); fromStr != "" {
+		q.From, err = time.Parse("2006-01-02", fromStr)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Ngày 'from' không hợp lệ"})
 			return
 		}
 	}
-	if toStr != "" {
-		to, err = time.Parse("2006-01-02", toStr)
+	if toStr := c.Query("to"); toStr != "" {
+		q.To, err = time.Parse("2006-01-02", toStr)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Ngày 'to' không hợp lệ"})
 			return
 		}
 	}
+	if pageStr := c.Query("page"); pageStr != "" {
+		q.Page, err = strconv.Atoi(pageStr)
+		if err != nil || q.Page < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "'page' phải là số nguyên dương"})
+			return
+		}
+	}
+	if sizeStr := c.Query("page_size"); sizeStr != "" {
+		q.PageSize, err = strconv.Atoi(sizeStr)
+		if err != nil || q.PageSize < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "'page_size' phải là số nguyên dương"})
+			return
+		}
+	}
 
-	// 🔹 Gọi service để lấy danh sách entity (đầy đủ)
-	events, err := h.service.List(c, status, from, to)
+	// 🔹 Gọi service để lấy một trang sự kiện
+	page, err := h.service.List(c, q)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
 		return
 	}
 
 	// 🔹 Map sang DTO
-	res := make([]requestx.EventResponse, 0, len(events))
-	for _, e := range events {
+	res := make([]requestx.EventResponse, 0, len(page.Events))
+	for _, e := range page.Events {
 		res = append(res, requestx.EventResponse{
-			ID:        e.ID,
-			Name:      e.Name,
-			Status:    e.Status,
-			StartDate: e.StartDate,
-			EndDate:   e.EndDate,
-			Location:  e.Location,
+			ID:         e.ID,
+			Name:       e.Name,
+			Status:     e.Status,
+			StartDate:  e.StartDate,
+			EndDate:    e.EndDate,
+			LocationID: e.LocationID,
+			Location:   e.Location,
+			SeriesID:   e.SeriesID,
 		})
 	}
 
-	c.JSON(http.StatusOK, gin.H{"data": res})
+	c.JSON(http.StatusOK, gin.H{
+		"data": res,
+		"meta": requestx.PageMeta{
+			Total:      page.Total,
+			Page:       page.Page,
+			PageSize:   page.PageSize,
+			NextCursor: page.NextCursor,
+		},
+	})
 }
 
 // PATCH /events/auto-update
@@ -0,0 +1,244 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"event_manager/internal/domain/entity"
+	service_interface "event_manager/internal/domain/service"
+	dto "event_manager/internal/dto/request"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EventSessionHandler exposes agenda endpoints nested under an event.
+type EventSessionHandler struct {
+	svc service_interface.EventSessionService
+}
+
+// NewEventSessionHandler constructs an event session handler.
+func NewEventSessionHandler(svc service_interface.EventSessionService) *EventSessionHandler {
+	return &EventSessionHandler{svc: svc}
+}
+
+// Create handles POST /events/:id/sessions.
+func (h *EventSessionHandler) Create(c *gin.Context) {
+	var req dto.EventSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	session := sessionFromRequest(&req)
+	session.EventID = strings.TrimSpace(c.Param("id"))
+	if err := h.svc.Create(ctx, session); err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": toEventSessionResponse(session)})
+}
+
+// Update handles PUT /events/:id/sessions/:sessionId.
+func (h *EventSessionHandler) Update(c *gin.Context) {
+	var req dto.EventSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	session := sessionFromRequest(&req)
+	session.ID = strings.TrimSpace(c.Param("sessionId"))
+	session.EventID = strings.TrimSpace(c.Param("id"))
+	if err := h.svc.Update(ctx, session); err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": toEventSessionResponse(session)})
+}
+
+// Delete handles DELETE /events/:id/sessions/:sessionId.
+func (h *EventSessionHandler) Delete(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	if err := h.svc.Delete(ctx, c.Param("id"), c.Param("sessionId")); err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "session deleted successfully"})
+}
+
+// GetByID handles GET /events/:id/sessions/:sessionId.
+func (h *EventSessionHandler) GetByID(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	session, err := h.svc.GetByID(ctx, c.Param("id"), c.Param("sessionId"))
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": toEventSessionResponse(session)})
+}
+
+// List handles GET /events/:id/sessions and returns the agenda ordered by start time.
+func (h *EventSessionHandler) List(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	sessions, err := h.svc.ListByEvent(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": toEventSessionResponses(sessions)})
+}
+
+// Register handles POST /events/:id/sessions/:sessionId/registrations.
+func (h *EventSessionHandler) Register(c *gin.Context) {
+	var req dto.SessionRegistrationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	reg, err := h.svc.Register(ctx, c.Param("id"), c.Param("sessionId"), req.RegistrationID)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": toSessionRegistrationResponse(reg)})
+}
+
+// CancelRegistration handles DELETE /events/:id/sessions/:sessionId/registrations/:registrationId.
+func (h *EventSessionHandler) CancelRegistration(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	reg, err := h.svc.CancelRegistration(ctx, c.Param("id"), c.Param("sessionId"), c.Param("registrationId"))
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": toSessionRegistrationResponse(reg)})
+}
+
+// CheckIn handles PUT /events/:id/sessions/:sessionId/registrations/:registrationId/check-in.
+func (h *EventSessionHandler) CheckIn(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	reg, err := h.svc.CheckIn(ctx, c.Param("id"), c.Param("sessionId"), c.Param("registrationId"))
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": toSessionRegistrationResponse(reg)})
+}
+
+// ListRegistrations handles GET /events/:id/sessions/:sessionId/registrations.
+func (h *EventSessionHandler) ListRegistrations(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	regs, err := h.svc.ListRegistrations(ctx, c.Param("id"), c.Param("sessionId"))
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := make([]dto.SessionRegistrationResponse, 0, len(regs))
+	for _, r := range regs {
+		resp = append(resp, toSessionRegistrationResponse(r))
+	}
+	c.JSON(http.StatusOK, gin.H{"data": resp})
+}
+
+// Schedule handles GET /registrations/:id/sessions and returns the guest's personal agenda.
+func (h *EventSessionHandler) Schedule(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	sessions, err := h.svc.ScheduleByRegistration(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": toEventSessionResponses(sessions)})
+}
+
+func sessionFromRequest(req *dto.EventSessionRequest) *entity.EventSession {
+	return &entity.EventSession{
+		Title:       req.Title,
+		Description: req.Description,
+		Room:        req.Room,
+		Speakers:    req.Speakers,
+		StartTime:   req.StartTime,
+		EndTime:     req.EndTime,
+		Capacity:    req.Capacity,
+	}
+}
+
+func toEventSessionResponse(s *entity.EventSession) dto.EventSessionResponse {
+	speakers := s.Speakers
+	if speakers == nil {
+		speakers = []string{}
+	}
+	return dto.EventSessionResponse{
+		ID:          s.ID,
+		EventID:     s.EventID,
+		Title:       s.Title,
+		Description: s.Description,
+		Room:        s.Room,
+		Speakers:    speakers,
+		StartTime:   s.StartTime,
+		EndTime:     s.EndTime,
+		Capacity:    s.Capacity,
+		SeatsTaken:  s.SeatsTaken,
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+	}
+}
+
+func toEventSessionResponses(sessions []*entity.EventSession) []dto.EventSessionResponse {
+	resp := make([]dto.EventSessionResponse, 0, len(sessions))
+	for _, s := range sessions {
+		resp = append(resp, toEventSessionResponse(s))
+	}
+	return resp
+}
+
+func toSessionRegistrationResponse(r *entity.SessionRegistration) dto.SessionRegistrationResponse {
+	return dto.SessionRegistrationResponse{
+		ID:             r.ID,
+		SessionID:      r.SessionID,
+		EventID:        r.EventID,
+		RegistrationID: r.RegistrationID,
+		GuestID:        r.GuestID,
+		GuestName:      r.GuestName,
+		Status:         string(r.Status),
+		CreatedAt:      r.CreatedAt,
+		CheckedInAt:    r.CheckedInAt,
+		CancelledAt:    r.CancelledAt,
+	}
+}
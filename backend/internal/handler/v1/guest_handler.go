@@ -2,25 +2,29 @@ package handler
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"event_manager/internal/domain/entity"
 	service_interface "event_manager/internal/domain/service"
 	dto "event_manager/internal/dto/request"
+	"event_manager/internal/sheet"
 
 	"github.com/gin-gonic/gin"
 )
 
 // GuestHandler exposes guest-related HTTP endpoints.
 type GuestHandler struct {
-	svc service_interface.GuestService
+	svc   service_interface.GuestService
+	dedup service_interface.GuestDedupService
 }
 
 // NewGuestHandler constructs a guest handler.
-func NewGuestHandler(svc service_interface.GuestService) *GuestHandler {
-	return &GuestHandler{svc: svc}
+func NewGuestHandler(svc service_interface.GuestService, dedup service_interface.GuestDedupService) *GuestHandler {
+	return &GuestHandler{svc: svc, dedup: dedup}
 }
 
 // CreateGuest handles POST /guests.
@@ -54,7 +58,7 @@ func (h *GuestHandler) CreateGuest(c *gin.Context) {
 	}
 
 	if err := h.svc.Create(ctx, guest, eventID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -94,7 +98,7 @@ func (h *GuestHandler) UpdateGuest(c *gin.Context) {
 	}
 
 	if err := h.svc.Update(ctx, guest, eventID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -113,7 +117,7 @@ func (h *GuestHandler) DeleteGuest(c *gin.Context) {
 	defer cancel()
 
 	if err := h.svc.Delete(ctx, id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -151,21 +155,83 @@ func (h *GuestHandler) GetGuestByID(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"data": resp})
 }
 
-// ListGuests handles GET /guests.
+// ListGuestEvents handles GET /guests/:id/events.
+func (h *GuestHandler) ListGuestEvents(c *gin.Context) {
+	id := c.Param("id")
+	if strings.TrimSpace(id) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "guest id is required"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	items, err := h.svc.ListEvents(ctx, id)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	responses := make([]dto.GuestEventResponse, 0, len(items))
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+		responses = append(responses, dto.GuestEventResponse{
+			Event: dto.EventResponse{
+				ID:        item.Event.ID,
+				Name:      item.Event.Name,
+				Status:    item.Event.Status,
+				StartDate: item.Event.StartDate,
+				EndDate:   item.Event.EndDate,
+				Location:  item.Event.Location,
+			},
+			RegistrationID:     item.Registration.ID,
+			RegistrationStatus: string(item.Registration.Status),
+			CheckedIn:          item.Registration.CheckedIn,
+			RegisteredAt:       item.Registration.CreatedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": responses})
+}
+
+// ListGuests handles GET /guests?event_id=&keyword=&field=name|email|phone&match=prefix|contains&page=&page_size=.
 func (h *GuestHandler) ListGuests(c *gin.Context) {
-	keyword := c.Query("keyword")
+	q := entity.GuestQuery{
+		EventID: strings.TrimSpace(c.Query("event_id")),
+		Keyword: strings.TrimSpace(c.Query("keyword")),
+		Field:   strings.TrimSpace(c.Query("field")),
+		Match:   strings.TrimSpace(c.Query("match")),
+	}
+
+	var err error
+	if pageStr := c.Query("page"); pageStr != "" {
+		q.Page, err = strconv.Atoi(pageStr)
+		if err != nil || q.Page < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "page must be a positive integer"})
+			return
+		}
+	}
+	if sizeStr := c.Query("page_size"); sizeStr != "" {
+		q.PageSize, err = strconv.Atoi(sizeStr)
+		if err != nil || q.PageSize < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "page_size must be a positive integer"})
+			return
+		}
+	}
 
 	ctx, cancel := context.WithTimeout(c, 5*time.Second)
 	defer cancel()
 
-	guests, err := h.svc.List(ctx, keyword)
+	page, err := h.svc.Search(ctx, q)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
 		return
 	}
 
-	responses := make([]dto.GuestResponse, 0, len(guests))
-	for _, g := range guests {
+	responses := make([]dto.GuestResponse, 0, len(page.Guests))
+	for _, g := range page.Guests {
 		if g == nil {
 			continue
 		}
@@ -177,7 +243,14 @@ func (h *GuestHandler) ListGuests(c *gin.Context) {
 		})
 	}
 
-	c.JSON(http.StatusOK, gin.H{"data": responses})
+	c.JSON(http.StatusOK, gin.H{
+		"data": responses,
+		"meta": dto.PageMeta{
+			Total:    page.Total,
+			Page:     page.Page,
+			PageSize: page.PageSize,
+		},
+	})
 }
 
 // FindGuestByContact handles GET /guests/search?email=&phone=.
@@ -212,3 +285,265 @@ func (h *GuestHandler) FindGuestByContact(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"data": resp})
 }
+
+const (
+	// maxGuestImportSize giới hạn kích thước file nhập khách
+	maxGuestImportSize = 20 << 20
+	// guestImportSyncSize: file nhỏ hơn được nhập ngay trong request, lớn hơn chạy thành job nền
+	guestImportSyncSize = 64 << 10
+)
+
+// ImportGuests handles POST /guests/import (multipart: file, event_id, format, async, *_column).
+// Small files are imported within the request (200 + report); larger ones or async=true start a
+// background job (202) whose progress is polled at GET /guests/import/:job_id.
+func (h *GuestHandler) ImportGuests(c *gin.Context) {
+	var req dto.GuestImportRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+	if file.Size > maxGuestImportSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("file must not exceed %d MB", maxGuestImportSize>>20)})
+		return
+	}
+
+	format := sheet.Format(strings.ToLower(strings.TrimSpace(req.Format)))
+	if format == "" {
+		if format, err = sheet.FormatFromFilename(file.Filename); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer f.Close()
+
+	importReq := entity.GuestImportRequest{
+		EventID:  strings.TrimSpace(req.EventID),
+		FileName: file.Filename,
+		Format:   string(format),
+		Columns: entity.GuestImportColumns{
+			FullName: req.FullNameColumn,
+			Email:    req.EmailColumn,
+			Phone:    req.PhoneColumn,
+		},
+	}
+
+	if req.Async || file.Size > guestImportSyncSize {
+		// Dùng context của http.Request: *gin.Context được tái sử dụng sau khi handler trả về
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+		defer cancel()
+
+		job, err := h.svc.StartImport(ctx, importReq, f)
+		if err != nil {
+			c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+			return
+		}
+		c.Header("Location", "/api/v1/guests/import/"+job.ID)
+		c.JSON(http.StatusAccepted, gin.H{"data": guestImportJobResponse(job)})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, 60*time.Second)
+	defer cancel()
+
+	job, err := h.svc.ImportGuests(ctx, importReq, f)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": guestImportJobResponse(job)})
+}
+
+// GetImportJob handles GET /guests/import/:job_id.
+func (h *GuestHandler) GetImportJob(c *gin.Context) {
+	id := strings.TrimSpace(c.Param("job_id"))
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "job id is required"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	job, err := h.svc.GetImportJob(ctx, id)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": guestImportJobResponse(job)})
+}
+
+func guestImportJobResponse(job *entity.GuestImportJob) dto.GuestImportJobResponse {
+	resp := dto.GuestImportJobResponse{
+		ID:         job.ID,
+		EventID:    job.EventID,
+		FileName:   job.FileName,
+		Status:     string(job.Status),
+		Total:      job.Total,
+		Processed:  job.Processed,
+		Created:    job.Created,
+		Reused:     job.Reused,
+		Failed:     job.Failed,
+		Error:      job.Error,
+		Rows:       make([]dto.GuestImportRowResponse, 0, len(job.Rows)),
+		CreatedAt:  job.CreatedAt,
+		UpdatedAt:  job.UpdatedAt,
+		FinishedAt: job.FinishedAt,
+	}
+	if job.Total > 0 {
+		resp.Progress = float64(job.Processed) * 100 / float64(job.Total)
+	}
+	for _, r := range job.Rows {
+		resp.Rows = append(resp.Rows, dto.GuestImportRowResponse{
+			Row:      r.Row,
+			FullName: r.FullName,
+			Email:    r.Email,
+			Phone:    r.Phone,
+			Action:   r.Action,
+			GuestID:  r.GuestID,
+			Error:    r.Error,
+		})
+	}
+	return resp
+}
+
+// ListDuplicates handles GET /guests/duplicates?reason=email|phone|name&min_score=&include_dismissed=&page=&page_size=.
+func (h *GuestHandler) ListDuplicates(c *gin.Context) {
+	q := entity.GuestDuplicateQuery{Reason: strings.TrimSpace(c.Query("reason"))}
+
+	var err error
+	if scoreStr := c.Query("min_score"); scoreStr != "" {
+		q.MinScore, err = strconv.ParseFloat(scoreStr, 64)
+		if err != nil || q.MinScore < 0 || q.MinScore > 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "min_score must be a number between 0 and 1"})
+			return
+		}
+	}
+	if dismissedStr := c.Query("include_dismissed"); dismissedStr != "" {
+		q.IncludeDismissed, err = strconv.ParseBool(dismissedStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "include_dismissed must be a boolean"})
+			return
+		}
+	}
+	if pageStr := c.Query("page"); pageStr != "" {
+		q.Page, err = strconv.Atoi(pageStr)
+		if err != nil || q.Page < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "page must be a positive integer"})
+			return
+		}
+	}
+	if sizeStr := c.Query("page_size"); sizeStr != "" {
+		q.PageSize, err = strconv.Atoi(sizeStr)
+		if err != nil || q.PageSize < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "page_size must be a positive integer"})
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	page, err := h.dedup.ListDuplicates(ctx, q)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	responses := make([]dto.GuestDuplicateResponse, 0, len(page.Items))
+	for _, d := range page.Items {
+		responses = append(responses, dto.GuestDuplicateResponse{
+			ID:             d.ID,
+			Guests:         []dto.GuestResponse{guestResponse(&d.Guests[0]), guestResponse(&d.Guests[1])},
+			Reasons:        d.Reasons,
+			NameSimilarity: d.NameSimilarity,
+			Score:          d.Score,
+			Dismissed:      d.Dismissed,
+			DetectedAt:     d.DetectedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": responses,
+		"meta": dto.PageMeta{
+			Total:    page.Total,
+			Page:     page.Page,
+			PageSize: page.PageSize,
+		},
+	})
+}
+
+// ScanDuplicates handles POST /guests/duplicates/scan; the scan runs in the background.
+func (h *GuestHandler) ScanDuplicates(c *gin.Context) {
+	if err := h.dedup.StartScan(c.Request.Context()); err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{"message": "duplicate scan started"})
+}
+
+// DismissDuplicate handles POST /guests/duplicates/:id/dismiss.
+func (h *GuestHandler) DismissDuplicate(c *gin.Context) {
+	id := strings.TrimSpace(c.Param("id"))
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "duplicate id is required"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	if err := h.dedup.Dismiss(ctx, id); err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "duplicate dismissed"})
+}
+
+// MergeGuests handles POST /guests/merge.
+func (h *GuestHandler) MergeGuests(c *gin.Context) {
+	var req dto.GuestMergeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, 30*time.Second)
+	defer cancel()
+
+	result, err := h.dedup.Merge(ctx, req.SurvivorID, req.DuplicateIDs)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": dto.GuestMergeResponse{
+		Survivor:             guestResponse(&result.Survivor),
+		MergedIDs:            result.MergedIDs,
+		RegistrationsMoved:   result.RegistrationsMoved,
+		RegistrationsDropped: result.RegistrationsDropped,
+		ReviewsMoved:         result.ReviewsMoved,
+		ReviewsDropped:       result.ReviewsDropped,
+		OrdersMoved:          result.OrdersMoved,
+	}})
+}
+
+func guestResponse(g *entity.Guest) dto.GuestResponse {
+	return dto.GuestResponse{
+		ID:       g.ID,
+		FullName: g.FullName,
+		Email:    g.Email,
+		Phone:    g.Phone,
+	}
+}
@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"event_manager/internal/domain/entity"
+	service_interface "event_manager/internal/domain/service"
+	dto "event_manager/internal/dto/request"
+
+	"github.com/gin-gonic/gin"
+)
+
+// KioskHandler exposes kiosk management endpoints for staff and the self check-in endpoints used by kiosks.
+type KioskHandler struct {
+	svc service_interface.KioskService
+}
+
+// NewKioskHandler constructs a kiosk handler.
+func NewKioskHandler(svc service_interface.KioskService) *KioskHandler {
+	return &KioskHandler{svc: svc}
+}
+
+// CreateSession handles POST /events/:id/kiosks.
+func (h *KioskHandler) CreateSession(c *gin.Context) {
+	var req dto.KioskCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	session, token, err := h.svc.CreateSession(ctx, c.Param("id"), req.Name, time.Duration(req.TTLHours)*time.Hour)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := kioskSessionResponse(session)
+	resp.Token = token
+	c.JSON(http.StatusCreated, gin.H{"data": resp})
+}
+
+// ListSessions handles GET /events/:id/kiosks.
+func (h *KioskHandler) ListSessions(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	sessions, err := h.svc.ListSessions(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	responses := make([]dto.KioskSessionResponse, 0, len(sessions))
+	for _, s := range sessions {
+		responses = append(responses, kioskSessionResponse(s))
+	}
+	c.JSON(http.StatusOK, gin.H{"data": responses})
+}
+
+// RevokeSession handles DELETE /events/:id/kiosks/:kioskId.
+func (h *KioskHandler) RevokeSession(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	if err := h.svc.RevokeSession(ctx, c.Param("id"), c.Param("kioskId")); err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "kiosk revoked"})
+}
+
+// Audit handles GET /events/:id/kiosks/:kioskId/audit?limit=.
+func (h *KioskHandler) Audit(c *gin.Context) {
+	limit := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive integer"})
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	entries, err := h.svc.Audit(ctx, c.Param("id"), c.Param("kioskId"), limit)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	responses := make([]dto.KioskAuditResponse, 0, len(entries))
+	for _, e := range entries {
+		responses = append(responses, dto.KioskAuditResponse{
+			ID:             e.ID,
+			Action:         e.Action,
+			Query:          e.Query,
+			Matches:        e.Matches,
+			RegistrationID: e.RegistrationID,
+			Result:         e.Result,
+			Message:        e.Message,
+			ClientIP:       e.ClientIP,
+			At:             e.At,
+		})
+	}
+	c.JSON(http.StatusOK, gin.H{"data": responses})
+}
+
+// Session handles GET /kiosk/session: the event the kiosk serves.
+func (h *KioskHandler) Session(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	event, err := h.svc.Event(ctx)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": dto.EventResponse{
+		ID:         event.ID,
+		Name:       event.Name,
+		Status:     string(event.Status),
+		StartDate:  event.StartDate,
+		EndDate:    event.EndDate,
+		LocationID: event.LocationID,
+		Location:   event.Location,
+	}})
+}
+
+// LookupGuests handles GET /kiosk/guests?q=: prefix search on name, email or phone.
+func (h *KioskHandler) LookupGuests(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	result, err := h.svc.LookupGuests(ctx, c.Query("q"), c.ClientIP())
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	responses := make([]dto.KioskGuestResponse, 0, len(result.Matches))
+	for _, m := range result.Matches {
+		responses = append(responses, kioskGuestResponse(m))
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"data": responses,
+		// Nhiều kết quả hơn số hiển thị: kiosk nhắc khách gõ thêm
+		"more": result.Total > int64(len(result.Matches)),
+	})
+}
+
+// CheckIn handles POST /kiosk/check-in.
+func (h *KioskHandler) CheckIn(c *gin.Context) {
+	var req dto.KioskCheckInRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	match, err := h.svc.CheckIn(ctx, strings.TrimSpace(req.RegistrationID), req.Confirm, c.ClientIP())
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": kioskGuestResponse(match)})
+}
+
+// RateLimited records a rejected kiosk request in the audit trail; used as the rate limit hook.
+func (h *KioskHandler) RateLimited(c *gin.Context) {
+	h.svc.RecordRateLimited(c.Request.Context(), c.FullPath(), c.ClientIP())
+}
+
+func kioskSessionResponse(s *entity.KioskSession) dto.KioskSessionResponse {
+	return dto.KioskSessionResponse{
+		ID:         s.ID,
+		EventID:    s.EventID,
+		Name:       s.Name,
+		Active:     s.IsActive(time.Now()),
+		CreatedBy:  s.CreatedBy,
+		CreatedAt:  s.CreatedAt,
+		ExpiresAt:  s.ExpiresAt,
+		RevokedAt:  s.RevokedAt,
+		LastSeenAt: s.LastSeenAt,
+	}
+}
+
+func kioskGuestResponse(m *entity.KioskGuestMatch) dto.KioskGuestResponse {
+	return dto.KioskGuestResponse{
+		RegistrationID: m.RegistrationID,
+		Name:           m.Name,
+		Email:          m.Email,
+		Phone:          m.Phone,
+		Status:         string(m.Status),
+		CheckedIn:      m.CheckedIn,
+	}
+}
@@ -0,0 +1,228 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"event_manager/internal/domain/entity"
+	service_interface "event_manager/internal/domain/service"
+	dto "event_manager/internal/dto/request"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LocationHandler exposes venue endpoints.
+type LocationHandler struct {
+	svc service_interface.LocationService
+}
+
+// NewLocationHandler constructs a location handler.
+func NewLocationHandler(svc service_interface.LocationService) *LocationHandler {
+	return &LocationHandler{svc: svc}
+}
+
+// Create handles POST /locations.
+func (h *LocationHandler) Create(c *gin.Context) {
+	var req dto.LocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	loc := locationFromRequest(req)
+	if err := h.svc.Create(ctx, loc); err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": toLocationResponse(loc)})
+}
+
+// Update handles PUT /locations/:id.
+func (h *LocationHandler) Update(c *gin.Context) {
+	var req dto.LocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	loc := locationFromRequest(req)
+	loc.ID = strings.TrimSpace(c.Param("id"))
+	if err := h.svc.Update(ctx, loc); err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": toLocationResponse(loc)})
+}
+
+// Delete handles DELETE /locations/:id.
+func (h *LocationHandler) Delete(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	if err := h.svc.Delete(ctx, c.Param("id")); err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "location deleted successfully"})
+}
+
+// GetByID handles GET /locations/:id.
+func (h *LocationHandler) GetByID(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	loc, err := h.svc.GetByID(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": toLocationResponse(loc)})
+}
+
+// List handles GET /locations.
+func (h *LocationHandler) List(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	list, err := h.svc.List(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	responses := make([]dto.LocationResponse, 0, len(list))
+	for _, loc := range list {
+		responses = append(responses, toLocationResponse(loc))
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": responses})
+}
+
+// CheckCapacity handles GET /locations/:id/capacity?expected=N.
+func (h *LocationHandler) CheckCapacity(c *gin.Context) {
+	expected, err := strconv.Atoi(c.Query("expected"))
+	if err != nil || expected < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "expected must be a non-negative integer"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	id := strings.TrimSpace(c.Param("id"))
+	loc, err := h.svc.GetByID(ctx, id)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+	fits, err := h.svc.CheckCapacity(ctx, id, expected)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": dto.LocationCapacityResponse{
+		LocationID: loc.ID,
+		Capacity:   loc.Capacity,
+		Expected:   expected,
+		Fits:       fits,
+	}})
+}
+
+// Availability handles GET /locations/:id/availability?from=&to=.
+// from/to accept RFC3339 or YYYY-MM-DD; the window defaults to the next 7 days.
+func (h *LocationHandler) Availability(c *gin.Context) {
+	from := time.Now().Truncate(time.Hour)
+	to := from.AddDate(0, 0, 7)
+	if raw := c.Query("from"); raw != "" {
+		t, err := parseTime(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid from"})
+			return
+		}
+		from = t
+		to = from.AddDate(0, 0, 7)
+	}
+	if raw := c.Query("to"); raw != "" {
+		t, err := parseTime(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid to"})
+			return
+		}
+		to = t
+	}
+	if !to.After(from) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to must be after from"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	av, err := h.svc.Availability(ctx, strings.TrimSpace(c.Param("id")), from, to)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := dto.VenueAvailabilityResponse{
+		LocationID:            av.LocationID,
+		From:                  av.From,
+		To:                    av.To,
+		SetupBufferMinutes:    int(av.SetupBuffer / time.Minute),
+		TeardownBufferMinutes: int(av.TeardownBuffer / time.Minute),
+		Busy:                  make([]dto.VenueBookingResponse, 0, len(av.Busy)),
+		Free:                  make([]dto.TimeSlotResponse, 0, len(av.Free)),
+	}
+	for _, b := range av.Busy {
+		resp.Busy = append(resp.Busy, toVenueBookingResponse(b))
+	}
+	for _, slot := range av.Free {
+		resp.Free = append(resp.Free, dto.TimeSlotResponse{From: slot.From, To: slot.To})
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": resp})
+}
+
+func toVenueBookingResponse(b entity.VenueBooking) dto.VenueBookingResponse {
+	return dto.VenueBookingResponse{
+		EventID:     b.EventID,
+		EventName:   b.EventName,
+		StartDate:   b.StartDate,
+		EndDate:     b.EndDate,
+		BlockedFrom: b.BlockedFrom,
+		BlockedTo:   b.BlockedTo,
+	}
+}
+
+func locationFromRequest(req dto.LocationRequest) *entity.Location {
+	return &entity.Location{
+		Name:        strings.TrimSpace(req.Name),
+		Address:     strings.TrimSpace(req.Address),
+		Capacity:    req.Capacity,
+		Description: strings.TrimSpace(req.Description),
+	}
+}
+
+func toLocationResponse(loc *entity.Location) dto.LocationResponse {
+	return dto.LocationResponse{
+		ID:          loc.ID,
+		Name:        loc.Name,
+		Address:     loc.Address,
+		Capacity:    loc.Capacity,
+		Description: loc.Description,
+	}
+}
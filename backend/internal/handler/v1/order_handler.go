@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"event_manager/internal/domain/entity"
+	service_interface "event_manager/internal/domain/service"
+	dto "event_manager/internal/dto/request"
+	"event_manager/internal/payment"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxWebhookBodySize giới hạn kích thước webhook thanh toán
+const maxWebhookBodySize = 64 << 10
+
+// OrderHandler exposes ticket order endpoints and the payment webhook.
+type OrderHandler struct {
+	svc      service_interface.OrderService
+	provider payment.Provider
+}
+
+// NewOrderHandler constructs an order handler; provider backs the fake checkout endpoint when it is
+// the local fake gateway.
+func NewOrderHandler(svc service_interface.OrderService, provider payment.Provider) *OrderHandler {
+	return &OrderHandler{svc: svc, provider: provider}
+}
+
+// Create handles POST /events/:id/orders and returns the order with its checkout URL.
+func (h *OrderHandler) Create(c *gin.Context) {
+	var req dto.OrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, 10*time.Second)
+	defer cancel()
+
+	order, err := h.svc.PlaceOrder(ctx, c.Param("id"), req.TicketTypeID, req.GuestID)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": toOrderResponse(order)})
+}
+
+// Cancel handles DELETE /events/:id/orders/:orderId.
+func (h *OrderHandler) Cancel(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, 10*time.Second)
+	defer cancel()
+
+	order, err := h.svc.Cancel(ctx, c.Param("id"), c.Param("orderId"))
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": toOrderResponse(order)})
+}
+
+// GetByID handles GET /events/:id/orders/:orderId.
+func (h *OrderHandler) GetByID(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	order, err := h.svc.GetByID(ctx, c.Param("id"), c.Param("orderId"))
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": toOrderResponse(order)})
+}
+
+// List handles GET /events/:id/orders?status=pending|paid|failed|expired|cancelled|refund_due.
+func (h *OrderHandler) List(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	list, err := h.svc.ListByEvent(ctx, c.Param("id"), entity.OrderStatus(strings.TrimSpace(c.Query("status"))))
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	responses := make([]dto.OrderResponse, 0, len(list))
+	for _, o := range list {
+		responses = append(responses, toOrderResponse(o))
+	}
+	c.JSON(http.StatusOK, gin.H{"data": responses})
+}
+
+// Webhook handles POST /payments/webhook; authenticity is checked by the provider's signature.
+func (h *OrderHandler) Webhook(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxWebhookBodySize)
+	body, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "read webhook body failed"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, 10*time.Second)
+	defer cancel()
+
+	order, err := h.svc.HandleWebhook(ctx, c.Request.Header, body)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{"order_id": order.ID, "status": order.Status}})
+}
+
+// FakeCheckout handles POST /payments/fake/:paymentId?status=succeeded|failed. It stands in for the
+// hosted checkout page of a real gateway and is only available with the fake provider.
+func (h *OrderHandler) FakeCheckout(c *gin.Context) {
+	fake, ok := h.provider.(*payment.FakeProvider)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "fake checkout is not enabled"})
+		return
+	}
+
+	status := payment.Status(c.DefaultQuery("status", string(payment.StatusSucceeded)))
+	header, body, err := fake.Complete(strings.TrimSpace(c.Param("paymentId")), status)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, 10*time.Second)
+	defer cancel()
+
+	order, err := h.svc.HandleWebhook(ctx, header, body)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": toOrderResponse(order)})
+}
+
+func toOrderResponse(o *entity.Order) dto.OrderResponse {
+	return dto.OrderResponse{
+		ID:             o.ID,
+		EventID:        o.EventID,
+		TicketTypeID:   o.TicketTypeID,
+		TicketName:     o.TicketName,
+		GuestID:        o.GuestID,
+		RegistrationID: o.RegistrationID,
+		Amount:         o.Amount,
+		Currency:       o.Currency,
+		EarlyBird:      o.EarlyBird,
+		Status:         string(o.Status),
+		Reason:         o.Reason,
+		Provider:       o.Provider,
+		PaymentID:      o.PaymentID,
+		CheckoutURL:    o.CheckoutURL,
+		ExpiresAt:      o.ExpiresAt,
+		PaidAt:         o.PaidAt,
+		CreatedAt:      o.CreatedAt,
+		UpdatedAt:      o.UpdatedAt,
+	}
+}
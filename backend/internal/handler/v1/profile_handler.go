@@ -0,0 +1,270 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"event_manager/internal/domain/entity"
+	service_interface "event_manager/internal/domain/service"
+	dto "event_manager/internal/dto/request"
+	"event_manager/internal/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxProfilePhotoSize giới hạn dung lượng ảnh hồ sơ
+const maxProfilePhotoSize = 5 << 20
+
+// ProfileHandler exposes speaker / sponsor profile endpoints.
+type ProfileHandler struct {
+	svc     service_interface.ProfileService
+	storage storage.ObjectStorage
+}
+
+// NewProfileHandler constructs a profile handler; photos are uploaded to st.
+func NewProfileHandler(svc service_interface.ProfileService, st storage.ObjectStorage) *ProfileHandler {
+	return &ProfileHandler{svc: svc, storage: st}
+}
+
+// Create handles POST /profiles.
+func (h *ProfileHandler) Create(c *gin.Context) {
+	var req dto.ProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	profile := profileFromRequest(&req)
+	if err := h.svc.Create(ctx, profile); err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": toProfileResponse(profile)})
+}
+
+// Update handles PUT /profiles/:id.
+func (h *ProfileHandler) Update(c *gin.Context) {
+	var req dto.ProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	profile := profileFromRequest(&req)
+	profile.ID = strings.TrimSpace(c.Param("id"))
+	if err := h.svc.Update(ctx, profile); err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": toProfileResponse(profile)})
+}
+
+// Delete handles DELETE /profiles/:id.
+func (h *ProfileHandler) Delete(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	if err := h.svc.Delete(ctx, c.Param("id")); err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "profile deleted successfully"})
+}
+
+// GetByID handles GET /profiles/:id.
+func (h *ProfileHandler) GetByID(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	profile, err := h.svc.GetByID(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": toProfileResponse(profile)})
+}
+
+// List handles GET /profiles?kind=speaker|sponsor.
+func (h *ProfileHandler) List(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	list, err := h.svc.List(ctx, entity.ProfileKind(strings.TrimSpace(c.Query("kind"))))
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	responses := make([]dto.ProfileResponse, 0, len(list))
+	for _, p := range list {
+		responses = append(responses, toProfileResponse(p))
+	}
+	c.JSON(http.StatusOK, gin.H{"data": responses})
+}
+
+// UploadPhoto handles POST /profiles/:id/photo (multipart, field "photo").
+func (h *ProfileHandler) UploadPhoto(c *gin.Context) {
+	if h.storage == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "object storage is not configured"})
+		return
+	}
+	file, err := c.FormFile("photo")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "photo file is required"})
+		return
+	}
+	if file.Size > maxProfilePhotoSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("photo must not exceed %d MB", maxProfilePhotoSize>>20)})
+		return
+	}
+	contentType := fileContentType(file)
+	if !strings.HasPrefix(contentType, "image/") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "photo must be an image"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, 30*time.Second)
+	defer cancel()
+
+	// Kiểm tra hồ sơ tồn tại trước khi tải ảnh lên kho lưu trữ
+	id := strings.TrimSpace(c.Param("id"))
+	if _, err := h.svc.GetByID(ctx, id); err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	reader, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer reader.Close()
+
+	objectName := fmt.Sprintf("profiles/%s/%d_%s", id, time.Now().UnixNano(), sanitizeFilename(file.Filename))
+	url, err := h.storage.Upload(ctx, objectName, reader, file.Size, contentType)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "upload photo failed: " + err.Error()})
+		return
+	}
+
+	profile, err := h.svc.SetPhoto(ctx, id, url)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": toProfileResponse(profile)})
+}
+
+// ListByEvent handles GET /events/:id/profiles.
+func (h *ProfileHandler) ListByEvent(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	list, err := h.svc.ListByEvent(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	speakers, sponsors := toEventProfileResponses(list)
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{"speakers": speakers, "sponsors": sponsors}})
+}
+
+// Assign handles POST /events/:id/profiles.
+func (h *ProfileHandler) Assign(c *gin.Context) {
+	var req dto.ProfileAssignmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	if err := h.svc.Assign(ctx, c.Param("id"), req.SessionID, req.ProfileID); err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "profile assigned successfully"})
+}
+
+// Unassign handles DELETE /events/:id/profiles/:profileId?session_id=.
+func (h *ProfileHandler) Unassign(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	if err := h.svc.Unassign(ctx, c.Param("id"), c.Query("session_id"), c.Param("profileId")); err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "profile unassigned successfully"})
+}
+
+func profileFromRequest(req *dto.ProfileRequest) *entity.Profile {
+	links := make([]entity.ProfileLink, 0, len(req.Links))
+	for _, l := range req.Links {
+		links = append(links, entity.ProfileLink{Label: l.Label, URL: l.URL})
+	}
+	return &entity.Profile{
+		Kind:     entity.ProfileKind(req.Kind),
+		Name:     req.Name,
+		Headline: req.Headline,
+		Bio:      req.Bio,
+		Links:    links,
+		Tier:     entity.SponsorTier(strings.ToLower(strings.TrimSpace(req.Tier))),
+	}
+}
+
+func toProfileResponse(p *entity.Profile) dto.ProfileResponse {
+	links := make([]dto.ProfileLinkPayload, 0, len(p.Links))
+	for _, l := range p.Links {
+		links = append(links, dto.ProfileLinkPayload{Label: l.Label, URL: l.URL})
+	}
+	return dto.ProfileResponse{
+		ID:        p.ID,
+		Kind:      string(p.Kind),
+		Name:      p.Name,
+		Headline:  p.Headline,
+		Bio:       p.Bio,
+		PhotoURL:  p.PhotoURL,
+		Links:     links,
+		Tier:      string(p.Tier),
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: p.UpdatedAt,
+	}
+}
+
+// toEventProfileResponses tách hồ sơ của sự kiện thành diễn giả và nhà tài trợ, giữ nguyên thứ tự
+func toEventProfileResponses(list []*entity.EventProfile) (speakers, sponsors []dto.EventProfileResponse) {
+	speakers = make([]dto.EventProfileResponse, 0)
+	sponsors = make([]dto.EventProfileResponse, 0)
+	for _, ep := range list {
+		resp := dto.EventProfileResponse{
+			ProfileResponse: toProfileResponse(ep.Profile),
+			OnEvent:         ep.OnEvent,
+			SessionIDs:      ep.SessionIDs,
+		}
+		if ep.Profile.Kind == entity.ProfileSponsor {
+			sponsors = append(sponsors, resp)
+		} else {
+			speakers = append(speakers, resp)
+		}
+	}
+	return speakers, sponsors
+}
@@ -2,25 +2,38 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"event_manager/internal/domain/entity"
 	service_interface "event_manager/internal/domain/service"
 	dto "event_manager/internal/dto/request"
+	"event_manager/internal/export"
 
 	"github.com/gin-gonic/gin"
+	"github.com/skip2/go-qrcode"
+)
+
+// Kích thước ảnh QR của vé (pixel)
+const (
+	defaultTicketSize = 320
+	minTicketSize     = 128
+	maxTicketSize     = 1024
 )
 
 // RegistrationHandler exposes registration HTTP endpoints.
 type RegistrationHandler struct {
-	svc service_interface.RegistrationService
+	svc    service_interface.RegistrationService
+	export export.Options
 }
 
 // NewRegistrationHandler constructs a registration handler.
-func NewRegistrationHandler(svc service_interface.RegistrationService) *RegistrationHandler {
-	return &RegistrationHandler{svc: svc}
+func NewRegistrationHandler(svc service_interface.RegistrationService, exportOpts export.Options) *RegistrationHandler {
+	return &RegistrationHandler{svc: svc, export: exportOpts}
 }
 
 // Register handles POST /registrations.
@@ -37,25 +50,16 @@ func (h *RegistrationHandler) Register(c *gin.Context) {
 	reg := &entity.Registration{
 		EventID:   strings.TrimSpace(req.EventID),
 		GuestID:   strings.TrimSpace(req.GuestID),
-		Status:    strings.TrimSpace(req.Status),
+		Status:    entity.RegistrationStatus(strings.TrimSpace(req.Status)),
 		CreatedAt: time.Now(),
 	}
 
 	if err := h.svc.Register(ctx, reg); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
 		return
 	}
 
-	resp := dto.RegistrationResponse{
-		ID:        reg.ID,
-		EventID:   reg.EventID,
-		GuestID:   reg.GuestID,
-		Status:    reg.Status,
-		CreatedAt: reg.CreatedAt,
-		CheckedIn: reg.CheckedIn,
-	}
-
-	c.JSON(http.StatusCreated, gin.H{"data": resp})
+	c.JSON(http.StatusCreated, gin.H{"data": registrationResponse(reg)})
 }
 
 // CheckIn handles PUT /registrations/:id/check-in.
@@ -71,20 +75,31 @@ func (h *RegistrationHandler) CheckIn(c *gin.Context) {
 
 	reg, err := h.svc.CheckIn(ctx, id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
 		return
 	}
 
-	resp := dto.RegistrationResponse{
-		ID:        reg.ID,
-		EventID:   reg.EventID,
-		GuestID:   reg.GuestID,
-		Status:    reg.Status,
-		CreatedAt: reg.CreatedAt,
-		CheckedIn: reg.CheckedIn,
+	c.JSON(http.StatusOK, gin.H{"data": registrationResponse(reg)})
+}
+
+// CheckOut handles PUT /registrations/:id/check-out.
+func (h *RegistrationHandler) CheckOut(c *gin.Context) {
+	id := c.Param("id")
+	if strings.TrimSpace(id) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "registration id is required"})
+		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"data": resp})
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	reg, err := h.svc.CheckOut(ctx, id)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": registrationResponse(reg)})
 }
 
 // Cancel handles PUT /registrations/:id/cancel.
@@ -100,20 +115,69 @@ func (h *RegistrationHandler) Cancel(c *gin.Context) {
 
 	reg, err := h.svc.Cancel(ctx, id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
 		return
 	}
 
-	resp := dto.RegistrationResponse{
-		ID:        reg.ID,
-		EventID:   reg.EventID,
-		GuestID:   reg.GuestID,
-		Status:    reg.Status,
-		CreatedAt: reg.CreatedAt,
-		CheckedIn: reg.CheckedIn,
+	c.JSON(http.StatusOK, gin.H{"data": registrationResponse(reg)})
+}
+
+// ChangeStatus handles PUT /registrations/:id/status.
+func (h *RegistrationHandler) ChangeStatus(c *gin.Context) {
+	id := c.Param("id")
+	if strings.TrimSpace(id) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "registration id is required"})
+		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"data": resp})
+	var req dto.RegistrationStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	status := entity.RegistrationStatus(strings.TrimSpace(req.Status))
+	reg, err := h.svc.ChangeStatus(ctx, id, status, strings.TrimSpace(req.Reason))
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": registrationResponse(reg)})
+}
+
+// History handles GET /registrations/:id/history.
+func (h *RegistrationHandler) History(c *gin.Context) {
+	id := c.Param("id")
+	if strings.TrimSpace(id) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "registration id is required"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	history, err := h.svc.History(ctx, id)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	responses := make([]dto.RegistrationTransitionResponse, 0, len(history))
+	for _, t := range history {
+		responses = append(responses, dto.RegistrationTransitionResponse{
+			From:    string(t.From),
+			To:      string(t.To),
+			ActorID: t.ActorID,
+			Reason:  t.Reason,
+			At:      t.At,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": responses})
 }
 
 // GetByID handles GET /registrations/:id.
@@ -137,16 +201,7 @@ func (h *RegistrationHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	resp := dto.RegistrationResponse{
-		ID:        reg.ID,
-		EventID:   reg.EventID,
-		GuestID:   reg.GuestID,
-		Status:    reg.Status,
-		CreatedAt: reg.CreatedAt,
-		CheckedIn: reg.CheckedIn,
-	}
-
-	c.JSON(http.StatusOK, gin.H{"data": resp})
+	c.JSON(http.StatusOK, gin.H{"data": registrationResponse(reg)})
 }
 
 // List handles GET /registrations.
@@ -187,15 +242,209 @@ func (h *RegistrationHandler) List(c *gin.Context) {
 		if reg == nil {
 			continue
 		}
-		responses = append(responses, dto.RegistrationResponse{
-			ID:        reg.ID,
-			EventID:   reg.EventID,
-			GuestID:   reg.GuestID,
-			Status:    reg.Status,
-			CreatedAt: reg.CreatedAt,
-			CheckedIn: reg.CheckedIn,
-		})
+		responses = append(responses, registrationResponse(reg))
 	}
 
 	c.JSON(http.StatusOK, gin.H{"data": responses})
 }
+
+// ExportGuests handles GET /events/:id/guests/export?format=csv|xlsx|pdf.
+// Rows are read from a cursor; CSV is streamed as it arrives, XLSX is spooled by excelize and PDF
+// is built in memory, so PDF exports stop at export.MaxPDFRows rows with 422.
+func (h *RegistrationHandler) ExportGuests(c *gin.Context) {
+	eventID := strings.TrimSpace(c.Param("id"))
+	if eventID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "event id is required"})
+		return
+	}
+	format, err := export.ParseFormat(c.DefaultQuery("format", string(export.FormatCSV)))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, 5*time.Minute)
+	defer cancel()
+
+	w := &guestExportWriter{c: c, format: format, opts: h.export}
+	err = h.svc.ExportGuests(ctx, eventID, w)
+	if err == nil && w.out != nil {
+		err = w.out.Close()
+	}
+	if err == nil {
+		return
+	}
+
+	// Đã gửi một phần file thì không thể đổi status, chỉ còn cách cắt ngang response
+	if c.Writer.Written() {
+		_ = c.Error(err)
+		c.Abort()
+		return
+	}
+	c.Writer.Header().Del("Content-Disposition")
+	c.Writer.Header().Del("Content-Type")
+	status := statusFromError(err, http.StatusInternalServerError)
+	if errors.Is(err, export.ErrTooManyRows) {
+		status = http.StatusUnprocessableEntity
+	}
+	c.JSON(status, gin.H{"error": err.Error()})
+}
+
+// guestExportWriter đặt header tải file khi service bắt đầu gửi dữ liệu (tức là đã qua kiểm tra quyền)
+type guestExportWriter struct {
+	c      *gin.Context
+	format export.Format
+	opts   export.Options
+	out    export.GuestListWriter
+}
+
+func (w *guestExportWriter) Begin(event *entity.Event) error {
+	out, err := export.NewGuestListWriter(w.format, w.c.Writer, w.opts)
+	if err != nil {
+		return err
+	}
+	w.out = out
+
+	w.c.Header("Content-Type", w.format.ContentType())
+	w.c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="guests-%s.%s"`, event.ID, w.format))
+	return out.Begin(event)
+}
+
+func (w *guestExportWriter) Write(guest *entity.EventGuest) error {
+	return w.out.Write(guest)
+}
+
+// Ticket handles GET /registrations/:id/ticket.png?size=, rendering the signed ticket as a QR code.
+func (h *RegistrationHandler) Ticket(c *gin.Context) {
+	h.renderTicket(c, h.svc.Ticket)
+}
+
+// ReissueTicket handles POST /registrations/:id/ticket/reissue?size=: the previous ticket stops
+// scanning and the new one is returned as a QR code.
+func (h *RegistrationHandler) ReissueTicket(c *gin.Context) {
+	h.renderTicket(c, h.svc.ReissueTicket)
+}
+
+func (h *RegistrationHandler) renderTicket(c *gin.Context, sign func(ctx context.Context, registrationID string) (string, error)) {
+	id := strings.TrimSpace(c.Param("id"))
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "registration id is required"})
+		return
+	}
+
+	size := defaultTicketSize
+	if sizeStr := c.Query("size"); sizeStr != "" {
+		var err error
+		size, err = strconv.Atoi(sizeStr)
+		if err != nil || size < minTicketSize || size > maxTicketSize {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("size must be between %d and %d", minTicketSize, maxTicketSize)})
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	token, err := sign(ctx, id)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	png, err := qrcode.Encode(token, qrcode.Medium, size)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "render ticket failed"})
+		return
+	}
+	c.Header("Cache-Control", "private, no-cache")
+	c.Data(http.StatusOK, "image/png", png)
+}
+
+// ScanTicket handles POST /check-in/scan.
+func (h *RegistrationHandler) ScanTicket(c *gin.Context) {
+	var req dto.CheckInScanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	g, err := h.svc.ScanTicket(ctx, req.Token, req.EventID)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": dto.CheckInScanResponse{
+		Registration: registrationResponse(&g.Registration),
+		Guest:        guestResponse(&g.Guest),
+		CheckedInAt:  g.CheckedInAt,
+	}})
+}
+
+// SyncCheckIns handles POST /check-in/sync. Operations with result "error" can be sent again;
+// every other result is final and is returned again as "duplicate" when resent.
+func (h *RegistrationHandler) SyncCheckIns(c *gin.Context) {
+	var req dto.CheckInSyncRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ops := make([]entity.CheckInSyncOp, 0, len(req.Operations))
+	for _, op := range req.Operations {
+		ops = append(ops, entity.CheckInSyncOp{
+			OpID:           op.OpID,
+			RegistrationID: op.RegistrationID,
+			Action:         entity.CheckInSyncAction(strings.TrimSpace(op.Action)),
+			ClientTime:     op.ClientTime,
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(c, 30*time.Second)
+	defer cancel()
+
+	results, err := h.svc.SyncCheckIns(ctx, req.DeviceID, ops)
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	summary := map[string]int{}
+	responses := make([]dto.CheckInSyncResultResponse, 0, len(results))
+	for _, r := range results {
+		summary[r.Result]++
+		responses = append(responses, dto.CheckInSyncResultResponse{
+			OpID:           r.OpID,
+			RegistrationID: r.RegistrationID,
+			Action:         string(r.Action),
+			Result:         r.Result,
+			Conflict:       r.Conflict,
+			Status:         string(r.Status),
+			Message:        r.Message,
+			SyncedAt:       r.SyncedAt,
+		})
+	}
+	c.JSON(http.StatusOK, gin.H{"data": responses, "summary": summary})
+}
+
+// registrationResponse maps a registration entity to its API representation.
+func registrationResponse(reg *entity.Registration) dto.RegistrationResponse {
+	resp := dto.RegistrationResponse{
+		ID:        reg.ID,
+		EventID:   reg.EventID,
+		GuestID:   reg.GuestID,
+		Status:    string(reg.Status),
+		CreatedAt: reg.CreatedAt,
+		CheckedIn: reg.CheckedIn,
+	}
+	for _, v := range reg.Visits {
+		resp.Visits = append(resp.Visits, dto.RegistrationVisitResponse{
+			CheckedInAt:  v.CheckedInAt,
+			CheckedOutAt: v.CheckedOutAt,
+		})
+	}
+	return resp
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"event_manager/internal/domain/entity"
+	service_interface "event_manager/internal/domain/service"
+	dto "event_manager/internal/dto/request"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReviewHandler exposes review endpoints nested under an event.
+type ReviewHandler struct {
+	svc service_interface.ReviewService
+}
+
+// NewReviewHandler constructs a review handler.
+func NewReviewHandler(svc service_interface.ReviewService) *ReviewHandler {
+	return &ReviewHandler{svc: svc}
+}
+
+// Create handles POST /events/:id/reviews.
+func (h *ReviewHandler) Create(c *gin.Context) {
+	var req dto.ReviewCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	review := &entity.Review{
+		EventID: strings.TrimSpace(c.Param("id")),
+		GuestID: strings.TrimSpace(req.GuestID),
+		Rating:  req.Rating,
+		Comment: req.Comment,
+	}
+	if err := h.svc.Create(ctx, review); err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": toReviewResponse(review)})
+}
+
+// Update handles PUT /events/:id/reviews/:reviewId.
+func (h *ReviewHandler) Update(c *gin.Context) {
+	var req dto.ReviewUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	review := &entity.Review{
+		ID:      strings.TrimSpace(c.Param("reviewId")),
+		EventID: strings.TrimSpace(c.Param("id")),
+		Rating:  req.Rating,
+		Comment: req.Comment,
+	}
+	if err := h.svc.Update(ctx, review); err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": toReviewResponse(review)})
+}
+
+// Delete handles DELETE /events/:id/reviews/:reviewId.
+func (h *ReviewHandler) Delete(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	if err := h.svc.Delete(ctx, c.Param("id"), c.Param("reviewId")); err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "review deleted successfully"})
+}
+
+// GetByID handles GET /events/:id/reviews/:reviewId.
+func (h *ReviewHandler) GetByID(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	review, err := h.svc.GetByID(ctx, c.Param("id"), c.Param("reviewId"))
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": toReviewResponse(review)})
+}
+
+// List handles GET /events/:id/reviews.
+func (h *ReviewHandler) List(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	summary, err := h.svc.ListByEvent(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := dto.ReviewSummaryResponse{
+		AverageRating: summary.AverageRating,
+		Count:         summary.Count,
+		Reviews:       make([]dto.ReviewResponse, 0, len(summary.Reviews)),
+	}
+	for _, r := range summary.Reviews {
+		resp.Reviews = append(resp.Reviews, toReviewResponse(r))
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": resp})
+}
+
+func toReviewResponse(r *entity.Review) dto.ReviewResponse {
+	return dto.ReviewResponse{
+		ID:        r.ID,
+		EventID:   r.EventID,
+		GuestID:   r.GuestID,
+		GuestName: r.GuestName,
+		Rating:    r.Rating,
+		Comment:   r.Comment,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"event_manager/internal/domain/entity"
+	service_interface "event_manager/internal/domain/service"
+	dto "event_manager/internal/dto/request"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TicketHandler exposes ticket type endpoints nested under an event.
+type TicketHandler struct {
+	svc service_interface.TicketService
+}
+
+// NewTicketHandler constructs a ticket type handler.
+func NewTicketHandler(svc service_interface.TicketService) *TicketHandler {
+	return &TicketHandler{svc: svc}
+}
+
+// Create handles POST /events/:id/ticket-types.
+func (h *TicketHandler) Create(c *gin.Context) {
+	var req dto.TicketTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	ticketType := ticketTypeFromRequest(&req)
+	ticketType.EventID = strings.TrimSpace(c.Param("id"))
+	if err := h.svc.CreateType(ctx, ticketType); err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": toTicketTypeResponse(ticketType, time.Now())})
+}
+
+// Update handles PUT /events/:id/ticket-types/:ticketTypeId.
+func (h *TicketHandler) Update(c *gin.Context) {
+	var req dto.TicketTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	ticketType := ticketTypeFromRequest(&req)
+	ticketType.ID = strings.TrimSpace(c.Param("ticketTypeId"))
+	ticketType.EventID = strings.TrimSpace(c.Param("id"))
+	if err := h.svc.UpdateType(ctx, ticketType); err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": toTicketTypeResponse(ticketType, time.Now())})
+}
+
+// Delete handles DELETE /events/:id/ticket-types/:ticketTypeId.
+func (h *TicketHandler) Delete(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	if err := h.svc.DeleteType(ctx, c.Param("id"), c.Param("ticketTypeId")); err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "ticket type deleted successfully"})
+}
+
+// GetByID handles GET /events/:id/ticket-types/:ticketTypeId.
+func (h *TicketHandler) GetByID(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	ticketType, err := h.svc.GetType(ctx, c.Param("id"), c.Param("ticketTypeId"))
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": toTicketTypeResponse(ticketType, time.Now())})
+}
+
+// List handles GET /events/:id/ticket-types, cheapest first.
+func (h *TicketHandler) List(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	list, err := h.svc.ListTypes(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(statusFromError(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	now := time.Now()
+	responses := make([]dto.TicketTypeResponse, 0, len(list))
+	for _, t := range list {
+		responses = append(responses, toTicketTypeResponse(t, now))
+	}
+	c.JSON(http.StatusOK, gin.H{"data": responses})
+}
+
+func ticketTypeFromRequest(req *dto.TicketTypeRequest) *entity.TicketType {
+	return &entity.TicketType{
+		Name:           req.Name,
+		Description:    req.Description,
+		Currency:       req.Currency,
+		Price:          req.Price,
+		EarlyBirdPrice: req.EarlyBirdPrice,
+		EarlyBirdUntil: req.EarlyBirdUntil,
+		Quantity:       req.Quantity,
+		SaleStart:      req.SaleStart,
+		SaleEnd:        req.SaleEnd,
+	}
+}
+
+// toTicketTypeResponse tính giá và tình trạng mở bán tại thời điểm now
+func toTicketTypeResponse(t *entity.TicketType, now time.Time) dto.TicketTypeResponse {
+	resp := dto.TicketTypeResponse{
+		ID:             t.ID,
+		EventID:        t.EventID,
+		Name:           t.Name,
+		Description:    t.Description,
+		Currency:       t.Currency,
+		Price:          t.Price,
+		EarlyBirdPrice: t.EarlyBirdPrice,
+		EarlyBirdUntil: t.EarlyBirdUntil,
+		CurrentPrice:   t.PriceAt(now),
+		EarlyBird:      t.IsEarlyBird(now),
+		Quantity:       t.Quantity,
+		Sold:           t.Sold,
+		Reserved:       t.Reserved,
+		OnSale:         t.OnSale(now),
+		SaleStart:      t.SaleStart,
+		SaleEnd:        t.SaleEnd,
+		CreatedAt:      t.CreatedAt,
+		UpdatedAt:      t.UpdatedAt,
+	}
+	if t.IsLimited() {
+		remaining := t.Remaining()
+		resp.Remaining = &remaining
+	}
+	return resp
+}
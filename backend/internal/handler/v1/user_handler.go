@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"event_manager/internal/domain/entity"
 	service_interface "event_manager/internal/domain/service"
 	dto "event_manager/internal/dto/request"
 	"net/http"
@@ -37,7 +38,7 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c, 5*time.Second)
 	defer cancel()
 
-	if err := h.svc.CreateUser(ctx, req.Username, req.Email, req.FullName); err != nil {
+	if err := h.svc.CreateUser(ctx, req.Username, req.Email, req.FullName, req.Password); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -96,6 +97,27 @@ func (h *UserHandler) ActivateUser(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "user activated"})
 }
 
+// 🛡️ ChangeRole
+func (h *UserHandler) ChangeRole(c *gin.Context) {
+	id := c.Param("id")
+	var req *dto.ChangeRoleRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, 5*time.Second)
+	defer cancel()
+
+	if err := h.svc.ChangeRole(ctx, id, entity.Role(req.Role)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "user role updated"})
+}
+
 // 🔍 GetUserByID
 func (h *UserHandler) GetUserByID(c *gin.Context) {
 	id := c.Param("id")
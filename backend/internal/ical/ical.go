@@ -0,0 +1,240 @@
+// Package ical xuất sự kiện ra tài liệu iCalendar (RFC 5545) và đọc file .ics được nhập vào.
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	_ "time/tzdata" // image chạy không có zoneinfo; VTIMEZONE cần quy tắc IANA
+
+	"event_manager/internal/domain/entity"
+)
+
+const (
+	utcLayout   = "20060102T150405Z"
+	localLayout = "20060102T150405"
+	maxLineLen  = 75
+)
+
+// Options là các thuộc tính cấp lịch (VCALENDAR)
+type Options struct {
+	ProdID    string         // PRODID, vd "-//Event Manager//VI"
+	UIDDomain string         // phần sau @ của mọi UID; không được đổi sau khi đã phát hành feed
+	Location  *time.Location // múi giờ của DTSTART/DTEND và khối VTIMEZONE
+	Name      string         // X-WR-CALNAME hiển thị trong ứng dụng lịch (tuỳ chọn)
+}
+
+// 🆔 UID là định danh cố định của sự kiện: chỉ phụ thuộc ID sự kiện để xuất lại / làm mới feed
+// cập nhật đúng mục lịch cũ thay vì tạo bản trùng
+func UID(eventID, domain string) string {
+	return eventID + "@" + domain
+}
+
+// 📤 Write ghi các sự kiện thành một tài liệu VCALENDAR
+func Write(w io.Writer, events []*entity.Event, opts Options) error {
+	loc := opts.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	bw := bufio.NewWriter(w)
+	lw := &lineWriter{w: bw}
+
+	lw.line("BEGIN:VCALENDAR")
+	lw.line("VERSION:2.0")
+	lw.line("PRODID:" + opts.ProdID)
+	lw.line("CALSCALE:GREGORIAN")
+	lw.line("METHOD:PUBLISH")
+	if opts.Name != "" {
+		lw.line("X-WR-CALNAME:" + escapeText(opts.Name))
+	}
+	if loc != time.UTC {
+		lw.line("X-WR-TIMEZONE:" + loc.String())
+		from, to := eventRange(events)
+		writeTimezone(lw, loc, from, to)
+	}
+
+	for _, e := range events {
+		writeEvent(lw, e, loc, opts.UIDDomain)
+	}
+	lw.line("END:VCALENDAR")
+
+	if lw.err != nil {
+		return lw.err
+	}
+	return bw.Flush()
+}
+
+func writeEvent(lw *lineWriter, e *entity.Event, loc *time.Location, domain string) {
+	stamp := e.UpdatedAt
+	if stamp.IsZero() {
+		stamp = e.CreatedAt
+	}
+
+	lw.line("BEGIN:VEVENT")
+	lw.line("UID:" + UID(e.ID, domain))
+	lw.line("DTSTAMP:" + stamp.UTC().Format(utcLayout))
+	if !e.CreatedAt.IsZero() {
+		lw.line("CREATED:" + e.CreatedAt.UTC().Format(utcLayout))
+	}
+	if !e.UpdatedAt.IsZero() {
+		lw.line("LAST-MODIFIED:" + e.UpdatedAt.UTC().Format(utcLayout))
+	}
+	lw.line(dateTimeProp("DTSTART", e.StartDate, loc))
+	lw.line(dateTimeProp("DTEND", e.EndDate, loc))
+	lw.line("SUMMARY:" + escapeText(e.Name))
+	if e.Description != "" {
+		lw.line("DESCRIPTION:" + escapeText(e.Description))
+	}
+	if e.Location != "" {
+		lw.line("LOCATION:" + escapeText(e.Location))
+	}
+	if e.Type != "" {
+		lw.line("CATEGORIES:" + escapeText(e.Type))
+	}
+	lw.line("STATUS:CONFIRMED")
+	lw.line("TRANSP:OPAQUE")
+	lw.line("END:VEVENT")
+}
+
+func dateTimeProp(name string, t time.Time, loc *time.Location) string {
+	if loc == time.UTC {
+		return name + ":" + t.UTC().Format(utcLayout)
+	}
+	return name + ";TZID=" + loc.String() + ":" + t.In(loc).Format(localLayout)
+}
+
+// eventRange trả về khoảng thời gian VTIMEZONE phải bao, nới ra tròn năm ở hai đầu
+func eventRange(events []*entity.Event) (time.Time, time.Time) {
+	var from, to time.Time
+	for _, e := range events {
+		if from.IsZero() || e.StartDate.Before(from) {
+			from = e.StartDate
+		}
+		if to.IsZero() || e.EndDate.After(to) {
+			to = e.EndDate
+		}
+	}
+	if from.IsZero() {
+		from = time.Now()
+	}
+	if to.Before(from) {
+		to = from
+	}
+	// làm tròn năm để khối VTIMEZONE không đổi giữa các lần làm mới feed
+	start := time.Date(from.Year()-1, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(to.Year()+2, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return start, end
+}
+
+type transition struct {
+	at         time.Time
+	offsetFrom int
+	offsetTo   int
+	name       string
+	dst        bool
+}
+
+// 🕒 writeTimezone ghi VTIMEZONE với một STANDARD/DAYLIGHT cho mỗi lần đổi offset trong [from, to]
+// (ghi từng mốc thay vì RRULE nên đúng với mọi múi giờ IANA)
+func writeTimezone(lw *lineWriter, loc *time.Location, from, to time.Time) {
+	lw.line("BEGIN:VTIMEZONE")
+	lw.line("TZID:" + loc.String())
+
+	start := from.In(loc)
+	name, offset := start.Zone()
+	writeObservance(lw, transition{at: start, offsetFrom: offset, offsetTo: offset, name: name, dst: start.IsDST()})
+	for _, tr := range findTransitions(loc, from, to) {
+		writeObservance(lw, tr)
+	}
+
+	lw.line("END:VTIMEZONE")
+}
+
+func writeObservance(lw *lineWriter, tr transition) {
+	kind := "STANDARD"
+	if tr.dst {
+		kind = "DAYLIGHT"
+	}
+	// DTSTART của một observance là giờ địa phương theo offset trước thời điểm đổi
+	onset := tr.at.UTC().Add(time.Duration(tr.offsetFrom) * time.Second)
+
+	lw.line("BEGIN:" + kind)
+	lw.line("DTSTART:" + onset.Format(localLayout))
+	lw.line("TZOFFSETFROM:" + formatOffset(tr.offsetFrom))
+	lw.line("TZOFFSETTO:" + formatOffset(tr.offsetTo))
+	lw.line("TZNAME:" + escapeText(tr.name))
+	lw.line("END:" + kind)
+}
+
+// findTransitions quét từng ngày rồi chia đôi đến đúng giây offset UTC thay đổi
+func findTransitions(loc *time.Location, from, to time.Time) []transition {
+	var result []transition
+	_, offset := from.In(loc).Zone()
+	for t := from; t.Before(to); t = t.Add(24 * time.Hour) {
+		next := t.Add(24 * time.Hour)
+		_, nextOffset := next.In(loc).Zone()
+		if nextOffset == offset {
+			continue
+		}
+		lo, hi := t, next
+		for hi.Sub(lo) > time.Second {
+			mid := lo.Add(hi.Sub(lo) / 2)
+			if _, o := mid.In(loc).Zone(); o == offset {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		at := hi.Truncate(time.Second).In(loc)
+		name, _ := at.Zone()
+		result = append(result, transition{at: at, offsetFrom: offset, offsetTo: nextOffset, name: name, dst: at.IsDST()})
+		offset = nextOffset
+	}
+	return result
+}
+
+func formatOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, seconds/3600, seconds%3600/60)
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// lineWriter ghi các dòng kết thúc bằng CRLF, gập ở 75 octet mà không cắt đôi ký tự UTF-8
+type lineWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (lw *lineWriter) line(s string) {
+	if lw.err != nil {
+		return
+	}
+	limit := maxLineLen
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(s[cut]) {
+			cut--
+		}
+		if _, lw.err = lw.w.WriteString(s[:cut] + "\r\n "); lw.err != nil {
+			return
+		}
+		s = s[cut:]
+		limit = maxLineLen - 1 // continuation lines start with a space
+	}
+	_, lw.err = lw.w.WriteString(s + "\r\n")
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
@@ -0,0 +1,110 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"event_manager/internal/domain/entity"
+)
+
+func TestWrite(t *testing.T) {
+	hcm, err := time.LoadLocation("Asia/Ho_Chi_Minh")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2025, time.March, 1, 2, 0, 0, 0, time.UTC)
+	event := &entity.Event{
+		ID:          "evt1",
+		Name:        "Hội thảo; Go, Mongo",
+		Description: "Dòng 1\nDòng 2 " + strings.Repeat("rất dài ", 20),
+		Location:    "Hà Nội",
+		Type:        "Sự kiện mở",
+		StartDate:   start,
+		EndDate:     start.Add(2 * time.Hour),
+		CreatedAt:   start.Add(-24 * time.Hour),
+	}
+
+	tests := []struct {
+		name     string
+		loc      *time.Location
+		contains []string
+		excludes []string
+	}{
+		{
+			name: "utc",
+			loc:  time.UTC,
+			contains: []string{
+				"UID:evt1@example.com",
+				"DTSTART:20250301T020000Z",
+				"DTEND:20250301T040000Z",
+				`SUMMARY:Hội thảo\; Go\, Mongo`,
+				"DTSTAMP:20250228T020000Z",
+			},
+			excludes: []string{"BEGIN:VTIMEZONE", "LAST-MODIFIED"},
+		},
+		{
+			name: "local zone",
+			loc:  hcm,
+			contains: []string{
+				"X-WR-TIMEZONE:Asia/Ho_Chi_Minh",
+				"BEGIN:VTIMEZONE",
+				"TZID:Asia/Ho_Chi_Minh",
+				"DTSTART;TZID=Asia/Ho_Chi_Minh:20250301T090000",
+				"DTEND;TZID=Asia/Ho_Chi_Minh:20250301T110000",
+			},
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := Write(&buf, []*entity.Event{event}, Options{ProdID: "-//Test//VI", UIDDomain: "example.com", Location: tt.loc}); err != nil {
+			t.Fatalf("%s: Write: %v", tt.name, err)
+		}
+		out := buf.String()
+		if !strings.HasPrefix(out, "BEGIN:VCALENDAR\r\n") || !strings.HasSuffix(out, "END:VCALENDAR\r\n") {
+			t.Errorf("%s: document is not wrapped in VCALENDAR with CRLF", tt.name)
+		}
+		for _, line := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+			if len(line) > maxLineLen {
+				t.Errorf("%s: line longer than %d octets: %q", tt.name, maxLineLen, line)
+			}
+		}
+		unfolded := strings.ReplaceAll(out, "\r\n ", "")
+		for _, want := range tt.contains {
+			if !strings.Contains(unfolded, want+"\r\n") {
+				t.Errorf("%s: missing %q", tt.name, want)
+			}
+		}
+		for _, unwanted := range tt.excludes {
+			if strings.Contains(unfolded, unwanted) {
+				t.Errorf("%s: unexpected %q", tt.name, unwanted)
+			}
+		}
+	}
+}
+
+func TestLineWriterKeepsRunesWhole(t *testing.T) {
+	tests := []string{
+		strings.Repeat("a", 200),
+		strings.Repeat("ệ", 100),
+		"SUMMARY:" + strings.Repeat("Sự kiện ", 30),
+	}
+	for _, s := range tests {
+		var buf bytes.Buffer
+		if err := Write(&buf, nil, Options{Name: s}); err != nil {
+			t.Fatal(err)
+		}
+		for _, line := range strings.Split(buf.String(), "\r\n") {
+			if len(line) > maxLineLen {
+				t.Errorf("line longer than %d octets: %q", maxLineLen, line)
+			}
+			if line != strings.ToValidUTF8(line, "") {
+				t.Errorf("line splits a UTF-8 sequence: %q", line)
+			}
+		}
+		if got := strings.ReplaceAll(buf.String(), "\r\n ", ""); !strings.Contains(got, "X-WR-CALNAME:"+escapeText(s)+"\r\n") {
+			t.Errorf("folded name does not unfold back to %q", s)
+		}
+	}
+}
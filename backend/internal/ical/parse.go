@@ -0,0 +1,294 @@
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"event_manager/internal/domain/entity"
+)
+
+// ErrNotCalendar trả về khi dữ liệu không có VCALENDAR
+var ErrNotCalendar = errors.New("input is not an iCalendar document")
+
+type contentLine struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// 📥 Parse đọc mọi VEVENT cấp cao nhất của tài liệu. Giờ không có TZID (floating) hoặc TZID lạ được
+// hiểu theo defaultLoc. VEVENT không nhập được vẫn được trả về kèm ParseError để caller báo cáo.
+func Parse(r io.Reader, defaultLoc *time.Location) ([]entity.ImportedEvent, error) {
+	if defaultLoc == nil {
+		defaultLoc = time.UTC
+	}
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		events     []entity.ImportedEvent
+		inCalendar bool
+		current    []contentLine
+		depth      int // nesting inside the current VEVENT (VALARM, ...)
+		inEvent    bool
+	)
+	for _, raw := range lines {
+		line, err := parseContentLine(raw)
+		if err != nil {
+			continue
+		}
+		switch {
+		case line.name == "BEGIN" && strings.EqualFold(line.value, "VCALENDAR"):
+			inCalendar = true
+		case line.name == "BEGIN" && strings.EqualFold(line.value, "VEVENT") && !inEvent:
+			inEvent, depth, current = true, 0, nil
+		case line.name == "BEGIN" && inEvent:
+			depth++
+		case line.name == "END" && inEvent && depth > 0:
+			depth--
+		case line.name == "END" && strings.EqualFold(line.value, "VEVENT") && inEvent:
+			events = append(events, buildEvent(current, defaultLoc))
+			inEvent = false
+		case inEvent && depth == 0:
+			current = append(current, line)
+		}
+	}
+	if !inCalendar {
+		return nil, ErrNotCalendar
+	}
+	return events, nil
+}
+
+func buildEvent(lines []contentLine, defaultLoc *time.Location) entity.ImportedEvent {
+	var (
+		e        entity.ImportedEvent
+		hasEnd   bool
+		allDay   bool
+		duration time.Duration
+		problems []string
+	)
+	for _, l := range lines {
+		switch l.name {
+		case "UID":
+			e.UID = strings.TrimSpace(l.value)
+		case "SUMMARY":
+			e.Name = strings.TrimSpace(unescapeText(l.value))
+		case "DESCRIPTION":
+			e.Description = strings.TrimSpace(unescapeText(l.value))
+		case "LOCATION":
+			e.Location = strings.TrimSpace(unescapeText(l.value))
+		case "DTSTART":
+			t, date, err := parseDateTime(l, defaultLoc)
+			if err != nil {
+				problems = append(problems, "invalid DTSTART")
+				continue
+			}
+			e.StartDate, allDay = t, date
+		case "DTEND":
+			t, _, err := parseDateTime(l, defaultLoc)
+			if err != nil {
+				problems = append(problems, "invalid DTEND")
+				continue
+			}
+			e.EndDate, hasEnd = t, true
+		case "DURATION":
+			d, err := parseDuration(l.value)
+			if err != nil {
+				problems = append(problems, "invalid DURATION")
+				continue
+			}
+			duration = d
+		case "RRULE":
+			rule, err := entity.ParseRRule(l.value)
+			if err != nil {
+				problems = append(problems, "unsupported RRULE: "+err.Error())
+				continue
+			}
+			if e.Recurrence != nil {
+				rule.ExDates = e.Recurrence.ExDates
+			}
+			e.Recurrence = rule
+		case "EXDATE":
+			for _, v := range strings.Split(l.value, ",") {
+				t, _, err := parseDateTime(contentLine{name: l.name, params: l.params, value: v}, defaultLoc)
+				if err != nil {
+					problems = append(problems, "invalid EXDATE")
+					continue
+				}
+				if e.Recurrence == nil {
+					e.Recurrence = &entity.Recurrence{}
+				}
+				e.Recurrence.ExDates = append(e.Recurrence.ExDates, t)
+			}
+		case "RECURRENCE-ID":
+			problems = append(problems, "RECURRENCE-ID overrides are not supported")
+		case "STATUS":
+			if strings.EqualFold(l.value, "CANCELLED") {
+				problems = append(problems, "event is cancelled")
+			}
+		}
+	}
+
+	// EXDATE không kèm RRULE thì không có ý nghĩa
+	if e.Recurrence != nil && e.Recurrence.Freq == "" {
+		e.Recurrence = nil
+	}
+	if !hasEnd && !e.StartDate.IsZero() {
+		switch {
+		case duration > 0:
+			e.EndDate = e.StartDate.Add(duration)
+		case allDay:
+			e.EndDate = e.StartDate.AddDate(0, 0, 1)
+		default:
+			e.EndDate = e.StartDate
+		}
+	}
+
+	if e.UID == "" {
+		problems = append(problems, "missing UID")
+	}
+	if e.StartDate.IsZero() {
+		problems = append(problems, "missing DTSTART")
+	}
+	if len(problems) > 0 {
+		e.ParseError = strings.Join(problems, "; ")
+	}
+	return e
+}
+
+// unfold nối các dòng bị gập (CRLF theo sau bởi dấu cách hoặc tab)
+func unfold(r io.Reader) ([]string, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var lines []string
+	for sc.Scan() {
+		text := strings.TrimRight(sc.Text(), "\r")
+		if (strings.HasPrefix(text, " ") || strings.HasPrefix(text, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += text[1:]
+			continue
+		}
+		if text != "" {
+			lines = append(lines, text)
+		}
+	}
+	return lines, sc.Err()
+}
+
+// parseContentLine tách `NAME;PARAM=VALUE;...:value`, giữ nguyên tham số nằm trong ngoặc kép
+func parseContentLine(raw string) (contentLine, error) {
+	inQuotes := false
+	colon := -1
+	for i, r := range raw {
+		if r == '"' {
+			inQuotes = !inQuotes
+		}
+		if r == ':' && !inQuotes {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return contentLine{}, fmt.Errorf("invalid content line %q", raw)
+	}
+
+	head, value := raw[:colon], raw[colon+1:]
+	parts := strings.Split(head, ";")
+	line := contentLine{name: strings.ToUpper(strings.TrimSpace(parts[0])), value: value, params: map[string]string{}}
+	for _, p := range parts[1:] {
+		if k, v, ok := strings.Cut(p, "="); ok {
+			line.params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+		}
+	}
+	return line, nil
+}
+
+// parseDateTime đọc giá trị DATE / DATE-TIME; bool cho biết là DATE cả ngày
+func parseDateTime(l contentLine, defaultLoc *time.Location) (time.Time, bool, error) {
+	value := strings.TrimSpace(l.value)
+	loc := defaultLoc
+	if tzid := strings.TrimPrefix(l.params["TZID"], "/"); tzid != "" {
+		if tz, err := time.LoadLocation(tzid); err == nil {
+			loc = tz
+		}
+	}
+
+	if strings.EqualFold(l.params["VALUE"], "DATE") || len(value) == 8 {
+		t, err := time.ParseInLocation("20060102", value, loc)
+		return t, true, err
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(utcLayout, value)
+		return t, false, err
+	}
+	t, err := time.ParseInLocation(localLayout, value, loc)
+	return t, false, err
+}
+
+// parseDuration đọc khoảng thời gian RFC 5545 như PT1H30M, P1D, P2W
+func parseDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	sign := time.Duration(1)
+	switch {
+	case strings.HasPrefix(value, "-"):
+		sign, value = -1, value[1:]
+	case strings.HasPrefix(value, "+"):
+		value = value[1:]
+	}
+	if !strings.HasPrefix(value, "P") {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	value = value[1:]
+
+	var (
+		total  time.Duration
+		num    string
+		inTime bool
+	)
+	for _, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+			num += string(r)
+			continue
+		case r == 'T':
+			inTime = true
+			continue
+		}
+		n, err := strconv.Atoi(num)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		num = ""
+		switch {
+		case r == 'W':
+			total += time.Duration(n) * 7 * 24 * time.Hour
+		case r == 'D':
+			total += time.Duration(n) * 24 * time.Hour
+		case r == 'H' && inTime:
+			total += time.Duration(n) * time.Hour
+		case r == 'M' && inTime:
+			total += time.Duration(n) * time.Minute
+		case r == 'S' && inTime:
+			total += time.Duration(n) * time.Second
+		default:
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+	}
+	if num != "" {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	return sign * total, nil
+}
+
+var textUnescaper = strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n")
+
+func unescapeText(s string) string {
+	return textUnescaper.Replace(s)
+}
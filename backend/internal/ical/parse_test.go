@@ -0,0 +1,183 @@
+package ical
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"event_manager/internal/domain/entity"
+)
+
+func calendar(lines ...string) string {
+	return "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n" + strings.Join(lines, "\r\n") + "\r\nEND:VCALENDAR\r\n"
+}
+
+func TestParse(t *testing.T) {
+	ict := time.FixedZone("ICT", 7*3600)
+	hcm, err := time.LoadLocation("Asia/Ho_Chi_Minh")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		input     string
+		want      entity.ImportedEvent
+		wantRule  string
+		wantExDts int
+		wantError string // chuỗi con của ParseError, rỗng nếu nhập được
+	}{
+		{
+			name: "utc with end",
+			input: calendar("BEGIN:VEVENT", "UID:a@x", `SUMMARY:Họp\, tổng kết\nnăm`,
+				"DTSTART:20250301T020000Z", "DTEND:20250301T040000Z", "END:VEVENT"),
+			want: entity.ImportedEvent{UID: "a@x", Name: "Họp, tổng kết\nnăm",
+				StartDate: time.Date(2025, 3, 1, 2, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 3, 1, 4, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:  "tzid and duration",
+			input: calendar("BEGIN:VEVENT", "UID:b@x", "DTSTART;TZID=Asia/Ho_Chi_Minh:20250301T090000", "DURATION:PT1H30M", "END:VEVENT"),
+			want: entity.ImportedEvent{UID: "b@x",
+				StartDate: time.Date(2025, 3, 1, 9, 0, 0, 0, hcm), EndDate: time.Date(2025, 3, 1, 10, 30, 0, 0, hcm)},
+		},
+		{
+			name:  "floating time uses default location",
+			input: calendar("BEGIN:VEVENT", "UID:c@x", "DTSTART:20250301T090000", "END:VEVENT"),
+			want: entity.ImportedEvent{UID: "c@x",
+				StartDate: time.Date(2025, 3, 1, 9, 0, 0, 0, ict), EndDate: time.Date(2025, 3, 1, 9, 0, 0, 0, ict)},
+		},
+		{
+			name:  "all-day event lasts one day",
+			input: calendar("BEGIN:VEVENT", "UID:d@x", "DTSTART;VALUE=DATE:20250301", "END:VEVENT"),
+			want: entity.ImportedEvent{UID: "d@x",
+				StartDate: time.Date(2025, 3, 1, 0, 0, 0, 0, ict), EndDate: time.Date(2025, 3, 2, 0, 0, 0, 0, ict)},
+		},
+		{
+			name: "folded line and nested alarm",
+			input: calendar("BEGIN:VEVENT", "UID:e@x", "SUMMARY:Sự kiện", " dài", "DTSTART:20250301T020000Z",
+				"BEGIN:VALARM", "SUMMARY:nhắc", "END:VALARM", "END:VEVENT"),
+			want: entity.ImportedEvent{UID: "e@x", Name: "Sự kiệndài",
+				StartDate: time.Date(2025, 3, 1, 2, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 3, 1, 2, 0, 0, 0, time.UTC)},
+		},
+		{
+			name: "recurrence with exdates",
+			input: calendar("BEGIN:VEVENT", "UID:f@x", "DTSTART:20250303T020000Z", "EXDATE:20250310T020000Z,20250317T020000Z",
+				"RRULE:FREQ=WEEKLY;COUNT=5", "END:VEVENT"),
+			want: entity.ImportedEvent{UID: "f@x",
+				StartDate: time.Date(2025, 3, 3, 2, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 3, 3, 2, 0, 0, 0, time.UTC)},
+			wantRule:  "FREQ=WEEKLY;COUNT=5",
+			wantExDts: 2,
+		},
+		{
+			name:      "missing uid",
+			input:     calendar("BEGIN:VEVENT", "DTSTART:20250301T020000Z", "END:VEVENT"),
+			wantError: "missing UID",
+		},
+		{
+			name:      "unsupported rule",
+			input:     calendar("BEGIN:VEVENT", "UID:g@x", "DTSTART:20250301T020000Z", "RRULE:FREQ=HOURLY", "END:VEVENT"),
+			wantError: "unsupported RRULE",
+		},
+		{
+			name:      "cancelled",
+			input:     calendar("BEGIN:VEVENT", "UID:h@x", "DTSTART:20250301T020000Z", "STATUS:CANCELLED", "END:VEVENT"),
+			wantError: "event is cancelled",
+		},
+	}
+	for _, tt := range tests {
+		events, err := Parse(strings.NewReader(tt.input), ict)
+		if err != nil {
+			t.Fatalf("%s: Parse: %v", tt.name, err)
+		}
+		if len(events) != 1 {
+			t.Fatalf("%s: got %d events, want 1", tt.name, len(events))
+		}
+		got := events[0]
+		if tt.wantError != "" {
+			if !strings.Contains(got.ParseError, tt.wantError) {
+				t.Errorf("%s: ParseError = %q, want it to contain %q", tt.name, got.ParseError, tt.wantError)
+			}
+			continue
+		}
+		if got.ParseError != "" {
+			t.Errorf("%s: unexpected ParseError %q", tt.name, got.ParseError)
+		}
+		if got.UID != tt.want.UID || got.Name != tt.want.Name ||
+			!got.StartDate.Equal(tt.want.StartDate) || !got.EndDate.Equal(tt.want.EndDate) {
+			t.Errorf("%s: got %+v, want %+v", tt.name, got, tt.want)
+		}
+		switch {
+		case tt.wantRule == "" && got.Recurrence != nil:
+			t.Errorf("%s: unexpected recurrence %s", tt.name, got.Recurrence)
+		case tt.wantRule != "" && (got.Recurrence == nil || got.Recurrence.String() != tt.wantRule || len(got.Recurrence.ExDates) != tt.wantExDts):
+			t.Errorf("%s: recurrence = %v, want %s with %d EXDATE", tt.name, got.Recurrence, tt.wantRule, tt.wantExDts)
+		}
+	}
+}
+
+func TestParseNotCalendar(t *testing.T) {
+	_, err := Parse(strings.NewReader("BEGIN:VEVENT\r\nUID:a\r\nEND:VEVENT\r\n"), nil)
+	if !errors.Is(err, ErrNotCalendar) {
+		t.Fatalf("got %v, want ErrNotCalendar", err)
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"PT1H30M", 90 * time.Minute, false},
+		{"P1D", 24 * time.Hour, false},
+		{"P2W", 14 * 24 * time.Hour, false},
+		{"P1DT2H", 26 * time.Hour, false},
+		{"-PT15M", -15 * time.Minute, false},
+		{"+PT45S", 45 * time.Second, false},
+		{"1H", 0, true},
+		{"P1H", 0, true},
+		{"PT5", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseDuration(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseDuration(%q) = %v, %v, want %v (error %v)", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestWriteParseRoundTrip(t *testing.T) {
+	hcm, err := time.LoadLocation("Asia/Ho_Chi_Minh")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2025, time.June, 10, 1, 30, 0, 0, time.UTC)
+	events := []*entity.Event{
+		{ID: "e1", Name: "Tiệc; cuối năm, 2025", Description: "Mang theo\nvé mời \\ QR", Location: "TP. HCM", StartDate: start, EndDate: start.Add(3 * time.Hour)},
+		{ID: "e2", Name: strings.Repeat("Tên rất dài ", 12), StartDate: start.AddDate(0, 1, 0), EndDate: start.AddDate(0, 1, 0).Add(time.Hour)},
+	}
+
+	for _, loc := range []*time.Location{time.UTC, hcm} {
+		var buf bytes.Buffer
+		if err := Write(&buf, events, Options{ProdID: "-//Test//VI", UIDDomain: "example.com", Location: loc}); err != nil {
+			t.Fatalf("%s: Write: %v", loc, err)
+		}
+		parsed, err := Parse(&buf, time.UTC)
+		if err != nil {
+			t.Fatalf("%s: Parse: %v", loc, err)
+		}
+		if len(parsed) != len(events) {
+			t.Fatalf("%s: got %d events, want %d", loc, len(parsed), len(events))
+		}
+		for i, e := range events {
+			got := parsed[i]
+			if got.ParseError != "" || got.UID != UID(e.ID, "example.com") || got.Name != strings.TrimSpace(e.Name) ||
+				got.Description != e.Description || got.Location != e.Location ||
+				!got.StartDate.Equal(e.StartDate) || !got.EndDate.Equal(e.EndDate) {
+				t.Errorf("%s: event %d = %+v, want %+v", loc, i, got, e)
+			}
+		}
+	}
+}
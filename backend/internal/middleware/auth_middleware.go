@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	service_interface "event_manager/internal/domain/service"
+	utils "event_manager/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireAuth kiểm tra header "Authorization: Bearer <access_token>" và
+// gắn user đã xác thực vào context của request (đọc lại bằng utils.UserFromContext).
+func RequireAuth(authSvc service_interface.AuthService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := strings.TrimSpace(c.GetHeader("Authorization"))
+		scheme, token, found := strings.Cut(header, " ")
+		if !found || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing bearer token"})
+			return
+		}
+
+		user, err := authSvc.Authenticate(c.Request.Context(), strings.TrimSpace(token))
+		if err != nil {
+			status := http.StatusInternalServerError
+			switch {
+			case errors.Is(err, service_interface.ErrInvalidToken):
+				status = http.StatusUnauthorized
+			case errors.Is(err, service_interface.ErrUserInactive):
+				status = http.StatusForbidden
+			}
+			c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.Request = c.Request.WithContext(utils.ContextWithUser(c.Request.Context(), user))
+		c.Next()
+	}
+}
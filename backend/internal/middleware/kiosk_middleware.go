@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	service_interface "event_manager/internal/domain/service"
+	utils "event_manager/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireKiosk kiểm tra header "Authorization: Kiosk <token>" và gắn phiên kiosk
+// vào context của request (đọc lại bằng utils.KioskFromContext).
+func RequireKiosk(kioskSvc service_interface.KioskService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := strings.TrimSpace(c.GetHeader("Authorization"))
+		scheme, token, found := strings.Cut(header, " ")
+		if !found || !strings.EqualFold(scheme, "Kiosk") || strings.TrimSpace(token) == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing kiosk token"})
+			return
+		}
+
+		kiosk, err := kioskSvc.Authenticate(c.Request.Context(), strings.TrimSpace(token))
+		if err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, service_interface.ErrInvalidToken) {
+				status = http.StatusUnauthorized
+			}
+			c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.Request = c.Request.WithContext(utils.ContextWithKiosk(c.Request.Context(), kiosk))
+		c.Next()
+	}
+}
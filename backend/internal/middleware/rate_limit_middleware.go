@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimiter đếm request theo khoá trong cửa sổ thời gian cố định.
+// Bộ đếm nằm trong bộ nhớ của từng instance, đủ cho kiosk (mỗi thiết bị gọi vào cùng một server).
+type RateLimiter struct {
+	limit  int
+	window time.Duration
+
+	mu        sync.Mutex
+	windows   map[string]*rateWindow
+	lastSweep time.Time
+}
+
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+// NewRateLimiter cho phép tối đa limit request mỗi window cho một khoá
+func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
+	return &RateLimiter{limit: limit, window: window, windows: map[string]*rateWindow{}}
+}
+
+// Allow ghi nhận một request của key; khi đã hết lượt trả về false và thời gian phải chờ
+func (l *RateLimiter) Allow(key string, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Dọn các cửa sổ đã hết hạn để map không lớn dần
+	if now.Sub(l.lastSweep) >= l.window {
+		for k, w := range l.windows {
+			if now.Sub(w.start) >= l.window {
+				delete(l.windows, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	w, ok := l.windows[key]
+	if !ok || now.Sub(w.start) >= l.window {
+		w = &rateWindow{start: now}
+		l.windows[key] = w
+	}
+	if w.count >= l.limit {
+		return false, w.start.Add(l.window).Sub(now)
+	}
+	w.count++
+	return true, 0
+}
+
+// RateLimit chặn request vượt giới hạn của limiter với 429 và header Retry-After.
+// key xác định đối tượng bị giới hạn (vd kiosk ID); onLimited (có thể nil) được gọi trước khi chặn.
+func RateLimit(limiter *RateLimiter, key func(*gin.Context) string, onLimited func(*gin.Context)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ok, retryAfter := limiter.Allow(key(c), time.Now())
+		if ok {
+			c.Next()
+			return
+		}
+
+		if onLimited != nil {
+			onLimited(c)
+		}
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "too many requests, please try again later"})
+	}
+}
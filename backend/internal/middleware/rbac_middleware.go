@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"net/http"
+
+	"event_manager/internal/domain/entity"
+	utils "event_manager/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequirePermission chỉ cho phép user có ít nhất một trong các quyền perms.
+// Phải đứng sau RequireAuth.
+func RequirePermission(perms ...entity.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := utils.UserFromContext(c.Request.Context())
+		if user == nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+			return
+		}
+
+		role := entity.ParseRole(user.Role)
+		for _, p := range perms {
+			if role.Can(p) {
+				c.Next()
+				return
+			}
+		}
+
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "permission denied"})
+	}
+}
+
+// RequireSelfOrPermission cho phép user thao tác trên chính tài khoản của mình
+// (path param idParam trùng user ID) hoặc user có quyền perm.
+func RequireSelfOrPermission(idParam string, perm entity.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := utils.UserFromContext(c.Request.Context())
+		if user == nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+			return
+		}
+
+		if user.ID == c.Param(idParam) || entity.ParseRole(user.Role).Can(perm) {
+			c.Next()
+			return
+		}
+
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "permission denied"})
+	}
+}
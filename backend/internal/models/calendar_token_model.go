@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"event_manager/internal/domain/entity"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CalendarTokenModel tương ứng với collection "calendar_tokens"
+type CalendarTokenModel struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	SubjectType string             `bson:"subject_type" json:"subject_type"`
+	SubjectID   string             `bson:"subject_id" json:"subject_id"`
+	TokenHash   string             `bson:"token_hash" json:"-"`
+	IssuedBy    string             `bson:"issued_by,omitempty" json:"issued_by,omitempty"`
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+	RevokedAt   *time.Time         `bson:"revoked_at,omitempty" json:"revoked_at,omitempty"`
+}
+
+// Convert CalendarToken model → domain entity
+func CalendarTokenModelToEntity(m *CalendarTokenModel) *entity.CalendarToken {
+	return &entity.CalendarToken{
+		ID:          m.ID.Hex(),
+		SubjectType: m.SubjectType,
+		SubjectID:   m.SubjectID,
+		TokenHash:   m.TokenHash,
+		IssuedBy:    m.IssuedBy,
+		CreatedAt:   m.CreatedAt,
+		RevokedAt:   m.RevokedAt,
+	}
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"event_manager/internal/domain/entity"
+)
+
+// CheckInSyncOpModel ghi lại kết quả của một thao tác offline đã đồng bộ, để lần gửi lại trả đúng kết quả cũ
+type CheckInSyncOpModel struct {
+	ID             string    `bson:"_id"` // CheckInSyncOpID(device, op)
+	DeviceID       string    `bson:"device_id"`
+	OpID           string    `bson:"op_id"`
+	RegistrationID string    `bson:"registration_id"`
+	Action         string    `bson:"action"`
+	ClientTime     time.Time `bson:"client_time"`
+	Result         string    `bson:"result"`
+	Conflict       string    `bson:"conflict,omitempty"`
+	Status         string    `bson:"status"`
+	Message        string    `bson:"message,omitempty"`
+	ActorID        string    `bson:"actor_id"`
+	SyncedAt       time.Time `bson:"synced_at"`
+}
+
+// CheckInSyncOpID ghép ID thiết bị và ID thao tác thành khoá idempotency
+func CheckInSyncOpID(deviceID, opID string) string {
+	return deviceID + ":" + opID
+}
+
+func (m *CheckInSyncOpModel) CheckInSyncOpModelToEntity() *entity.CheckInSyncResult {
+	return &entity.CheckInSyncResult{
+		OpID:           m.OpID,
+		RegistrationID: m.RegistrationID,
+		Action:         entity.CheckInSyncAction(m.Action),
+		Result:         m.Result,
+		Conflict:       m.Conflict,
+		Status:         entity.RegistrationStatus(m.Status),
+		Message:        m.Message,
+		SyncedAt:       m.SyncedAt,
+	}
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"event_manager/internal/domain/entity"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// EventGuestModel là một dòng kết quả join "registrations" với "guests"
+type EventGuestModel struct {
+	RegistrationID primitive.ObjectID `bson:"_id"`
+	EventID        string             `bson:"event_id"`
+	GuestID        string             `bson:"guest_id"`
+	FullName       string             `bson:"full_name"`
+	Email          string             `bson:"email"`
+	Phone          string             `bson:"phone"`
+	Status         string             `bson:"status"`
+	CheckedIn      bool               `bson:"checked_in"`
+	CreatedAt      time.Time          `bson:"created_at"`
+	CheckedInAt    *time.Time         `bson:"checked_in_at,omitempty"`
+}
+
+// EventGuestModelToEntity converts a joined row into the domain entity.
+func (m *EventGuestModel) EventGuestModelToEntity() *entity.EventGuest {
+	return &entity.EventGuest{
+		Guest: entity.Guest{
+			ID:       m.GuestID,
+			FullName: m.FullName,
+			Email:    m.Email,
+			Phone:    m.Phone,
+		},
+		Registration: entity.Registration{
+			ID:        m.RegistrationID.Hex(),
+			EventID:   m.EventID,
+			GuestID:   m.GuestID,
+			Status:    entity.ParseRegistrationStatus(m.Status),
+			CreatedAt: m.CreatedAt,
+			CheckedIn: m.CheckedIn,
+		},
+		CheckedInAt: m.CheckedInAt,
+	}
+}
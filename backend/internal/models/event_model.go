@@ -7,52 +7,110 @@ import (
 )
 
 type EventModel struct {
-	ID          string    `bson:"_id,omitempty" json:"id"`
-	Name        string    `bson:"name" json:"name"`
-	Description string    `bson:"description" json:"description"`
-	Type        string    `bson:"type" json:"type"`
-	Status      string    `bson:"status" json:"status"`
-	Location    string    `bson:"location" json:"location"`
-	MaxGuests   uint      `bson:"max_guests" json:"max_guests"`
-	StartDate   time.Time `bson:"start_date" json:"start_date"`
-	EndDate     time.Time `bson:"end_date" json:"end_date"`
-	ImageURLs   []string  `bson:"image_urls" json:"image_urls"`
-	CreatedAt   time.Time `bson:"created_at" json:"created_at"`
-	UpdatedAt   time.Time `bson:"updated_at" json:"updated_at"`
+	ID              string             `bson:"_id,omitempty" json:"id"`
+	Name            string             `bson:"name" json:"name"`
+	Description     string             `bson:"description" json:"description"`
+	Type            string             `bson:"type" json:"type"`
+	Status          string             `bson:"status" json:"status"`
+	LocationID      string             `bson:"location_id,omitempty" json:"location_id"`
+	Location        string             `bson:"location" json:"location"`
+	MaxGuests       uint               `bson:"max_guests" json:"max_guests"`
+	SeatsTaken      int                `bson:"seats_taken" json:"seats_taken"`
+	StartDate       time.Time          `bson:"start_date" json:"start_date"`
+	EndDate         time.Time          `bson:"end_date" json:"end_date"`
+	ImageURLs       []string           `bson:"image_urls" json:"image_urls"`
+	OwnerID         string             `bson:"owner_id,omitempty" json:"owner_id"`
+	CoOrganizers    []CoOrganizerModel `bson:"co_organizers,omitempty" json:"co_organizers"`
+	SeriesID        string             `bson:"series_id,omitempty" json:"series_id,omitempty"`
+	OccurrenceStart time.Time          `bson:"occurrence_start,omitempty" json:"occurrence_start,omitempty"`
+	ICalUID         string             `bson:"ical_uid,omitempty" json:"ical_uid,omitempty"`
+	CreatedAt       time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt       time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// IsLimited cho biết sự kiện có áp dụng giới hạn số khách hay không
+func (m *EventModel) IsLimited() bool {
+	return m.Type == entity.EventTypeLimited && m.MaxGuests > 0
+}
+
+// CoOrganizerModel là phần tử nhúng trong mảng "co_organizers" của sự kiện
+type CoOrganizerModel struct {
+	UserID      string   `bson:"user_id" json:"user_id"`
+	Permissions []string `bson:"permissions" json:"permissions"`
 }
 
 // Convert từ domain entity sang DB model
 func EventModelToEntity(e entity.Event) EventModel {
 	return EventModel{
-		ID:          e.ID,
-		Name:        e.Name,
-		Description: e.Description,
-		Type:        e.Type,
-		Status:      e.Status,
-		Location:    e.Location,
-		MaxGuests:   e.MaxGuests,
-		StartDate:   e.StartDate,
-		EndDate:     e.EndDate,
-		ImageURLs:   e.ImageURLs,
-		CreatedAt:   e.CreatedAt,
-		UpdatedAt:   e.UpdatedAt,
+		ID:              e.ID,
+		Name:            e.Name,
+		Description:     e.Description,
+		Type:            e.Type,
+		Status:          e.Status,
+		LocationID:      e.LocationID,
+		Location:        e.Location,
+		MaxGuests:       e.MaxGuests,
+		SeatsTaken:      e.SeatsTaken,
+		StartDate:       e.StartDate,
+		EndDate:         e.EndDate,
+		ImageURLs:       e.ImageURLs,
+		OwnerID:         e.OwnerID,
+		CoOrganizers:    CoOrganizerEntitiesToModels(e.CoOrganizers),
+		SeriesID:        e.SeriesID,
+		OccurrenceStart: e.OccurrenceStart,
+		ICalUID:         e.ICalUID,
+		CreatedAt:       e.CreatedAt,
+		UpdatedAt:       e.UpdatedAt,
 	}
 }
 
 // Convert từ DB model sang domain entity
 func (m EventModel) EventEntityToModel() entity.Event {
 	return entity.Event{
-		ID:          m.ID,
-		Name:        m.Name,
-		Description: m.Description,
-		Type:        m.Type,
-		Status:      m.Status,
-		Location:    m.Location,
-		MaxGuests:   m.MaxGuests,
-		StartDate:   m.StartDate,
-		EndDate:     m.EndDate,
-		ImageURLs:   m.ImageURLs,
-		CreatedAt:   m.CreatedAt,
-		UpdatedAt:   m.UpdatedAt,
+		ID:              m.ID,
+		Name:            m.Name,
+		Description:     m.Description,
+		Type:            m.Type,
+		Status:          m.Status,
+		LocationID:      m.LocationID,
+		Location:        m.Location,
+		MaxGuests:       m.MaxGuests,
+		SeatsTaken:      m.SeatsTaken,
+		StartDate:       m.StartDate,
+		EndDate:         m.EndDate,
+		ImageURLs:       m.ImageURLs,
+		OwnerID:         m.OwnerID,
+		CoOrganizers:    CoOrganizerModelsToEntities(m.CoOrganizers),
+		SeriesID:        m.SeriesID,
+		OccurrenceStart: m.OccurrenceStart,
+		ICalUID:         m.ICalUID,
+		CreatedAt:       m.CreatedAt,
+		UpdatedAt:       m.UpdatedAt,
+	}
+}
+
+// CoOrganizerEntitiesToModels converts co-organizer entities into embedded documents.
+func CoOrganizerEntitiesToModels(list []entity.CoOrganizer) []CoOrganizerModel {
+	result := make([]CoOrganizerModel, 0, len(list))
+	for _, co := range list {
+		perms := make([]string, 0, len(co.Permissions))
+		for _, p := range co.Permissions {
+			perms = append(perms, string(p))
+		}
+		result = append(result, CoOrganizerModel{UserID: co.UserID, Permissions: perms})
+	}
+	return result
+}
+
+// CoOrganizerModelsToEntities converts embedded co-organizer documents into entities.
+func CoOrganizerModelsToEntities(list []CoOrganizerModel) []entity.CoOrganizer {
+	result := make([]entity.CoOrganizer, 0, len(list))
+	for _, co := range list {
+		perms := make([]entity.EventPermission, 0, len(co.Permissions))
+		for _, p := range co.Permissions {
+			perms = append(perms, entity.EventPermission(p))
+		}
+		result = append(result, entity.CoOrganizer{UserID: co.UserID, Permissions: perms})
 	}
+	return result
 }
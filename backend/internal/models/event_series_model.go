@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"event_manager/internal/domain/entity"
+)
+
+// EventSeriesModel tương ứng với collection "event_series"
+type EventSeriesModel struct {
+	ID        string      `bson:"_id" json:"id"`
+	DTStart   time.Time   `bson:"dtstart" json:"dtstart"`
+	RRule     string      `bson:"rrule" json:"rrule"` // RRULE không gồm EXDATE
+	ExDates   []time.Time `bson:"exdates,omitempty" json:"exdates"`
+	CreatedAt time.Time   `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time   `bson:"updated_at" json:"updated_at"`
+}
+
+// Convert EventSeries entity → Mongo model
+func EventSeriesEntityToModel(e *entity.EventSeries) *EventSeriesModel {
+	return &EventSeriesModel{
+		ID:        e.ID,
+		DTStart:   e.DTStart,
+		RRule:     e.Recurrence.String(),
+		ExDates:   e.Recurrence.ExDates,
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+	}
+}
+
+// Convert EventSeries model → domain entity (RRULE đã được kiểm tra khi lưu)
+func EventSeriesModelToEntity(m *EventSeriesModel) (*entity.EventSeries, error) {
+	rule, err := entity.ParseRRule(m.RRule)
+	if err != nil {
+		return nil, err
+	}
+	rule.ExDates = m.ExDates
+	return &entity.EventSeries{
+		ID:         m.ID,
+		DTStart:    m.DTStart,
+		Recurrence: *rule,
+		CreatedAt:  m.CreatedAt,
+		UpdatedAt:  m.UpdatedAt,
+	}, nil
+}
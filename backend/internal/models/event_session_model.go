@@ -0,0 +1,128 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"event_manager/internal/domain/entity"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// EventSessionModel là document của collection "event_sessions"
+type EventSessionModel struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	EventID     string             `bson:"event_id" json:"event_id"`
+	Title       string             `bson:"title" json:"title"`
+	Description string             `bson:"description,omitempty" json:"description,omitempty"`
+	Room        string             `bson:"room,omitempty" json:"room,omitempty"`
+	Speakers    []string           `bson:"speakers,omitempty" json:"speakers,omitempty"`
+	StartTime   time.Time          `bson:"start_time" json:"start_time"`
+	EndTime     time.Time          `bson:"end_time" json:"end_time"`
+	Capacity    int                `bson:"capacity" json:"capacity"`
+	SeatsTaken  int                `bson:"seats_taken" json:"seats_taken"`
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at,omitempty" json:"updated_at"`
+}
+
+// SessionRegistrationModel là document của collection "session_registrations"
+type SessionRegistrationModel struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	SessionID      string             `bson:"session_id" json:"session_id"`
+	EventID        string             `bson:"event_id" json:"event_id"`
+	RegistrationID string             `bson:"registration_id" json:"registration_id"`
+	Status         string             `bson:"status" json:"status"`
+	CreatedAt      time.Time          `bson:"created_at" json:"created_at"`
+	CheckedInAt    *time.Time         `bson:"checked_in_at,omitempty" json:"checked_in_at,omitempty"`
+	CancelledAt    *time.Time         `bson:"cancelled_at,omitempty" json:"cancelled_at,omitempty"`
+}
+
+// SessionGuestAggregation represents registration counts of a session.
+type SessionGuestAggregation struct {
+	SessionID   primitive.ObjectID `bson:"_id"`
+	EventID     string             `bson:"event_id"`
+	Title       string             `bson:"title"`
+	Room        string             `bson:"room,omitempty"`
+	StartTime   time.Time          `bson:"start_time"`
+	EndTime     time.Time          `bson:"end_time"`
+	Capacity    int                `bson:"capacity"`
+	TotalGuests int                `bson:"total_guests"`
+	CheckedIn   int                `bson:"checked_in"`
+}
+
+// Convert từ entity -> model
+func EventSessionEntityToModel(e *entity.EventSession) (*EventSessionModel, error) {
+	var id primitive.ObjectID
+	if strings.TrimSpace(e.ID) != "" {
+		var err error
+		id, err = primitive.ObjectIDFromHex(e.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &EventSessionModel{
+		ID:          id,
+		EventID:     strings.TrimSpace(e.EventID),
+		Title:       e.Title,
+		Description: e.Description,
+		Room:        e.Room,
+		Speakers:    e.Speakers,
+		StartTime:   e.StartTime,
+		EndTime:     e.EndTime,
+		Capacity:    e.Capacity,
+		SeatsTaken:  e.SeatsTaken,
+		CreatedAt:   e.CreatedAt,
+		UpdatedAt:   e.UpdatedAt,
+	}, nil
+}
+
+// Convert từ model -> entity
+func (m *EventSessionModel) EventSessionModelToEntity() *entity.EventSession {
+	return &entity.EventSession{
+		ID:          m.ID.Hex(),
+		EventID:     m.EventID,
+		Title:       m.Title,
+		Description: m.Description,
+		Room:        m.Room,
+		Speakers:    m.Speakers,
+		StartTime:   m.StartTime,
+		EndTime:     m.EndTime,
+		Capacity:    m.Capacity,
+		SeatsTaken:  m.SeatsTaken,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+// Convert từ model -> entity; GuestID/GuestName do service điền
+func (m *SessionRegistrationModel) SessionRegistrationModelToEntity() *entity.SessionRegistration {
+	return &entity.SessionRegistration{
+		ID:             m.ID.Hex(),
+		SessionID:      m.SessionID,
+		EventID:        m.EventID,
+		RegistrationID: m.RegistrationID,
+		Status:         entity.SessionRegistrationStatus(m.Status),
+		CreatedAt:      m.CreatedAt,
+		CheckedInAt:    m.CheckedInAt,
+		CancelledAt:    m.CancelledAt,
+	}
+}
+
+// ToEntity converts SessionGuestAggregation to domain entity.
+func (a *SessionGuestAggregation) ToEntity() *entity.SessionGuestStat {
+	if a == nil {
+		return nil
+	}
+	return &entity.SessionGuestStat{
+		SessionID:   a.SessionID.Hex(),
+		EventID:     a.EventID,
+		Title:       a.Title,
+		Room:        a.Room,
+		StartTime:   a.StartTime,
+		EndTime:     a.EndTime,
+		Capacity:    a.Capacity,
+		TotalGuests: a.TotalGuests,
+		CheckedIn:   a.CheckedIn,
+	}
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// GuestDuplicateModel tương ứng với collection "guest_duplicates"; _id là "<id nhỏ>:<id lớn>"
+type GuestDuplicateModel struct {
+	ID             string    `bson:"_id" json:"id"`
+	GuestIDs       []string  `bson:"guest_ids" json:"guest_ids"`
+	Reasons        []string  `bson:"reasons" json:"reasons"`
+	NameSimilarity float64   `bson:"name_similarity" json:"name_similarity"`
+	Score          float64   `bson:"score" json:"score"`
+	Dismissed      bool      `bson:"dismissed" json:"dismissed"`
+	ScanID         string    `bson:"scan_id" json:"scan_id"`
+	DetectedAt     time.Time `bson:"detected_at" json:"detected_at"`
+}
+
+// DuplicatePairID trả về khoá ổn định của một cặp khách, không phụ thuộc thứ tự
+func DuplicatePairID(a, b string) string {
+	if b < a {
+		a, b = b, a
+	}
+	return a + ":" + b
+}
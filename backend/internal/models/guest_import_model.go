@@ -0,0 +1,114 @@
+package models
+
+import (
+	"time"
+
+	"event_manager/internal/domain/entity"
+)
+
+// GuestImportRowModel là báo cáo của một dòng trong file nhập
+type GuestImportRowModel struct {
+	Row      int    `bson:"row" json:"row"`
+	FullName string `bson:"full_name,omitempty" json:"full_name,omitempty"`
+	Email    string `bson:"email,omitempty" json:"email,omitempty"`
+	Phone    string `bson:"phone,omitempty" json:"phone,omitempty"`
+	Action   string `bson:"action" json:"action"`
+	GuestID  string `bson:"guest_id,omitempty" json:"guest_id,omitempty"`
+	Error    string `bson:"error,omitempty" json:"error,omitempty"`
+}
+
+// GuestImportJobModel tương ứng với collection "guest_import_jobs"
+type GuestImportJobModel struct {
+	ID         string                `bson:"_id" json:"id"`
+	EventID    string                `bson:"event_id" json:"event_id"`
+	FileName   string                `bson:"file_name" json:"file_name"`
+	Format     string                `bson:"format" json:"format"`
+	Status     string                `bson:"status" json:"status"`
+	Total      int                   `bson:"total" json:"total"`
+	Processed  int                   `bson:"processed" json:"processed"`
+	Created    int                   `bson:"created" json:"created"`
+	Reused     int                   `bson:"reused" json:"reused"`
+	Failed     int                   `bson:"failed" json:"failed"`
+	Rows       []GuestImportRowModel `bson:"rows" json:"rows"`
+	Error      string                `bson:"error,omitempty" json:"error,omitempty"`
+	CreatedBy  string                `bson:"created_by,omitempty" json:"created_by,omitempty"`
+	CreatedAt  time.Time             `bson:"created_at" json:"created_at"`
+	UpdatedAt  time.Time             `bson:"updated_at" json:"updated_at"`
+	FinishedAt *time.Time            `bson:"finished_at,omitempty" json:"finished_at,omitempty"`
+}
+
+// GuestImportRowEntityToModel converts one row report into its persistence model.
+func GuestImportRowEntityToModel(e entity.GuestImportRow) GuestImportRowModel {
+	return GuestImportRowModel{
+		Row:      e.Row,
+		FullName: e.FullName,
+		Email:    e.Email,
+		Phone:    e.Phone,
+		Action:   e.Action,
+		GuestID:  e.GuestID,
+		Error:    e.Error,
+	}
+}
+
+// GuestImportJobEntityToModel converts a job entity into its persistence model.
+func GuestImportJobEntityToModel(e *entity.GuestImportJob) *GuestImportJobModel {
+	rows := make([]GuestImportRowModel, 0, len(e.Rows))
+	for _, r := range e.Rows {
+		rows = append(rows, GuestImportRowEntityToModel(r))
+	}
+	return &GuestImportJobModel{
+		ID:         e.ID,
+		EventID:    e.EventID,
+		FileName:   e.FileName,
+		Format:     e.Format,
+		Status:     string(e.Status),
+		Total:      e.Total,
+		Processed:  e.Processed,
+		Created:    e.Created,
+		Reused:     e.Reused,
+		Failed:     e.Failed,
+		Rows:       rows,
+		Error:      e.Error,
+		CreatedBy:  e.CreatedBy,
+		CreatedAt:  e.CreatedAt,
+		UpdatedAt:  e.UpdatedAt,
+		FinishedAt: e.FinishedAt,
+	}
+}
+
+// GuestImportJobModelToEntity converts a stored job into the domain entity.
+func GuestImportJobModelToEntity(m *GuestImportJobModel) *entity.GuestImportJob {
+	if m == nil {
+		return nil
+	}
+	rows := make([]entity.GuestImportRow, 0, len(m.Rows))
+	for _, r := range m.Rows {
+		rows = append(rows, entity.GuestImportRow{
+			Row:      r.Row,
+			FullName: r.FullName,
+			Email:    r.Email,
+			Phone:    r.Phone,
+			Action:   r.Action,
+			GuestID:  r.GuestID,
+			Error:    r.Error,
+		})
+	}
+	return &entity.GuestImportJob{
+		ID:         m.ID,
+		EventID:    m.EventID,
+		FileName:   m.FileName,
+		Format:     m.Format,
+		Status:     entity.GuestImportStatus(m.Status),
+		Total:      m.Total,
+		Processed:  m.Processed,
+		Created:    m.Created,
+		Reused:     m.Reused,
+		Failed:     m.Failed,
+		Rows:       rows,
+		Error:      m.Error,
+		CreatedBy:  m.CreatedBy,
+		CreatedAt:  m.CreatedAt,
+		UpdatedAt:  m.UpdatedAt,
+		FinishedAt: m.FinishedAt,
+	}
+}
@@ -14,6 +14,17 @@ type GuestModel struct {
 	FullName string `bson:"full_name" json:"full_name"`
 	Email    string `bson:"email" json:"email"`
 	Phone    string `bson:"phone" json:"phone"`
+
+	// Khoá tìm kiếm được repository tính lại mỗi lần ghi (không dấu, chữ thường / chỉ chữ số)
+	SearchName  string `bson:"search_name,omitempty" json:"-"`
+	SearchEmail string `bson:"search_email,omitempty" json:"-"`
+	SearchPhone string `bson:"search_phone,omitempty" json:"-"`
+
+	// Các sự kiện khách đã đăng ký, đồng bộ theo collection "registrations" để lọc theo sự kiện
+	EventIDs []string `bson:"event_ids,omitempty" json:"-"`
+
+	// User tạo khách; chỉ người này (và admin) quản lý được khách khi khách chưa đăng ký sự kiện nào
+	CreatedBy string `bson:"created_by,omitempty" json:"-"`
 }
 
 // GuestEntityToModel converts a domain guest entity into its persistence model.
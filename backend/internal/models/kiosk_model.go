@@ -0,0 +1,84 @@
+package models
+
+import (
+	"time"
+
+	"event_manager/internal/domain/entity"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// KioskSessionModel tương ứng với collection "kiosk_sessions"
+type KioskSessionModel struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	EventID    string             `bson:"event_id" json:"event_id"`
+	Name       string             `bson:"name" json:"name"`
+	TokenHash  string             `bson:"token_hash" json:"-"`
+	CreatedBy  string             `bson:"created_by" json:"created_by"`
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+	ExpiresAt  time.Time          `bson:"expires_at" json:"expires_at"`
+	RevokedAt  *time.Time         `bson:"revoked_at,omitempty" json:"revoked_at,omitempty"`
+	LastSeenAt *time.Time         `bson:"last_seen_at,omitempty" json:"last_seen_at,omitempty"`
+}
+
+// Convert KioskSession model → domain entity
+func KioskSessionModelToEntity(m *KioskSessionModel) *entity.KioskSession {
+	return &entity.KioskSession{
+		ID:         m.ID.Hex(),
+		EventID:    m.EventID,
+		Name:       m.Name,
+		CreatedBy:  m.CreatedBy,
+		CreatedAt:  m.CreatedAt,
+		ExpiresAt:  m.ExpiresAt,
+		RevokedAt:  m.RevokedAt,
+		LastSeenAt: m.LastSeenAt,
+	}
+}
+
+// KioskAuditModel tương ứng với collection "kiosk_audit"
+type KioskAuditModel struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty"`
+	KioskID        string             `bson:"kiosk_id"`
+	EventID        string             `bson:"event_id"`
+	Action         string             `bson:"action"`
+	Query          string             `bson:"query,omitempty"`
+	Matches        int                `bson:"matches,omitempty"`
+	RegistrationID string             `bson:"registration_id,omitempty"`
+	Result         string             `bson:"result"`
+	Message        string             `bson:"message,omitempty"`
+	ClientIP       string             `bson:"client_ip,omitempty"`
+	At             time.Time          `bson:"at"`
+}
+
+// Convert KioskAudit domain entity → model
+func KioskAuditEntityToModel(e *entity.KioskAuditEntry) *KioskAuditModel {
+	return &KioskAuditModel{
+		KioskID:        e.KioskID,
+		EventID:        e.EventID,
+		Action:         e.Action,
+		Query:          e.Query,
+		Matches:        e.Matches,
+		RegistrationID: e.RegistrationID,
+		Result:         e.Result,
+		Message:        e.Message,
+		ClientIP:       e.ClientIP,
+		At:             e.At,
+	}
+}
+
+// Convert KioskAudit model → domain entity
+func KioskAuditModelToEntity(m *KioskAuditModel) *entity.KioskAuditEntry {
+	return &entity.KioskAuditEntry{
+		ID:             m.ID.Hex(),
+		KioskID:        m.KioskID,
+		EventID:        m.EventID,
+		Action:         m.Action,
+		Query:          m.Query,
+		Matches:        m.Matches,
+		RegistrationID: m.RegistrationID,
+		Result:         m.Result,
+		Message:        m.Message,
+		ClientIP:       m.ClientIP,
+		At:             m.At,
+	}
+}
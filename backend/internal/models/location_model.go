@@ -1,7 +1,10 @@
 package models
 
 import (
+	"strings"
+
 	"event_manager/internal/domain/entity"
+	utils "event_manager/util"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -10,16 +13,27 @@ import (
 type LocationModel struct {
 	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
 	Name        string             `bson:"name" json:"name"`
+	NameKey     string             `bson:"name_key" json:"-"` // tên đã chuẩn hoá, unique để tránh trùng do gõ khác nhau
 	Address     string             `bson:"address" json:"address"`
 	Capacity    int                `bson:"capacity" json:"capacity"`
 	Description string             `bson:"description" json:"description"`
 }
 
+// LocationNameKey chuẩn hoá tên địa điểm: "  Nhà Văn hoá  " và "nha van hoa" cho cùng một khoá
+func LocationNameKey(name string) string {
+	return utils.FoldText(name)
+}
+
 // Convert Location entity → Mongo model
 func LocationEntityToModel(e *entity.Location) *LocationModel {
+	id, err := primitive.ObjectIDFromHex(strings.TrimSpace(e.ID))
+	if err != nil {
+		id = primitive.NewObjectID()
+	}
 	return &LocationModel{
-		ID:          primitive.NewObjectID(),
-		Name:        e.Name,
+		ID:          id,
+		Name:        strings.TrimSpace(e.Name),
+		NameKey:     LocationNameKey(e.Name),
 		Address:     e.Address,
 		Capacity:    e.Capacity,
 		Description: e.Description,
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"event_manager/internal/domain/entity"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ProfileModel là document của collection "profiles"
+type ProfileModel struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Kind      string             `bson:"kind" json:"kind"`
+	Name      string             `bson:"name" json:"name"`
+	Headline  string             `bson:"headline,omitempty" json:"headline,omitempty"`
+	Bio       string             `bson:"bio,omitempty" json:"bio,omitempty"`
+	PhotoURL  string             `bson:"photo_url,omitempty" json:"photo_url,omitempty"`
+	Links     []ProfileLinkModel `bson:"links,omitempty" json:"links,omitempty"`
+	Tier      string             `bson:"tier,omitempty" json:"tier,omitempty"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time          `bson:"updated_at,omitempty" json:"updated_at"`
+}
+
+// ProfileLinkModel là một liên kết trên hồ sơ
+type ProfileLinkModel struct {
+	Label string `bson:"label,omitempty" json:"label,omitempty"`
+	URL   string `bson:"url" json:"url"`
+}
+
+// ProfileAssignmentModel là document của collection "event_profiles": gắn hồ sơ vào sự kiện
+// (session_id rỗng) hoặc vào một phiên của sự kiện
+type ProfileAssignmentModel struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	EventID   string             `bson:"event_id" json:"event_id"`
+	SessionID string             `bson:"session_id" json:"session_id"`
+	ProfileID string             `bson:"profile_id" json:"profile_id"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// Convert từ entity -> model
+func ProfileEntityToModel(e *entity.Profile) (*ProfileModel, error) {
+	var id primitive.ObjectID
+	if strings.TrimSpace(e.ID) != "" {
+		var err error
+		id, err = primitive.ObjectIDFromHex(e.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var links []ProfileLinkModel
+	for _, l := range e.Links {
+		links = append(links, ProfileLinkModel{Label: l.Label, URL: l.URL})
+	}
+
+	return &ProfileModel{
+		ID:        id,
+		Kind:      string(e.Kind),
+		Name:      e.Name,
+		Headline:  e.Headline,
+		Bio:       e.Bio,
+		PhotoURL:  e.PhotoURL,
+		Links:     links,
+		Tier:      string(e.Tier),
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+	}, nil
+}
+
+// Convert từ model -> entity
+func (m *ProfileModel) ProfileModelToEntity() *entity.Profile {
+	links := make([]entity.ProfileLink, 0, len(m.Links))
+	for _, l := range m.Links {
+		links = append(links, entity.ProfileLink{Label: l.Label, URL: l.URL})
+	}
+
+	return &entity.Profile{
+		ID:        m.ID.Hex(),
+		Kind:      entity.ProfileKind(m.Kind),
+		Name:      m.Name,
+		Headline:  m.Headline,
+		Bio:       m.Bio,
+		PhotoURL:  m.PhotoURL,
+		Links:     links,
+		Tier:      entity.SponsorTier(m.Tier),
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
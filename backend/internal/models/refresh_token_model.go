@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"event_manager/internal/domain/entity"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RefreshTokenModel tương ứng với collection "refresh_tokens"
+type RefreshTokenModel struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID    string             `bson:"user_id" json:"user_id"`
+	TokenHash string             `bson:"token_hash" json:"-"`
+	ExpiresAt time.Time          `bson:"expires_at" json:"expires_at"`
+	RevokedAt *time.Time         `bson:"revoked_at,omitempty" json:"revoked_at,omitempty"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// RefreshTokenEntityToModel converts a refresh token entity into its persistence model.
+func RefreshTokenEntityToModel(e *entity.RefreshToken) *RefreshTokenModel {
+	var id primitive.ObjectID
+	if e.ID != "" {
+		id, _ = primitive.ObjectIDFromHex(e.ID)
+	}
+
+	return &RefreshTokenModel{
+		ID:        id,
+		UserID:    e.UserID,
+		TokenHash: e.TokenHash,
+		ExpiresAt: e.ExpiresAt,
+		RevokedAt: e.RevokedAt,
+		CreatedAt: e.CreatedAt,
+	}
+}
+
+// RefreshTokenModelToEntity converts a MongoDB refresh token document into the domain entity.
+func (m *RefreshTokenModel) RefreshTokenModelToEntity() *entity.RefreshToken {
+	return &entity.RefreshToken{
+		ID:        m.ID.Hex(),
+		UserID:    m.UserID,
+		TokenHash: m.TokenHash,
+		ExpiresAt: m.ExpiresAt,
+		RevokedAt: m.RevokedAt,
+		CreatedAt: m.CreatedAt,
+	}
+}
@@ -12,12 +12,34 @@ import (
 
 // RegistrationModel represents the MongoDB document for a registration.
 type RegistrationModel struct {
-	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
-	EventID   string             `bson:"event_id" json:"event_id"`
-	GuestID   string             `bson:"guest_id" json:"guest_id"`
-	Status    string             `bson:"status" json:"status"`
-	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
-	CheckedIn bool               `bson:"checked_in" json:"checked_in"`
+	ID        primitive.ObjectID            `bson:"_id,omitempty" json:"id"`
+	EventID   string                        `bson:"event_id" json:"event_id"`
+	GuestID   string                        `bson:"guest_id" json:"guest_id"`
+	Status    string                        `bson:"status" json:"status"`
+	CreatedAt time.Time                     `bson:"created_at" json:"created_at"`
+	CheckedIn bool                          `bson:"checked_in" json:"checked_in"`
+	History   []RegistrationTransitionModel `bson:"history,omitempty" json:"history,omitempty"`
+	Visits    []RegistrationVisitModel      `bson:"visits,omitempty" json:"visits,omitempty"`
+	// KioskConfirmFailures đếm số lần xác nhận sai trên kiosk, đủ ngưỡng thì khoá tự check-in
+	KioskConfirmFailures int `bson:"kiosk_confirm_failures,omitempty" json:"-"`
+	// TicketVersion tăng mỗi lần cấp lại vé; vé mang version cũ bị từ chối khi quét
+	TicketVersion  int        `bson:"ticket_version,omitempty" json:"-"`
+	TicketIssuedAt *time.Time `bson:"ticket_issued_at,omitempty" json:"-"`
+}
+
+// RegistrationVisitModel is an entry of the embedded "visits" array: one check-in/check-out cycle.
+type RegistrationVisitModel struct {
+	CheckedInAt  time.Time  `bson:"checked_in_at" json:"checked_in_at"`
+	CheckedOutAt *time.Time `bson:"checked_out_at,omitempty" json:"checked_out_at,omitempty"`
+}
+
+// RegistrationTransitionModel is an entry of the embedded "history" array.
+type RegistrationTransitionModel struct {
+	From    string    `bson:"from" json:"from"`
+	To      string    `bson:"to" json:"to"`
+	ActorID string    `bson:"actor_id" json:"actor_id"`
+	Reason  string    `bson:"reason,omitempty" json:"reason,omitempty"`
+	At      time.Time `bson:"at" json:"at"`
 }
 
 // RegistrationEntityToModel converts a domain registration into its persistence model.
@@ -37,24 +59,80 @@ func RegistrationEntityToModel(e *entity.Registration) (*RegistrationModel, erro
 		id, _ = primitive.ObjectIDFromHex(e.ID)
 	}
 
+	history := make([]RegistrationTransitionModel, 0, len(e.History))
+	for _, t := range e.History {
+		history = append(history, RegistrationTransitionEntityToModel(t))
+	}
+
+	var visits []RegistrationVisitModel
+	for _, v := range e.Visits {
+		visits = append(visits, RegistrationVisitModel{CheckedInAt: v.CheckedInAt, CheckedOutAt: v.CheckedOutAt})
+	}
+
 	return &RegistrationModel{
 		ID:        id,
 		EventID:   eventID,
 		GuestID:   guestID,
-		Status:    e.Status,
+		Status:    string(e.Status),
 		CreatedAt: e.CreatedAt,
 		CheckedIn: e.CheckedIn,
+		History:   history,
+		Visits:    visits,
 	}, nil
 }
 
 // RegistrationModelToEntity converts a MongoDB registration document into the domain entity.
 func (m *RegistrationModel) RegistrationModelToEntity() *entity.Registration {
+	history := make([]entity.RegistrationTransition, 0, len(m.History))
+	for _, t := range m.History {
+		history = append(history, t.ToEntity())
+	}
+
+	var visits []entity.RegistrationVisit
+	for _, v := range m.Visits {
+		visits = append(visits, entity.RegistrationVisit{CheckedInAt: v.CheckedInAt, CheckedOutAt: v.CheckedOutAt})
+	}
+
 	return &entity.Registration{
 		ID:        m.ID.Hex(),
 		EventID:   strings.TrimSpace(m.EventID),
 		GuestID:   strings.TrimSpace(m.GuestID),
-		Status:    m.Status,
+		Status:    entity.ParseRegistrationStatus(m.Status),
 		CreatedAt: m.CreatedAt,
 		CheckedIn: m.CheckedIn,
+		History:   history,
+		Visits:    visits,
+	}
+}
+
+// OpenVisit trả về lượt vào chưa check-out, nil nếu khách không ở trong sự kiện
+func (m *RegistrationModel) OpenVisit() *RegistrationVisitModel {
+	for i := len(m.Visits) - 1; i >= 0; i-- {
+		if m.Visits[i].CheckedOutAt == nil {
+			return &m.Visits[i]
+		}
+	}
+	return nil
+}
+
+// RegistrationTransitionEntityToModel converts a transition entity into its embedded document.
+func RegistrationTransitionEntityToModel(t entity.RegistrationTransition) RegistrationTransitionModel {
+	return RegistrationTransitionModel{
+		From:    string(t.From),
+		To:      string(t.To),
+		ActorID: t.ActorID,
+		Reason:  t.Reason,
+		At:      t.At,
+	}
+}
+
+// ToEntity converts an embedded transition document into the domain entity.
+func (t RegistrationTransitionModel) ToEntity() entity.RegistrationTransition {
+	return entity.RegistrationTransition{
+		From:    entity.RegistrationStatus(t.From),
+		To:      entity.RegistrationStatus(t.To),
+		ActorID: t.ActorID,
+		Reason:  t.Reason,
+		At:      t.At,
 	}
 }
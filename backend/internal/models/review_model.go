@@ -12,26 +12,33 @@ import (
 // MongoDB model cho Review
 type ReviewModel struct {
 	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
-	EventID   primitive.ObjectID `bson:"event_id" json:"event_id"`
+	EventID   string             `bson:"event_id" json:"event_id"`
 	GuestID   string             `bson:"guest_id" json:"guest_id"`
 	Rating    float64            `bson:"rating" json:"rating"`
 	Comment   string             `bson:"comment" json:"comment"`
 	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time          `bson:"updated_at,omitempty" json:"updated_at"`
 }
 
 // Convert từ entity -> model
 func ReviewEntityToModel(e *entity.Review) (*ReviewModel, error) {
-	eventOID, err := primitive.ObjectIDFromHex(e.EventID)
-	if err != nil {
-		return nil, err
+	var id primitive.ObjectID
+	if strings.TrimSpace(e.ID) != "" {
+		var err error
+		id, err = primitive.ObjectIDFromHex(e.ID)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return &ReviewModel{
-		EventID:   eventOID,
+		ID:        id,
+		EventID:   strings.TrimSpace(e.EventID),
 		GuestID:   strings.TrimSpace(e.GuestID),
 		Rating:    e.Rating,
 		Comment:   e.Comment,
 		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
 	}, nil
 }
 
@@ -39,10 +46,11 @@ func ReviewEntityToModel(e *entity.Review) (*ReviewModel, error) {
 func (m *ReviewModel) ReviewModelToEntity() *entity.Review {
 	return &entity.Review{
 		ID:        m.ID.Hex(),
-		EventID:   m.EventID.Hex(),
+		EventID:   m.EventID,
 		GuestID:   strings.TrimSpace(m.GuestID),
 		Rating:    m.Rating,
 		Comment:   m.Comment,
 		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
 	}
 }
@@ -23,6 +23,7 @@ type EventTypeGuestAggregation struct {
 
 // LocationGuestAggregation represents aggregated counts grouped by location.
 type LocationGuestAggregation struct {
+	LocationID  string `bson:"location_id,omitempty"`
 	Location    string `bson:"location"`
 	TotalGuests int    `bson:"total_guests"`
 	CheckedIn   int    `bson:"checked_in"`
@@ -35,6 +36,25 @@ type ParticipationTrendAggregation struct {
 	CheckedIn   int       `bson:"checked_in"`
 }
 
+// EventOccupancyAggregation represents the live headcount of an event.
+type EventOccupancyAggregation struct {
+	EventID    string `bson:"event_id"`
+	EventName  string `bson:"event_name,omitempty"`
+	Capacity   int    `bson:"capacity"`
+	OnSite     int    `bson:"on_site"`
+	CheckedOut int    `bson:"checked_out"`
+	Attended   int    `bson:"attended"`
+}
+
+// EventDwellTimeAggregation represents dwell-time averages of an event, in milliseconds.
+type EventDwellTimeAggregation struct {
+	EventID    string  `bson:"event_id"`
+	Guests     int     `bson:"guests"`
+	Visits     int     `bson:"visits"`
+	AvgVisitMs float64 `bson:"avg_visit_ms"`
+	AvgGuestMs float64 `bson:"avg_guest_ms"`
+}
+
 // ToEntity converts EventGuestAggregation to domain entity.
 func (a *EventGuestAggregation) ToEntity() *entity.EventGuestStat {
 	if a == nil {
@@ -67,6 +87,7 @@ func (a *LocationGuestAggregation) ToEntity() *entity.LocationGuestStat {
 		return nil
 	}
 	return &entity.LocationGuestStat{
+		LocationID:  a.LocationID,
 		Location:    a.Location,
 		TotalGuests: a.TotalGuests,
 		CheckedIn:   a.CheckedIn,
@@ -84,3 +105,32 @@ func (a *ParticipationTrendAggregation) ToEntity() *entity.ParticipationTrendPoi
 		CheckedIn:   a.CheckedIn,
 	}
 }
+
+// ToEntity converts EventOccupancyAggregation to domain entity.
+func (a *EventOccupancyAggregation) ToEntity() *entity.EventOccupancy {
+	if a == nil {
+		return nil
+	}
+	return &entity.EventOccupancy{
+		EventID:    a.EventID,
+		EventName:  a.EventName,
+		Capacity:   a.Capacity,
+		OnSite:     a.OnSite,
+		CheckedOut: a.CheckedOut,
+		Attended:   a.Attended,
+	}
+}
+
+// ToEntity converts EventDwellTimeAggregation to domain entity.
+func (a *EventDwellTimeAggregation) ToEntity() *entity.EventDwellTime {
+	if a == nil {
+		return nil
+	}
+	return &entity.EventDwellTime{
+		EventID:     a.EventID,
+		Guests:      a.Guests,
+		Visits:      a.Visits,
+		AvgVisit:    time.Duration(a.AvgVisitMs) * time.Millisecond,
+		AvgPerGuest: time.Duration(a.AvgGuestMs) * time.Millisecond,
+	}
+}
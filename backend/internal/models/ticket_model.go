@@ -0,0 +1,126 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"event_manager/internal/domain/entity"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TicketTypeModel là document của collection "ticket_types"
+type TicketTypeModel struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	EventID        string             `bson:"event_id" json:"event_id"`
+	Name           string             `bson:"name" json:"name"`
+	Description    string             `bson:"description,omitempty" json:"description,omitempty"`
+	Currency       string             `bson:"currency" json:"currency"`
+	Price          int64              `bson:"price" json:"price"`
+	EarlyBirdPrice int64              `bson:"early_bird_price,omitempty" json:"early_bird_price,omitempty"`
+	EarlyBirdUntil *time.Time         `bson:"early_bird_until,omitempty" json:"early_bird_until,omitempty"`
+	Quantity       int                `bson:"quantity" json:"quantity"`
+	Sold           int                `bson:"sold" json:"sold"`
+	Reserved       int                `bson:"reserved" json:"reserved"`
+	SaleStart      *time.Time         `bson:"sale_start,omitempty" json:"sale_start,omitempty"`
+	SaleEnd        *time.Time         `bson:"sale_end,omitempty" json:"sale_end,omitempty"`
+	CreatedAt      time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt      time.Time          `bson:"updated_at,omitempty" json:"updated_at"`
+}
+
+// OrderModel là document của collection "orders"
+type OrderModel struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	EventID        string             `bson:"event_id" json:"event_id"`
+	TicketTypeID   string             `bson:"ticket_type_id" json:"ticket_type_id"`
+	TicketName     string             `bson:"ticket_name" json:"ticket_name"`
+	GuestID        string             `bson:"guest_id" json:"guest_id"`
+	RegistrationID string             `bson:"registration_id,omitempty" json:"registration_id,omitempty"`
+	Amount         int64              `bson:"amount" json:"amount"`
+	Currency       string             `bson:"currency" json:"currency"`
+	EarlyBird      bool               `bson:"early_bird,omitempty" json:"early_bird,omitempty"`
+	Status         string             `bson:"status" json:"status"`
+	Reason         string             `bson:"reason,omitempty" json:"reason,omitempty"`
+	Provider       string             `bson:"provider,omitempty" json:"provider,omitempty"`
+	PaymentID      string             `bson:"payment_id,omitempty" json:"payment_id,omitempty"`
+	CheckoutURL    string             `bson:"checkout_url,omitempty" json:"checkout_url,omitempty"`
+	ExpiresAt      time.Time          `bson:"expires_at" json:"expires_at"`
+	PaidAt         *time.Time         `bson:"paid_at,omitempty" json:"paid_at,omitempty"`
+	CreatedAt      time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt      time.Time          `bson:"updated_at,omitempty" json:"updated_at"`
+}
+
+// Convert từ entity -> model
+func TicketTypeEntityToModel(e *entity.TicketType) (*TicketTypeModel, error) {
+	var id primitive.ObjectID
+	if strings.TrimSpace(e.ID) != "" {
+		var err error
+		id, err = primitive.ObjectIDFromHex(e.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &TicketTypeModel{
+		ID:             id,
+		EventID:        strings.TrimSpace(e.EventID),
+		Name:           e.Name,
+		Description:    e.Description,
+		Currency:       e.Currency,
+		Price:          e.Price,
+		EarlyBirdPrice: e.EarlyBirdPrice,
+		EarlyBirdUntil: e.EarlyBirdUntil,
+		Quantity:       e.Quantity,
+		Sold:           e.Sold,
+		Reserved:       e.Reserved,
+		SaleStart:      e.SaleStart,
+		SaleEnd:        e.SaleEnd,
+		CreatedAt:      e.CreatedAt,
+		UpdatedAt:      e.UpdatedAt,
+	}, nil
+}
+
+// Convert từ model -> entity
+func (m *TicketTypeModel) TicketTypeModelToEntity() *entity.TicketType {
+	return &entity.TicketType{
+		ID:             m.ID.Hex(),
+		EventID:        m.EventID,
+		Name:           m.Name,
+		Description:    m.Description,
+		Currency:       m.Currency,
+		Price:          m.Price,
+		EarlyBirdPrice: m.EarlyBirdPrice,
+		EarlyBirdUntil: m.EarlyBirdUntil,
+		Quantity:       m.Quantity,
+		Sold:           m.Sold,
+		Reserved:       m.Reserved,
+		SaleStart:      m.SaleStart,
+		SaleEnd:        m.SaleEnd,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
+
+// Convert từ model -> entity
+func (m *OrderModel) OrderModelToEntity() *entity.Order {
+	return &entity.Order{
+		ID:             m.ID.Hex(),
+		EventID:        m.EventID,
+		TicketTypeID:   m.TicketTypeID,
+		TicketName:     m.TicketName,
+		GuestID:        m.GuestID,
+		RegistrationID: m.RegistrationID,
+		Amount:         m.Amount,
+		Currency:       m.Currency,
+		EarlyBird:      m.EarlyBird,
+		Status:         entity.OrderStatus(m.Status),
+		Reason:         m.Reason,
+		Provider:       m.Provider,
+		PaymentID:      m.PaymentID,
+		CheckoutURL:    m.CheckoutURL,
+		ExpiresAt:      m.ExpiresAt,
+		PaidAt:         m.PaidAt,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
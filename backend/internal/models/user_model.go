@@ -9,40 +9,43 @@ import (
 
 // 🧩 UserModel cho MongoDB
 type UserModel struct {
-	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
-	Username  string             `bson:"username" json:"username"`
-	Email     string             `bson:"email" json:"email"`
-	FullName  string             `bson:"full_name" json:"full_name"`
-	Status    string             `bson:"status" json:"status"`
-	Role      string             `bson:"role" json:"role"`
-	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
-	UpdatedAt time.Time          `bson:"updated_at" json:"updated_at"`
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Username     string             `bson:"username" json:"username"`
+	Email        string             `bson:"email" json:"email"`
+	FullName     string             `bson:"full_name" json:"full_name"`
+	PasswordHash string             `bson:"password_hash" json:"-"`
+	Status       string             `bson:"status" json:"status"`
+	Role         string             `bson:"role" json:"role"`
+	CreatedAt    time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt    time.Time          `bson:"updated_at" json:"updated_at"`
 }
 
 // 🧭 Map từ Entity → Model
 func (u *UserModel) UserEntityToModel(e *entity.User) *UserModel {
 	return &UserModel{
-		ID:        primitive.NewObjectID(),
-		Username:  e.Username,
-		Email:     e.Email,
-		FullName:  e.FullName,
-		Status:    e.Status,
-		Role:      e.Role,
-		CreatedAt: e.CreatedAt,
-		UpdatedAt: e.UpdatedAt,
+		ID:           primitive.NewObjectID(),
+		Username:     e.Username,
+		Email:        e.Email,
+		FullName:     e.FullName,
+		PasswordHash: e.PasswordHash,
+		Status:       e.Status,
+		Role:         e.Role,
+		CreatedAt:    e.CreatedAt,
+		UpdatedAt:    e.UpdatedAt,
 	}
 }
 
 // 🔁 Map từ Model → Entity
 func (u *UserModel) UserModelToEntity() *entity.User {
 	return &entity.User{
-		ID:        u.ID.Hex(),
-		Username:  u.Username,
-		Email:     u.Email,
-		FullName:  u.FullName,
-		Status:    u.Status,
-		Role:      u.Role,
-		CreatedAt: u.CreatedAt,
-		UpdatedAt: u.UpdatedAt,
+		ID:           u.ID.Hex(),
+		Username:     u.Username,
+		Email:        u.Email,
+		FullName:     u.FullName,
+		PasswordHash: u.PasswordHash,
+		Status:       u.Status,
+		Role:         u.Role,
+		CreatedAt:    u.CreatedAt,
+		UpdatedAt:    u.UpdatedAt,
 	}
 }
@@ -0,0 +1,115 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// FakeSignatureHeader carries the hex HMAC-SHA256 of the webhook body signed with the webhook secret.
+const FakeSignatureHeader = "X-Fake-Signature"
+
+// FakeProvider is a local payment gateway for development and tests: no money moves, payments
+// are completed by calling the checkout URL, which delivers a signed webhook like a real gateway.
+type FakeProvider struct {
+	secret      []byte
+	checkoutURL string
+
+	mu        sync.Mutex
+	cancelled map[string]bool
+}
+
+type fakeWebhook struct {
+	PaymentID string `json:"payment_id"`
+	Status    Status `json:"status"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// NewFakeProvider builds a FakeProvider; checkoutBaseURL is the public URL of the fake checkout
+// endpoint, the payment ID is appended to it.
+func NewFakeProvider(secret, checkoutBaseURL string) *FakeProvider {
+	return &FakeProvider{
+		secret:      []byte(secret),
+		checkoutURL: strings.TrimRight(checkoutBaseURL, "/"),
+		cancelled:   map[string]bool{},
+	}
+}
+
+// Name implements Provider.
+func (p *FakeProvider) Name() string {
+	return "fake"
+}
+
+// CreatePayment implements Provider.
+func (p *FakeProvider) CreatePayment(ctx context.Context, req Request) (*Payment, error) {
+	if req.Amount <= 0 {
+		return nil, fmt.Errorf("amount must be positive, got %d", req.Amount)
+	}
+	id := "fake_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	return &Payment{ID: id, CheckoutURL: p.checkoutURL + "/" + id}, nil
+}
+
+// CancelPayment implements Provider.
+func (p *FakeProvider) CancelPayment(ctx context.Context, paymentID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cancelled[paymentID] = true
+	return nil
+}
+
+// ParseWebhook implements Provider.
+func (p *FakeProvider) ParseWebhook(header http.Header, body []byte) (*Event, error) {
+	sig, err := hex.DecodeString(header.Get(FakeSignatureHeader))
+	if err != nil || !hmac.Equal(sig, p.sign(body)) {
+		return nil, ErrInvalidWebhook
+	}
+	var w fakeWebhook
+	if err := json.Unmarshal(body, &w); err != nil || w.PaymentID == "" {
+		return nil, ErrInvalidWebhook
+	}
+	if w.Status != StatusSucceeded && w.Status != StatusFailed {
+		return nil, ErrInvalidWebhook
+	}
+	return &Event{PaymentID: w.PaymentID, Status: w.Status, Reason: w.Reason}, nil
+}
+
+// Complete simulates the guest finishing (or failing) the checkout and returns the signed webhook
+// the gateway would send. Cancellations are kept in memory only and are forgotten on restart.
+func (p *FakeProvider) Complete(paymentID string, status Status) (http.Header, []byte, error) {
+	if status != StatusSucceeded && status != StatusFailed {
+		return nil, nil, fmt.Errorf("unsupported payment status %q", status)
+	}
+	p.mu.Lock()
+	cancelled := p.cancelled[paymentID]
+	p.mu.Unlock()
+	if cancelled {
+		return nil, nil, fmt.Errorf("payment %s was cancelled", paymentID)
+	}
+
+	w := fakeWebhook{PaymentID: paymentID, Status: status}
+	if status == StatusFailed {
+		w.Reason = "card declined"
+	}
+	body, err := json.Marshal(w)
+	if err != nil {
+		return nil, nil, err
+	}
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set(FakeSignatureHeader, hex.EncodeToString(p.sign(body)))
+	return header, body, nil
+}
+
+func (p *FakeProvider) sign(body []byte) []byte {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write(body)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,57 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// ErrInvalidWebhook is returned when a webhook payload is malformed or its signature does not match.
+var ErrInvalidWebhook = errors.New("invalid payment webhook")
+
+// Status is the outcome of a payment reported by the provider.
+type Status string
+
+const (
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+)
+
+// Request describes the payment to collect for an order; Amount is in the currency's minor unit.
+type Request struct {
+	OrderID     string
+	Amount      int64
+	Currency    string
+	Description string
+	ExpiresAt   time.Time
+}
+
+// Payment is a payment created at the provider, paid by the guest at CheckoutURL.
+type Payment struct {
+	ID          string
+	CheckoutURL string
+}
+
+// Event is a payment outcome delivered to the webhook.
+type Event struct {
+	PaymentID string
+	Status    Status
+	Reason    string // Why the payment failed, when reported
+}
+
+// Provider defines the contract for a payment gateway.
+type Provider interface {
+	// Name identifies the provider; orders store it next to the payment ID.
+	Name() string
+
+	// CreatePayment starts a payment the guest can complete at the returned checkout URL.
+	CreatePayment(ctx context.Context, req Request) (*Payment, error)
+
+	// CancelPayment voids a payment that has not completed, e.g. when its order expires.
+	CancelPayment(ctx context.Context, paymentID string) error
+
+	// ParseWebhook verifies the signature of a webhook request and decodes the payment outcome.
+	// Returns ErrInvalidWebhook when the request is not authentic or cannot be decoded.
+	ParseWebhook(header http.Header, body []byte) (*Event, error)
+}
@@ -7,21 +7,24 @@ import (
 	"strings"
 	"time"
 
+	"event_manager/internal/domain/entity"
 	repository_interface "event_manager/internal/domain/repository"
 	"event_manager/internal/models"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type AggregateRepo struct {
-	col *mongo.Collection
+	col      *mongo.Collection
+	sessions *mongo.Collection
 }
 
 // ✅ Khởi tạo repository chuyên cho aggregation
 func NewAggregateRepo(db *mongo.Database) repository_interface.AggregateRepo {
-	return &AggregateRepo{col: db.Collection("registrations")}
+	return &AggregateRepo{col: db.Collection("registrations"), sessions: db.Collection("event_sessions")}
 }
 
 // ======================================================
@@ -150,12 +153,15 @@ func (r *AggregateRepo) AggregateGuestCountByLocation(ctx context.Context) ([]*m
 	  }},
 	  { "$unwind": { "path": "$event", "preserveNullAndEmptyArrays": true }},
 	  { "$group": {
-	      "_id": "$event.location",
+	      "_id": { "$ifNull": ["$event.location_id", "$event.location"] },
+	      "location_id": { "$first": "$event.location_id" },
+	      "location": { "$first": "$event.location" },
 	      "total_guests": { "$sum": 1 },
 	      "checked_in": { "$sum": { "$cond": ["$checked_in", 1, 0] } }
 	  }},
 	  { "$project": {
-	      "location": "$_id",
+	      "location_id": 1,
+	      "location": 1,
 	      "total_guests": 1,
 	      "checked_in": 1
 	  }},
@@ -306,3 +312,206 @@ func (r *AggregateRepo) AggregateGuestStatsByEvent(ctx context.Context, eventID
 		Absent:      0,
 	}, nil
 }
+
+// ======================================================
+// 🚪 Số khách đang có mặt (check-in chưa check-out)
+// ======================================================
+func (r *AggregateRepo) AggregateLiveOccupancy(ctx context.Context) ([]*models.EventOccupancyAggregation, error) {
+	return r.aggregateOccupancy(ctx, bson.M{}, true)
+}
+
+func (r *AggregateRepo) AggregateOccupancyByEvent(ctx context.Context, eventID string) (*models.EventOccupancyAggregation, error) {
+	eventID = strings.TrimSpace(eventID)
+	if eventID == "" {
+		return nil, errors.New("event id is required")
+	}
+
+	results, err := r.aggregateOccupancy(ctx, bson.M{"event_id": eventID}, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) > 0 {
+		return results[0], nil
+	}
+	return &models.EventOccupancyAggregation{EventID: eventID}, nil
+}
+
+// aggregateOccupancy đếm theo trạng thái hiện tại: checked_in là đang ở trong, checked_out là đã ra
+func (r *AggregateRepo) aggregateOccupancy(ctx context.Context, match bson.M, liveOnly bool) ([]*models.EventOccupancyAggregation, error) {
+	checkedIn := string(entity.RegistrationCheckedIn)
+	checkedOut := string(entity.RegistrationCheckedOut)
+	match["status"] = bson.M{"$in": bson.A{checkedIn, checkedOut}}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{
+			"_id":         "$event_id",
+			"on_site":     bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$status", checkedIn}}, 1, 0}}},
+			"checked_out": bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$status", checkedOut}}, 1, 0}}},
+			"attended":    bson.M{"$sum": 1},
+		}}},
+	}
+	if liveOnly {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"on_site": bson.M{"$gt": 0}}}})
+	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "on_site", Value: -1}, {Key: "_id", Value: 1}}}},
+		bson.D{{Key: "$lookup", Value: bson.M{"from": "events", "localField": "_id", "foreignField": "_id", "as": "event"}}},
+		bson.D{{Key: "$unwind", Value: bson.M{"path": "$event", "preserveNullAndEmptyArrays": true}}},
+		bson.D{{Key: "$project", Value: bson.M{
+			"event_id":    "$_id",
+			"event_name":  "$event.name",
+			"capacity":    bson.M{"$ifNull": bson.A{"$event.max_guests", 0}},
+			"on_site":     1,
+			"checked_out": 1,
+			"attended":    1,
+		}}},
+	)
+
+	cursor, err := r.col.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []*models.EventOccupancyAggregation
+	for cursor.Next(ctx) {
+		var agg models.EventOccupancyAggregation
+		if err := cursor.Decode(&agg); err != nil {
+			return nil, err
+		}
+		results = append(results, &agg)
+	}
+	return results, cursor.Err()
+}
+
+// ======================================================
+// ⏱️ Thời gian lưu lại trung bình, chỉ tính các lượt đã check-out
+// ======================================================
+func (r *AggregateRepo) AggregateDwellTimeByEvent(ctx context.Context, eventID string) (*models.EventDwellTimeAggregation, error) {
+	eventID = strings.TrimSpace(eventID)
+	if eventID == "" {
+		return nil, errors.New("event id is required")
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"event_id": eventID, "visits.checked_out_at": bson.M{"$exists": true}}}},
+		{{Key: "$unwind", Value: "$visits"}},
+		{{Key: "$match", Value: bson.M{"visits.checked_out_at": bson.M{"$exists": true}}}},
+		// Mỗi đăng ký: tổng thời gian và số lượt đã hoàn tất
+		{{Key: "$group", Value: bson.M{
+			"_id":      "$_id",
+			"visits":   bson.M{"$sum": 1},
+			"total_ms": bson.M{"$sum": bson.M{"$subtract": bson.A{"$visits.checked_out_at", "$visits.checked_in_at"}}},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":          nil,
+			"guests":       bson.M{"$sum": 1},
+			"visits":       bson.M{"$sum": "$visits"},
+			"total_ms":     bson.M{"$sum": "$total_ms"},
+			"avg_guest_ms": bson.M{"$avg": "$total_ms"},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":          0,
+			"event_id":     bson.M{"$literal": eventID},
+			"guests":       1,
+			"visits":       1,
+			"avg_visit_ms": bson.M{"$divide": bson.A{"$total_ms", "$visits"}},
+			"avg_guest_ms": 1,
+		}}},
+	}
+
+	cursor, err := r.col.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	if cursor.Next(ctx) {
+		var result models.EventDwellTimeAggregation
+		if err := cursor.Decode(&result); err != nil {
+			return nil, err
+		}
+		return &result, nil
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	return &models.EventDwellTimeAggregation{EventID: eventID}, nil
+}
+
+// ======================================================
+// 🎤 Thống kê theo phiên (session) của sự kiện
+// ======================================================
+func (r *AggregateRepo) AggregateGuestStatsBySession(ctx context.Context, eventID string) ([]*models.SessionGuestAggregation, error) {
+	eventID = strings.TrimSpace(eventID)
+	if eventID == "" {
+		return nil, errors.New("event id is required")
+	}
+	return r.aggregateSessionStats(ctx, bson.M{"event_id": eventID})
+}
+
+func (r *AggregateRepo) AggregateSessionStat(ctx context.Context, sessionID string) (*models.SessionGuestAggregation, error) {
+	objID, err := primitive.ObjectIDFromHex(strings.TrimSpace(sessionID))
+	if err != nil {
+		return nil, nil
+	}
+	results, err := r.aggregateSessionStats(ctx, bson.M{"_id": objID})
+	if err != nil || len(results) == 0 {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+// aggregateSessionStats đi từ "event_sessions" để phiên chưa có ai đăng ký vẫn có mặt trong kết quả
+func (r *AggregateRepo) aggregateSessionStats(ctx context.Context, match bson.M) ([]*models.SessionGuestAggregation, error) {
+	cancelled := string(entity.SessionCancelled)
+	checkedIn := string(entity.SessionCheckedIn)
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$lookup", Value: bson.M{
+			"from": "session_registrations",
+			"let":  bson.M{"sid": bson.M{"$toString": "$_id"}},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{"$expr": bson.M{"$and": bson.A{
+					bson.M{"$eq": bson.A{"$session_id", "$$sid"}},
+					bson.M{"$ne": bson.A{"$status", cancelled}},
+				}}}},
+				bson.M{"$project": bson.M{"status": 1}},
+			},
+			"as": "regs",
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"event_id":     1,
+			"title":        1,
+			"room":         1,
+			"start_time":   1,
+			"end_time":     1,
+			"capacity":     1,
+			"total_guests": bson.M{"$size": "$regs"},
+			"checked_in": bson.M{"$size": bson.M{"$filter": bson.M{
+				"input": "$regs",
+				"as":    "r",
+				"cond":  bson.M{"$eq": bson.A{"$$r.status", checkedIn}},
+			}}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "start_time", Value: 1}, {Key: "_id", Value: 1}}}},
+	}
+
+	cursor, err := r.sessions.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []*models.SessionGuestAggregation
+	for cursor.Next(ctx) {
+		var agg models.SessionGuestAggregation
+		if err := cursor.Decode(&agg); err != nil {
+			return nil, err
+		}
+		results = append(results, &agg)
+	}
+	return results, cursor.Err()
+}
@@ -0,0 +1,78 @@
+package repository_imple
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	repository_interface "event_manager/internal/domain/repository"
+	"event_manager/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CalendarTokenRepoImpl lưu token feed lịch (dạng hash) trong collection "calendar_tokens"
+type CalendarTokenRepoImpl struct {
+	col *mongo.Collection
+}
+
+// ✅ Khởi tạo repository, đảm bảo index cho token_hash và chủ thể
+func NewCalendarTokenMongoRepository(db *mongo.Database) repository_interface.CalendarTokenRepository {
+	col := db.Collection("calendar_tokens")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, _ = col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "token_hash", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "subject_type", Value: 1}, {Key: "subject_id", Value: 1}}},
+	})
+
+	return &CalendarTokenRepoImpl{col: col}
+}
+
+// Insert thêm token mới
+func (r *CalendarTokenRepoImpl) Insert(ctx context.Context, m *models.CalendarTokenModel) error {
+	if m == nil {
+		return errors.New("calendar token model is nil")
+	}
+	if m.ID.IsZero() {
+		m.ID = primitive.NewObjectID()
+	}
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = time.Now()
+	}
+	_, err := r.col.InsertOne(ctx, m)
+	return err
+}
+
+// FindByHash tìm token theo hash
+func (r *CalendarTokenRepoImpl) FindByHash(ctx context.Context, tokenHash string) (*models.CalendarTokenModel, error) {
+	var m models.CalendarTokenModel
+	err := r.col.FindOne(ctx, bson.M{"token_hash": tokenHash}).Decode(&m)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &m, nil
+}
+
+// RevokeBySubject thu hồi mọi token còn hiệu lực của chủ thể
+func (r *CalendarTokenRepoImpl) RevokeBySubject(ctx context.Context, subjectType, subjectID string) error {
+	filter := bson.M{"subject_type": subjectType, "subject_id": subjectID, "revoked_at": bson.M{"$exists": false}}
+	update := bson.M{"$set": bson.M{"revoked_at": time.Now()}}
+
+	_, err := r.col.UpdateMany(ctx, filter, update)
+	return err
+}
+
+// ReassignSubject chuyển token của chủ thể cũ sang chủ thể mới (dùng khi gộp khách trùng)
+func (r *CalendarTokenRepoImpl) ReassignSubject(ctx context.Context, subjectType, fromID, toID string) error {
+	filter := bson.M{"subject_type": subjectType, "subject_id": fromID}
+	_, err := r.col.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"subject_id": toID}})
+	return err
+}
@@ -0,0 +1,66 @@
+package repository_imple
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	repository_interface "event_manager/internal/domain/repository"
+	"event_manager/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// checkInSyncTTL là thời gian giữ khoá idempotency; thiết bị phải đồng bộ trong khoảng này
+const checkInSyncTTL = 30 * 24 * time.Hour
+
+// CheckInSyncRepoImpl lưu kết quả đồng bộ check-in offline trong collection "check_in_sync_ops"
+type CheckInSyncRepoImpl struct {
+	col *mongo.Collection
+}
+
+// ✅ Khởi tạo repository, bản ghi cũ được MongoDB tự xoá sau checkInSyncTTL
+func NewCheckInSyncMongoRepository(db *mongo.Database) repository_interface.CheckInSyncRepository {
+	col := db.Collection("check_in_sync_ops")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, _ = col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "synced_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(int32(checkInSyncTTL.Seconds()))},
+		{Keys: bson.D{{Key: "registration_id", Value: 1}, {Key: "client_time", Value: 1}}},
+	})
+
+	return &CheckInSyncRepoImpl{col: col}
+}
+
+// FindByIDs tìm các thao tác đã đồng bộ
+func (r *CheckInSyncRepoImpl) FindByIDs(ctx context.Context, ids []string) ([]*models.CheckInSyncOpModel, error) {
+	if len(ids) == 0 {
+		return []*models.CheckInSyncOpModel{}, nil
+	}
+	cur, err := r.col.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var result []*models.CheckInSyncOpModel
+	if err := cur.All(ctx, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Insert ghi kết quả một thao tác; hai lần đồng bộ song song cùng thao tác chỉ giữ bản ghi đầu
+func (r *CheckInSyncRepoImpl) Insert(ctx context.Context, m *models.CheckInSyncOpModel) error {
+	if m == nil {
+		return errors.New("check-in sync op model is nil")
+	}
+	_, err := r.col.InsertOne(ctx, m)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+	return err
+}
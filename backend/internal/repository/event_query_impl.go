@@ -0,0 +1,183 @@
+package repository_imple
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"event_manager/internal/domain/entity"
+	repository_interface "event_manager/internal/domain/repository"
+	"event_manager/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	mongooptions "go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// eventCursor là vị trí của bản ghi cuối trang: giá trị trường sắp xếp và _id
+type eventCursor struct {
+	Value json.RawMessage `json:"v"`
+	ID    string          `json:"id"`
+}
+
+// 🔎 FindByQuery — lọc, sắp xếp, phân trang ngay trong Mongo
+func (r *EventRepoImpl) FindByQuery(ctx context.Context, q entity.EventQuery) ([]*models.EventModel, int64, string, error) {
+	q.Normalize()
+
+	filter := eventQueryFilter(q, time.Now())
+	total, err := r.col.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, "", err
+	}
+
+	dir := 1
+	if q.SortDesc {
+		dir = -1
+	}
+	opts := mongooptions.Find().
+		SetSort(bson.D{{Key: q.SortBy, Value: dir}, {Key: "_id", Value: dir}}).
+		SetLimit(int64(q.PageSize) + 1)
+
+	pageFilter := filter
+	if q.Cursor != "" {
+		after, err := decodeEventCursor(q.Cursor, q.SortBy, q.SortDesc)
+		if err != nil {
+			return nil, 0, "", err
+		}
+		pageFilter = bson.M{"$and": bson.A{filter, after}}
+	} else if q.Page > 1 {
+		opts.SetSkip(int64(q.Page-1) * int64(q.PageSize))
+	}
+
+	cursor, err := r.col.Find(ctx, pageFilter, opts)
+	if err != nil {
+		return nil, 0, "", err
+	}
+	defer cursor.Close(ctx)
+
+	var events []*models.EventModel
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, 0, "", err
+	}
+
+	next := ""
+	if len(events) > q.PageSize {
+		events = events[:q.PageSize]
+		next, err = encodeEventCursor(events[len(events)-1], q.SortBy)
+		if err != nil {
+			return nil, 0, "", err
+		}
+	}
+
+	for _, e := range events {
+		e.Status = getStatusByTime(e.StartDate, e.EndDate)
+	}
+	return events, total, next, nil
+}
+
+// eventQueryFilter dựng điều kiện lọc; trạng thái được quy về điều kiện thời gian
+// vì nó được tính từ start_date / end_date chứ không lưu cố định.
+func eventQueryFilter(q entity.EventQuery, now time.Time) bson.M {
+	var conds bson.A
+
+	switch q.Status {
+	case entity.EventStatusUpcoming:
+		conds = append(conds, bson.M{"start_date": bson.M{"$gt": now}})
+	case entity.EventStatusOngoing:
+		conds = append(conds, bson.M{"start_date": bson.M{"$lte": now}, "end_date": bson.M{"$gte": now}})
+	case entity.EventStatusEnded:
+		conds = append(conds, bson.M{"end_date": bson.M{"$lt": now}})
+	}
+
+	if t := strings.TrimSpace(q.Type); t != "" {
+		conds = append(conds, bson.M{"type": t})
+	}
+	if id := strings.TrimSpace(q.LocationID); id != "" {
+		conds = append(conds, bson.M{"location_id": id})
+	}
+	if loc := strings.TrimSpace(q.Location); loc != "" {
+		conds = append(conds, bson.M{"location": loc})
+	}
+	if !q.From.IsZero() {
+		conds = append(conds, bson.M{"start_date": bson.M{"$gte": q.From}})
+	}
+	if !q.To.IsZero() {
+		conds = append(conds, bson.M{"end_date": bson.M{"$lte": q.To}})
+	}
+	if text := strings.TrimSpace(q.Text); text != "" {
+		conds = append(conds, bson.M{"$text": bson.M{"$search": text}})
+	}
+	if q.OrganizerID != "" {
+		conds = append(conds, bson.M{"$or": bson.A{
+			bson.M{"owner_id": q.OrganizerID},
+			bson.M{"co_organizers.user_id": q.OrganizerID},
+		}})
+	}
+
+	if len(conds) == 0 {
+		return bson.M{}
+	}
+	return bson.M{"$and": conds}
+}
+
+// encodeEventCursor mã hoá vị trí của sự kiện cuối trang
+func encodeEventCursor(last *models.EventModel, sortBy string) (string, error) {
+	var value interface{}
+	switch sortBy {
+	case entity.EventSortName:
+		value = last.Name
+	case entity.EventSortEndDate:
+		value = last.EndDate
+	case entity.EventSortCreatedAt:
+		value = last.CreatedAt
+	default:
+		value = last.StartDate
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(eventCursor{Value: raw, ID: last.ID})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeEventCursor trả về điều kiện "sau vị trí con trỏ" theo thứ tự (sortBy, _id)
+func decodeEventCursor(token, sortBy string, desc bool) (bson.M, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, repository_interface.ErrInvalidCursor
+	}
+	var c eventCursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == "" {
+		return nil, repository_interface.ErrInvalidCursor
+	}
+
+	var value interface{}
+	if sortBy == entity.EventSortName {
+		var s string
+		if err := json.Unmarshal(c.Value, &s); err != nil {
+			return nil, repository_interface.ErrInvalidCursor
+		}
+		value = s
+	} else {
+		var t time.Time
+		if err := json.Unmarshal(c.Value, &t); err != nil {
+			return nil, repository_interface.ErrInvalidCursor
+		}
+		value = t
+	}
+
+	op := "$gt"
+	if desc {
+		op = "$lt"
+	}
+	return bson.M{"$or": bson.A{
+		bson.M{sortBy: bson.M{op: value}},
+		bson.M{sortBy: value, "_id": bson.M{op: c.ID}},
+	}}, nil
+}
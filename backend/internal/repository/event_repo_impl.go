@@ -3,6 +3,7 @@ package repository_imple
 import (
 	"context"
 	"errors"
+	"event_manager/internal/domain/entity"
 	repository_interface "event_manager/internal/domain/repository"
 	"event_manager/internal/models"
 	"time"
@@ -20,7 +21,38 @@ type EventRepoImpl struct {
 
 // ✅ Khởi tạo repository Mongo
 func NewEventMongoRepository(db *mongo.Database) repository_interface.EventRepository {
-	return &EventRepoImpl{col: db.Collection("events")}
+	col := db.Collection("events")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, _ = col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "owner_id", Value: 1}}},
+		{Keys: bson.D{{Key: "co_organizers.user_id", Value: 1}}},
+		// Phục vụ FindByQuery: sắp xếp/keyset theo (field, _id) và các bộ lọc phổ biến
+		{Keys: bson.D{{Key: "start_date", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "end_date", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "type", Value: 1}, {Key: "start_date", Value: 1}}},
+		{Keys: bson.D{{Key: "location", Value: 1}, {Key: "start_date", Value: 1}}},
+		{Keys: bson.D{{Key: "location_id", Value: 1}, {Key: "start_date", Value: 1}}},
+		{Keys: bson.D{{Key: "series_id", Value: 1}, {Key: "occurrence_start", Value: 1}}},
+		{
+			Keys:    bson.D{{Key: "ical_uid", Value: 1}},
+			Options: mongooptions.Index().SetSparse(true),
+		},
+		{
+			Keys: bson.D{
+				{Key: "name", Value: "text"},
+				{Key: "description", Value: "text"},
+				{Key: "location", Value: "text"},
+			},
+			// Tiếng Việt không có stemmer, tách từ theo khoảng trắng
+			Options: mongooptions.Index().SetDefaultLanguage("none").SetName("events_text"),
+		},
+	})
+
+	return &EventRepoImpl{col: col}
 }
 
 // =======================================
@@ -45,6 +77,27 @@ func (r *EventRepoImpl) Insert(ctx context.Context, m *models.EventModel) error
 	return err
 }
 
+// 🟢 InsertMany — thêm các buổi của một chuỗi sự kiện
+func (r *EventRepoImpl) InsertMany(ctx context.Context, list []*models.EventModel) error {
+	if len(list) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, 0, len(list))
+	for _, m := range list {
+		if m.ID == "" {
+			m.ID = primitive.NewObjectID().Hex()
+		}
+		m.Status = getStatusByTime(m.StartDate, m.EndDate)
+		m.CreatedAt = time.Now()
+		m.UpdatedAt = time.Now()
+		docs = append(docs, m)
+	}
+
+	_, err := r.col.InsertMany(ctx, docs)
+	return err
+}
+
 // 🟡 Update — cập nhật sự kiện
 func (r *EventRepoImpl) Update(ctx context.Context, m *models.EventModel) error {
 	if m.ID == "" {
@@ -58,6 +111,7 @@ func (r *EventRepoImpl) Update(ctx context.Context, m *models.EventModel) error
 			"description": m.Description,
 			"type":        m.Type,
 			"status":      getStatusByTime(m.StartDate, m.EndDate),
+			"location_id": m.LocationID,
 			"location":    m.Location,
 			"max_guests":  m.MaxGuests,
 			"start_date":  m.StartDate,
@@ -114,6 +168,128 @@ func (r *EventRepoImpl) FindAll(ctx context.Context) ([]*models.EventModel, erro
 	return events, nil
 }
 
+// 🗂️ FindByIDs — lấy nhiều sự kiện theo danh sách ID, sắp xếp theo ngày bắt đầu
+func (r *EventRepoImpl) FindByIDs(ctx context.Context, ids []string) ([]*models.EventModel, error) {
+	if len(ids) == 0 {
+		return []*models.EventModel{}, nil
+	}
+
+	filter := bson.M{"_id": bson.M{"$in": ids}}
+	opts := mongooptions.Find().SetSort(bson.D{{Key: "start_date", Value: 1}})
+
+	cursor, err := r.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var events []*models.EventModel
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+
+	for _, e := range events {
+		e.Status = getStatusByTime(e.StartDate, e.EndDate)
+	}
+	return events, nil
+}
+
+// 📍 CountByLocation — số sự kiện đang dùng địa điểm
+func (r *EventRepoImpl) CountByLocation(ctx context.Context, locationID string) (int64, error) {
+	return r.col.CountDocuments(ctx, bson.M{"location_id": locationID})
+}
+
+// 📍 FindByLocationBetween — sự kiện tại địa điểm giao với khoảng [from, to)
+func (r *EventRepoImpl) FindByLocationBetween(ctx context.Context, locationID string, from, to time.Time) ([]*models.EventModel, error) {
+	filter := bson.M{
+		"location_id": locationID,
+		"start_date":  bson.M{"$lt": to},
+		"end_date":    bson.M{"$gt": from},
+	}
+	opts := mongooptions.Find().SetSort(bson.D{{Key: "start_date", Value: 1}})
+
+	cursor, err := r.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var events []*models.EventModel
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	for _, e := range events {
+		e.Status = getStatusByTime(e.StartDate, e.EndDate)
+	}
+	return events, nil
+}
+
+// 🔁 FindBySeries — các buổi của chuỗi sự kiện, theo thời điểm gốc
+func (r *EventRepoImpl) FindBySeries(ctx context.Context, seriesID string) ([]*models.EventModel, error) {
+	opts := mongooptions.Find().SetSort(bson.D{{Key: "occurrence_start", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := r.col.Find(ctx, bson.M{"series_id": seriesID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var events []*models.EventModel
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	for _, e := range events {
+		e.Status = getStatusByTime(e.StartDate, e.EndDate)
+	}
+	return events, nil
+}
+
+// 📥 FindByICalUID — các sự kiện được nhập từ VEVENT có UID này
+func (r *EventRepoImpl) FindByICalUID(ctx context.Context, uid string) ([]*models.EventModel, error) {
+	opts := mongooptions.Find().SetSort(bson.D{{Key: "start_date", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := r.col.Find(ctx, bson.M{"ical_uid": uid}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var events []*models.EventModel
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	for _, e := range events {
+		e.Status = getStatusByTime(e.StartDate, e.EndDate)
+	}
+	return events, nil
+}
+
+// 🔁 SetOccurrence — gắn sự kiện vào chuỗi với thời điểm gốc của buổi
+func (r *EventRepoImpl) SetOccurrence(ctx context.Context, id, seriesID string, occurrenceStart time.Time) error {
+	if id == "" {
+		return errors.New("missing event ID")
+	}
+	update := bson.M{"$set": bson.M{"series_id": seriesID, "occurrence_start": occurrenceStart}}
+	_, err := r.col.UpdateOne(ctx, bson.M{"_id": id}, update)
+	return err
+}
+
+// 📍 SetLocation — gắn sự kiện với địa điểm và cập nhật tên hiển thị
+func (r *EventRepoImpl) SetLocation(ctx context.Context, id, locationID, name string) error {
+	if id == "" {
+		return errors.New("missing event ID")
+	}
+	update := bson.M{"$set": bson.M{"location_id": locationID, "location": name}}
+	_, err := r.col.UpdateOne(ctx, bson.M{"_id": id}, update)
+	return err
+}
+
+// 📍 RenameLocation — đồng bộ tên hiển thị cho mọi sự kiện của địa điểm
+func (r *EventRepoImpl) RenameLocation(ctx context.Context, locationID, name string) error {
+	_, err := r.col.UpdateMany(ctx, bson.M{"location_id": locationID}, bson.M{"$set": bson.M{"location": name}})
+	return err
+}
+
 // ⏳ FindUpcoming — các sự kiện sắp diễn ra
 func (r *EventRepoImpl) FindUpcoming(ctx context.Context) ([]*models.EventModel, error) {
 	filter := bson.M{"start_date": bson.M{"$gte": time.Now()}}
@@ -136,6 +312,99 @@ func (r *EventRepoImpl) FindUpcoming(ctx context.Context) ([]*models.EventModel,
 	return events, nil
 }
 
+// 👤 FindByOrganizer — sự kiện mà user là chủ hoặc đồng tổ chức
+func (r *EventRepoImpl) FindByOrganizer(ctx context.Context, userID string) ([]*models.EventModel, error) {
+	if userID == "" {
+		return nil, errors.New("missing user ID")
+	}
+
+	filter := bson.M{"$or": bson.A{
+		bson.M{"owner_id": userID},
+		bson.M{"co_organizers.user_id": userID},
+	}}
+	opts := mongooptions.Find().SetSort(bson.D{{Key: "start_date", Value: -1}})
+
+	cursor, err := r.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var events []*models.EventModel
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+
+	for _, e := range events {
+		e.Status = getStatusByTime(e.StartDate, e.EndDate)
+	}
+	return events, nil
+}
+
+// 👥 UpdateOrganizers — đổi chủ sự kiện và danh sách đồng tổ chức
+func (r *EventRepoImpl) UpdateOrganizers(ctx context.Context, id string, ownerID string, coOrganizers []models.CoOrganizerModel) error {
+	if id == "" {
+		return errors.New("missing event ID")
+	}
+	if coOrganizers == nil {
+		coOrganizers = []models.CoOrganizerModel{}
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"owner_id":      ownerID,
+			"co_organizers": coOrganizers,
+			"updated_at":    time.Now(),
+		},
+	}
+
+	res, err := r.col.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return errors.New("event not found")
+	}
+	return nil
+}
+
+// 🪑 InitSeatCounter — khởi tạo seats_taken cho sự kiện cũ chưa có bộ đếm
+func (r *EventRepoImpl) InitSeatCounter(ctx context.Context, id string, taken int) error {
+	filter := bson.M{"_id": id, "seats_taken": bson.M{"$exists": false}}
+	_, err := r.col.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"seats_taken": taken}})
+	return err
+}
+
+// 🪑 SetSeatCounter — ghi đè seats_taken (sự kiện mở không đếm chỗ nên phải đếm lại khi chuyển sang giới hạn)
+func (r *EventRepoImpl) SetSeatCounter(ctx context.Context, id string, taken int) error {
+	_, err := r.col.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"seats_taken": taken}})
+	return err
+}
+
+// 🪑 ReserveSeat — giữ 1 chỗ nếu còn trống; điều kiện và $inc nằm trong cùng
+// một lệnh update nên các request đồng thời không thể vượt max_guests
+func (r *EventRepoImpl) ReserveSeat(ctx context.Context, id string) (bool, error) {
+	filter := bson.M{
+		"_id": id,
+		"$expr": bson.M{"$lt": bson.A{
+			bson.M{"$ifNull": bson.A{"$seats_taken", 0}},
+			"$max_guests",
+		}},
+	}
+	res, err := r.col.UpdateOne(ctx, filter, bson.M{"$inc": bson.M{"seats_taken": 1}})
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+// 🪑 ReleaseSeat — trả lại 1 chỗ
+func (r *EventRepoImpl) ReleaseSeat(ctx context.Context, id string) error {
+	filter := bson.M{"_id": id, "seats_taken": bson.M{"$gt": 0}}
+	_, err := r.col.UpdateOne(ctx, filter, bson.M{"$inc": bson.M{"seats_taken": -1}})
+	return err
+}
+
 // =======================================
 // ⚙️ Helper functions
 // =======================================
@@ -145,10 +414,10 @@ func getStatusByTime(start, end time.Time) string {
 	now := time.Now()
 	switch {
 	case now.Before(start):
-		return "Sắp diễn ra"
+		return entity.EventStatusUpcoming
 	case now.After(end):
-		return "Đã kết thúc"
+		return entity.EventStatusEnded
 	default:
-		return "Đang diễn ra"
+		return entity.EventStatusOngoing
 	}
 }
@@ -0,0 +1,68 @@
+package repository_imple
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	repository_interface "event_manager/internal/domain/repository"
+	"event_manager/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// EventSeriesRepoImpl thao tác collection "event_series"
+type EventSeriesRepoImpl struct {
+	col *mongo.Collection
+}
+
+// ✅ Hàm khởi tạo
+func NewEventSeriesMongoRepository(db *mongo.Database) repository_interface.EventSeriesRepository {
+	return &EventSeriesRepoImpl{col: db.Collection("event_series")}
+}
+
+// Thêm mới chuỗi sự kiện
+func (r *EventSeriesRepoImpl) Insert(ctx context.Context, m *models.EventSeriesModel) error {
+	if m == nil || m.ID == "" {
+		return errors.New("missing series ID")
+	}
+	m.CreatedAt = time.Now()
+	m.UpdatedAt = time.Now()
+	_, err := r.col.InsertOne(ctx, m)
+	return err
+}
+
+// Cập nhật quy tắc lặp / EXDATE của chuỗi
+func (r *EventSeriesRepoImpl) Update(ctx context.Context, m *models.EventSeriesModel) error {
+	if m == nil || m.ID == "" {
+		return errors.New("missing series ID")
+	}
+	update := bson.M{"$set": bson.M{
+		"dtstart":    m.DTStart,
+		"rrule":      m.RRule,
+		"exdates":    m.ExDates,
+		"updated_at": time.Now(),
+	}}
+	_, err := r.col.UpdateOne(ctx, bson.M{"_id": m.ID}, update)
+	return err
+}
+
+// Xoá chuỗi sự kiện
+func (r *EventSeriesRepoImpl) Delete(ctx context.Context, id string) error {
+	_, err := r.col.DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
+
+// Tìm chuỗi sự kiện theo ID
+func (r *EventSeriesRepoImpl) FindByID(ctx context.Context, id string) (*models.EventSeriesModel, error) {
+	var result models.EventSeriesModel
+	err := r.col.FindOne(ctx, bson.M{"_id": id}).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &result, nil
+}
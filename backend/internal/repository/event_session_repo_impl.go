@@ -0,0 +1,289 @@
+package repository_imple
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"event_manager/internal/domain/entity"
+	repository_interface "event_manager/internal/domain/repository"
+	"event_manager/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// EventSessionRepoImpl thao tác collection "event_sessions"
+type EventSessionRepoImpl struct {
+	col *mongo.Collection
+}
+
+// ✅ Khởi tạo repository và index theo chương trình của sự kiện
+func NewEventSessionMongoRepository(db *mongo.Database) repository_interface.EventSessionRepository {
+	col := db.Collection("event_sessions")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, _ = col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "event_id", Value: 1}, {Key: "start_time", Value: 1}}},
+	})
+
+	return &EventSessionRepoImpl{col: col}
+}
+
+// Thêm phiên mới
+func (r *EventSessionRepoImpl) Insert(ctx context.Context, m *models.EventSessionModel) error {
+	if m == nil {
+		return errors.New("event session model is nil")
+	}
+	if m.ID.IsZero() {
+		m.ID = primitive.NewObjectID()
+	}
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = time.Now()
+	}
+	_, err := r.col.InsertOne(ctx, m)
+	return err
+}
+
+// Cập nhật thông tin phiên, không đụng tới bộ đếm chỗ
+func (r *EventSessionRepoImpl) Update(ctx context.Context, m *models.EventSessionModel) error {
+	if m == nil || m.ID.IsZero() {
+		return errors.New("missing event session ID")
+	}
+	if m.UpdatedAt.IsZero() {
+		m.UpdatedAt = time.Now()
+	}
+	update := bson.M{"$set": bson.M{
+		"title":       m.Title,
+		"description": m.Description,
+		"room":        m.Room,
+		"speakers":    m.Speakers,
+		"start_time":  m.StartTime,
+		"end_time":    m.EndTime,
+		"capacity":    m.Capacity,
+		"updated_at":  m.UpdatedAt,
+	}}
+	_, err := r.col.UpdateOne(ctx, bson.M{"_id": m.ID}, update)
+	return err
+}
+
+// Xoá phiên
+func (r *EventSessionRepoImpl) Delete(ctx context.Context, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	_, err = r.col.DeleteOne(ctx, bson.M{"_id": objID})
+	return err
+}
+
+// Tìm phiên theo ID
+func (r *EventSessionRepoImpl) FindByID(ctx context.Context, id string) (*models.EventSessionModel, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil
+	}
+	var result models.EventSessionModel
+	err = r.col.FindOne(ctx, bson.M{"_id": objID}).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Lấy nhiều phiên theo danh sách ID
+func (r *EventSessionRepoImpl) FindByIDs(ctx context.Context, ids []string) ([]*models.EventSessionModel, error) {
+	objIDs := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if objID, err := primitive.ObjectIDFromHex(id); err == nil {
+			objIDs = append(objIDs, objID)
+		}
+	}
+	if len(objIDs) == 0 {
+		return []*models.EventSessionModel{}, nil
+	}
+	return r.find(ctx, bson.M{"_id": bson.M{"$in": objIDs}})
+}
+
+// Chương trình của sự kiện, sắp theo giờ bắt đầu
+func (r *EventSessionRepoImpl) FindByEvent(ctx context.Context, eventID string) ([]*models.EventSessionModel, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "start_time", Value: 1}, {Key: "_id", Value: 1}})
+	return r.find(ctx, bson.M{"event_id": eventID}, opts)
+}
+
+// 🪑 ReserveSeat — giữ 1 chỗ; phiên không giới hạn (capacity = 0) luôn còn chỗ nhưng vẫn được đếm
+func (r *EventSessionRepoImpl) ReserveSeat(ctx context.Context, id string) (bool, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, err
+	}
+	filter := bson.M{
+		"_id": objID,
+		"$or": bson.A{
+			bson.M{"capacity": bson.M{"$lte": 0}},
+			bson.M{"$expr": bson.M{"$lt": bson.A{bson.M{"$ifNull": bson.A{"$seats_taken", 0}}, "$capacity"}}},
+		},
+	}
+	res, err := r.col.UpdateOne(ctx, filter, bson.M{"$inc": bson.M{"seats_taken": 1}})
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+// 🪑 ReleaseSeat — trả lại 1 chỗ
+func (r *EventSessionRepoImpl) ReleaseSeat(ctx context.Context, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	filter := bson.M{"_id": objID, "seats_taken": bson.M{"$gt": 0}}
+	_, err = r.col.UpdateOne(ctx, filter, bson.M{"$inc": bson.M{"seats_taken": -1}})
+	return err
+}
+
+func (r *EventSessionRepoImpl) find(ctx context.Context, filter bson.M, opts ...*options.FindOptions) ([]*models.EventSessionModel, error) {
+	cur, err := r.col.Find(ctx, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	sessions := make([]*models.EventSessionModel, 0)
+	if err := cur.All(ctx, &sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// SessionRegistrationRepoImpl thao tác collection "session_registrations"
+type SessionRegistrationRepoImpl struct {
+	col *mongo.Collection
+}
+
+// ✅ Khởi tạo repository; mỗi đăng ký sự kiện chỉ có một bản ghi cho mỗi phiên
+func NewSessionRegistrationMongoRepository(db *mongo.Database) repository_interface.SessionRegistrationRepository {
+	col := db.Collection("session_registrations")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, _ = col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "session_id", Value: 1}, {Key: "registration_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "registration_id", Value: 1}}},
+		{Keys: bson.D{{Key: "event_id", Value: 1}}},
+	})
+
+	return &SessionRegistrationRepoImpl{col: col}
+}
+
+// Thêm đăng ký phiên
+func (r *SessionRegistrationRepoImpl) Insert(ctx context.Context, m *models.SessionRegistrationModel) error {
+	if m == nil {
+		return errors.New("session registration model is nil")
+	}
+	if m.ID.IsZero() {
+		m.ID = primitive.NewObjectID()
+	}
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = time.Now()
+	}
+	_, err := r.col.InsertOne(ctx, m)
+	if mongo.IsDuplicateKeyError(err) {
+		return repository_interface.ErrDuplicate
+	}
+	return err
+}
+
+// Tìm bản ghi của một đăng ký sự kiện trong một phiên
+func (r *SessionRegistrationRepoImpl) FindBySessionAndRegistration(ctx context.Context, sessionID, registrationID string) (*models.SessionRegistrationModel, error) {
+	var result models.SessionRegistrationModel
+	err := r.col.FindOne(ctx, bson.M{"session_id": sessionID, "registration_id": registrationID}).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Danh sách đăng ký còn hiệu lực của phiên, cũ nhất trước
+func (r *SessionRegistrationRepoImpl) FindBySession(ctx context.Context, sessionID string) ([]*models.SessionRegistrationModel, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+	return r.find(ctx, bson.M{"session_id": sessionID, "status": activeSessionStatus()}, opts)
+}
+
+// Các phiên còn hiệu lực mà một đăng ký sự kiện đã chọn
+func (r *SessionRegistrationRepoImpl) FindByRegistration(ctx context.Context, registrationID string) ([]*models.SessionRegistrationModel, error) {
+	return r.find(ctx, bson.M{"registration_id": registrationID, "status": activeSessionStatus()})
+}
+
+// Đếm đăng ký còn hiệu lực của phiên
+func (r *SessionRegistrationRepoImpl) CountActiveBySession(ctx context.Context, sessionID string) (int, error) {
+	count, err := r.col.CountDocuments(ctx, bson.M{"session_id": sessionID, "status": activeSessionStatus()})
+	return int(count), err
+}
+
+// Đăng ký lại phiên đã huỷ
+func (r *SessionRegistrationRepoImpl) Reactivate(ctx context.Context, id string, at time.Time) (bool, error) {
+	return r.transition(ctx, id, bson.M{"status": string(entity.SessionCancelled)}, bson.M{
+		"$set":   bson.M{"status": string(entity.SessionRegistered), "created_at": at},
+		"$unset": bson.M{"cancelled_at": "", "checked_in_at": ""},
+	})
+}
+
+// Huỷ đăng ký phiên
+func (r *SessionRegistrationRepoImpl) Cancel(ctx context.Context, id string, at time.Time) (bool, error) {
+	return r.transition(ctx, id, bson.M{"status": activeSessionStatus()}, bson.M{
+		"$set": bson.M{"status": string(entity.SessionCancelled), "cancelled_at": at},
+	})
+}
+
+// Check-in vào phiên
+func (r *SessionRegistrationRepoImpl) CheckIn(ctx context.Context, id string, at time.Time) (bool, error) {
+	return r.transition(ctx, id, bson.M{"status": string(entity.SessionRegistered)}, bson.M{
+		"$set": bson.M{"status": string(entity.SessionCheckedIn), "checked_in_at": at},
+	})
+}
+
+// transition cập nhật có điều kiện (compare-and-set) theo trạng thái hiện tại
+func (r *SessionRegistrationRepoImpl) transition(ctx context.Context, id string, filter, update bson.M) (bool, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, err
+	}
+	filter["_id"] = objID
+	res, err := r.col.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+func (r *SessionRegistrationRepoImpl) find(ctx context.Context, filter bson.M, opts ...*options.FindOptions) ([]*models.SessionRegistrationModel, error) {
+	cur, err := r.col.Find(ctx, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	regs := make([]*models.SessionRegistrationModel, 0)
+	if err := cur.All(ctx, &regs); err != nil {
+		return nil, err
+	}
+	return regs, nil
+}
+
+// activeSessionStatus khớp các đăng ký phiên chưa bị huỷ
+func activeSessionStatus() bson.M {
+	return bson.M{"$ne": string(entity.SessionCancelled)}
+}
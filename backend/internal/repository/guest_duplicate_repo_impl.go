@@ -0,0 +1,120 @@
+package repository_imple
+
+import (
+	"context"
+	"time"
+
+	"event_manager/internal/domain/entity"
+	repository_interface "event_manager/internal/domain/repository"
+	"event_manager/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GuestDuplicateRepoImpl lưu các cặp khách nghi trùng trong collection "guest_duplicates"
+type GuestDuplicateRepoImpl struct {
+	col *mongo.Collection
+}
+
+// ✅ Khởi tạo repository, index phục vụ sắp theo điểm và xoá theo khách
+func NewGuestDuplicateMongoRepository(db *mongo.Database) repository_interface.GuestDuplicateRepository {
+	col := db.Collection("guest_duplicates")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, _ = col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "dismissed", Value: 1}, {Key: "score", Value: -1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "guest_ids", Value: 1}}},
+		{Keys: bson.D{{Key: "scan_id", Value: 1}}},
+	})
+
+	return &GuestDuplicateRepoImpl{col: col}
+}
+
+// UpsertMany ghi các cặp của một lần quét; cờ dismissed của cặp đã có được giữ nguyên
+func (r *GuestDuplicateRepoImpl) UpsertMany(ctx context.Context, pairs []*models.GuestDuplicateModel) error {
+	if len(pairs) == 0 {
+		return nil
+	}
+	writes := make([]mongo.WriteModel, 0, len(pairs))
+	for _, p := range pairs {
+		update := bson.M{
+			"$set": bson.M{
+				"guest_ids":       p.GuestIDs,
+				"reasons":         p.Reasons,
+				"name_similarity": p.NameSimilarity,
+				"score":           p.Score,
+				"scan_id":         p.ScanID,
+				"detected_at":     p.DetectedAt,
+			},
+			"$setOnInsert": bson.M{"dismissed": false},
+		}
+		writes = append(writes, mongo.NewUpdateOneModel().SetFilter(bson.M{"_id": p.ID}).SetUpdate(update).SetUpsert(true))
+	}
+	_, err := r.col.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+// DeleteStale xoá các cặp không còn được lần quét scanID phát hiện
+func (r *GuestDuplicateRepoImpl) DeleteStale(ctx context.Context, scanID string) error {
+	_, err := r.col.DeleteMany(ctx, bson.M{"scan_id": bson.M{"$ne": scanID}})
+	return err
+}
+
+// Search lọc theo dấu hiệu / điểm, sắp điểm giảm dần, có phân trang
+func (r *GuestDuplicateRepoImpl) Search(ctx context.Context, q entity.GuestDuplicateQuery) ([]*models.GuestDuplicateModel, int64, error) {
+	q.Normalize()
+
+	filter := bson.M{}
+	if !q.IncludeDismissed {
+		filter["dismissed"] = false
+	}
+	if q.Reason != "" {
+		filter["reasons"] = q.Reason
+	}
+	if q.MinScore > 0 {
+		filter["score"] = bson.M{"$gte": q.MinScore}
+	}
+
+	total, err := r.col.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "score", Value: -1}, {Key: "_id", Value: 1}}).
+		SetSkip(int64(q.Page-1) * int64(q.PageSize)).
+		SetLimit(int64(q.PageSize))
+
+	cur, err := r.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cur.Close(ctx)
+
+	pairs := make([]*models.GuestDuplicateModel, 0, q.PageSize)
+	if err := cur.All(ctx, &pairs); err != nil {
+		return nil, 0, err
+	}
+	return pairs, total, nil
+}
+
+// SetDismissed đánh dấu cặp là (không) trùng
+func (r *GuestDuplicateRepoImpl) SetDismissed(ctx context.Context, pairID string, dismissed bool) (bool, error) {
+	res, err := r.col.UpdateOne(ctx, bson.M{"_id": pairID}, bson.M{"$set": bson.M{"dismissed": dismissed}})
+	if err != nil {
+		return false, err
+	}
+	return res.MatchedCount > 0, nil
+}
+
+// DeleteByGuests xoá mọi cặp có một trong các khách
+func (r *GuestDuplicateRepoImpl) DeleteByGuests(ctx context.Context, guestIDs []string) error {
+	if len(guestIDs) == 0 {
+		return nil
+	}
+	_, err := r.col.DeleteMany(ctx, bson.M{"guest_ids": bson.M{"$in": guestIDs}})
+	return err
+}
@@ -0,0 +1,120 @@
+package repository_imple
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"event_manager/internal/domain/entity"
+	repository_interface "event_manager/internal/domain/repository"
+	"event_manager/internal/models"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// guestImportJobTTL là thời gian giữ báo cáo nhập khách
+const guestImportJobTTL = 7 * 24 * time.Hour
+
+// GuestImportJobRepoImpl lưu tiến độ và báo cáo nhập khách trong collection "guest_import_jobs"
+type GuestImportJobRepoImpl struct {
+	col *mongo.Collection
+}
+
+// ✅ Khởi tạo repository, job cũ được MongoDB tự xoá sau guestImportJobTTL
+func NewGuestImportJobMongoRepository(db *mongo.Database) repository_interface.GuestImportJobRepository {
+	col := db.Collection("guest_import_jobs")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, _ = col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "created_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(int32(guestImportJobTTL.Seconds()))},
+		{Keys: bson.D{{Key: "event_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	})
+
+	return &GuestImportJobRepoImpl{col: col}
+}
+
+// Insert thêm job mới
+func (r *GuestImportJobRepoImpl) Insert(ctx context.Context, m *models.GuestImportJobModel) error {
+	if m == nil {
+		return errors.New("guest import job model is nil")
+	}
+	if m.ID == "" {
+		m.ID = uuid.NewString()
+	}
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = time.Now()
+	}
+	if m.UpdatedAt.IsZero() {
+		m.UpdatedAt = m.CreatedAt
+	}
+	if m.Rows == nil {
+		m.Rows = []models.GuestImportRowModel{}
+	}
+	_, err := r.col.InsertOne(ctx, m)
+	return err
+}
+
+// FindByID tìm job theo ID
+func (r *GuestImportJobRepoImpl) FindByID(ctx context.Context, id string) (*models.GuestImportJobModel, error) {
+	var m models.GuestImportJobModel
+	err := r.col.FindOne(ctx, bson.M{"_id": id}).Decode(&m)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &m, nil
+}
+
+// SaveProgress cập nhật trạng thái, bộ đếm và nối thêm các dòng báo cáo
+func (r *GuestImportJobRepoImpl) SaveProgress(ctx context.Context, m *models.GuestImportJobModel, rows []models.GuestImportRowModel) error {
+	if m == nil {
+		return errors.New("guest import job model is nil")
+	}
+	m.UpdatedAt = time.Now()
+	set := bson.M{
+		"status":     m.Status,
+		"total":      m.Total,
+		"processed":  m.Processed,
+		"created":    m.Created,
+		"reused":     m.Reused,
+		"failed":     m.Failed,
+		"error":      m.Error,
+		"updated_at": m.UpdatedAt,
+	}
+	if m.FinishedAt != nil {
+		set["finished_at"] = m.FinishedAt
+	}
+	update := bson.M{"$set": set}
+	if len(rows) > 0 {
+		update["$push"] = bson.M{"rows": bson.M{"$each": rows}}
+	}
+
+	_, err := r.col.UpdateOne(ctx, bson.M{"_id": m.ID}, update)
+	return err
+}
+
+// FailUnfinished đánh dấu thất bại các job còn pending / running (tiến trình chạy job đã dừng)
+func (r *GuestImportJobRepoImpl) FailUnfinished(ctx context.Context, reason string) (int64, error) {
+	now := time.Now()
+	filter := bson.M{"status": bson.M{"$in": []string{
+		string(entity.GuestImportPending),
+		string(entity.GuestImportRunning),
+	}}}
+	update := bson.M{"$set": bson.M{
+		"status":      string(entity.GuestImportFailed),
+		"error":       reason,
+		"updated_at":  now,
+		"finished_at": now,
+	}}
+	res, err := r.col.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
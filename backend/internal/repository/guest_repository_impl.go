@@ -3,13 +3,19 @@ package repository_imple
 import (
 	"context"
 	"errors"
+	"regexp"
 	"strings"
+	"time"
 
+	"event_manager/internal/domain/entity"
 	models "event_manager/internal/models"
+	utils "event_manager/util"
 
 	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // GuestRepositoryImpl implements GuestRepository interface
@@ -19,9 +25,30 @@ type GuestRepositoryImpl struct {
 
 // NewGuestRepository khởi tạo repository với collection "guests"
 func NewGuestRepository(db *mongo.Database) *GuestRepositoryImpl {
-	return &GuestRepositoryImpl{
-		col: db.Collection("guests"),
-	}
+	col := db.Collection("guests")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, _ = col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "email", Value: 1}}},
+		{Keys: bson.D{{Key: "phone", Value: 1}}},
+		// Tìm theo tiền tố dùng trực tiếp index; tìm "chứa" chỉ quét key trong phạm vi sự kiện
+		{Keys: bson.D{{Key: "event_ids", Value: 1}, {Key: "search_name", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "event_ids", Value: 1}, {Key: "search_email", Value: 1}}},
+		{Keys: bson.D{{Key: "event_ids", Value: 1}, {Key: "search_phone", Value: 1}}},
+		{Keys: bson.D{{Key: "search_name", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "search_email", Value: 1}}},
+		{Keys: bson.D{{Key: "search_phone", Value: 1}}},
+	})
+
+	return &GuestRepositoryImpl{col: col}
+}
+
+// setSearchKeys tính lại các khoá tìm kiếm từ dữ liệu hiển thị
+func setSearchKeys(m *models.GuestModel) {
+	m.SearchName = utils.FoldText(m.FullName)
+	m.SearchEmail = strings.ToLower(strings.TrimSpace(m.Email))
+	m.SearchPhone = utils.PhoneSearchKey(m.Phone)
 }
 
 // Insert thêm khách mời mới
@@ -32,6 +59,7 @@ func (r *GuestRepositoryImpl) Insert(ctx context.Context, m *models.GuestModel)
 	if strings.TrimSpace(m.ID) == "" {
 		m.ID = uuid.NewString()
 	}
+	setSearchKeys(m)
 	_, err := r.col.InsertOne(ctx, m)
 	return err
 }
@@ -45,11 +73,15 @@ func (r *GuestRepositoryImpl) Update(ctx context.Context, m *models.GuestModel)
 		return errors.New("missing guest ID")
 	}
 
+	setSearchKeys(m)
 	update := bson.M{
 		"$set": bson.M{
-			"full_name": m.FullName,
-			"email":     m.Email,
-			"phone":     m.Phone,
+			"full_name":    m.FullName,
+			"email":        m.Email,
+			"phone":        m.Phone,
+			"search_name":  m.SearchName,
+			"search_email": m.SearchEmail,
+			"search_phone": m.SearchPhone,
 		},
 	}
 
@@ -81,10 +113,34 @@ func (r *GuestRepositoryImpl) FindByID(ctx context.Context, id string) (*models.
 	return &result, err
 }
 
-// FindByEmail tìm khách mời theo email
+// FindByIDs lấy nhiều khách mời theo danh sách ID
+func (r *GuestRepositoryImpl) FindByIDs(ctx context.Context, ids []string) ([]*models.GuestModel, error) {
+	if len(ids) == 0 {
+		return []*models.GuestModel{}, nil
+	}
+
+	cur, err := r.col.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	guests := make([]*models.GuestModel, 0, len(ids))
+	if err := cur.All(ctx, &guests); err != nil {
+		return nil, err
+	}
+	return guests, nil
+}
+
+// FindByEmail tìm khách mời theo email, không phân biệt hoa thường / khoảng trắng (khoá search_email;
+// khách cũ cần chạy tác vụ migrate guest-search)
 func (r *GuestRepositoryImpl) FindByEmail(ctx context.Context, email string) (*models.GuestModel, error) {
+	key := strings.ToLower(strings.TrimSpace(email))
+	if key == "" {
+		return nil, nil
+	}
 	var result models.GuestModel
-	err := r.col.FindOne(ctx, bson.M{"email": email}).Decode(&result)
+	err := r.col.FindOne(ctx, bson.M{"search_email": key}).Decode(&result)
 	if err == mongo.ErrNoDocuments {
 		return nil, nil
 	}
@@ -109,3 +165,143 @@ func (r *GuestRepositoryImpl) FindAll(ctx context.Context) ([]*models.GuestModel
 	}
 	return guests, cur.Err()
 }
+
+// FindByPhone tìm khách mời theo số điện thoại đã chuẩn hoá E.164
+func (r *GuestRepositoryImpl) FindByPhone(ctx context.Context, phone string) (*models.GuestModel, error) {
+	phone = strings.TrimSpace(phone)
+	if phone == "" {
+		return nil, nil
+	}
+
+	var result models.GuestModel
+	err := r.col.FindOne(ctx, bson.M{"phone": phone}).Decode(&result)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	return &result, err
+}
+
+// Search tìm khách mời theo tiền tố / chuỗi con trên tên, email, số điện thoại, có phân trang
+func (r *GuestRepositoryImpl) Search(ctx context.Context, q entity.GuestQuery) ([]*models.GuestModel, int64, error) {
+	q.Normalize()
+
+	filter := bson.M{}
+	if eventID := strings.TrimSpace(q.EventID); eventID != "" {
+		filter["event_ids"] = eventID
+	}
+
+	if keyword := strings.TrimSpace(q.Keyword); keyword != "" {
+		var conds bson.A
+		if q.Field == entity.GuestFieldAll || q.Field == entity.GuestFieldName {
+			if v := utils.FoldText(keyword); v != "" {
+				conds = append(conds, bson.M{"search_name": searchPattern(v, q.Match)})
+			}
+		}
+		if q.Field == entity.GuestFieldAll || q.Field == entity.GuestFieldEmail {
+			conds = append(conds, bson.M{"search_email": searchPattern(strings.ToLower(keyword), q.Match)})
+		}
+		if q.Field == entity.GuestFieldAll || q.Field == entity.GuestFieldPhone {
+			if v := utils.PhoneSearchKey(keyword); v != "" {
+				conds = append(conds, bson.M{"search_phone": searchPattern(v, q.Match)})
+			}
+		}
+
+		switch len(conds) {
+		case 0:
+			return []*models.GuestModel{}, 0, nil
+		case 1:
+			for k, v := range conds[0].(bson.M) {
+				filter[k] = v
+			}
+		default:
+			filter["$or"] = conds
+		}
+	}
+
+	total, err := r.col.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "search_name", Value: 1}, {Key: "_id", Value: 1}}).
+		SetSkip(int64(q.Page-1) * int64(q.PageSize)).
+		SetLimit(int64(q.PageSize))
+
+	cur, err := r.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cur.Close(ctx)
+
+	guests := make([]*models.GuestModel, 0, q.PageSize)
+	if err := cur.All(ctx, &guests); err != nil {
+		return nil, 0, err
+	}
+	return guests, total, nil
+}
+
+// AddEvent ghi nhận khách đã đăng ký sự kiện
+func (r *GuestRepositoryImpl) AddEvent(ctx context.Context, guestID, eventID string) error {
+	if strings.TrimSpace(guestID) == "" || strings.TrimSpace(eventID) == "" {
+		return errors.New("guest id and event id are required")
+	}
+	_, err := r.col.UpdateOne(ctx,
+		bson.M{"_id": guestID},
+		bson.M{"$addToSet": bson.M{"event_ids": eventID}},
+	)
+	return err
+}
+
+// RemoveEvent bỏ sự kiện khỏi danh sách sự kiện của khách (khi huỷ hoặc xoá đăng ký)
+func (r *GuestRepositoryImpl) RemoveEvent(ctx context.Context, guestID, eventID string) error {
+	if strings.TrimSpace(guestID) == "" || strings.TrimSpace(eventID) == "" {
+		return errors.New("guest id and event id are required")
+	}
+	_, err := r.col.UpdateOne(ctx,
+		bson.M{"_id": guestID},
+		bson.M{"$pull": bson.M{"event_ids": eventID}},
+	)
+	return err
+}
+
+// RemoveEventFromAll bỏ sự kiện khỏi mọi khách (khi xoá sự kiện)
+func (r *GuestRepositoryImpl) RemoveEventFromAll(ctx context.Context, eventID string) error {
+	if strings.TrimSpace(eventID) == "" {
+		return errors.New("event id is required")
+	}
+	_, err := r.col.UpdateMany(ctx,
+		bson.M{"event_ids": eventID},
+		bson.M{"$pull": bson.M{"event_ids": eventID}},
+	)
+	return err
+}
+
+// searchPattern dựng regex tiền tố (dùng được index) hoặc chứa chuỗi con
+func searchPattern(value, match string) primitive.Regex {
+	pattern := regexp.QuoteMeta(value)
+	if match != entity.GuestMatchContains {
+		pattern = "^" + pattern
+	}
+	return primitive.Regex{Pattern: pattern}
+}
+
+// StreamAll duyệt toàn bộ khách qua cursor, không nạp hết vào bộ nhớ
+func (r *GuestRepositoryImpl) StreamAll(ctx context.Context, fn func(*models.GuestModel) error) error {
+	cur, err := r.col.Find(ctx, bson.M{}, options.Find().SetBatchSize(1000))
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var g models.GuestModel
+		if err := cur.Decode(&g); err != nil {
+			return err
+		}
+		if err := fn(&g); err != nil {
+			return err
+		}
+	}
+	return cur.Err()
+}
@@ -0,0 +1,173 @@
+package repository_imple
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	repository_interface "event_manager/internal/domain/repository"
+	"event_manager/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// kioskAuditTTL là thời gian giữ nhật ký kiosk
+const kioskAuditTTL = 90 * 24 * time.Hour
+
+// KioskSessionRepoImpl lưu phiên kiosk trong collection "kiosk_sessions"
+type KioskSessionRepoImpl struct {
+	col *mongo.Collection
+}
+
+// ✅ Khởi tạo repository và index tra cứu theo token / sự kiện
+func NewKioskSessionMongoRepository(db *mongo.Database) repository_interface.KioskSessionRepository {
+	col := db.Collection("kiosk_sessions")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, _ = col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "token_hash", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "event_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	})
+
+	return &KioskSessionRepoImpl{col: col}
+}
+
+// Insert thêm phiên kiosk mới
+func (r *KioskSessionRepoImpl) Insert(ctx context.Context, m *models.KioskSessionModel) error {
+	if m == nil {
+		return errors.New("kiosk session model is nil")
+	}
+	if m.ID.IsZero() {
+		m.ID = primitive.NewObjectID()
+	}
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = time.Now()
+	}
+	_, err := r.col.InsertOne(ctx, m)
+	return err
+}
+
+// FindByID tìm phiên kiosk theo ID
+func (r *KioskSessionRepoImpl) FindByID(ctx context.Context, id string) (*models.KioskSessionModel, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil
+	}
+	return r.findOne(ctx, bson.M{"_id": objID})
+}
+
+// FindByHash tìm phiên kiosk theo hash của token
+func (r *KioskSessionRepoImpl) FindByHash(ctx context.Context, tokenHash string) (*models.KioskSessionModel, error) {
+	return r.findOne(ctx, bson.M{"token_hash": tokenHash})
+}
+
+func (r *KioskSessionRepoImpl) findOne(ctx context.Context, filter bson.M) (*models.KioskSessionModel, error) {
+	var m models.KioskSessionModel
+	err := r.col.FindOne(ctx, filter).Decode(&m)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &m, nil
+}
+
+// FindByEvent liệt kê phiên kiosk của sự kiện, mới nhất trước
+func (r *KioskSessionRepoImpl) FindByEvent(ctx context.Context, eventID string) ([]*models.KioskSessionModel, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cur, err := r.col.Find(ctx, bson.M{"event_id": eventID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var result []*models.KioskSessionModel
+	for cur.Next(ctx) {
+		var m models.KioskSessionModel
+		if err := cur.Decode(&m); err != nil {
+			return nil, err
+		}
+		result = append(result, &m)
+	}
+	return result, cur.Err()
+}
+
+// Revoke thu hồi phiên kiosk
+func (r *KioskSessionRepoImpl) Revoke(ctx context.Context, id string, at time.Time) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	filter := bson.M{"_id": objID, "revoked_at": bson.M{"$exists": false}}
+	_, err = r.col.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"revoked_at": at}})
+	return err
+}
+
+// Touch cập nhật lần cuối kiosk dùng token
+func (r *KioskSessionRepoImpl) Touch(ctx context.Context, id string, at time.Time) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	_, err = r.col.UpdateOne(ctx, bson.M{"_id": objID}, bson.M{"$set": bson.M{"last_seen_at": at}})
+	return err
+}
+
+// KioskAuditRepoImpl lưu nhật ký thao tác kiosk trong collection "kiosk_audit"
+type KioskAuditRepoImpl struct {
+	col *mongo.Collection
+}
+
+// ✅ Khởi tạo repository, nhật ký cũ được MongoDB tự xoá sau kioskAuditTTL
+func NewKioskAuditMongoRepository(db *mongo.Database) repository_interface.KioskAuditRepository {
+	col := db.Collection("kiosk_audit")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, _ = col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(int32(kioskAuditTTL.Seconds()))},
+		{Keys: bson.D{{Key: "kiosk_id", Value: 1}, {Key: "at", Value: -1}}},
+	})
+
+	return &KioskAuditRepoImpl{col: col}
+}
+
+// Insert ghi một dòng nhật ký
+func (r *KioskAuditRepoImpl) Insert(ctx context.Context, m *models.KioskAuditModel) error {
+	if m == nil {
+		return errors.New("kiosk audit model is nil")
+	}
+	if m.ID.IsZero() {
+		m.ID = primitive.NewObjectID()
+	}
+	if m.At.IsZero() {
+		m.At = time.Now()
+	}
+	_, err := r.col.InsertOne(ctx, m)
+	return err
+}
+
+// FindByKiosk lấy nhật ký mới nhất của kiosk
+func (r *KioskAuditRepoImpl) FindByKiosk(ctx context.Context, kioskID string, limit int) ([]*models.KioskAuditModel, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "at", Value: -1}, {Key: "_id", Value: -1}}).SetLimit(int64(limit))
+	cur, err := r.col.Find(ctx, bson.M{"kiosk_id": kioskID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var result []*models.KioskAuditModel
+	for cur.Next(ctx) {
+		var m models.KioskAuditModel
+		if err := cur.Decode(&m); err != nil {
+			return nil, err
+		}
+		result = append(result, &m)
+	}
+	return result, cur.Err()
+}
@@ -0,0 +1,132 @@
+package repository_imple
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	repository_interface "event_manager/internal/domain/repository"
+	"event_manager/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// LocationRepoImpl thao tác collection "locations"
+type LocationRepoImpl struct {
+	col *mongo.Collection
+}
+
+// ✅ Hàm khởi tạo
+func NewLocationMongoRepository(db *mongo.Database) repository_interface.LocationRepository {
+	col := db.Collection("locations")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, _ = col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "name_key", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	})
+
+	return &LocationRepoImpl{col: col}
+}
+
+// Thêm mới địa điểm
+func (r *LocationRepoImpl) Insert(ctx context.Context, m *models.LocationModel) error {
+	if m == nil {
+		return errors.New("location model is nil")
+	}
+	if m.ID.IsZero() {
+		m.ID = primitive.NewObjectID()
+	}
+	_, err := r.col.InsertOne(ctx, m)
+	if mongo.IsDuplicateKeyError(err) {
+		return repository_interface.ErrDuplicate
+	}
+	return err
+}
+
+// Cập nhật địa điểm
+func (r *LocationRepoImpl) Update(ctx context.Context, m *models.LocationModel) error {
+	if m == nil || m.ID.IsZero() {
+		return errors.New("missing location ID")
+	}
+	update := bson.M{"$set": bson.M{
+		"name":        m.Name,
+		"name_key":    m.NameKey,
+		"address":     m.Address,
+		"capacity":    m.Capacity,
+		"description": m.Description,
+	}}
+	_, err := r.col.UpdateOne(ctx, bson.M{"_id": m.ID}, update)
+	if mongo.IsDuplicateKeyError(err) {
+		return repository_interface.ErrDuplicate
+	}
+	return err
+}
+
+// Khoá lịch đặt của địa điểm trong transaction hiện tại
+func (r *LocationRepoImpl) LockBookings(ctx context.Context, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	_, err = r.col.UpdateOne(ctx, bson.M{"_id": objID}, bson.M{"$inc": bson.M{"booking_seq": 1}})
+	return err
+}
+
+// Xoá địa điểm
+func (r *LocationRepoImpl) Delete(ctx context.Context, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	_, err = r.col.DeleteOne(ctx, bson.M{"_id": objID})
+	return err
+}
+
+// Tìm địa điểm theo ID
+func (r *LocationRepoImpl) FindByID(ctx context.Context, id string) (*models.LocationModel, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil
+	}
+	return r.findOne(ctx, bson.M{"_id": objID})
+}
+
+// Tìm địa điểm theo tên đã chuẩn hoá
+func (r *LocationRepoImpl) FindByNameKey(ctx context.Context, nameKey string) (*models.LocationModel, error) {
+	return r.findOne(ctx, bson.M{"name_key": nameKey})
+}
+
+// Lấy tất cả địa điểm, sắp theo tên
+func (r *LocationRepoImpl) FindAll(ctx context.Context) ([]*models.LocationModel, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "name_key", Value: 1}})
+	cur, err := r.col.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	locations := make([]*models.LocationModel, 0)
+	if err := cur.All(ctx, &locations); err != nil {
+		return nil, err
+	}
+	return locations, nil
+}
+
+func (r *LocationRepoImpl) findOne(ctx context.Context, filter bson.M) (*models.LocationModel, error) {
+	var result models.LocationModel
+	err := r.col.FindOne(ctx, filter).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,215 @@
+package repository_imple
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	repository_interface "event_manager/internal/domain/repository"
+	"event_manager/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ProfileRepoImpl thao tác collection "profiles"
+type ProfileRepoImpl struct {
+	col *mongo.Collection
+}
+
+// ✅ Khởi tạo repository và index lọc theo loại hồ sơ
+func NewProfileMongoRepository(db *mongo.Database) repository_interface.ProfileRepository {
+	col := db.Collection("profiles")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, _ = col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "kind", Value: 1}, {Key: "name", Value: 1}}},
+	})
+
+	return &ProfileRepoImpl{col: col}
+}
+
+// Thêm hồ sơ mới
+func (r *ProfileRepoImpl) Insert(ctx context.Context, m *models.ProfileModel) error {
+	if m == nil {
+		return errors.New("profile model is nil")
+	}
+	if m.ID.IsZero() {
+		m.ID = primitive.NewObjectID()
+	}
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = time.Now()
+	}
+	_, err := r.col.InsertOne(ctx, m)
+	return err
+}
+
+// Cập nhật hồ sơ; ảnh đổi qua SetPhoto
+func (r *ProfileRepoImpl) Update(ctx context.Context, m *models.ProfileModel) error {
+	if m == nil || m.ID.IsZero() {
+		return errors.New("missing profile ID")
+	}
+	if m.UpdatedAt.IsZero() {
+		m.UpdatedAt = time.Now()
+	}
+	update := bson.M{"$set": bson.M{
+		"name":       m.Name,
+		"headline":   m.Headline,
+		"bio":        m.Bio,
+		"links":      m.Links,
+		"tier":       m.Tier,
+		"updated_at": m.UpdatedAt,
+	}}
+	_, err := r.col.UpdateOne(ctx, bson.M{"_id": m.ID}, update)
+	return err
+}
+
+// Đổi ảnh hồ sơ
+func (r *ProfileRepoImpl) SetPhoto(ctx context.Context, id, photoURL string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	update := bson.M{"$set": bson.M{"photo_url": photoURL, "updated_at": time.Now()}}
+	_, err = r.col.UpdateOne(ctx, bson.M{"_id": objID}, update)
+	return err
+}
+
+// Xoá hồ sơ
+func (r *ProfileRepoImpl) Delete(ctx context.Context, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	_, err = r.col.DeleteOne(ctx, bson.M{"_id": objID})
+	return err
+}
+
+// Tìm hồ sơ theo ID
+func (r *ProfileRepoImpl) FindByID(ctx context.Context, id string) (*models.ProfileModel, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil
+	}
+	var m models.ProfileModel
+	if err := r.col.FindOne(ctx, bson.M{"_id": objID}).Decode(&m); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &m, nil
+}
+
+// Tìm nhiều hồ sơ theo ID, bỏ qua ID không hợp lệ
+func (r *ProfileRepoImpl) FindByIDs(ctx context.Context, ids []string) ([]*models.ProfileModel, error) {
+	objIDs := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if objID, err := primitive.ObjectIDFromHex(id); err == nil {
+			objIDs = append(objIDs, objID)
+		}
+	}
+	if len(objIDs) == 0 {
+		return []*models.ProfileModel{}, nil
+	}
+	return r.find(ctx, bson.M{"_id": bson.M{"$in": objIDs}})
+}
+
+// Danh sách hồ sơ theo loại, sắp theo tên
+func (r *ProfileRepoImpl) FindAll(ctx context.Context, kind string) ([]*models.ProfileModel, error) {
+	filter := bson.M{}
+	if kind != "" {
+		filter["kind"] = kind
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}})
+	return r.find(ctx, filter, opts)
+}
+
+func (r *ProfileRepoImpl) find(ctx context.Context, filter bson.M, opts ...*options.FindOptions) ([]*models.ProfileModel, error) {
+	cur, err := r.col.Find(ctx, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	profiles := make([]*models.ProfileModel, 0)
+	if err := cur.All(ctx, &profiles); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+// ProfileAssignmentRepoImpl thao tác collection "event_profiles"
+type ProfileAssignmentRepoImpl struct {
+	col *mongo.Collection
+}
+
+// ✅ Khởi tạo repository; mỗi hồ sơ chỉ gắn một lần vào sự kiện / phiên
+func NewProfileAssignmentMongoRepository(db *mongo.Database) repository_interface.ProfileAssignmentRepository {
+	col := db.Collection("event_profiles")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, _ = col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "event_id", Value: 1}, {Key: "session_id", Value: 1}, {Key: "profile_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "profile_id", Value: 1}}},
+	})
+
+	return &ProfileAssignmentRepoImpl{col: col}
+}
+
+// Gắn hồ sơ vào sự kiện / phiên
+func (r *ProfileAssignmentRepoImpl) Insert(ctx context.Context, m *models.ProfileAssignmentModel) error {
+	if m == nil {
+		return errors.New("profile assignment model is nil")
+	}
+	if m.ID.IsZero() {
+		m.ID = primitive.NewObjectID()
+	}
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = time.Now()
+	}
+	_, err := r.col.InsertOne(ctx, m)
+	if mongo.IsDuplicateKeyError(err) {
+		return repository_interface.ErrDuplicate
+	}
+	return err
+}
+
+// Gỡ hồ sơ khỏi sự kiện / phiên
+func (r *ProfileAssignmentRepoImpl) Delete(ctx context.Context, eventID, sessionID, profileID string) (bool, error) {
+	filter := bson.M{"event_id": eventID, "session_id": sessionID, "profile_id": profileID}
+	res, err := r.col.DeleteOne(ctx, filter)
+	if err != nil {
+		return false, err
+	}
+	return res.DeletedCount > 0, nil
+}
+
+// Gỡ hồ sơ khỏi mọi sự kiện / phiên
+func (r *ProfileAssignmentRepoImpl) DeleteByProfile(ctx context.Context, profileID string) error {
+	_, err := r.col.DeleteMany(ctx, bson.M{"profile_id": profileID})
+	return err
+}
+
+// Các hồ sơ gắn với sự kiện và các phiên của nó
+func (r *ProfileAssignmentRepoImpl) FindByEvent(ctx context.Context, eventID string) ([]*models.ProfileAssignmentModel, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+	cur, err := r.col.Find(ctx, bson.M{"event_id": eventID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	assignments := make([]*models.ProfileAssignmentModel, 0)
+	if err := cur.All(ctx, &assignments); err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
@@ -0,0 +1,90 @@
+package repository_imple
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	repository_interface "event_manager/internal/domain/repository"
+	"event_manager/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// RefreshTokenRepoImpl lưu refresh token (dạng hash) trong collection "refresh_tokens"
+type RefreshTokenRepoImpl struct {
+	col *mongo.Collection
+}
+
+// ✅ Khởi tạo repository, đảm bảo index cho token_hash và TTL theo expires_at
+func NewRefreshTokenMongoRepository(db *mongo.Database) repository_interface.RefreshTokenRepository {
+	col := db.Collection("refresh_tokens")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, _ = col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "token_hash", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "user_id", Value: 1}}},
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	})
+
+	return &RefreshTokenRepoImpl{col: col}
+}
+
+// Insert thêm refresh token mới
+func (r *RefreshTokenRepoImpl) Insert(ctx context.Context, m *models.RefreshTokenModel) error {
+	if m == nil {
+		return errors.New("refresh token model is nil")
+	}
+	if m.ID.IsZero() {
+		m.ID = primitive.NewObjectID()
+	}
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = time.Now()
+	}
+	_, err := r.col.InsertOne(ctx, m)
+	return err
+}
+
+// FindByHash tìm refresh token theo hash
+func (r *RefreshTokenRepoImpl) FindByHash(ctx context.Context, tokenHash string) (*models.RefreshTokenModel, error) {
+	var m models.RefreshTokenModel
+	err := r.col.FindOne(ctx, bson.M{"token_hash": tokenHash}).Decode(&m)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &m, nil
+}
+
+// Revoke thu hồi token; chỉ token chưa bị thu hồi mới khớp filter nên
+// hai request refresh song song không thể cùng xoay vòng một token
+func (r *RefreshTokenRepoImpl) Revoke(ctx context.Context, id string) (bool, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, err
+	}
+
+	filter := bson.M{"_id": objID, "revoked_at": bson.M{"$exists": false}}
+	update := bson.M{"$set": bson.M{"revoked_at": time.Now()}}
+
+	res, err := r.col.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+// RevokeAllByUser thu hồi toàn bộ refresh token còn hiệu lực của user
+func (r *RefreshTokenRepoImpl) RevokeAllByUser(ctx context.Context, userID string) error {
+	filter := bson.M{"user_id": userID, "revoked_at": bson.M{"$exists": false}}
+	update := bson.M{"$set": bson.M{"revoked_at": time.Now()}}
+
+	_, err := r.col.UpdateMany(ctx, filter, update)
+	return err
+}
@@ -3,14 +3,17 @@
 import (
 	"context"
 	"errors"
+	"event_manager/internal/domain/entity"
 	repository_interface "event_manager/internal/domain/repository"
 	"event_manager/internal/models"
+	"log"
 	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // RegistrationRepoImpl là struct thao tác MongoDB
@@ -20,7 +23,30 @@ type RegistrationRepoImpl struct {
 
 // ✅ Hàm khởi tạo
 func NewRegistrationMongoRepository(db *mongo.Database) repository_interface.RegistrationRepository {
-	return &RegistrationRepoImpl{col: db.Collection("registrations")}
+	col := db.Collection("registrations")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, _ = col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "event_id", Value: 1}, {Key: "status", Value: 1}, {Key: "created_at", Value: 1}}},
+		{Keys: bson.D{{Key: "guest_id", Value: 1}}},
+	})
+
+	r := &RegistrationRepoImpl{col: col}
+	// Unique index tạo riêng: dữ liệu cũ còn đăng ký trùng thì tạo thất bại, cần chạy migrate trước
+	if err := r.EnsureUniqueGuestIndex(ctx); err != nil {
+		log.Printf("⚠️ Không tạo được unique index (event_id, guest_id) cho registrations: %v — chạy `go run ./cmd/migrate -task registration-duplicates`", err)
+	}
+	return r
+}
+
+// Một khách chỉ có một đăng ký cho mỗi sự kiện
+func (r *RegistrationRepoImpl) EnsureUniqueGuestIndex(ctx context.Context) error {
+	_, err := r.col.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "event_id", Value: 1}, {Key: "guest_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
 }
 
 // ============================
@@ -36,6 +62,9 @@ func (r *RegistrationRepoImpl) Insert(ctx context.Context, m *models.Registratio
 		m.CreatedAt = time.Now()
 	}
 	_, err := r.col.InsertOne(ctx, m)
+	if mongo.IsDuplicateKeyError(err) {
+		return repository_interface.ErrDuplicate
+	}
 	return err
 }
 
@@ -46,6 +75,9 @@ func (r *RegistrationRepoImpl) Update(ctx context.Context, m *models.Registratio
 		"status":     m.Status,
 		"checked_in": m.CheckedIn,
 	}
+	if m.Visits != nil {
+		setFields["visits"] = m.Visits
+	}
 	if strings.TrimSpace(m.EventID) != "" {
 		setFields["event_id"] = strings.TrimSpace(m.EventID)
 	}
@@ -99,6 +131,31 @@ func (r *RegistrationRepoImpl) FindByEvent(ctx context.Context, eventID string)
 	return regs, cur.Err()
 }
 
+// Lấy nhiều đăng ký theo danh sách ID
+func (r *RegistrationRepoImpl) FindByIDs(ctx context.Context, ids []string) ([]*models.RegistrationModel, error) {
+	objIDs := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if objID, err := primitive.ObjectIDFromHex(id); err == nil {
+			objIDs = append(objIDs, objID)
+		}
+	}
+	if len(objIDs) == 0 {
+		return []*models.RegistrationModel{}, nil
+	}
+
+	cur, err := r.col.Find(ctx, bson.M{"_id": bson.M{"$in": objIDs}})
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	regs := make([]*models.RegistrationModel, 0, len(objIDs))
+	if err := cur.All(ctx, &regs); err != nil {
+		return nil, err
+	}
+	return regs, nil
+}
+
 // Lấy danh sách đăng ký theo GuestID
 func (r *RegistrationRepoImpl) FindByGuest(ctx context.Context, guestID string) ([]*models.RegistrationModel, error) {
 	guestID = strings.TrimSpace(guestID)
@@ -120,3 +177,295 @@ func (r *RegistrationRepoImpl) FindByGuest(ctx context.Context, guestID string)
 	}
 	return regs, cur.Err()
 }
+
+// Tìm đăng ký của khách cho một sự kiện
+func (r *RegistrationRepoImpl) FindByEventAndGuest(ctx context.Context, eventID, guestID string) (*models.RegistrationModel, error) {
+	var result models.RegistrationModel
+	err := r.col.FindOne(ctx, bson.M{"event_id": eventID, "guest_id": guestID}).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Đếm số đăng ký đang giữ chỗ của sự kiện
+func (r *RegistrationRepoImpl) CountHoldingSeat(ctx context.Context, eventID string) (int, error) {
+	filter := bson.M{
+		"event_id": eventID,
+		"status":   bson.M{"$nin": bson.A{string(entity.RegistrationCancelled), string(entity.RegistrationWaitlisted)}},
+	}
+	count, err := r.col.CountDocuments(ctx, filter)
+	return int(count), err
+}
+
+// Chuyển trạng thái có điều kiện (compare-and-set) và ghi lịch sử trong cùng một lệnh update
+func (r *RegistrationRepoImpl) Transition(ctx context.Context, id, from string, checkedIn bool, entry models.RegistrationTransitionModel) (bool, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, err
+	}
+
+	filter := bson.M{"_id": objID, "status": statusFilter(from)}
+	set := bson.M{"status": entry.To, "checked_in": checkedIn}
+	push := bson.M{"history": entry}
+
+	switch entry.To {
+	case string(entity.RegistrationCheckedIn):
+		// Mỗi lần check-in mở một lượt vào mới
+		push["visits"] = models.RegistrationVisitModel{CheckedInAt: entry.At}
+	case string(entity.RegistrationCheckedOut):
+		return r.checkOut(ctx, filter, set, push, entry.At)
+	}
+
+	res, err := r.col.UpdateOne(ctx, filter, bson.M{"$set": set, "$push": push})
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+// checkOut đóng lượt vào đang mở; đăng ký check-in từ trước khi có "visits" không có lượt để đóng
+// nên chỉ đổi trạng thái. Cả hai nhánh vẫn so khớp trạng thái cũ như Transition.
+func (r *RegistrationRepoImpl) checkOut(ctx context.Context, filter, set, push bson.M, at time.Time) (bool, error) {
+	openVisit := bson.M{"$elemMatch": bson.M{"checked_out_at": bson.M{"$exists": false}}}
+
+	withVisit := bson.M{"visits": openVisit}
+	for k, v := range filter {
+		withVisit[k] = v
+	}
+	closeSet := bson.M{"visits.$[open].checked_out_at": at}
+	for k, v := range set {
+		closeSet[k] = v
+	}
+	opts := options.Update().SetArrayFilters(options.ArrayFilters{Filters: bson.A{
+		bson.M{"open.checked_out_at": bson.M{"$exists": false}},
+	}})
+	res, err := r.col.UpdateOne(ctx, withVisit, bson.M{"$set": closeSet, "$push": push}, opts)
+	if err != nil {
+		return false, err
+	}
+	if res.ModifiedCount > 0 {
+		return true, nil
+	}
+
+	withoutVisit := bson.M{"visits": bson.M{"$not": openVisit}}
+	for k, v := range filter {
+		withoutVisit[k] = v
+	}
+	res, err = r.col.UpdateOne(ctx, withoutVisit, bson.M{"$set": set, "$push": push})
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+// StreamMissingVisits đọc dần các đăng ký đã tham dự nhưng chưa có mảng "visits" (dữ liệu cũ)
+func (r *RegistrationRepoImpl) StreamMissingVisits(ctx context.Context, fn func(*models.RegistrationModel) error) error {
+	filter := bson.M{"checked_in": true, "visits": bson.M{"$exists": false}}
+	cur, err := r.col.Find(ctx, filter, options.Find().SetBatchSize(500))
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var reg models.RegistrationModel
+		if err := cur.Decode(&reg); err != nil {
+			return err
+		}
+		if err := fn(&reg); err != nil {
+			return err
+		}
+	}
+	return cur.Err()
+}
+
+// archivedRegistration là bản lưu của đăng ký trùng đã gộp, giữ nguyên lịch sử chuyển trạng thái
+type archivedRegistration struct {
+	models.RegistrationModel `bson:",inline"`
+	MergedInto               string    `bson:"merged_into"`
+	ArchivedAt               time.Time `bson:"archived_at"`
+}
+
+// ArchiveDuplicate chép đăng ký trùng sang "registrations_archive" rồi xoá khỏi "registrations"
+func (r *RegistrationRepoImpl) ArchiveDuplicate(ctx context.Context, m *models.RegistrationModel, keptID string) error {
+	archive := r.col.Database().Collection("registrations_archive")
+	doc := archivedRegistration{RegistrationModel: *m, MergedInto: keptID, ArchivedAt: time.Now()}
+	// Chạy lại sau khi lỗi giữa chừng: bản lưu đã có thì chỉ còn việc xoá
+	if _, err := archive.InsertOne(ctx, doc); err != nil && !mongo.IsDuplicateKeyError(err) {
+		return err
+	}
+	_, err := r.col.DeleteOne(ctx, bson.M{"_id": m.ID})
+	return err
+}
+
+// StreamDuplicates gom các đăng ký trùng (event_id, guest_id), mỗi nhóm sắp theo thời gian tạo
+func (r *RegistrationRepoImpl) StreamDuplicates(ctx context.Context, fn func([]*models.RegistrationModel) error) error {
+	pipeline := mongo.Pipeline{
+		{{Key: "$sort", Value: bson.D{{Key: "created_at", Value: 1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"event_id": "$event_id", "guest_id": "$guest_id"},
+			"docs":  bson.M{"$push": "$$ROOT"},
+			"count": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$match", Value: bson.M{"count": bson.M{"$gt": 1}}}},
+	}
+	cur, err := r.col.Aggregate(ctx, pipeline, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var group struct {
+			Docs []*models.RegistrationModel `bson:"docs"`
+		}
+		if err := cur.Decode(&group); err != nil {
+			return err
+		}
+		if err := fn(group.Docs); err != nil {
+			return err
+		}
+	}
+	return cur.Err()
+}
+
+// Tăng bộ đếm xác nhận sai trên kiosk của đăng ký, trả về giá trị mới
+func (r *RegistrationRepoImpl) AddKioskConfirmFailure(ctx context.Context, registrationID string) (int, error) {
+	objID, err := primitive.ObjectIDFromHex(registrationID)
+	if err != nil {
+		return 0, err
+	}
+	opts := options.FindOneAndUpdate().
+		SetProjection(bson.M{"kiosk_confirm_failures": 1}).
+		SetReturnDocument(options.After)
+
+	var result models.RegistrationModel
+	err = r.col.FindOneAndUpdate(ctx, bson.M{"_id": objID}, bson.M{"$inc": bson.M{"kiosk_confirm_failures": 1}}, opts).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return result.KioskConfirmFailures, nil
+}
+
+// BumpTicketVersion tăng version vé (vé cũ hết hiệu lực) và ghi thời điểm cấp lại
+func (r *RegistrationRepoImpl) BumpTicketVersion(ctx context.Context, registrationID string, at time.Time) (*models.RegistrationModel, error) {
+	objID, err := primitive.ObjectIDFromHex(registrationID)
+	if err != nil {
+		return nil, err
+	}
+	update := bson.M{
+		"$inc": bson.M{"ticket_version": 1},
+		"$set": bson.M{"ticket_issued_at": at},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var result models.RegistrationModel
+	err = r.col.FindOneAndUpdate(ctx, bson.M{"_id": objID}, update, opts).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Đưa đăng ký waitlist cũ nhất của sự kiện lên trạng thái entry.To
+func (r *RegistrationRepoImpl) PromoteOldestWaitlisted(ctx context.Context, eventID string, entry models.RegistrationTransitionModel) (*models.RegistrationModel, error) {
+	filter := bson.M{"event_id": eventID, "status": string(entity.RegistrationWaitlisted)}
+	update := bson.M{
+		"$set":  bson.M{"status": entry.To},
+		"$push": bson.M{"history": entry},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var result models.RegistrationModel
+	err := r.col.FindOneAndUpdate(ctx, filter, update, opts).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &result, nil
+}
+
+// statusFilter khớp trạng thái; dữ liệu cũ để trống được coi là pending
+func statusFilter(status string) interface{} {
+	if status == string(entity.RegistrationPending) {
+		return bson.M{"$in": bson.A{status, "", nil}}
+	}
+	return status
+}
+
+// StreamGuestsByEvent join đăng ký của sự kiện với khách, sắp theo tên và đọc dần qua cursor
+func (r *RegistrationRepoImpl) StreamGuestsByEvent(ctx context.Context, eventID string, fn func(*models.EventGuestModel) error) error {
+	// Thời điểm check-in gần nhất lấy từ lịch sử chuyển trạng thái
+	checkedInAt := bson.M{"$max": bson.M{"$map": bson.M{
+		"input": bson.M{"$filter": bson.M{
+			"input": bson.M{"$ifNull": bson.A{"$history", bson.A{}}},
+			"as":    "h",
+			"cond":  bson.M{"$eq": bson.A{"$$h.to", string(entity.RegistrationCheckedIn)}},
+		}},
+		"as": "h",
+		"in": "$$h.at",
+	}}}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"event_id": eventID}}},
+		{{Key: "$lookup", Value: bson.M{"from": "guests", "localField": "guest_id", "foreignField": "_id", "as": "guest"}}},
+		{{Key: "$unwind", Value: bson.M{"path": "$guest", "preserveNullAndEmptyArrays": true}}},
+		{{Key: "$project", Value: bson.M{
+			"event_id":      1,
+			"guest_id":      1,
+			"status":        1,
+			"checked_in":    1,
+			"created_at":    1,
+			"full_name":     "$guest.full_name",
+			"email":         "$guest.email",
+			"phone":         "$guest.phone",
+			"sort_name":     "$guest.search_name",
+			"checked_in_at": checkedInAt,
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "sort_name", Value: 1}, {Key: "_id", Value: 1}}}},
+	}
+
+	opts := options.Aggregate().SetAllowDiskUse(true).SetBatchSize(500)
+	cur, err := r.col.Aggregate(ctx, pipeline, opts)
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var row models.EventGuestModel
+		if err := cur.Decode(&row); err != nil {
+			return err
+		}
+		if err := fn(&row); err != nil {
+			return err
+		}
+	}
+	return cur.Err()
+}
+
+// ReassignGuest chuyển mọi đăng ký của một khách sang khách khác (dùng khi gộp khách trùng)
+func (r *RegistrationRepoImpl) ReassignGuest(ctx context.Context, fromGuestID, toGuestID string) (int64, error) {
+	res, err := r.col.UpdateMany(ctx, bson.M{"guest_id": fromGuestID}, bson.M{"$set": bson.M{"guest_id": toGuestID}})
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return 0, repository_interface.ErrDuplicate
+		}
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
@@ -0,0 +1,162 @@
+package repository_imple
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	repository_interface "event_manager/internal/domain/repository"
+	"event_manager/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ReviewRepoImpl thao tác collection "reviews"
+type ReviewRepoImpl struct {
+	col *mongo.Collection
+}
+
+// ✅ Hàm khởi tạo
+func NewReviewMongoRepository(db *mongo.Database) repository_interface.ReviewRepository {
+	col := db.Collection("reviews")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, _ = col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		// Mỗi khách chỉ đánh giá một lần cho mỗi sự kiện
+		{
+			Keys:    bson.D{{Key: "event_id", Value: 1}, {Key: "guest_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "event_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "guest_id", Value: 1}}},
+	})
+
+	return &ReviewRepoImpl{col: col}
+}
+
+// Thêm mới đánh giá
+func (r *ReviewRepoImpl) Insert(ctx context.Context, m *models.ReviewModel) error {
+	if m == nil {
+		return errors.New("review model is nil")
+	}
+	if m.ID.IsZero() {
+		m.ID = primitive.NewObjectID()
+	}
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = time.Now()
+	}
+	_, err := r.col.InsertOne(ctx, m)
+	if mongo.IsDuplicateKeyError(err) {
+		return repository_interface.ErrDuplicate
+	}
+	return err
+}
+
+// Cập nhật điểm và nhận xét
+func (r *ReviewRepoImpl) Update(ctx context.Context, m *models.ReviewModel) error {
+	if m == nil || m.ID.IsZero() {
+		return errors.New("missing review ID")
+	}
+	if m.UpdatedAt.IsZero() {
+		m.UpdatedAt = time.Now()
+	}
+	update := bson.M{"$set": bson.M{
+		"rating":     m.Rating,
+		"comment":    m.Comment,
+		"updated_at": m.UpdatedAt,
+	}}
+	_, err := r.col.UpdateOne(ctx, bson.M{"_id": m.ID}, update)
+	return err
+}
+
+// Xoá đánh giá
+func (r *ReviewRepoImpl) Delete(ctx context.Context, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	_, err = r.col.DeleteOne(ctx, bson.M{"_id": objID})
+	return err
+}
+
+// Tìm đánh giá theo ID
+func (r *ReviewRepoImpl) FindByID(ctx context.Context, id string) (*models.ReviewModel, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	var result models.ReviewModel
+	err = r.col.FindOne(ctx, bson.M{"_id": objID}).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Danh sách đánh giá của sự kiện, mới nhất trước
+func (r *ReviewRepoImpl) FindByEvent(ctx context.Context, eventID string) ([]*models.ReviewModel, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	return r.find(ctx, bson.M{"event_id": eventID}, opts)
+}
+
+// Danh sách đánh giá của khách
+func (r *ReviewRepoImpl) FindByGuest(ctx context.Context, guestID string) ([]*models.ReviewModel, error) {
+	return r.find(ctx, bson.M{"guest_id": guestID})
+}
+
+// Điểm trung bình của sự kiện (0 nếu chưa có đánh giá)
+func (r *ReviewRepoImpl) AverageRating(ctx context.Context, eventID string) (float64, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"event_id": eventID}}},
+		{{Key: "$group", Value: bson.M{"_id": nil, "avg": bson.M{"$avg": "$rating"}}}},
+	}
+	cur, err := r.col.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, err
+	}
+	defer cur.Close(ctx)
+
+	var result []struct {
+		Avg float64 `bson:"avg"`
+	}
+	if err := cur.All(ctx, &result); err != nil {
+		return 0, err
+	}
+	if len(result) == 0 {
+		return 0, nil
+	}
+	return result[0].Avg, nil
+}
+
+func (r *ReviewRepoImpl) find(ctx context.Context, filter bson.M, opts ...*options.FindOptions) ([]*models.ReviewModel, error) {
+	cur, err := r.col.Find(ctx, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	reviews := make([]*models.ReviewModel, 0)
+	if err := cur.All(ctx, &reviews); err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+// ReassignGuest chuyển mọi đánh giá của một khách sang khách khác (dùng khi gộp khách trùng)
+func (r *ReviewRepoImpl) ReassignGuest(ctx context.Context, fromGuestID, toGuestID string) (int64, error) {
+	res, err := r.col.UpdateMany(ctx, bson.M{"guest_id": fromGuestID}, bson.M{"$set": bson.M{"guest_id": toGuestID}})
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return 0, repository_interface.ErrDuplicate
+		}
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
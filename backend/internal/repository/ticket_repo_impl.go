@@ -0,0 +1,375 @@
+package repository_imple
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	repository_interface "event_manager/internal/domain/repository"
+	"event_manager/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TicketTypeRepoImpl thao tác collection "ticket_types"
+type TicketTypeRepoImpl struct {
+	col *mongo.Collection
+}
+
+// ✅ Khởi tạo repository và index theo sự kiện
+func NewTicketTypeMongoRepository(db *mongo.Database) repository_interface.TicketTypeRepository {
+	col := db.Collection("ticket_types")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, _ = col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "event_id", Value: 1}, {Key: "price", Value: 1}}},
+	})
+
+	return &TicketTypeRepoImpl{col: col}
+}
+
+// Thêm loại vé mới
+func (r *TicketTypeRepoImpl) Insert(ctx context.Context, m *models.TicketTypeModel) error {
+	if m == nil {
+		return errors.New("ticket type model is nil")
+	}
+	if m.ID.IsZero() {
+		m.ID = primitive.NewObjectID()
+	}
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = time.Now()
+	}
+	_, err := r.col.InsertOne(ctx, m)
+	return err
+}
+
+// Cập nhật loại vé; số lượng mới không được nhỏ hơn số vé đã bán + đang giữ
+func (r *TicketTypeRepoImpl) Update(ctx context.Context, m *models.TicketTypeModel) (bool, error) {
+	if m == nil || m.ID.IsZero() {
+		return false, errors.New("missing ticket type ID")
+	}
+	if m.UpdatedAt.IsZero() {
+		m.UpdatedAt = time.Now()
+	}
+	filter := bson.M{"_id": m.ID}
+	if m.Quantity > 0 {
+		filter["$expr"] = bson.M{"$lte": bson.A{
+			bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$sold", 0}}, bson.M{"$ifNull": bson.A{"$reserved", 0}}}},
+			m.Quantity,
+		}}
+	}
+	set := bson.M{
+		"name":        m.Name,
+		"description": m.Description,
+		"currency":    m.Currency,
+		"price":       m.Price,
+		"quantity":    m.Quantity,
+		"updated_at":  m.UpdatedAt,
+	}
+	unset := bson.M{}
+	// Trường con trỏ: nil thì xoá khỏi document thay vì lưu null
+	optional := map[string]*time.Time{
+		"early_bird_until": m.EarlyBirdUntil,
+		"sale_start":       m.SaleStart,
+		"sale_end":         m.SaleEnd,
+	}
+	for key, value := range optional {
+		if value != nil {
+			set[key] = *value
+		} else {
+			unset[key] = ""
+		}
+	}
+	if m.EarlyBirdUntil != nil {
+		set["early_bird_price"] = m.EarlyBirdPrice
+	} else {
+		unset["early_bird_price"] = ""
+	}
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	res, err := r.col.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return res.MatchedCount > 0, nil
+}
+
+// Xoá loại vé
+func (r *TicketTypeRepoImpl) Delete(ctx context.Context, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	_, err = r.col.DeleteOne(ctx, bson.M{"_id": objID})
+	return err
+}
+
+// Tìm loại vé theo ID
+func (r *TicketTypeRepoImpl) FindByID(ctx context.Context, id string) (*models.TicketTypeModel, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil
+	}
+	var m models.TicketTypeModel
+	if err := r.col.FindOne(ctx, bson.M{"_id": objID}).Decode(&m); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &m, nil
+}
+
+// Các loại vé của sự kiện, rẻ nhất trước
+func (r *TicketTypeRepoImpl) FindByEvent(ctx context.Context, eventID string) ([]*models.TicketTypeModel, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "price", Value: 1}, {Key: "_id", Value: 1}})
+	cur, err := r.col.Find(ctx, bson.M{"event_id": eventID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	types := make([]*models.TicketTypeModel, 0)
+	if err := cur.All(ctx, &types); err != nil {
+		return nil, err
+	}
+	return types, nil
+}
+
+// Sự kiện có bán vé có phí hay không; giá ưu đãi luôn thấp hơn giá thường nên chỉ cần xét price
+func (r *TicketTypeRepoImpl) HasPaidTypes(ctx context.Context, eventID string) (bool, error) {
+	n, err := r.col.CountDocuments(ctx, bson.M{"event_id": eventID, "price": bson.M{"$gt": 0}}, options.Count().SetLimit(1))
+	return n > 0, err
+}
+
+// 🎟️ Reserve — giữ 1 vé; loại vé không giới hạn (quantity = 0) luôn còn vé nhưng vẫn được đếm
+func (r *TicketTypeRepoImpl) Reserve(ctx context.Context, id string) (bool, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, err
+	}
+	filter := bson.M{
+		"_id": objID,
+		"$or": bson.A{
+			bson.M{"quantity": bson.M{"$lte": 0}},
+			bson.M{"$expr": bson.M{"$lt": bson.A{
+				bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$sold", 0}}, bson.M{"$ifNull": bson.A{"$reserved", 0}}}},
+				"$quantity",
+			}}},
+		},
+	}
+	res, err := r.col.UpdateOne(ctx, filter, bson.M{"$inc": bson.M{"reserved": 1}})
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+// 🎟️ Release — trả lại 1 vé đang giữ
+func (r *TicketTypeRepoImpl) Release(ctx context.Context, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	filter := bson.M{"_id": objID, "reserved": bson.M{"$gt": 0}}
+	_, err = r.col.UpdateOne(ctx, filter, bson.M{"$inc": bson.M{"reserved": -1}})
+	return err
+}
+
+// 🎟️ ConfirmSale — chuyển 1 vé đang giữ thành đã bán
+func (r *TicketTypeRepoImpl) ConfirmSale(ctx context.Context, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	filter := bson.M{"_id": objID, "reserved": bson.M{"$gt": 0}}
+	_, err = r.col.UpdateOne(ctx, filter, bson.M{"$inc": bson.M{"reserved": -1, "sold": 1}})
+	return err
+}
+
+// OrderRepoImpl thao tác collection "orders"
+type OrderRepoImpl struct {
+	col *mongo.Collection
+}
+
+// ✅ Khởi tạo repository; mỗi khách chỉ có một đơn đang chờ thanh toán cho mỗi sự kiện
+func NewOrderMongoRepository(db *mongo.Database) repository_interface.OrderRepository {
+	col := db.Collection("orders")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, _ = col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "event_id", Value: 1}, {Key: "guest_id", Value: 1}},
+			Options: options.Index().SetUnique(true).
+				SetPartialFilterExpression(bson.M{"status": "pending"}),
+		},
+		{
+			Keys:    bson.D{{Key: "provider", Value: 1}, {Key: "payment_id", Value: 1}},
+			Options: options.Index().SetPartialFilterExpression(bson.M{"payment_id": bson.M{"$exists": true}}),
+		},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "expires_at", Value: 1}}},
+		{Keys: bson.D{{Key: "event_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "ticket_type_id", Value: 1}}},
+	})
+
+	return &OrderRepoImpl{col: col}
+}
+
+// Thêm đơn mới
+func (r *OrderRepoImpl) Insert(ctx context.Context, m *models.OrderModel) error {
+	if m == nil {
+		return errors.New("order model is nil")
+	}
+	if m.ID.IsZero() {
+		m.ID = primitive.NewObjectID()
+	}
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = time.Now()
+	}
+	_, err := r.col.InsertOne(ctx, m)
+	if mongo.IsDuplicateKeyError(err) {
+		return repository_interface.ErrDuplicate
+	}
+	return err
+}
+
+// Ghi giao dịch đã tạo ở cổng thanh toán
+func (r *OrderRepoImpl) SetPayment(ctx context.Context, id, provider, paymentID, checkoutURL string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	update := bson.M{"$set": bson.M{
+		"provider":     provider,
+		"payment_id":   paymentID,
+		"checkout_url": checkoutURL,
+		"updated_at":   time.Now(),
+	}}
+	_, err = r.col.UpdateOne(ctx, bson.M{"_id": objID, "status": "pending"}, update)
+	return err
+}
+
+// Chuyển trạng thái đơn nếu trạng thái hiện tại vẫn là from
+func (r *OrderRepoImpl) Transition(ctx context.Context, m *models.OrderModel, from string) (bool, error) {
+	if m == nil || m.ID.IsZero() {
+		return false, errors.New("missing order ID")
+	}
+	if m.UpdatedAt.IsZero() {
+		m.UpdatedAt = time.Now()
+	}
+	set := bson.M{
+		"status":     m.Status,
+		"updated_at": m.UpdatedAt,
+	}
+	if m.Reason != "" {
+		set["reason"] = m.Reason
+	}
+	if m.RegistrationID != "" {
+		set["registration_id"] = m.RegistrationID
+	}
+	if m.PaidAt != nil {
+		set["paid_at"] = *m.PaidAt
+	}
+	res, err := r.col.UpdateOne(ctx, bson.M{"_id": m.ID, "status": from}, bson.M{"$set": set})
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+// Tìm đơn theo ID
+func (r *OrderRepoImpl) FindByID(ctx context.Context, id string) (*models.OrderModel, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil
+	}
+	return r.findOne(ctx, bson.M{"_id": objID})
+}
+
+// Tìm đơn theo giao dịch của cổng thanh toán
+func (r *OrderRepoImpl) FindByPayment(ctx context.Context, provider, paymentID string) (*models.OrderModel, error) {
+	return r.findOne(ctx, bson.M{"provider": provider, "payment_id": paymentID})
+}
+
+// Các đơn của sự kiện, mới nhất trước
+func (r *OrderRepoImpl) FindByEvent(ctx context.Context, eventID, status string) ([]*models.OrderModel, error) {
+	filter := bson.M{"event_id": eventID}
+	if status != "" {
+		filter["status"] = status
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+	return r.find(ctx, filter, opts)
+}
+
+// Các đơn chờ thanh toán đã hết thời gian giữ vé
+func (r *OrderRepoImpl) FindExpired(ctx context.Context, ticketTypeID string, now time.Time, limit int) ([]*models.OrderModel, error) {
+	filter := bson.M{"status": "pending", "expires_at": bson.M{"$lte": now}}
+	if ticketTypeID != "" {
+		filter["ticket_type_id"] = ticketTypeID
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "expires_at", Value: 1}}).SetLimit(int64(limit))
+	return r.find(ctx, filter, opts)
+}
+
+// Đếm số đơn của loại vé
+func (r *OrderRepoImpl) CountByTicketType(ctx context.Context, ticketTypeID string) (int, error) {
+	n, err := r.col.CountDocuments(ctx, bson.M{"ticket_type_id": ticketTypeID})
+	return int(n), err
+}
+
+// ReassignGuest chuyển mọi đơn của một khách sang khách khác (dùng khi gộp khách trùng)
+func (r *OrderRepoImpl) ReassignGuest(ctx context.Context, fromGuestID, toGuestID string) (int64, error) {
+	res, err := r.col.UpdateMany(ctx, bson.M{"guest_id": fromGuestID}, bson.M{"$set": bson.M{"guest_id": toGuestID}})
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return 0, repository_interface.ErrDuplicate
+		}
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+// ReassignRegistration chuyển các đơn đang trỏ vào một đăng ký sang đăng ký khác (gộp đăng ký trùng)
+func (r *OrderRepoImpl) ReassignRegistration(ctx context.Context, fromRegistrationID, toRegistrationID string) (int64, error) {
+	res, err := r.col.UpdateMany(ctx,
+		bson.M{"registration_id": fromRegistrationID},
+		bson.M{"$set": bson.M{"registration_id": toRegistrationID, "updated_at": time.Now()}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+func (r *OrderRepoImpl) findOne(ctx context.Context, filter bson.M) (*models.OrderModel, error) {
+	var m models.OrderModel
+	if err := r.col.FindOne(ctx, filter).Decode(&m); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (r *OrderRepoImpl) find(ctx context.Context, filter bson.M, opts ...*options.FindOptions) ([]*models.OrderModel, error) {
+	cur, err := r.col.Find(ctx, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	orders := make([]*models.OrderModel, 0)
+	if err := cur.All(ctx, &orders); err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
@@ -0,0 +1,33 @@
+package repository_imple
+
+import (
+	"context"
+
+	repository_interface "event_manager/internal/domain/repository"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MongoTransactor chạy transaction MongoDB (cần replica set, Atlas luôn có)
+type MongoTransactor struct {
+	client *mongo.Client
+}
+
+// ✅ Khởi tạo transactor từ client dùng chung của ứng dụng
+func NewMongoTransactor(client *mongo.Client) repository_interface.Transactor {
+	return &MongoTransactor{client: client}
+}
+
+// WithTransaction mở session, chạy fn trong transaction và tự retry khi gặp lỗi tạm thời
+func (t *MongoTransactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	session, err := t.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	})
+	return err
+}
@@ -46,12 +46,13 @@ func (r *UserMongoRepository) Update(ctx context.Context, user *models.UserModel
 	filter := bson.M{"_id": user.ID}
 	update := bson.M{
 		"$set": bson.M{
-			"username":   user.Username,
-			"email":      user.Email,
-			"full_name":  user.FullName,
-			"status":     user.Status,
-			"role":       user.Role,
-			"updated_at": user.UpdatedAt,
+			"username":      user.Username,
+			"email":         user.Email,
+			"full_name":     user.FullName,
+			"password_hash": user.PasswordHash,
+			"status":        user.Status,
+			"role":          user.Role,
+			"updated_at":    user.UpdatedAt,
 		},
 	}
 
@@ -142,3 +143,13 @@ func (r *UserMongoRepository) List(ctx context.Context, limit, offset int) ([]*m
 	}
 	return users, nil
 }
+
+// 🔢 Đếm tổng số user
+func (r *UserMongoRepository) Count(ctx context.Context) (int64, error) {
+	return r.col.CountDocuments(ctx, bson.M{})
+}
+
+// 🔢 Đếm số user đang hoạt động theo role
+func (r *UserMongoRepository) CountByRole(ctx context.Context, role string) (int64, error) {
+	return r.col.CountDocuments(ctx, bson.M{"role": role, "status": "active"})
+}
@@ -109,6 +109,86 @@ func (s *AggregateServiceImpl) TopEventsByGuestCount(ctx context.Context, limit
 	return result, nil
 }
 
+// LiveOccupancy returns events with guests on site, busiest first.
+func (s *AggregateServiceImpl) LiveOccupancy(ctx context.Context) ([]*entity.EventOccupancy, error) {
+	aggs, err := s.repo.AggregateLiveOccupancy(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("aggregate live occupancy failed: %w", err)
+	}
+	result := make([]*entity.EventOccupancy, 0, len(aggs))
+	for _, agg := range aggs {
+		if agg == nil {
+			continue
+		}
+		result = append(result, agg.ToEntity())
+	}
+	return result, nil
+}
+
+// OccupancyByEvent returns the headcount of the specified event.
+func (s *AggregateServiceImpl) OccupancyByEvent(ctx context.Context, eventID string) (*entity.EventOccupancy, error) {
+	if strings.TrimSpace(eventID) == "" {
+		return nil, errors.New("event id is required")
+	}
+	agg, err := s.repo.AggregateOccupancyByEvent(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("aggregate occupancy by event failed: %w", err)
+	}
+	if agg == nil {
+		return &entity.EventOccupancy{EventID: eventID}, nil
+	}
+	return agg.ToEntity(), nil
+}
+
+// DwellTimeByEvent returns average stay durations of the specified event.
+func (s *AggregateServiceImpl) DwellTimeByEvent(ctx context.Context, eventID string) (*entity.EventDwellTime, error) {
+	if strings.TrimSpace(eventID) == "" {
+		return nil, errors.New("event id is required")
+	}
+	agg, err := s.repo.AggregateDwellTimeByEvent(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("aggregate dwell time by event failed: %w", err)
+	}
+	if agg == nil {
+		return &entity.EventDwellTime{EventID: eventID}, nil
+	}
+	return agg.ToEntity(), nil
+}
+
+// GuestStatsBySession returns aggregated guest statistics per session of an event.
+func (s *AggregateServiceImpl) GuestStatsBySession(ctx context.Context, eventID string) ([]*entity.SessionGuestStat, error) {
+	if strings.TrimSpace(eventID) == "" {
+		return nil, errors.New("event id is required")
+	}
+	aggs, err := s.repo.AggregateGuestStatsBySession(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("aggregate guest stats by session failed: %w", err)
+	}
+	result := make([]*entity.SessionGuestStat, 0, len(aggs))
+	for _, agg := range aggs {
+		if agg == nil {
+			continue
+		}
+		result = append(result, agg.ToEntity())
+	}
+	return result, nil
+}
+
+// GuestStatsSummaryBySession returns attendance statistics for the specified session.
+func (s *AggregateServiceImpl) GuestStatsSummaryBySession(ctx context.Context, sessionID string) (*entity.SessionGuestStat, error) {
+	if strings.TrimSpace(sessionID) == "" {
+		return nil, errors.New("session id is required")
+	}
+	agg, err := s.repo.AggregateSessionStat(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("aggregate session stats failed: %w", err)
+	}
+	if agg == nil {
+		return nil, fmt.Errorf("session %w", service_interface.ErrNotFound)
+	}
+	return agg.ToEntity(), nil
+}
+
 func mapRegistrationModels(modelsList []*models.RegistrationModel) []*entity.Registration {
 	result := make([]*entity.Registration, 0, len(modelsList))
 	for _, m := range modelsList {
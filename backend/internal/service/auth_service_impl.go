@@ -0,0 +1,202 @@
+package service_imple
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"event_manager/internal/domain/entity"
+	repository "event_manager/internal/domain/repository"
+	service_interface "event_manager/internal/domain/service"
+	"event_manager/internal/models"
+	utils "event_manager/util"
+)
+
+// refreshTokenPrefix đứng trước phần random của refresh token (xem utils.GenToken)
+const refreshTokenPrefix = "rt"
+
+// AuthConfig chứa secret và thời hạn của token
+type AuthConfig struct {
+	JWTSecret       string
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+}
+
+// AuthServiceImpl implements AuthService
+type AuthServiceImpl struct {
+	userRepo  repository.UserRepository
+	tokenRepo repository.RefreshTokenRepository
+	cfg       AuthConfig
+}
+
+// NewAuthService wires dependencies into an AuthService implementation.
+func NewAuthService(
+	userRepo repository.UserRepository,
+	tokenRepo repository.RefreshTokenRepository,
+	cfg AuthConfig,
+) service_interface.AuthService {
+	if cfg.AccessTokenTTL <= 0 {
+		cfg.AccessTokenTTL = 15 * time.Minute
+	}
+	if cfg.RefreshTokenTTL <= 0 {
+		cfg.RefreshTokenTTL = 30 * 24 * time.Hour
+	}
+	return &AuthServiceImpl{
+		userRepo:  userRepo,
+		tokenRepo: tokenRepo,
+		cfg:       cfg,
+	}
+}
+
+// Login kiểm tra thông tin đăng nhập và cấp token
+func (s *AuthServiceImpl) Login(ctx context.Context, username, password string) (*entity.TokenPair, error) {
+	username = strings.TrimSpace(username)
+	if username == "" || password == "" {
+		return nil, service_interface.ErrInvalidCredentials
+	}
+
+	user, err := s.userRepo.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("find user failed: %w", err)
+	}
+	if user == nil || user.PasswordHash == "" {
+		return nil, service_interface.ErrInvalidCredentials
+	}
+	if err := utils.ComparePassword(user.PasswordHash, password); err != nil {
+		return nil, service_interface.ErrInvalidCredentials
+	}
+	if user.Status != "active" {
+		return nil, service_interface.ErrUserInactive
+	}
+
+	return s.issueTokens(ctx, user.ID.Hex())
+}
+
+// Refresh xoay vòng refresh token
+func (s *AuthServiceImpl) Refresh(ctx context.Context, refreshToken string) (*entity.TokenPair, error) {
+	stored, err := s.findRefreshToken(ctx, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// Token đã bị thu hồi mà vẫn được dùng lại => có thể đã bị lộ, thu hồi toàn bộ phiên của user
+	if stored.RevokedAt != nil {
+		if err := s.tokenRepo.RevokeAllByUser(ctx, stored.UserID); err != nil {
+			return nil, fmt.Errorf("revoke user sessions failed: %w", err)
+		}
+		return nil, service_interface.ErrInvalidToken
+	}
+	if time.Now().After(stored.ExpiresAt) {
+		return nil, service_interface.ErrInvalidToken
+	}
+
+	revoked, err := s.tokenRepo.Revoke(ctx, stored.ID.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("revoke refresh token failed: %w", err)
+	}
+	if !revoked {
+		// Một request khác vừa xoay vòng token này
+		return nil, service_interface.ErrInvalidToken
+	}
+
+	user, err := s.userRepo.FindByID(ctx, stored.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("find user failed: %w", err)
+	}
+	if user == nil {
+		return nil, service_interface.ErrInvalidToken
+	}
+	if user.Status != "active" {
+		return nil, service_interface.ErrUserInactive
+	}
+
+	return s.issueTokens(ctx, stored.UserID)
+}
+
+// Logout thu hồi refresh token
+func (s *AuthServiceImpl) Logout(ctx context.Context, refreshToken string) error {
+	stored, err := s.findRefreshToken(ctx, refreshToken)
+	if err != nil {
+		return err
+	}
+	if stored.RevokedAt != nil {
+		return nil
+	}
+	if _, err := s.tokenRepo.Revoke(ctx, stored.ID.Hex()); err != nil {
+		return fmt.Errorf("revoke refresh token failed: %w", err)
+	}
+	return nil
+}
+
+// Authenticate xác thực access token và load user hiện tại.
+// User được đọc lại từ DB để việc khoá tài khoản có hiệu lực ngay.
+func (s *AuthServiceImpl) Authenticate(ctx context.Context, accessToken string) (*entity.User, error) {
+	userID, err := utils.ParseJWT(s.cfg.JWTSecret, accessToken)
+	if err != nil {
+		return nil, service_interface.ErrInvalidToken
+	}
+
+	m, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("find user failed: %w", err)
+	}
+	if m == nil {
+		return nil, service_interface.ErrInvalidToken
+	}
+	if m.Status != "active" {
+		return nil, service_interface.ErrUserInactive
+	}
+	return m.UserModelToEntity(), nil
+}
+
+// ========================================
+// 🧩 Helper
+// ========================================
+
+func (s *AuthServiceImpl) findRefreshToken(ctx context.Context, refreshToken string) (*models.RefreshTokenModel, error) {
+	refreshToken = strings.TrimSpace(refreshToken)
+	if refreshToken == "" {
+		return nil, service_interface.ErrInvalidToken
+	}
+
+	stored, err := s.tokenRepo.FindByHash(ctx, utils.HashToken(refreshToken))
+	if err != nil {
+		return nil, fmt.Errorf("find refresh token failed: %w", err)
+	}
+	if stored == nil {
+		return nil, service_interface.ErrInvalidToken
+	}
+	return stored, nil
+}
+
+func (s *AuthServiceImpl) issueTokens(ctx context.Context, userID string) (*entity.TokenPair, error) {
+	now := time.Now()
+
+	accessToken, err := utils.GenJWT(s.cfg.JWTSecret, userID, s.cfg.AccessTokenTTL)
+	if err != nil {
+		return nil, fmt.Errorf("sign access token failed: %w", err)
+	}
+
+	refreshToken, err := utils.GenToken(refreshTokenPrefix, 32)
+	if err != nil {
+		return nil, fmt.Errorf("generate refresh token failed: %w", err)
+	}
+
+	record := models.RefreshTokenEntityToModel(&entity.RefreshToken{
+		UserID:    userID,
+		TokenHash: utils.HashToken(refreshToken),
+		ExpiresAt: now.Add(s.cfg.RefreshTokenTTL),
+		CreatedAt: now,
+	})
+	if err := s.tokenRepo.Insert(ctx, record); err != nil {
+		return nil, fmt.Errorf("store refresh token failed: %w", err)
+	}
+
+	return &entity.TokenPair{
+		AccessToken:      accessToken,
+		AccessExpiresAt:  now.Add(s.cfg.AccessTokenTTL),
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: record.ExpiresAt,
+	}, nil
+}
@@ -0,0 +1,212 @@
+package service_imple
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"event_manager/internal/domain/entity"
+	repository "event_manager/internal/domain/repository"
+	service_interface "event_manager/internal/domain/service"
+	"event_manager/internal/models"
+	utils "event_manager/util"
+)
+
+// calendarTokenPrefix đứng trước phần random của token feed lịch (xem utils.GenToken)
+const calendarTokenPrefix = "cal"
+
+// CalendarServiceImpl triển khai CalendarService
+type CalendarServiceImpl struct {
+	tokenRepo        repository.CalendarTokenRepository
+	eventRepo        repository.EventRepository
+	registrationRepo repository.RegistrationRepository
+	guestRepo        repository.GuestRepository
+	userRepo         repository.UserRepository
+}
+
+// NewCalendarService wires dependencies into a CalendarService implementation.
+func NewCalendarService(
+	tokenRepo repository.CalendarTokenRepository,
+	eventRepo repository.EventRepository,
+	registrationRepo repository.RegistrationRepository,
+	guestRepo repository.GuestRepository,
+	userRepo repository.UserRepository,
+) service_interface.CalendarService {
+	return &CalendarServiceImpl{
+		tokenRepo:        tokenRepo,
+		eventRepo:        eventRepo,
+		registrationRepo: registrationRepo,
+		guestRepo:        guestRepo,
+		userRepo:         userRepo,
+	}
+}
+
+// IssueToken cấp token mới; user chỉ tự cấp cho mình (admin cấp cho bất kỳ ai).
+func (s *CalendarServiceImpl) IssueToken(ctx context.Context, subjectType, subjectID string) (string, error) {
+	subjectID = strings.TrimSpace(subjectID)
+	if err := s.checkSubject(ctx, subjectType, subjectID); err != nil {
+		return "", err
+	}
+	if err := s.tokenRepo.RevokeBySubject(ctx, subjectType, subjectID); err != nil {
+		return "", fmt.Errorf("revoke calendar tokens failed: %w", err)
+	}
+
+	token, err := utils.GenToken(calendarTokenPrefix, 32)
+	if err != nil {
+		return "", fmt.Errorf("generate calendar token failed: %w", err)
+	}
+	record := &models.CalendarTokenModel{
+		SubjectType: subjectType,
+		SubjectID:   subjectID,
+		TokenHash:   utils.HashToken(token),
+		CreatedAt:   time.Now(),
+	}
+	if caller := utils.UserFromContext(ctx); caller != nil {
+		record.IssuedBy = caller.ID
+	}
+	if err := s.tokenRepo.Insert(ctx, record); err != nil {
+		return "", fmt.Errorf("store calendar token failed: %w", err)
+	}
+	return token, nil
+}
+
+// RevokeTokens thu hồi mọi token feed của chủ thể
+func (s *CalendarServiceImpl) RevokeTokens(ctx context.Context, subjectType, subjectID string) error {
+	subjectID = strings.TrimSpace(subjectID)
+	if err := s.checkSubject(ctx, subjectType, subjectID); err != nil {
+		return err
+	}
+	return s.tokenRepo.RevokeBySubject(ctx, subjectType, subjectID)
+}
+
+// Feed dựng danh sách sự kiện cho token: sự kiện user tổ chức, hoặc sự kiện khách đã đăng ký (chưa huỷ)
+func (s *CalendarServiceImpl) Feed(ctx context.Context, token string) (*entity.CalendarFeed, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, service_interface.ErrInvalidToken
+	}
+	stored, err := s.tokenRepo.FindByHash(ctx, utils.HashToken(token))
+	if err != nil {
+		return nil, fmt.Errorf("find calendar token failed: %w", err)
+	}
+	if stored == nil || stored.RevokedAt != nil {
+		return nil, service_interface.ErrInvalidToken
+	}
+	// 🔒 Người cấp token bị khoá thì feed cũng ngừng (token khách do người tổ chức cấp)
+	if stored.IssuedBy != "" {
+		active, err := s.activeUser(ctx, stored.IssuedBy)
+		if err != nil {
+			return nil, err
+		}
+		if !active {
+			return nil, service_interface.ErrInvalidToken
+		}
+	}
+
+	var (
+		name   string
+		events []*models.EventModel
+	)
+	switch stored.SubjectType {
+	case entity.CalendarSubjectUser:
+		user, err := s.userRepo.FindByID(ctx, stored.SubjectID)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil || user.Status != "active" {
+			return nil, service_interface.ErrInvalidToken
+		}
+		name = user.FullName
+		if events, err = s.eventRepo.FindByOrganizer(ctx, stored.SubjectID); err != nil {
+			return nil, err
+		}
+	case entity.CalendarSubjectGuest:
+		guest, err := s.guestRepo.FindByID(ctx, stored.SubjectID)
+		if err != nil {
+			return nil, err
+		}
+		if guest == nil {
+			return nil, service_interface.ErrInvalidToken
+		}
+		name = guest.FullName
+		if events, err = s.guestEvents(ctx, stored.SubjectID); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, service_interface.ErrInvalidToken
+	}
+
+	feed := &entity.CalendarFeed{Name: name, Events: make([]*entity.Event, 0, len(events))}
+	for _, m := range events {
+		e := m.EventEntityToModel()
+		feed.Events = append(feed.Events, &e)
+	}
+	sort.Slice(feed.Events, func(i, j int) bool { return feed.Events[i].StartDate.Before(feed.Events[j].StartDate) })
+	return feed, nil
+}
+
+// guestEvents lấy các sự kiện khách còn đăng ký (bỏ đăng ký đã huỷ)
+func (s *CalendarServiceImpl) guestEvents(ctx context.Context, guestID string) ([]*models.EventModel, error) {
+	regs, err := s.registrationRepo.FindByGuest(ctx, guestID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(regs))
+	for _, r := range regs {
+		if entity.ParseRegistrationStatus(r.Status) == entity.RegistrationCancelled {
+			continue
+		}
+		ids = append(ids, r.EventID)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return s.eventRepo.FindByIDs(ctx, ids)
+}
+
+// activeUser cho biết user còn tồn tại và đang hoạt động
+func (s *CalendarServiceImpl) activeUser(ctx context.Context, userID string) (bool, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return false, fmt.Errorf("find token issuer failed: %w", err)
+	}
+	return user != nil && user.Status == "active", nil
+}
+
+// checkSubject kiểm tra chủ thể tồn tại và người gọi có quyền cấp / thu hồi token cho chủ thể đó
+func (s *CalendarServiceImpl) checkSubject(ctx context.Context, subjectType, subjectID string) error {
+	if subjectID == "" {
+		return fmt.Errorf("%s id is required", subjectType)
+	}
+	switch subjectType {
+	case entity.CalendarSubjectUser:
+		if caller := utils.UserFromContext(ctx); caller != nil && caller.ID != subjectID &&
+			entity.ParseRole(caller.Role) != entity.RoleAdmin {
+			return service_interface.ErrForbidden
+		}
+		user, err := s.userRepo.FindByID(ctx, subjectID)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return fmt.Errorf("user %w", service_interface.ErrNotFound)
+		}
+	case entity.CalendarSubjectGuest:
+		guest, err := s.guestRepo.FindByID(ctx, subjectID)
+		if err != nil {
+			return err
+		}
+		if guest == nil {
+			return fmt.Errorf("guest %w", service_interface.ErrNotFound)
+		}
+		// Feed lộ mọi sự kiện khách tham gia nên người gọi phải quản lý được khách trên các sự kiện đó
+		if err := authorizeGuestEvents(ctx, s.registrationRepo, s.eventRepo, s.guestRepo, subjectID); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown calendar subject %q", subjectType)
+	}
+	return nil
+}
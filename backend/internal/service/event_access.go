@@ -0,0 +1,176 @@
+package service_imple
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"event_manager/internal/domain/entity"
+	repo "event_manager/internal/domain/repository"
+	service_interface "event_manager/internal/domain/service"
+	"event_manager/internal/models"
+	utils "event_manager/util"
+)
+
+// systemActor ghi vào lịch sử khi thao tác không gắn với user (job nền, tự động)
+const systemActor = "system"
+
+type actorKey struct{}
+
+// contextWithActor ghi tên người thực hiện cho thao tác không có user (vd "kiosk:<id>")
+func contextWithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// detachedContext dựng context cho việc chạy nền sau khi request kết thúc: chỉ chép user và người
+// thực hiện sang context mới. Không dùng context.WithoutCancel(ctx) vì ctx của handler có thể là
+// *gin.Context, bị gin tái sử dụng cho request khác ngay khi handler trả về.
+func detachedContext(ctx context.Context) context.Context {
+	detached := context.Background()
+	if user := utils.UserFromContext(ctx); user != nil {
+		u := *user
+		detached = utils.ContextWithUser(detached, &u)
+	}
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		detached = contextWithActor(detached, actor)
+	}
+	return detached
+}
+
+// actorFromContext trả về ID của user trong ctx, người thực hiện gắn bằng contextWithActor, hoặc systemActor
+func actorFromContext(ctx context.Context) string {
+	if user := utils.UserFromContext(ctx); user != nil {
+		return user.ID
+	}
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return systemActor
+}
+
+// canAccessEvent kiểm tra user có quyền perm trên sự kiện hay không:
+// admin luôn được phép, chủ sự kiện có mọi quyền, đồng tổ chức chỉ có quyền được cấp.
+// Sự kiện cũ chưa có chủ chỉ admin được thao tác.
+func canAccessEvent(user *entity.User, event *models.EventModel, perm entity.EventPermission) bool {
+	if user == nil || event == nil {
+		return false
+	}
+	if entity.ParseRole(user.Role) == entity.RoleAdmin {
+		return true
+	}
+	if event.OwnerID != "" && event.OwnerID == user.ID {
+		return true
+	}
+	for _, co := range event.CoOrganizers {
+		if co.UserID != user.ID {
+			continue
+		}
+		for _, p := range co.Permissions {
+			if entity.EventPermission(p) == perm {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// authorizeEvent kiểm tra quyền của user trong ctx trên sự kiện.
+// Lời gọi nội bộ không gắn user (job nền, webhook) được coi là tin cậy; mọi route HTTP
+// đều đi qua middleware.RequireAuth nên luôn có user.
+func authorizeEvent(ctx context.Context, event *models.EventModel, perm entity.EventPermission) error {
+	user := utils.UserFromContext(ctx)
+	if user == nil {
+		return nil
+	}
+	if !canAccessEvent(user, event, perm) {
+		return service_interface.ErrForbidden
+	}
+	return nil
+}
+
+// authorizeEventOwner chỉ cho phép chủ sự kiện hoặc admin (xoá sự kiện, đổi người tổ chức)
+func authorizeEventOwner(ctx context.Context, event *models.EventModel) error {
+	user := utils.UserFromContext(ctx)
+	if user == nil {
+		return nil
+	}
+	if entity.ParseRole(user.Role) == entity.RoleAdmin {
+		return nil
+	}
+	if event == nil || event.OwnerID == "" || event.OwnerID != user.ID {
+		return service_interface.ErrForbidden
+	}
+	return nil
+}
+
+// authorizeEventByID load sự kiện rồi kiểm tra quyền; trả lỗi nếu sự kiện không tồn tại
+func authorizeEventByID(ctx context.Context, eventRepo repo.EventRepository, eventID string, perm entity.EventPermission) (*models.EventModel, error) {
+	eventID = strings.TrimSpace(eventID)
+	if eventID == "" {
+		return nil, errors.New("event id is required")
+	}
+
+	event, err := eventRepo.FindByID(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("find event failed: %w", err)
+	}
+	if event == nil {
+		return nil, fmt.Errorf("event %w", service_interface.ErrNotFound)
+	}
+	if err := authorizeEvent(ctx, event, perm); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// authorizeGuestEvents yêu cầu quyền manage_guests trên mọi sự kiện khách đã đăng ký,
+// để người tổ chức không sửa được khách thuộc sự kiện của người khác. Khách chưa đăng ký
+// sự kiện nào (còn tồn tại) chỉ admin hoặc người tạo khách được thao tác.
+func authorizeGuestEvents(ctx context.Context, registrationRepo repo.RegistrationRepository, eventRepo repo.EventRepository, guestRepo repo.GuestRepository, guestID string) error {
+	user := utils.UserFromContext(ctx)
+	if user == nil {
+		return nil
+	}
+
+	regs, err := registrationRepo.FindByGuest(ctx, guestID)
+	if err != nil {
+		return fmt.Errorf("find registration by guest failed: %w", err)
+	}
+
+	checked := map[string]struct{}{}
+	var found bool
+	for _, reg := range regs {
+		if reg == nil {
+			continue
+		}
+		if _, ok := checked[reg.EventID]; ok {
+			continue
+		}
+		checked[reg.EventID] = struct{}{}
+
+		_, err := authorizeEventByID(ctx, eventRepo, reg.EventID, entity.EventPermManageGuests)
+		if errors.Is(err, service_interface.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		found = true
+	}
+	if found || entity.ParseRole(user.Role) == entity.RoleAdmin {
+		return nil
+	}
+
+	guest, err := guestRepo.FindByID(ctx, guestID)
+	if err != nil {
+		return fmt.Errorf("find guest failed: %w", err)
+	}
+	if guest == nil {
+		return fmt.Errorf("guest %w", service_interface.ErrNotFound)
+	}
+	if guest.CreatedBy == "" || guest.CreatedBy != user.ID {
+		return service_interface.ErrForbidden
+	}
+	return nil
+}
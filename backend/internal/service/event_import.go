@@ -0,0 +1,183 @@
+package service_imple
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"event_manager/internal/domain/entity"
+	"event_manager/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// defaultImportType là loại sự kiện gán cho sự kiện nhập mới khi client không chỉ định
+const defaultImportType = "Sự kiện mở"
+
+// 📥 ImportEvents nhập các VEVENT đã đọc từ file .ics. Mỗi UID ứng với một sự kiện (hoặc một chuỗi
+// lặp): chưa có thì tạo, đã có thì cập nhật nếu khác, nên nhập lại cùng một file không tạo trùng.
+// DryRun chỉ kiểm tra dữ liệu và báo cáo hành động, không tạo địa điểm / sự kiện và không kiểm tra
+// trùng lịch địa điểm. Lỗi của từng VEVENT được ghi vào báo cáo, không dừng cả lần nhập.
+func (s *EventServiceImpl) ImportEvents(ctx context.Context, items []entity.ImportedEvent, opts entity.ImportOptions) (*entity.ImportReport, error) {
+	if strings.TrimSpace(opts.Type) == "" {
+		opts.Type = defaultImportType
+	}
+
+	report := &entity.ImportReport{DryRun: opts.DryRun, Items: make([]entity.ImportItem, 0, len(items))}
+	seen := make(map[string]struct{}, len(items))
+	for _, item := range items {
+		result := entity.ImportItem{UID: item.UID, Name: item.Name}
+		if _, dup := seen[item.UID]; dup && item.UID != "" {
+			result.Action, result.Error = entity.ImportActionError, "duplicate UID in file"
+		} else {
+			seen[item.UID] = struct{}{}
+			if err := s.importOne(ctx, item, opts, &result); err != nil {
+				result.Action, result.Error = entity.ImportActionError, err.Error()
+			}
+		}
+
+		switch result.Action {
+		case entity.ImportActionCreate:
+			report.Created++
+		case entity.ImportActionUpdate:
+			report.Updated++
+		case entity.ImportActionUnchanged:
+			report.Unchanged++
+		default:
+			report.Failed++
+		}
+		report.Items = append(report.Items, result)
+	}
+	return report, nil
+}
+
+// importOne xử lý một VEVENT và điền hành động vào result
+func (s *EventServiceImpl) importOne(ctx context.Context, item entity.ImportedEvent, opts entity.ImportOptions, result *entity.ImportItem) error {
+	if item.ParseError != "" {
+		return errors.New(item.ParseError)
+	}
+	if item.Name == "" {
+		return errors.New("missing SUMMARY")
+	}
+	if item.EndDate.Before(item.StartDate) {
+		return errors.New("DTEND is before DTSTART")
+	}
+	location := item.Location
+	if location == "" {
+		location = strings.TrimSpace(opts.DefaultLocation)
+	}
+	if location == "" {
+		return errors.New("missing LOCATION")
+	}
+	if item.Recurrence != nil {
+		if err := item.Recurrence.Validate(); err != nil {
+			return err
+		}
+		result.Occurrences = len(expandSeries(*item.Recurrence, item.StartDate))
+		if result.Occurrences == 0 {
+			return errors.New("RRULE produces no occurrences")
+		}
+	}
+
+	e := &entity.Event{
+		Name:         item.Name,
+		Description:  item.Description,
+		Type:         opts.Type,
+		Location:     location,
+		StartDate:    item.StartDate,
+		EndDate:      item.EndDate,
+		Recurrence:   item.Recurrence,
+		ICalUID:      item.UID,
+		AllowOverlap: opts.AllowOverlap,
+	}
+
+	existing, err := s.eventRepo.FindByICalUID(ctx, item.UID)
+	if err != nil {
+		return err
+	}
+
+	// 🟢 UID mới → tạo sự kiện / chuỗi
+	if len(existing) == 0 {
+		result.Action = entity.ImportActionCreate
+		if opts.DryRun {
+			return nil
+		}
+		e.ID = uuid.New().String()
+		if err := s.Create(ctx, e); err != nil {
+			return err
+		}
+		result.EventID = e.ID
+		return nil
+	}
+
+	// 🟡 UID đã có → cập nhật (giữ loại, giới hạn khách, ảnh của sự kiện hiện tại)
+	first := existing[0]
+	result.EventID = first.ID
+	e.ID = first.ID
+	e.Type = first.Type
+	e.MaxGuests = first.MaxGuests
+	e.ImageURLs = first.ImageURLs
+
+	series, _, err := s.loadSeries(ctx, first)
+	if err != nil {
+		return err
+	}
+	if series == nil && item.Recurrence == nil {
+		if sameImportedEvent(first, item, location) {
+			result.Action = entity.ImportActionUnchanged
+			return nil
+		}
+		result.Action = entity.ImportActionUpdate
+		if opts.DryRun {
+			return nil
+		}
+		return s.Update(ctx, e)
+	}
+	if item.Recurrence == nil {
+		return errors.New("recurrence was removed from this UID; delete the existing series before re-importing")
+	}
+
+	if series != nil && sameImportedEvent(first, item, location) && sameRecurrence(series, item) {
+		result.Action = entity.ImportActionUnchanged
+		return nil
+	}
+	result.Action = entity.ImportActionUpdate
+	if opts.DryRun {
+		return nil
+	}
+	// UpdateSeries dịch cả chuỗi theo độ lệch của buổi first; quy đổi để DTSTART mới đúng bằng item.StartDate
+	if series != nil {
+		e.StartDate = item.StartDate.Add(first.StartDate.Sub(series.DTStart))
+		e.EndDate = e.StartDate.Add(item.EndDate.Sub(item.StartDate))
+	}
+	return s.UpdateSeries(ctx, e, entity.SeriesScopeAll)
+}
+
+// sameImportedEvent so sánh nội dung VEVENT với sự kiện (buổi đầu tiên của chuỗi) đã nhập trước đó
+func sameImportedEvent(m *models.EventModel, item entity.ImportedEvent, location string) bool {
+	return m.Name == item.Name &&
+		m.Description == item.Description &&
+		models.LocationNameKey(m.Location) == models.LocationNameKey(location) &&
+		m.EndDate.Sub(m.StartDate) == item.EndDate.Sub(item.StartDate) &&
+		(m.SeriesID != "" || m.StartDate.Equal(item.StartDate))
+}
+
+// sameRecurrence so sánh quy tắc lặp, DTSTART và EXDATE của chuỗi với VEVENT
+func sameRecurrence(series *entity.EventSeries, item entity.ImportedEvent) bool {
+	if !series.DTStart.Equal(item.StartDate) || series.Recurrence.String() != item.Recurrence.String() {
+		return false
+	}
+	if len(series.Recurrence.ExDates) != len(item.Recurrence.ExDates) {
+		return false
+	}
+	stored := make(map[int64]struct{}, len(series.Recurrence.ExDates))
+	for _, t := range series.Recurrence.ExDates {
+		stored[t.UnixNano()] = struct{}{}
+	}
+	for _, t := range item.Recurrence.ExDates {
+		if _, ok := stored[t.UnixNano()]; !ok {
+			return false
+		}
+	}
+	return true
+}
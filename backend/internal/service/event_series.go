@@ -0,0 +1,493 @@
+package service_imple
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"event_manager/internal/domain/entity"
+	service_interface "event_manager/internal/domain/service"
+	"event_manager/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// ========================================
+// 🔁 Chuỗi sự kiện lặp lại
+// Mỗi buổi là một Event riêng (đăng ký, check-in, đánh giá riêng) tham chiếu series_id;
+// quy tắc lặp và EXDATE lưu ở collection "event_series".
+// ========================================
+
+// 🟢 createSeries tạo chuỗi từ sự kiện mẫu: buổi đầu tiên giữ ID của sự kiện mẫu
+func (s *EventServiceImpl) createSeries(ctx context.Context, e *entity.Event) error {
+	if err := e.Recurrence.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", service_interface.ErrInvalidRecurrence, err)
+	}
+	starts := expandSeries(*e.Recurrence, e.StartDate)
+	if len(starts) == 0 {
+		return fmt.Errorf("%w: rule produces no occurrences", service_interface.ErrInvalidRecurrence)
+	}
+	if err := s.resolveLocation(ctx, e); err != nil {
+		return err
+	}
+
+	series := &entity.EventSeries{
+		ID:         uuid.New().String(),
+		DTStart:    e.StartDate,
+		Recurrence: *e.Recurrence,
+	}
+	duration := e.EndDate.Sub(e.StartDate)
+
+	occurrences := make([]*models.EventModel, 0, len(starts))
+	for i, start := range starts {
+		m := seriesOccurrence(e, series.ID, start, duration)
+		if i == 0 {
+			m.ID = e.ID
+		}
+		occurrences = append(occurrences, m)
+	}
+	// 💾 Chuỗi và các buổi được ghi cùng một transaction để không còn chuỗi mồ côi khi lỗi giữa chừng
+	err := s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.checkSeriesVenue(ctx, e, occurrences, nil); err != nil {
+			return err
+		}
+		if err := s.seriesRepo.Insert(ctx, models.EventSeriesEntityToModel(series)); err != nil {
+			return err
+		}
+		return s.eventRepo.InsertMany(ctx, occurrences)
+	})
+	if err != nil {
+		return err
+	}
+	e.SeriesID = series.ID
+	return nil
+}
+
+// 🟡 UpdateSeries sửa buổi e.ID theo phạm vi. Với "following" / "all", độ lệch thời gian của buổi
+// đang sửa được áp cho mọi buổi trong phạm vi; các buổi được sinh lại theo quy tắc (mới hoặc cũ):
+// buổi khớp được cập nhật, buổi mới được thêm, buổi không còn trong quy tắc bị xoá nếu chưa có đăng ký.
+// "following" từ giữa chuỗi sẽ tách phần sau thành chuỗi mới, chuỗi cũ kết thúc trước buổi này.
+func (s *EventServiceImpl) UpdateSeries(ctx context.Context, e *entity.Event, scope entity.SeriesScope) error {
+	if e == nil || e.ID == "" {
+		return errors.New("invalid event")
+	}
+	if scope == "" || scope == entity.SeriesScopeThis {
+		if e.Recurrence != nil {
+			return fmt.Errorf("%w: recurrence can only be changed for following or all occurrences", service_interface.ErrInvalidRecurrence)
+		}
+		return s.Update(ctx, e)
+	}
+	if !scope.IsValid() {
+		return fmt.Errorf("%w: unknown scope %q", service_interface.ErrInvalidRecurrence, scope)
+	}
+	if e.Recurrence != nil {
+		if err := e.Recurrence.Validate(); err != nil {
+			return fmt.Errorf("%w: %v", service_interface.ErrInvalidRecurrence, err)
+		}
+	}
+
+	anchor, err := s.eventRepo.FindByID(ctx, e.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get existing event: %w", err)
+	}
+	if anchor == nil {
+		return fmt.Errorf("event %w", service_interface.ErrNotFound)
+	}
+
+	series, occurrences, err := s.loadSeries(ctx, anchor)
+	if err != nil {
+		return err
+	}
+	if series == nil {
+		// Sự kiện đơn: không đổi quy tắc thì như sửa thường, có quy tắc thì biến thành chuỗi
+		if e.Recurrence == nil {
+			return s.Update(ctx, e)
+		}
+		anchor.OccurrenceStart = anchor.StartDate
+		series = &entity.EventSeries{DTStart: anchor.StartDate, Recurrence: *e.Recurrence}
+		occurrences = []*models.EventModel{anchor}
+	}
+
+	// ⏱️ Thời gian mới của buổi đang sửa → độ lệch áp cho cả phạm vi
+	newStart, newEnd := e.StartDate, e.EndDate
+	if newStart.IsZero() {
+		newStart = anchor.StartDate
+	}
+	if newEnd.IsZero() {
+		newEnd = newStart.Add(anchor.EndDate.Sub(anchor.StartDate))
+	}
+	shift := newStart.Sub(anchor.StartDate)
+	duration := newEnd.Sub(newStart)
+
+	tailFrom := series.DTStart
+	if scope == entity.SeriesScopeFollowing {
+		tailFrom = anchor.OccurrenceStart
+	}
+	var head, tail []*models.EventModel
+	for _, o := range occurrences {
+		if o.OccurrenceStart.Before(tailFrom) {
+			head = append(head, o)
+		} else {
+			tail = append(tail, o)
+		}
+	}
+	for _, o := range tail {
+		if err := authorizeEvent(ctx, o, entity.EventPermEdit); err != nil {
+			return err
+		}
+	}
+
+	// 📐 Quy tắc cho phần được sửa
+	oldRule := series.Recurrence
+	newRule := oldRule
+	if e.Recurrence != nil {
+		newRule = *e.Recurrence
+	} else if len(head) > 0 && oldRule.Count > 0 {
+		// Giữ tổng số buổi: phần sau chỉ còn số buổi chưa diễn ra theo quy tắc cũ
+		newRule.Count = oldRule.Count - countBefore(oldRule, series.DTStart, tailFrom)
+		if newRule.Count < 1 {
+			newRule.Count = 1
+		}
+	}
+	if e.Recurrence == nil || len(e.Recurrence.ExDates) == 0 {
+		newRule.ExDates = nil
+		for _, t := range oldRule.ExDates {
+			if !t.Before(tailFrom) {
+				newRule.ExDates = append(newRule.ExDates, t.Add(shift))
+			}
+		}
+	}
+	newDTStart := tailFrom.Add(shift)
+	starts := expandSeries(newRule, newDTStart)
+	if len(starts) == 0 {
+		return fmt.Errorf("%w: rule produces no occurrences", service_interface.ErrInvalidRecurrence)
+	}
+
+	// 📍 Địa điểm dùng chung cho cả phạm vi
+	e.StartDate, e.EndDate = newStart, newEnd
+	keepLocation(e, anchor)
+	if err := s.resolveLocation(ctx, e); err != nil {
+		return err
+	}
+
+	// 🔗 Ghép buổi cũ với thời điểm mới theo thời điểm gốc đã dịch
+	existing := make(map[int64]*models.EventModel, len(tail))
+	for _, o := range tail {
+		existing[o.OccurrenceStart.Add(shift).UnixNano()] = o
+	}
+	var kept, created []*models.EventModel
+	recount := map[string]bool{}
+	previous := map[string]*models.EventModel{}
+	for _, start := range starts {
+		m := seriesOccurrence(e, "", start, duration)
+		if o, ok := existing[start.UnixNano()]; ok {
+			delete(existing, start.UnixNano())
+			m.ID = o.ID
+			previous[m.ID] = o
+			// 🪑 Số chỗ mới phải đủ cho các đăng ký đang giữ chỗ của từng buổi
+			if recount[m.ID], err = s.checkSeats(ctx, o, m); err != nil {
+				return err
+			}
+			kept = append(kept, m)
+			continue
+		}
+		created = append(created, m)
+	}
+	removed := make([]*models.EventModel, 0, len(existing))
+	for _, o := range existing {
+		if err := s.ensureOccurrenceUnused(ctx, o); err != nil {
+			return err
+		}
+		removed = append(removed, o)
+	}
+
+	exclude := make(map[string]struct{}, len(tail))
+	for _, o := range tail {
+		exclude[o.ID] = struct{}{}
+	}
+	booked := append(append([]*models.EventModel{}, kept...), created...)
+
+	// 💾 Ghi chuỗi: tách chuỗi mới nếu sửa từ giữa, ngược lại cập nhật tại chỗ.
+	// Mọi thao tác ghi nằm trong một transaction; hàm có thể được chạy lại nên chỉ dùng giá trị đã tính sẵn.
+	seriesID := series.ID
+	target := &entity.EventSeries{ID: seriesID, DTStart: newDTStart, Recurrence: newRule}
+	split := len(head) > 0
+	if split {
+		headRule := oldRule
+		headRule.Count = 0
+		headRule.Until = tailFrom.Add(-time.Second)
+		headRule.ExDates = nil
+		for _, t := range oldRule.ExDates {
+			if t.Before(tailFrom) {
+				headRule.ExDates = append(headRule.ExDates, t)
+			}
+		}
+		series.Recurrence = headRule
+	}
+	if split || seriesID == "" {
+		target.ID = uuid.New().String()
+	}
+	for _, m := range created {
+		m.SeriesID = target.ID
+		m.OwnerID = anchor.OwnerID
+		m.CoOrganizers = anchor.CoOrganizers
+		if m.ICalUID == "" {
+			m.ICalUID = anchor.ICalUID
+		}
+	}
+
+	return s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.checkSeriesVenue(ctx, e, booked, exclude); err != nil {
+			return err
+		}
+		switch {
+		case split:
+			if err := s.seriesRepo.Update(ctx, models.EventSeriesEntityToModel(series)); err != nil {
+				return err
+			}
+			if err := s.seriesRepo.Insert(ctx, models.EventSeriesEntityToModel(target)); err != nil {
+				return err
+			}
+		case seriesID == "":
+			if err := s.seriesRepo.Insert(ctx, models.EventSeriesEntityToModel(target)); err != nil {
+				return err
+			}
+		default:
+			if err := s.seriesRepo.Update(ctx, models.EventSeriesEntityToModel(target)); err != nil {
+				return err
+			}
+		}
+
+		for _, m := range kept {
+			if err := s.eventRepo.Update(ctx, m); err != nil {
+				return err
+			}
+			if err := s.eventRepo.SetOccurrence(ctx, m.ID, target.ID, m.OccurrenceStart); err != nil {
+				return err
+			}
+			if recount[m.ID] {
+				if err := s.resetSeats(ctx, m.ID); err != nil {
+					return err
+				}
+			}
+			if err := s.fillWaitlist(ctx, previous[m.ID], m); err != nil {
+				return err
+			}
+		}
+		if err := s.eventRepo.InsertMany(ctx, created); err != nil {
+			return err
+		}
+		for _, o := range removed {
+			if err := s.eventRepo.Delete(ctx, o.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// 🔴 DeleteSeries xoá buổi eventID theo phạm vi; xoá "this" ghi thêm EXDATE để buổi không bị sinh lại
+func (s *EventServiceImpl) DeleteSeries(ctx context.Context, eventID string, scope entity.SeriesScope) error {
+	if eventID == "" {
+		return errors.New("missing event ID")
+	}
+	if scope == "" {
+		scope = entity.SeriesScopeThis
+	}
+	if !scope.IsValid() {
+		return fmt.Errorf("%w: unknown scope %q", service_interface.ErrInvalidRecurrence, scope)
+	}
+
+	anchor, err := s.eventRepo.FindByID(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to get existing event: %w", err)
+	}
+	if anchor == nil {
+		return fmt.Errorf("event %w", service_interface.ErrNotFound)
+	}
+	series, occurrences, err := s.loadSeries(ctx, anchor)
+	if err != nil {
+		return err
+	}
+	if series == nil {
+		return s.Delete(ctx, eventID)
+	}
+
+	tailFrom := series.DTStart
+	switch scope {
+	case entity.SeriesScopeThis:
+		if err := authorizeEventOwner(ctx, anchor); err != nil {
+			return err
+		}
+		if err := s.ensureOccurrenceUnused(ctx, anchor); err != nil {
+			return err
+		}
+		series.Recurrence.ExDates = append(series.Recurrence.ExDates, anchor.OccurrenceStart)
+		return s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+			if err := s.eventRepo.Delete(ctx, anchor.ID); err != nil {
+				return err
+			}
+			if len(occurrences) == 1 {
+				return s.seriesRepo.Delete(ctx, series.ID)
+			}
+			return s.seriesRepo.Update(ctx, models.EventSeriesEntityToModel(series))
+		})
+	case entity.SeriesScopeFollowing:
+		tailFrom = anchor.OccurrenceStart
+	}
+
+	var headCount int
+	targets := make([]*models.EventModel, 0, len(occurrences))
+	for _, o := range occurrences {
+		if o.OccurrenceStart.Before(tailFrom) {
+			headCount++
+			continue
+		}
+		if err := authorizeEventOwner(ctx, o); err != nil {
+			return err
+		}
+		if err := s.ensureOccurrenceUnused(ctx, o); err != nil {
+			return err
+		}
+		targets = append(targets, o)
+	}
+
+	series.Recurrence.Count = 0
+	series.Recurrence.Until = tailFrom.Add(-time.Second)
+	return s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		for _, o := range targets {
+			if err := s.eventRepo.Delete(ctx, o.ID); err != nil {
+				return err
+			}
+		}
+		if headCount == 0 {
+			return s.seriesRepo.Delete(ctx, series.ID)
+		}
+		return s.seriesRepo.Update(ctx, models.EventSeriesEntityToModel(series))
+	})
+}
+
+// 🔒 ensureOccurrenceUnused từ chối xoá buổi đã có khách đăng ký hoặc đơn vé
+func (s *EventServiceImpl) ensureOccurrenceUnused(ctx context.Context, o *models.EventModel) error {
+	if err := s.ensureEventUnused(ctx, o); err != nil {
+		if errors.Is(err, service_interface.ErrEventInUse) {
+			return fmt.Errorf("%w: %s", service_interface.ErrOccurrenceInUse, o.StartDate.Format(time.RFC3339))
+		}
+		return err
+	}
+	return nil
+}
+
+// 📋 ListOccurrences trả về quy tắc lặp và các buổi của chuỗi chứa sự kiện
+func (s *EventServiceImpl) ListOccurrences(ctx context.Context, eventID string) (*entity.EventSeries, []*entity.Event, error) {
+	anchor, err := s.eventRepo.FindByID(ctx, eventID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if anchor == nil {
+		return nil, nil, fmt.Errorf("event %w", service_interface.ErrNotFound)
+	}
+	series, occurrences, err := s.loadSeries(ctx, anchor)
+	if err != nil {
+		return nil, nil, err
+	}
+	if series == nil {
+		occurrences = []*models.EventModel{anchor}
+	}
+
+	events := make([]*entity.Event, 0, len(occurrences))
+	for _, m := range occurrences {
+		e := m.EventEntityToModel()
+		events = append(events, &e)
+	}
+	return series, events, nil
+}
+
+// loadSeries đọc chuỗi và các buổi của sự kiện; series = nil nếu là sự kiện đơn
+func (s *EventServiceImpl) loadSeries(ctx context.Context, anchor *models.EventModel) (*entity.EventSeries, []*models.EventModel, error) {
+	if anchor.SeriesID == "" {
+		return nil, nil, nil
+	}
+	sm, err := s.seriesRepo.FindByID(ctx, anchor.SeriesID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if sm == nil {
+		return nil, nil, nil
+	}
+	series, err := models.EventSeriesModelToEntity(sm)
+	if err != nil {
+		return nil, nil, err
+	}
+	occurrences, err := s.eventRepo.FindBySeries(ctx, series.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return series, occurrences, nil
+}
+
+// checkSeriesVenue khoá lịch địa điểm và gom xung đột của mọi buổi (bỏ qua các sự kiện trong exclude);
+// như checkVenue, phải gọi trong transaction ghi các buổi
+func (s *EventServiceImpl) checkSeriesVenue(ctx context.Context, e *entity.Event, occurrences []*models.EventModel, exclude map[string]struct{}) error {
+	if err := s.locations.LockBookings(ctx, e.LocationID); err != nil {
+		return err
+	}
+	if e.AllowOverlap {
+		return nil
+	}
+	var conflicts []entity.VenueBooking
+	for _, o := range occurrences {
+		found, err := s.venueConflicts(ctx, e.LocationID, o.StartDate, o.EndDate, exclude)
+		if err != nil {
+			return err
+		}
+		conflicts = append(conflicts, found...)
+	}
+	if len(conflicts) > 0 {
+		return &service_interface.VenueConflictError{Conflicts: conflicts}
+	}
+	return nil
+}
+
+// seriesOccurrence dựng một buổi từ sự kiện mẫu
+func seriesOccurrence(e *entity.Event, seriesID string, start time.Time, duration time.Duration) *models.EventModel {
+	return &models.EventModel{
+		Name:            e.Name,
+		Description:     e.Description,
+		Type:            e.Type,
+		Status:          getStatusByTime(start, start.Add(duration)),
+		LocationID:      e.LocationID,
+		Location:        e.Location,
+		MaxGuests:       e.MaxGuests,
+		StartDate:       start,
+		EndDate:         start.Add(duration),
+		ImageURLs:       e.ImageURLs,
+		OwnerID:         e.OwnerID,
+		SeriesID:        seriesID,
+		OccurrenceStart: start,
+		ICalUID:         e.ICalUID,
+	}
+}
+
+// expandSeries sinh các buổi; chuỗi không có COUNT / UNTIL chỉ sinh trong RecurrenceHorizon
+func expandSeries(rule entity.Recurrence, dtstart time.Time) []time.Time {
+	var horizon time.Time
+	if rule.Count == 0 && rule.Until.IsZero() {
+		horizon = dtstart.Add(entity.RecurrenceHorizon)
+	}
+	return rule.Expand(dtstart, entity.MaxSeriesOccurrences, horizon)
+}
+
+// countBefore đếm số buổi theo quy tắc (tính cả buổi bị EXDATE) trước mốc t
+func countBefore(rule entity.Recurrence, dtstart, t time.Time) int {
+	rule.ExDates = nil
+	n := 0
+	for _, start := range expandSeries(rule, dtstart) {
+		if !start.Before(t) {
+			break
+		}
+		n++
+	}
+	return n
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"event_manager/internal/domain/entity"
 	repo "event_manager/internal/domain/repository"
 	service_interface "event_manager/internal/domain/service"
 	"event_manager/internal/models"
+	utils "event_manager/util"
 )
 
 // ========================================
@@ -18,16 +20,33 @@ import (
 type EventServiceImpl struct {
 	eventRepo        repo.EventRepository
 	registrationRepo repo.RegistrationRepository
+	orderRepo        repo.OrderRepository
+	guestRepo        repo.GuestRepository
+	seriesRepo       repo.EventSeriesRepository
+	locations        service_interface.LocationService
+	tx               repo.Transactor
+	seats            seatAllocator
 }
 
 // ✅ Khởi tạo service
 func NewEventService(
 	eventRepo repo.EventRepository,
 	registrationRepo repo.RegistrationRepository,
+	orderRepo repo.OrderRepository,
+	guestRepo repo.GuestRepository,
+	seriesRepo repo.EventSeriesRepository,
+	locations service_interface.LocationService,
+	tx repo.Transactor,
 ) service_interface.EventService {
 	return &EventServiceImpl{
 		eventRepo:        eventRepo,
 		registrationRepo: registrationRepo,
+		orderRepo:        orderRepo,
+		guestRepo:        guestRepo,
+		seriesRepo:       seriesRepo,
+		locations:        locations,
+		tx:               tx,
+		seats:            seatAllocator{eventRepo: eventRepo, registrationRepo: registrationRepo},
 	}
 }
 
@@ -37,21 +56,119 @@ func (s *EventServiceImpl) Create(ctx context.Context, e *entity.Event) error {
 		return errors.New("event is nil")
 	}
 
+	// 👤 Người tạo trở thành chủ sự kiện
+	if e.OwnerID == "" {
+		if user := utils.UserFromContext(ctx); user != nil {
+			e.OwnerID = user.ID
+		}
+	}
+	// 🔁 Có quy tắc lặp: tạo chuỗi và sinh từng buổi
+	if e.Recurrence != nil {
+		return s.createSeries(ctx, e)
+	}
+	if err := s.resolveLocation(ctx, e); err != nil {
+		return err
+	}
+
 	model := &models.EventModel{
 		ID:          e.ID,
 		Name:        e.Name,
 		Description: e.Description,
 		Type:        e.Type,
 		Status:      getStatusByTime(e.StartDate, e.EndDate),
+		LocationID:  e.LocationID,
 		Location:    e.Location,
 		MaxGuests:   e.MaxGuests,
 		StartDate:   e.StartDate,
 		EndDate:     e.EndDate,
 		ImageURLs:   e.ImageURLs,
+		OwnerID:     e.OwnerID,
+		ICalUID:     e.ICalUID,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
-	return s.eventRepo.Insert(ctx, model)
+	return s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.checkVenue(ctx, e); err != nil {
+			return err
+		}
+		return s.eventRepo.Insert(ctx, model)
+	})
+}
+
+// 📍 checkVenue khoá lịch địa điểm rồi kiểm tra địa điểm không bị đặt trùng giờ. Phải gọi trong
+// transaction cùng với thao tác ghi sự kiện: hai request song song cùng khoá một địa điểm thì
+// request sau bị chạy lại và thấy sự kiện request trước vừa ghi.
+func (s *EventServiceImpl) checkVenue(ctx context.Context, e *entity.Event) error {
+	if err := s.locations.LockBookings(ctx, e.LocationID); err != nil {
+		return err
+	}
+
+	// ⛔ Không cho hai sự kiện chiếm cùng địa điểm (tính cả setup / teardown), trừ khi client xác nhận
+	if e.AllowOverlap {
+		return nil
+	}
+	conflicts, err := s.venueConflicts(ctx, e.LocationID, e.StartDate, e.EndDate, map[string]struct{}{e.ID: {}})
+	if err != nil {
+		return err
+	}
+	if len(conflicts) > 0 {
+		return &service_interface.VenueConflictError{Conflicts: conflicts}
+	}
+	return nil
+}
+
+// keepLocation giữ địa điểm cũ khi client không gửi; sự kiện tạo trước khi có danh mục địa điểm
+// không có location_id nên dùng tên địa điểm cũ để tìm / tạo địa điểm tương ứng
+func keepLocation(e *entity.Event, old *models.EventModel) {
+	if e.LocationID != "" || e.Location != "" {
+		return
+	}
+	e.LocationID = old.LocationID
+	if e.LocationID == "" {
+		e.Location = old.Location
+	}
+}
+
+// resolveLocation gắn sự kiện với địa điểm: ưu tiên LocationID, nếu chỉ có tên thì tìm/tạo địa điểm
+// theo tên đã chuẩn hoá; tên hiển thị luôn lấy từ địa điểm và MaxGuests không vượt sức chứa
+func (s *EventServiceImpl) resolveLocation(ctx context.Context, e *entity.Event) error {
+	var (
+		loc *entity.Location
+		err error
+	)
+	switch {
+	case strings.TrimSpace(e.LocationID) != "":
+		loc, err = s.locations.GetByID(ctx, e.LocationID)
+	case strings.TrimSpace(e.Location) != "":
+		loc, err = s.locations.Resolve(ctx, e.Location)
+	default:
+		return errors.New("location is required")
+	}
+	if err != nil {
+		return err
+	}
+
+	if e.MaxGuests > 0 && !loc.Fits(int(e.MaxGuests)) {
+		return fmt.Errorf("%w: %d > %d", service_interface.ErrExceedsCapacity, e.MaxGuests, loc.Capacity)
+	}
+	e.LocationID = loc.ID
+	e.Location = loc.Name
+	return nil
+}
+
+// venueConflicts liệt kê các sự kiện trùng lịch địa điểm, bỏ qua các sự kiện trong exclude
+func (s *EventServiceImpl) venueConflicts(ctx context.Context, locationID string, start, end time.Time, exclude map[string]struct{}) ([]entity.VenueBooking, error) {
+	conflicts, err := s.locations.FindConflicts(ctx, locationID, start, end, "")
+	if err != nil {
+		return nil, err
+	}
+	result := conflicts[:0]
+	for _, c := range conflicts {
+		if _, ok := exclude[c.EventID]; !ok {
+			result = append(result, c)
+		}
+	}
+	return result, nil
 }
 
 // 🟡 Cập nhật thông tin sự kiện
@@ -65,6 +182,12 @@ func (s *EventServiceImpl) Update(ctx context.Context, e *entity.Event) error {
 	if err != nil {
 		return fmt.Errorf("failed to get existing event: %w", err)
 	}
+	if old == nil {
+		return fmt.Errorf("event %w", service_interface.ErrNotFound)
+	}
+	if err := authorizeEvent(ctx, old, entity.EventPermEdit); err != nil {
+		return err
+	}
 
 	// ⚙️ Giữ lại nếu client không gửi
 	if e.StartDate.IsZero() {
@@ -76,6 +199,10 @@ func (s *EventServiceImpl) Update(ctx context.Context, e *entity.Event) error {
 	if e.Status == "" {
 		e.Status = old.Status
 	}
+	keepLocation(e, old)
+	if err := s.resolveLocation(ctx, e); err != nil {
+		return err
+	}
 
 	model := &models.EventModel{
 		ID:          e.ID,
@@ -83,6 +210,7 @@ func (s *EventServiceImpl) Update(ctx context.Context, e *entity.Event) error {
 		Description: e.Description,
 		Type:        e.Type,
 		Status:      getStatusByTime(e.StartDate, e.EndDate),
+		LocationID:  e.LocationID,
 		Location:    e.Location,
 		MaxGuests:   e.MaxGuests,
 		StartDate:   e.StartDate,
@@ -91,7 +219,67 @@ func (s *EventServiceImpl) Update(ctx context.Context, e *entity.Event) error {
 		UpdatedAt:   time.Now(),
 	}
 
-	return s.eventRepo.Update(ctx, model)
+	recount, err := s.checkSeats(ctx, old, model)
+	if err != nil {
+		return err
+	}
+	return s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.checkVenue(ctx, e); err != nil {
+			return err
+		}
+		if err := s.eventRepo.Update(ctx, model); err != nil {
+			return err
+		}
+		if recount {
+			if err := s.resetSeats(ctx, model.ID); err != nil {
+				return err
+			}
+		}
+		return s.fillWaitlist(ctx, old, model)
+	})
+}
+
+// 🪑 checkSeats không cho giảm max_guests của sự kiện giới hạn xuống dưới số chỗ đã giữ. Sự kiện mở
+// không đếm chỗ nên khi chuyển sang giới hạn (hoặc chưa có bộ đếm) trả về recount = true: caller gọi
+// resetSeats sau khi lưu để đếm lại từ các đăng ký hiện có
+func (s *EventServiceImpl) checkSeats(ctx context.Context, old, updated *models.EventModel) (recount bool, err error) {
+	if !updated.IsLimited() {
+		return false, nil
+	}
+	recount = !old.IsLimited() || old.SeatsTaken == 0
+	taken := old.SeatsTaken
+	if recount {
+		if taken, err = s.registrationRepo.CountHoldingSeat(ctx, old.ID); err != nil {
+			return false, fmt.Errorf("count registrations failed: %w", err)
+		}
+	}
+	if int(updated.MaxGuests) < taken {
+		return false, fmt.Errorf("%w: %d < %d", service_interface.ErrBelowSeatsTaken, updated.MaxGuests, taken)
+	}
+	return recount, nil
+}
+
+// fillWaitlist đưa đăng ký waitlist lên khi lần cập nhật tăng max_guests hoặc bỏ giới hạn chỗ
+func (s *EventServiceImpl) fillWaitlist(ctx context.Context, old, updated *models.EventModel) error {
+	grew := old.IsLimited() && (!updated.IsLimited() || updated.MaxGuests > old.MaxGuests)
+	if !grew {
+		return nil
+	}
+	_, err := s.seats.fill(ctx, updated)
+	return err
+}
+
+// resetSeats ghi lại bộ đếm chỗ từ số đăng ký đang giữ chỗ; đếm sau khi lưu để tính cả đăng ký tạo
+// trong lúc cập nhật
+func (s *EventServiceImpl) resetSeats(ctx context.Context, eventID string) error {
+	taken, err := s.registrationRepo.CountHoldingSeat(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("count registrations failed: %w", err)
+	}
+	if err := s.eventRepo.SetSeatCounter(ctx, eventID, taken); err != nil {
+		return fmt.Errorf("reset seat counter failed: %w", err)
+	}
+	return nil
 }
 
 // 🔴 Xoá sự kiện
@@ -99,66 +287,145 @@ func (s *EventServiceImpl) Delete(ctx context.Context, eventID string) error {
 	if eventID == "" {
 		return errors.New("missing event ID")
 	}
-	return s.eventRepo.Delete(ctx, eventID)
+
+	old, err := s.eventRepo.FindByID(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to get existing event: %w", err)
+	}
+	if old == nil {
+		return fmt.Errorf("event %w", service_interface.ErrNotFound)
+	}
+	if err := authorizeEventOwner(ctx, old); err != nil {
+		return err
+	}
+	// 🔒 Không xoá sự kiện đã có đăng ký hoặc đơn vé: phiên đã chọn, vé và khoản đã thanh toán
+	// sẽ mồ côi. Người tổ chức huỷ sự kiện (status cancelled) thay vì xoá.
+	if err := s.ensureEventUnused(ctx, old); err != nil {
+		return err
+	}
+	if err := s.eventRepo.Delete(ctx, eventID); err != nil {
+		return err
+	}
+	if err := s.guestRepo.RemoveEventFromAll(ctx, eventID); err != nil {
+		return fmt.Errorf("unlink guests from event failed: %w", err)
+	}
+	return nil
 }
 
-// 🔍 Lấy thông tin chi tiết sự kiện
-func (s *EventServiceImpl) GetByID(ctx context.Context, eventID string) (*entity.Event, error) {
-	m, err := s.eventRepo.FindByID(ctx, eventID)
-	if err != nil || m == nil {
-		return nil, err
+// ensureEventUnused trả về ErrEventInUse khi sự kiện đã có đăng ký (kèm đăng ký phiên) hoặc đơn vé
+func (s *EventServiceImpl) ensureEventUnused(ctx context.Context, event *models.EventModel) error {
+	regs, err := s.registrationRepo.FindByEvent(ctx, event.ID)
+	if err != nil {
+		return fmt.Errorf("find registrations failed: %w", err)
 	}
-	return &entity.Event{
-		ID:          m.ID,
-		Name:        m.Name,
-		Description: m.Description,
-		Type:        m.Type,
-		Status:      m.Status,
-		Location:    m.Location,
-		MaxGuests:   m.MaxGuests,
-		StartDate:   m.StartDate,
-		EndDate:     m.EndDate,
-		ImageURLs:   m.ImageURLs,
-		CreatedAt:   m.CreatedAt,
-		UpdatedAt:   m.UpdatedAt,
-	}, nil
+	if len(regs) > 0 {
+		return fmt.Errorf("%w: %d registrations", service_interface.ErrEventInUse, len(regs))
+	}
+	orders, err := s.orderRepo.FindByEvent(ctx, event.ID, "")
+	if err != nil {
+		return fmt.Errorf("find orders failed: %w", err)
+	}
+	if len(orders) > 0 {
+		return fmt.Errorf("%w: %d orders", service_interface.ErrEventInUse, len(orders))
+	}
+	return nil
 }
 
-// 📋 Lấy danh sách sự kiện (lọc theo trạng thái / thời gian)
-func (s *EventServiceImpl) List(ctx context.Context, status string, from, to time.Time) ([]*entity.Event, error) {
-	all, err := s.eventRepo.FindAll(ctx)
+// 👥 Đổi chủ sự kiện / danh sách đồng tổ chức
+func (s *EventServiceImpl) SetOrganizers(ctx context.Context, eventID, ownerID string, coOrganizers []entity.CoOrganizer) error {
+	if eventID == "" {
+		return errors.New("missing event ID")
+	}
+
+	old, err := s.eventRepo.FindByID(ctx, eventID)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("failed to get existing event: %w", err)
+	}
+	if old == nil {
+		return fmt.Errorf("event %w", service_interface.ErrNotFound)
+	}
+	if err := authorizeEventOwner(ctx, old); err != nil {
+		return err
 	}
 
-	var result []*entity.Event
-	for _, m := range all {
-		if !from.IsZero() && m.StartDate.Before(from) {
+	ownerID = strings.TrimSpace(ownerID)
+	if ownerID == "" {
+		ownerID = old.OwnerID
+	}
+	if ownerID == "" {
+		return errors.New("event owner is required")
+	}
+
+	// Chuẩn hoá: bỏ trùng, bỏ chủ sự kiện khỏi danh sách đồng tổ chức, kiểm tra quyền hợp lệ
+	seen := map[string]struct{}{}
+	cleaned := make([]entity.CoOrganizer, 0, len(coOrganizers))
+	for _, co := range coOrganizers {
+		userID := strings.TrimSpace(co.UserID)
+		if userID == "" || userID == ownerID {
 			continue
 		}
-		if !to.IsZero() && m.EndDate.After(to) {
+		if _, ok := seen[userID]; ok {
 			continue
 		}
-		if status != "" && status != "Tất cả" && m.Status != status {
-			continue
+		seen[userID] = struct{}{}
+
+		for _, p := range co.Permissions {
+			if !p.IsValid() {
+				return fmt.Errorf("invalid event permission: %s", p)
+			}
 		}
+		cleaned = append(cleaned, entity.CoOrganizer{UserID: userID, Permissions: co.Permissions})
+	}
+
+	return s.eventRepo.UpdateOrganizers(ctx, eventID, ownerID, models.CoOrganizerEntitiesToModels(cleaned))
+}
 
-		result = append(result, &entity.Event{
-			ID:          m.ID,
-			Name:        m.Name,
-			Description: m.Description,
-			Type:        m.Type,
-			Status:      m.Status,
-			Location:    m.Location,
-			MaxGuests:   m.MaxGuests,
-			StartDate:   m.StartDate,
-			EndDate:     m.EndDate,
-			ImageURLs:   m.ImageURLs,
-			CreatedAt:   m.CreatedAt,
-			UpdatedAt:   m.UpdatedAt,
-		})
+// 🔐 Kiểm tra quyền sửa sự kiện trước các bước tốn kém (tải ảnh); Update vẫn kiểm tra lại
+func (s *EventServiceImpl) AuthorizeEdit(ctx context.Context, eventID string) error {
+	_, err := authorizeEventByID(ctx, s.eventRepo, eventID, entity.EventPermEdit)
+	return err
+}
+
+// 🔍 Lấy thông tin chi tiết sự kiện
+func (s *EventServiceImpl) GetByID(ctx context.Context, eventID string) (*entity.Event, error) {
+	m, err := s.eventRepo.FindByID(ctx, eventID)
+	if err != nil || m == nil {
+		return nil, err
 	}
-	return result, nil
+	e := m.EventEntityToModel()
+	return &e, nil
+}
+
+// 📋 Lấy danh sách sự kiện (lọc / sắp xếp / phân trang chạy trong Mongo)
+func (s *EventServiceImpl) List(ctx context.Context, q entity.EventQuery) (*entity.EventPage, error) {
+	q.Normalize()
+	if !q.From.IsZero() && !q.To.IsZero() && q.To.Before(q.From) {
+		return nil, fmt.Errorf("%w: 'to' must not be before 'from'", service_interface.ErrInvalidQuery)
+	}
+
+	list, total, next, err := s.eventRepo.FindByQuery(ctx, q)
+	if err != nil {
+		if errors.Is(err, repo.ErrInvalidCursor) {
+			return nil, fmt.Errorf("%w: %v", service_interface.ErrInvalidQuery, err)
+		}
+		return nil, err
+	}
+
+	page := &entity.EventPage{
+		Events:     make([]*entity.Event, 0, len(list)),
+		Total:      total,
+		Page:       q.Page,
+		PageSize:   q.PageSize,
+		NextCursor: next,
+	}
+	if q.Cursor != "" {
+		page.Page = 0
+	}
+	for _, m := range list {
+		e := m.EventEntityToModel()
+		page.Events = append(page.Events, &e)
+	}
+	return page, nil
 }
 
 // 🔄 Tự động cập nhật trạng thái sự kiện
@@ -0,0 +1,514 @@
+package service_imple
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"event_manager/internal/domain/entity"
+	repository "event_manager/internal/domain/repository"
+	service_interface "event_manager/internal/domain/service"
+	"event_manager/internal/models"
+)
+
+// EventSessionServiceImpl triển khai EventSessionService
+type EventSessionServiceImpl struct {
+	repo             repository.EventSessionRepository
+	sessionRegRepo   repository.SessionRegistrationRepository
+	eventRepo        repository.EventRepository
+	registrationRepo repository.RegistrationRepository
+	guestRepo        repository.GuestRepository
+	seats            sessionSeats
+}
+
+// NewEventSessionService wires dependencies into an EventSessionService implementation.
+func NewEventSessionService(
+	repo repository.EventSessionRepository,
+	sessionRegRepo repository.SessionRegistrationRepository,
+	eventRepo repository.EventRepository,
+	registrationRepo repository.RegistrationRepository,
+	guestRepo repository.GuestRepository,
+) service_interface.EventSessionService {
+	return &EventSessionServiceImpl{
+		repo:             repo,
+		sessionRegRepo:   sessionRegRepo,
+		eventRepo:        eventRepo,
+		registrationRepo: registrationRepo,
+		guestRepo:        guestRepo,
+		seats:            sessionSeats{sessionRepo: repo, sessionRegRepo: sessionRegRepo},
+	}
+}
+
+// Create thêm phiên vào chương trình của sự kiện.
+func (s *EventSessionServiceImpl) Create(ctx context.Context, session *entity.EventSession) error {
+	if session == nil {
+		return errors.New("session is nil")
+	}
+	event, err := authorizeEventByID(ctx, s.eventRepo, strings.TrimSpace(session.EventID), entity.EventPermEdit)
+	if err != nil {
+		return err
+	}
+	session.EventID = event.ID
+	if err := s.validate(ctx, event, session); err != nil {
+		return err
+	}
+
+	session.ID = ""
+	session.SeatsTaken = 0
+	session.CreatedAt = time.Now()
+	session.UpdatedAt = time.Time{}
+	model, err := models.EventSessionEntityToModel(session)
+	if err != nil {
+		return fmt.Errorf("map session to model failed: %w", err)
+	}
+	if err := s.repo.Insert(ctx, model); err != nil {
+		return fmt.Errorf("insert session failed: %w", err)
+	}
+	session.ID = model.ID.Hex()
+	return nil
+}
+
+// Update sửa thông tin phiên; sự kiện và bộ đếm chỗ của phiên không đổi.
+func (s *EventSessionServiceImpl) Update(ctx context.Context, session *entity.EventSession) error {
+	if session == nil {
+		return errors.New("session is nil")
+	}
+	model, err := s.load(ctx, session.EventID, session.ID)
+	if err != nil {
+		return err
+	}
+	event, err := authorizeEventByID(ctx, s.eventRepo, model.EventID, entity.EventPermEdit)
+	if err != nil {
+		return err
+	}
+
+	session.EventID = model.EventID
+	if err := s.validate(ctx, event, session); err != nil {
+		return err
+	}
+	// Chỉ chặn khi có giới hạn: phiên không giới hạn vẫn đếm chỗ nhưng không có sức chứa để so
+	if session.IsLimited() && session.Capacity < model.SeatsTaken {
+		return fmt.Errorf("%w: capacity %d is below the %d guests already registered", service_interface.ErrInvalidSession, session.Capacity, model.SeatsTaken)
+	}
+
+	model.Title = session.Title
+	model.Description = session.Description
+	model.Room = session.Room
+	model.Speakers = session.Speakers
+	model.StartTime = session.StartTime
+	model.EndTime = session.EndTime
+	model.Capacity = session.Capacity
+	model.UpdatedAt = time.Now()
+	if err := s.repo.Update(ctx, model); err != nil {
+		return fmt.Errorf("update session failed: %w", err)
+	}
+
+	*session = *model.EventSessionModelToEntity()
+	return nil
+}
+
+// Delete xoá phiên; phiên còn khách đăng ký phải được huỷ đăng ký trước.
+func (s *EventSessionServiceImpl) Delete(ctx context.Context, eventID, sessionID string) error {
+	model, err := s.load(ctx, eventID, sessionID)
+	if err != nil {
+		return err
+	}
+	if _, err := authorizeEventByID(ctx, s.eventRepo, model.EventID, entity.EventPermEdit); err != nil {
+		return err
+	}
+
+	active, err := s.sessionRegRepo.CountActiveBySession(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("count session registrations failed: %w", err)
+	}
+	if active > 0 {
+		return fmt.Errorf("%w (%d guests)", service_interface.ErrSessionInUse, active)
+	}
+	if err := s.repo.Delete(ctx, sessionID); err != nil {
+		return fmt.Errorf("delete session failed: %w", err)
+	}
+	return nil
+}
+
+// GetByID trả về một phiên thuộc sự kiện.
+func (s *EventSessionServiceImpl) GetByID(ctx context.Context, eventID, sessionID string) (*entity.EventSession, error) {
+	model, err := s.load(ctx, eventID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return model.EventSessionModelToEntity(), nil
+}
+
+// ListByEvent trả về chương trình của sự kiện.
+func (s *EventSessionServiceImpl) ListByEvent(ctx context.Context, eventID string) ([]*entity.EventSession, error) {
+	eventID = strings.TrimSpace(eventID)
+	if eventID == "" {
+		return nil, errors.New("event id is required")
+	}
+
+	event, err := s.eventRepo.FindByID(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("find event failed: %w", err)
+	}
+	if event == nil {
+		return nil, fmt.Errorf("event %w", service_interface.ErrNotFound)
+	}
+
+	list, err := s.repo.FindByEvent(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("list sessions failed: %w", err)
+	}
+	return mapEventSessionModels(list), nil
+}
+
+// Register giữ một chỗ của phiên cho đăng ký sự kiện; khách không được chọn hai phiên trùng giờ.
+func (s *EventSessionServiceImpl) Register(ctx context.Context, eventID, sessionID, registrationID string) (*entity.SessionRegistration, error) {
+	session, reg, err := s.loadForRegistration(ctx, eventID, sessionID, registrationID, entity.EventPermManageRegistrations)
+	if err != nil {
+		return nil, err
+	}
+	if !entity.ParseRegistrationStatus(reg.Status).HoldsSeat() {
+		return nil, service_interface.ErrNotAttending
+	}
+
+	existing, err := s.sessionRegRepo.FindBySessionAndRegistration(ctx, sessionID, registrationID)
+	if err != nil {
+		return nil, fmt.Errorf("find session registration failed: %w", err)
+	}
+	if existing != nil && entity.SessionRegistrationStatus(existing.Status) != entity.SessionCancelled {
+		return s.withGuest(existing, reg), nil
+	}
+
+	if err := s.checkScheduleConflict(ctx, session, registrationID); err != nil {
+		return nil, err
+	}
+
+	ok, err := s.repo.ReserveSeat(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("reserve session seat failed: %w", err)
+	}
+	if !ok {
+		return nil, service_interface.ErrSessionFull
+	}
+
+	now := time.Now()
+	if existing != nil {
+		ok, err = s.sessionRegRepo.Reactivate(ctx, existing.ID.Hex(), now)
+		if err != nil || !ok {
+			_ = s.repo.ReleaseSeat(ctx, sessionID)
+			if err != nil {
+				return nil, fmt.Errorf("reactivate session registration failed: %w", err)
+			}
+			return nil, service_interface.ErrStatusConflict
+		}
+		existing.Status = string(entity.SessionRegistered)
+		existing.CreatedAt = now
+		existing.CheckedInAt = nil
+		existing.CancelledAt = nil
+		return s.withGuest(existing, reg), nil
+	}
+
+	model := &models.SessionRegistrationModel{
+		SessionID:      sessionID,
+		EventID:        session.EventID,
+		RegistrationID: registrationID,
+		Status:         string(entity.SessionRegistered),
+		CreatedAt:      now,
+	}
+	if err := s.sessionRegRepo.Insert(ctx, model); err != nil {
+		_ = s.repo.ReleaseSeat(ctx, sessionID)
+		// Request đồng thời đã tạo bản ghi trước
+		if errors.Is(err, repository.ErrDuplicate) {
+			return nil, service_interface.ErrStatusConflict
+		}
+		return nil, fmt.Errorf("insert session registration failed: %w", err)
+	}
+	return s.withGuest(model, reg), nil
+}
+
+// CancelRegistration huỷ đăng ký phiên và trả chỗ.
+func (s *EventSessionServiceImpl) CancelRegistration(ctx context.Context, eventID, sessionID, registrationID string) (*entity.SessionRegistration, error) {
+	_, reg, err := s.loadForRegistration(ctx, eventID, sessionID, registrationID, entity.EventPermManageRegistrations)
+	if err != nil {
+		return nil, err
+	}
+	model, err := s.loadActive(ctx, sessionID, registrationID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	ok, err := s.sessionRegRepo.Cancel(ctx, model.ID.Hex(), now)
+	if err != nil {
+		return nil, fmt.Errorf("cancel session registration failed: %w", err)
+	}
+	if !ok {
+		return nil, service_interface.ErrStatusConflict
+	}
+	if err := s.repo.ReleaseSeat(ctx, sessionID); err != nil {
+		return nil, fmt.Errorf("release session seat failed: %w", err)
+	}
+
+	model.Status = string(entity.SessionCancelled)
+	model.CancelledAt = &now
+	return s.withGuest(model, reg), nil
+}
+
+// CheckIn ghi nhận khách có mặt tại phiên; khách phải đăng ký phiên trước.
+func (s *EventSessionServiceImpl) CheckIn(ctx context.Context, eventID, sessionID, registrationID string) (*entity.SessionRegistration, error) {
+	_, reg, err := s.loadForRegistration(ctx, eventID, sessionID, registrationID, entity.EventPermCheckIn)
+	if err != nil {
+		return nil, err
+	}
+	if !entity.ParseRegistrationStatus(reg.Status).HoldsSeat() {
+		return nil, service_interface.ErrNotAttending
+	}
+	model, err := s.loadActive(ctx, sessionID, registrationID)
+	if err != nil {
+		return nil, err
+	}
+	if entity.SessionRegistrationStatus(model.Status) == entity.SessionCheckedIn {
+		return nil, service_interface.ErrAlreadyCheckedIn
+	}
+
+	now := time.Now()
+	ok, err := s.sessionRegRepo.CheckIn(ctx, model.ID.Hex(), now)
+	if err != nil {
+		return nil, fmt.Errorf("check in session registration failed: %w", err)
+	}
+	if !ok {
+		return nil, service_interface.ErrStatusConflict
+	}
+
+	model.Status = string(entity.SessionCheckedIn)
+	model.CheckedInAt = &now
+	return s.withGuest(model, reg), nil
+}
+
+// ListRegistrations trả về các đăng ký còn hiệu lực của phiên, kèm khách.
+func (s *EventSessionServiceImpl) ListRegistrations(ctx context.Context, eventID, sessionID string) ([]*entity.SessionRegistration, error) {
+	if _, err := s.load(ctx, eventID, sessionID); err != nil {
+		return nil, err
+	}
+
+	list, err := s.sessionRegRepo.FindBySession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("list session registrations failed: %w", err)
+	}
+	result := make([]*entity.SessionRegistration, 0, len(list))
+	for _, m := range list {
+		result = append(result, m.SessionRegistrationModelToEntity())
+	}
+	if err := s.attachGuests(ctx, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ScheduleByRegistration trả về lịch cá nhân của khách trong sự kiện.
+func (s *EventSessionServiceImpl) ScheduleByRegistration(ctx context.Context, registrationID string) ([]*entity.EventSession, error) {
+	registrationID = strings.TrimSpace(registrationID)
+	if registrationID == "" {
+		return nil, errors.New("registration id is required")
+	}
+	reg, err := s.registrationRepo.FindByID(ctx, registrationID)
+	if err != nil {
+		return nil, fmt.Errorf("find registration failed: %w", err)
+	}
+	if reg == nil {
+		return nil, fmt.Errorf("registration %w", service_interface.ErrNotFound)
+	}
+
+	sessions, err := s.sessionsOf(ctx, registrationID)
+	if err != nil {
+		return nil, err
+	}
+	return mapEventSessionModels(sessions), nil
+}
+
+// validate chuẩn hoá và kiểm tra phiên trước khi lưu
+func (s *EventSessionServiceImpl) validate(ctx context.Context, event *models.EventModel, session *entity.EventSession) error {
+	session.Title = strings.TrimSpace(session.Title)
+	session.Description = strings.TrimSpace(session.Description)
+	session.Room = strings.TrimSpace(session.Room)
+	speakers := make([]string, 0, len(session.Speakers))
+	for _, sp := range session.Speakers {
+		if sp = strings.TrimSpace(sp); sp != "" {
+			speakers = append(speakers, sp)
+		}
+	}
+	session.Speakers = speakers
+
+	if session.Title == "" {
+		return fmt.Errorf("%w: title is required", service_interface.ErrInvalidSession)
+	}
+	if session.StartTime.IsZero() || !session.EndTime.After(session.StartTime) {
+		return fmt.Errorf("%w: end time must be after start time", service_interface.ErrInvalidSession)
+	}
+	if session.Capacity < 0 {
+		return fmt.Errorf("%w: capacity must not be negative", service_interface.ErrInvalidSession)
+	}
+	if !event.StartDate.IsZero() && session.StartTime.Before(event.StartDate) ||
+		!event.EndDate.IsZero() && session.EndTime.After(event.EndDate) {
+		return fmt.Errorf("%w: session must take place within the event", service_interface.ErrInvalidSession)
+	}
+
+	// Một phòng không chứa hai phiên cùng lúc
+	if session.Room == "" {
+		return nil
+	}
+	others, err := s.repo.FindByEvent(ctx, session.EventID)
+	if err != nil {
+		return fmt.Errorf("list sessions failed: %w", err)
+	}
+	for _, o := range others {
+		other := o.EventSessionModelToEntity()
+		if other.ID == session.ID || !strings.EqualFold(other.Room, session.Room) {
+			continue
+		}
+		if session.Overlaps(other) {
+			return fmt.Errorf("%w: room %q is booked for %q", service_interface.ErrSessionConflict, session.Room, other.Title)
+		}
+	}
+	return nil
+}
+
+// checkScheduleConflict chặn khách đăng ký hai phiên trùng giờ
+func (s *EventSessionServiceImpl) checkScheduleConflict(ctx context.Context, session *models.EventSessionModel, registrationID string) error {
+	booked, err := s.sessionsOf(ctx, registrationID)
+	if err != nil {
+		return err
+	}
+	target := session.EventSessionModelToEntity()
+	for _, b := range booked {
+		other := b.EventSessionModelToEntity()
+		if other.ID != target.ID && target.Overlaps(other) {
+			return fmt.Errorf("%w: guest is already registered for %q", service_interface.ErrSessionConflict, other.Title)
+		}
+	}
+	return nil
+}
+
+// sessionsOf trả về các phiên còn hiệu lực của một đăng ký sự kiện, sắp theo giờ bắt đầu
+func (s *EventSessionServiceImpl) sessionsOf(ctx context.Context, registrationID string) ([]*models.EventSessionModel, error) {
+	regs, err := s.sessionRegRepo.FindByRegistration(ctx, registrationID)
+	if err != nil {
+		return nil, fmt.Errorf("find session registrations failed: %w", err)
+	}
+	ids := make([]string, 0, len(regs))
+	for _, r := range regs {
+		ids = append(ids, r.SessionID)
+	}
+	sessions, err := s.repo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("find sessions failed: %w", err)
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].StartTime.Before(sessions[j].StartTime)
+	})
+	return sessions, nil
+}
+
+// load tìm phiên và đảm bảo nó thuộc đúng sự kiện trên đường dẫn
+func (s *EventSessionServiceImpl) load(ctx context.Context, eventID, sessionID string) (*models.EventSessionModel, error) {
+	eventID = strings.TrimSpace(eventID)
+	sessionID = strings.TrimSpace(sessionID)
+	if eventID == "" || sessionID == "" {
+		return nil, errors.New("event id and session id are required")
+	}
+
+	model, err := s.repo.FindByID(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("find session failed: %w", err)
+	}
+	if model == nil || model.EventID != eventID {
+		return nil, fmt.Errorf("session %w", service_interface.ErrNotFound)
+	}
+	return model, nil
+}
+
+// loadForRegistration tìm phiên và đăng ký sự kiện, kiểm tra chúng cùng sự kiện và quyền của user
+func (s *EventSessionServiceImpl) loadForRegistration(ctx context.Context, eventID, sessionID, registrationID string, perm entity.EventPermission) (*models.EventSessionModel, *models.RegistrationModel, error) {
+	session, err := s.load(ctx, eventID, sessionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := authorizeEventByID(ctx, s.eventRepo, session.EventID, perm); err != nil {
+		return nil, nil, err
+	}
+
+	registrationID = strings.TrimSpace(registrationID)
+	if registrationID == "" {
+		return nil, nil, errors.New("registration id is required")
+	}
+	reg, err := s.registrationRepo.FindByID(ctx, registrationID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("find registration failed: %w", err)
+	}
+	if reg == nil || reg.EventID != session.EventID {
+		return nil, nil, fmt.Errorf("registration %w", service_interface.ErrNotFound)
+	}
+	return session, reg, nil
+}
+
+// loadActive tìm đăng ký phiên chưa bị huỷ
+func (s *EventSessionServiceImpl) loadActive(ctx context.Context, sessionID, registrationID string) (*models.SessionRegistrationModel, error) {
+	model, err := s.sessionRegRepo.FindBySessionAndRegistration(ctx, sessionID, registrationID)
+	if err != nil {
+		return nil, fmt.Errorf("find session registration failed: %w", err)
+	}
+	if model == nil || entity.SessionRegistrationStatus(model.Status) == entity.SessionCancelled {
+		return nil, fmt.Errorf("session registration %w", service_interface.ErrNotFound)
+	}
+	return model, nil
+}
+
+// withGuest chuyển bản ghi sang entity, điền khách từ đăng ký sự kiện đã tải
+func (s *EventSessionServiceImpl) withGuest(m *models.SessionRegistrationModel, reg *models.RegistrationModel) *entity.SessionRegistration {
+	e := m.SessionRegistrationModelToEntity()
+	e.GuestID = reg.GuestID
+	return e
+}
+
+// attachGuests điền khách và tên khách để hiển thị cùng đăng ký phiên
+func (s *EventSessionServiceImpl) attachGuests(ctx context.Context, list []*entity.SessionRegistration) error {
+	regIDs := make([]string, 0, len(list))
+	for _, r := range list {
+		regIDs = append(regIDs, r.RegistrationID)
+	}
+	regs, err := s.registrationRepo.FindByIDs(ctx, regIDs)
+	if err != nil {
+		return fmt.Errorf("find registrations failed: %w", err)
+	}
+	guestByReg := make(map[string]string, len(regs))
+	guestIDs := make([]string, 0, len(regs))
+	for _, r := range regs {
+		guestByReg[r.ID.Hex()] = r.GuestID
+		guestIDs = append(guestIDs, r.GuestID)
+	}
+
+	guests, err := s.guestRepo.FindByIDs(ctx, guestIDs)
+	if err != nil {
+		return fmt.Errorf("find guests failed: %w", err)
+	}
+	names := make(map[string]string, len(guests))
+	for _, g := range guests {
+		names[g.ID] = g.FullName
+	}
+	for _, r := range list {
+		r.GuestID = guestByReg[r.RegistrationID]
+		r.GuestName = names[r.GuestID]
+	}
+	return nil
+}
+
+func mapEventSessionModels(list []*models.EventSessionModel) []*entity.EventSession {
+	result := make([]*entity.EventSession, 0, len(list))
+	for _, m := range list {
+		result = append(result, m.EventSessionModelToEntity())
+	}
+	return result
+}
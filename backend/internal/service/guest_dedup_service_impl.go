@@ -0,0 +1,622 @@
+package service_imple
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"event_manager/internal/domain/entity"
+	repository "event_manager/internal/domain/repository"
+	service_interface "event_manager/internal/domain/service"
+	"event_manager/internal/models"
+	utils "event_manager/util"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// dupNameThreshold là độ giống tối thiểu của họ tên (0..1) để coi là trùng tên
+	dupNameThreshold = 0.85
+	// dupMaxGroup: nhóm dùng chung email / SĐT lớn hơn (vd email chung của công ty) không sinh cặp
+	dupMaxGroup = 50
+	// dupMaxNameBlock giới hạn số khách so tên từng đôi trong một nhóm họ + tên
+	dupMaxNameBlock = 200
+	// dupWriteBatch là số cặp ghi mỗi lần
+	dupWriteBatch = 500
+	// dupScanTimeout giới hạn thời gian chạy nền của một lần quét
+	dupScanTimeout = 30 * time.Minute
+)
+
+// GuestDedupServiceImpl phát hiện và gộp khách trùng.
+type GuestDedupServiceImpl struct {
+	guestRepo        repository.GuestRepository
+	registrationRepo repository.RegistrationRepository
+	reviewRepo       repository.ReviewRepository
+	orderRepo        repository.OrderRepository
+	eventRepo        repository.EventRepository
+	calendarTokens   repository.CalendarTokenRepository
+	duplicates       repository.GuestDuplicateRepository
+	tx               repository.Transactor
+	seats            seatAllocator
+	sessions         sessionSeats
+	phoneRegion      string
+	scanning         atomic.Bool
+}
+
+// NewGuestDedupService wires dependencies into a GuestDedupService implementation.
+func NewGuestDedupService(
+	guestRepo repository.GuestRepository,
+	registrationRepo repository.RegistrationRepository,
+	reviewRepo repository.ReviewRepository,
+	orderRepo repository.OrderRepository,
+	eventRepo repository.EventRepository,
+	calendarTokens repository.CalendarTokenRepository,
+	duplicates repository.GuestDuplicateRepository,
+	sessionRepo repository.EventSessionRepository,
+	sessionRegRepo repository.SessionRegistrationRepository,
+	tx repository.Transactor,
+	phoneRegion string,
+) service_interface.GuestDedupService {
+	return &GuestDedupServiceImpl{
+		guestRepo:        guestRepo,
+		registrationRepo: registrationRepo,
+		reviewRepo:       reviewRepo,
+		orderRepo:        orderRepo,
+		eventRepo:        eventRepo,
+		calendarTokens:   calendarTokens,
+		duplicates:       duplicates,
+		tx:               tx,
+		seats:            seatAllocator{eventRepo: eventRepo, registrationRepo: registrationRepo},
+		sessions:         sessionSeats{sessionRepo: sessionRepo, sessionRegRepo: sessionRegRepo},
+		phoneRegion:      phoneRegion,
+	}
+}
+
+// dupGuest là dữ liệu đã chuẩn hoá của một khách dùng để so trùng
+type dupGuest struct {
+	id    string
+	name  string // họ tên bỏ dấu, các từ đã sắp xếp
+	email string
+	phone string
+}
+
+// dupCandidate là một cặp nghi trùng (chỉ số trong danh sách khách, a < b)
+type dupCandidate struct {
+	a, b    int
+	reasons []string
+	nameSim float64
+}
+
+// 🔍 DetectDuplicates quét toàn bộ khách, ghép cặp theo email, SĐT đã chuẩn hoá và họ tên gần giống,
+// rồi thay danh sách cặp nghi trùng đã lưu bằng kết quả mới (giữ cờ dismissed của cặp cũ).
+func (s *GuestDedupServiceImpl) DetectDuplicates(ctx context.Context) (*entity.GuestDuplicateScan, error) {
+	if !s.scanning.CompareAndSwap(false, true) {
+		return nil, service_interface.ErrScanInProgress
+	}
+	defer s.scanning.Store(false)
+	return s.detect(ctx)
+}
+
+// StartScan chạy DetectDuplicates trong nền.
+func (s *GuestDedupServiceImpl) StartScan(ctx context.Context) error {
+	if !s.scanning.CompareAndSwap(false, true) {
+		return service_interface.ErrScanInProgress
+	}
+	// Tách context trước khi trả về: ctx của handler bị gin tái sử dụng sau request
+	detached := detachedContext(ctx)
+	go func() {
+		defer s.scanning.Store(false)
+		ctx, cancel := context.WithTimeout(detached, dupScanTimeout)
+		defer cancel()
+		if _, err := s.detect(ctx); err != nil {
+			log.Println("⚠️ Quét khách trùng thất bại:", err)
+		}
+	}()
+	return nil
+}
+
+func (s *GuestDedupServiceImpl) detect(ctx context.Context) (*entity.GuestDuplicateScan, error) {
+	scan := &entity.GuestDuplicateScan{ID: uuid.NewString(), StartedAt: time.Now()}
+
+	var (
+		guests  []dupGuest
+		byEmail = map[string][]int{}
+		byPhone = map[string][]int{}
+		byName  = map[string][]int{}
+	)
+	err := s.guestRepo.StreamAll(ctx, func(m *models.GuestModel) error {
+		g := dupGuest{
+			id:    m.ID,
+			name:  sortedNameTokens(m.FullName),
+			email: normalizeEmail(m.Email),
+			phone: normalizePhone(m.Phone, s.phoneRegion),
+		}
+		i := len(guests)
+		guests = append(guests, g)
+		if g.email != "" {
+			byEmail[g.email] = append(byEmail[g.email], i)
+		}
+		if g.phone != "" {
+			byPhone[g.phone] = append(byPhone[g.phone], i)
+		}
+		if key := nameBlockKey(m.FullName); key != "" {
+			byName[key] = append(byName[key], i)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan guests failed: %w", err)
+	}
+	scan.Guests = len(guests)
+
+	candidates := map[[2]int]*dupCandidate{}
+	add := func(a, b int, reason string) *dupCandidate {
+		if b < a {
+			a, b = b, a
+		}
+		c, ok := candidates[[2]int{a, b}]
+		if !ok {
+			c = &dupCandidate{a: a, b: b, nameSim: -1}
+			candidates[[2]int{a, b}] = c
+		}
+		c.reasons = append(c.reasons, reason)
+		return c
+	}
+	addGroups := func(groups map[string][]int, reason string) {
+		for _, members := range groups {
+			if len(members) < 2 || len(members) > dupMaxGroup {
+				continue
+			}
+			for i := 0; i < len(members); i++ {
+				for j := i + 1; j < len(members); j++ {
+					add(members[i], members[j], reason)
+				}
+			}
+		}
+	}
+	addGroups(byEmail, entity.DuplicateByEmail)
+	addGroups(byPhone, entity.DuplicateByPhone)
+
+	// Trùng tên chỉ tính khi không có email / SĐT mâu thuẫn (nhiều người trùng họ tên là chuyện thường)
+	for _, members := range byName {
+		if len(members) < 2 || len(members) > dupMaxNameBlock {
+			continue
+		}
+		for i := 0; i < len(members); i++ {
+			for j := i + 1; j < len(members); j++ {
+				a, b := guests[members[i]], guests[members[j]]
+				if contactsConflict(a, b) {
+					continue
+				}
+				if sim := nameSimilarity(a.name, b.name); sim >= dupNameThreshold {
+					add(members[i], members[j], entity.DuplicateByName).nameSim = sim
+				}
+			}
+		}
+	}
+
+	batch := make([]*models.GuestDuplicateModel, 0, dupWriteBatch)
+	flush := func() error {
+		if err := s.duplicates.UpsertMany(ctx, batch); err != nil {
+			return fmt.Errorf("save duplicate pairs failed: %w", err)
+		}
+		batch = batch[:0]
+		return nil
+	}
+	now := time.Now()
+	for _, c := range candidates {
+		a, b := guests[c.a], guests[c.b]
+		if c.nameSim < 0 {
+			c.nameSim = nameSimilarity(a.name, b.name)
+		}
+		batch = append(batch, &models.GuestDuplicateModel{
+			ID:             models.DuplicatePairID(a.id, b.id),
+			GuestIDs:       []string{a.id, b.id},
+			Reasons:        c.reasons,
+			NameSimilarity: c.nameSim,
+			Score:          duplicateScore(c.reasons, c.nameSim),
+			ScanID:         scan.ID,
+			DetectedAt:     now,
+		})
+		if len(batch) == dupWriteBatch {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	if err := s.duplicates.DeleteStale(ctx, scan.ID); err != nil {
+		return nil, fmt.Errorf("delete stale duplicate pairs failed: %w", err)
+	}
+
+	scan.Pairs = len(candidates)
+	scan.FinishedAt = time.Now()
+	return scan, nil
+}
+
+// ListDuplicates trả về một trang cặp nghi trùng kèm thông tin hai khách.
+func (s *GuestDedupServiceImpl) ListDuplicates(ctx context.Context, q entity.GuestDuplicateQuery) (*entity.GuestDuplicatePage, error) {
+	q.Normalize()
+
+	pairs, total, err := s.duplicates.Search(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("search duplicate pairs failed: %w", err)
+	}
+
+	ids := make([]string, 0, len(pairs)*2)
+	for _, p := range pairs {
+		ids = append(ids, p.GuestIDs...)
+	}
+	guests, err := s.guestRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("find guests failed: %w", err)
+	}
+	byID := make(map[string]*models.GuestModel, len(guests))
+	for _, g := range guests {
+		byID[g.ID] = g
+	}
+
+	page := &entity.GuestDuplicatePage{
+		Items:    make([]*entity.GuestDuplicate, 0, len(pairs)),
+		Total:    total,
+		Page:     q.Page,
+		PageSize: q.PageSize,
+	}
+	for _, p := range pairs {
+		if len(p.GuestIDs) != 2 {
+			continue
+		}
+		// Khách đã bị xoá / gộp sau lần quét: cặp sẽ biến mất ở lần quét sau
+		a, b := byID[p.GuestIDs[0]], byID[p.GuestIDs[1]]
+		if a == nil || b == nil {
+			continue
+		}
+		page.Items = append(page.Items, &entity.GuestDuplicate{
+			ID:             p.ID,
+			Guests:         [2]entity.Guest{*models.GuestModelToEntity(a), *models.GuestModelToEntity(b)},
+			Reasons:        p.Reasons,
+			NameSimilarity: p.NameSimilarity,
+			Score:          p.Score,
+			Dismissed:      p.Dismissed,
+			DetectedAt:     p.DetectedAt,
+		})
+	}
+	return page, nil
+}
+
+// Dismiss đánh dấu cặp là hai người khác nhau.
+func (s *GuestDedupServiceImpl) Dismiss(ctx context.Context, pairID string) error {
+	pairID = strings.TrimSpace(pairID)
+	if pairID == "" {
+		return errors.New("pair id is required")
+	}
+	found, err := s.duplicates.SetDismissed(ctx, pairID, true)
+	if err != nil {
+		return fmt.Errorf("dismiss duplicate pair failed: %w", err)
+	}
+	if !found {
+		return fmt.Errorf("duplicate pair %w", service_interface.ErrNotFound)
+	}
+	return nil
+}
+
+// 🔗 Merge gộp các khách trùng vào survivor trong một transaction: đăng ký, đánh giá, đơn mua vé và
+// token lịch được chuyển sang survivor; khi cả hai có đăng ký / đánh giá cho cùng sự kiện thì giữ bản tốt hơn.
+// Liên hệ còn trống của survivor được lấy từ khách bị gộp, sau đó các khách bị gộp bị xoá.
+func (s *GuestDedupServiceImpl) Merge(ctx context.Context, survivorID string, duplicateIDs []string) (*entity.GuestMergeResult, error) {
+	survivorID = strings.TrimSpace(survivorID)
+	if survivorID == "" {
+		return nil, fmt.Errorf("%w: survivor id is required", service_interface.ErrInvalidMerge)
+	}
+	seen := map[string]struct{}{survivorID: {}}
+	dups := make([]string, 0, len(duplicateIDs))
+	for _, id := range duplicateIDs {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		dups = append(dups, id)
+	}
+	if len(dups) == 0 {
+		return nil, fmt.Errorf("%w: at least one duplicate id other than the survivor is required", service_interface.ErrInvalidMerge)
+	}
+
+	all := append([]string{survivorID}, dups...)
+	found, err := s.guestRepo.FindByIDs(ctx, all)
+	if err != nil {
+		return nil, fmt.Errorf("find guests failed: %w", err)
+	}
+	byID := make(map[string]*models.GuestModel, len(found))
+	for _, g := range found {
+		byID[g.ID] = g
+	}
+	for _, id := range all {
+		if byID[id] == nil {
+			return nil, fmt.Errorf("guest %s %w", id, service_interface.ErrNotFound)
+		}
+		if err := authorizeGuestEvents(ctx, s.registrationRepo, s.eventRepo, s.guestRepo, id); err != nil {
+			return nil, err
+		}
+	}
+
+	var result *entity.GuestMergeResult
+	err = s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		// fn có thể được chạy lại, nên mọi trạng thái được tính lại từ đầu
+		res := &entity.GuestMergeResult{MergedIDs: dups}
+		var released []string // sự kiện có đăng ký giữ chỗ bị bỏ, cần trả chỗ
+
+		regs, err := s.registrationRepo.FindByGuest(ctx, survivorID)
+		if err != nil {
+			return fmt.Errorf("find registrations failed: %w", err)
+		}
+		regByEvent := make(map[string]*models.RegistrationModel, len(regs))
+		for _, r := range regs {
+			regByEvent[r.EventID] = r
+		}
+
+		reviews, err := s.reviewRepo.FindByGuest(ctx, survivorID)
+		if err != nil {
+			return fmt.Errorf("find reviews failed: %w", err)
+		}
+		reviewByEvent := make(map[string]*models.ReviewModel, len(reviews))
+		for _, r := range reviews {
+			reviewByEvent[r.EventID] = r
+		}
+
+		survivor := *byID[survivorID]
+		for _, dupID := range dups {
+			dup := byID[dupID]
+
+			dupRegs, err := s.registrationRepo.FindByGuest(ctx, dupID)
+			if err != nil {
+				return fmt.Errorf("find registrations failed: %w", err)
+			}
+			for _, r := range dupRegs {
+				kept, ok := regByEvent[r.EventID]
+				if !ok {
+					regByEvent[r.EventID] = r
+					continue
+				}
+				// Unique (event_id, guest_id): chỉ giữ một đăng ký cho mỗi sự kiện
+				loser := r
+				if entity.ParseRegistrationStatus(r.Status).KeepRank() > entity.ParseRegistrationStatus(kept.Status).KeepRank() {
+					loser, regByEvent[r.EventID] = kept, r
+				}
+				if err := s.registrationRepo.Delete(ctx, loser.ID.Hex()); err != nil {
+					return fmt.Errorf("delete registration failed: %w", err)
+				}
+				// Đơn vé của đăng ký bị bỏ trỏ sang đăng ký được giữ; các phiên đã chọn được huỷ và trả chỗ
+				if _, err := s.orderRepo.ReassignRegistration(ctx, loser.ID.Hex(), regByEvent[r.EventID].ID.Hex()); err != nil {
+					return fmt.Errorf("move orders of dropped registration failed: %w", err)
+				}
+				if err := s.sessions.releaseAll(ctx, loser.ID.Hex()); err != nil {
+					return err
+				}
+				if entity.ParseRegistrationStatus(loser.Status).HoldsSeat() {
+					released = append(released, loser.EventID)
+				}
+				res.RegistrationsDropped++
+			}
+			moved, err := s.registrationRepo.ReassignGuest(ctx, dupID, survivorID)
+			if err != nil {
+				return fmt.Errorf("move registrations failed: %w", err)
+			}
+			res.RegistrationsMoved += int(moved)
+
+			dupReviews, err := s.reviewRepo.FindByGuest(ctx, dupID)
+			if err != nil {
+				return fmt.Errorf("find reviews failed: %w", err)
+			}
+			for _, r := range dupReviews {
+				kept, ok := reviewByEvent[r.EventID]
+				if !ok {
+					reviewByEvent[r.EventID] = r
+					continue
+				}
+				// Giữ đánh giá mới hơn
+				loser := r
+				if reviewTime(r).After(reviewTime(kept)) {
+					loser, reviewByEvent[r.EventID] = kept, r
+				}
+				if err := s.reviewRepo.Delete(ctx, loser.ID.Hex()); err != nil {
+					return fmt.Errorf("delete review failed: %w", err)
+				}
+				res.ReviewsDropped++
+			}
+			moved, err = s.reviewRepo.ReassignGuest(ctx, dupID, survivorID)
+			if err != nil {
+				return fmt.Errorf("move reviews failed: %w", err)
+			}
+			res.ReviewsMoved += int(moved)
+
+			// Đơn mua vé đi theo khách: webhook thanh toán đến sau vẫn tạo đăng ký cho survivor
+			moved, err = s.orderRepo.ReassignGuest(ctx, dupID, survivorID)
+			if err != nil {
+				if errors.Is(err, repository.ErrDuplicate) {
+					return fmt.Errorf("%w: guests have unpaid orders for the same event, cancel one of them first", service_interface.ErrInvalidMerge)
+				}
+				return fmt.Errorf("move orders failed: %w", err)
+			}
+			res.OrdersMoved += int(moved)
+
+			if err := s.calendarTokens.ReassignSubject(ctx, entity.CalendarSubjectGuest, dupID, survivorID); err != nil {
+				return fmt.Errorf("move calendar tokens failed: %w", err)
+			}
+
+			if survivor.FullName == "" {
+				survivor.FullName = dup.FullName
+			}
+			if survivor.Email == "" {
+				survivor.Email = dup.Email
+			}
+			if survivor.Phone == "" {
+				survivor.Phone = dup.Phone
+			}
+			if err := s.guestRepo.Delete(ctx, dupID); err != nil {
+				return fmt.Errorf("delete guest failed: %w", err)
+			}
+		}
+
+		if err := s.guestRepo.Update(ctx, &survivor); err != nil {
+			return fmt.Errorf("update guest failed: %w", err)
+		}
+		// Đăng ký còn lại đã huỷ thì khách không còn tham gia sự kiện đó
+		for eventID, kept := range regByEvent {
+			if entity.ParseRegistrationStatus(kept.Status) == entity.RegistrationCancelled {
+				if err := s.guestRepo.RemoveEvent(ctx, survivorID, eventID); err != nil {
+					return fmt.Errorf("unlink guest from event failed: %w", err)
+				}
+				continue
+			}
+			if err := s.guestRepo.AddEvent(ctx, survivorID, eventID); err != nil {
+				return fmt.Errorf("link guest to event failed: %w", err)
+			}
+		}
+		if err := s.duplicates.DeleteByGuests(ctx, dups); err != nil {
+			return fmt.Errorf("delete duplicate pairs failed: %w", err)
+		}
+
+		// 🪑 Đăng ký giữ chỗ bị bỏ: trả chỗ (hoặc đưa người chờ lên) trong cùng transaction,
+		// lỗi thì huỷ cả việc gộp để bộ đếm chỗ không lệch
+		for _, eventID := range released {
+			event, err := s.eventRepo.FindByID(ctx, eventID)
+			if err != nil {
+				return fmt.Errorf("find event failed: %w", err)
+			}
+			if _, err := s.seats.release(ctx, event); err != nil {
+				return err
+			}
+		}
+
+		res.Survivor = *models.GuestModelToEntity(&survivor)
+		result = res
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func reviewTime(r *models.ReviewModel) time.Time {
+	if r.UpdatedAt.After(r.CreatedAt) {
+		return r.UpdatedAt
+	}
+	return r.CreatedAt
+}
+
+// duplicateScore: email trùng 0.5, SĐT trùng 0.4, cộng tối đa 0.3 theo độ giống họ tên; tối đa 1
+func duplicateScore(reasons []string, nameSim float64) float64 {
+	score := 0.3 * nameSim
+	for _, r := range reasons {
+		switch r {
+		case entity.DuplicateByEmail:
+			score += 0.5
+		case entity.DuplicateByPhone:
+			score += 0.4
+		}
+	}
+	if score > 1 {
+		score = 1
+	}
+	return float64(int(score*1000+0.5)) / 1000
+}
+
+// contactsConflict: cả hai cùng có email (hoặc SĐT) nhưng khác nhau thì không phải một người
+func contactsConflict(a, b dupGuest) bool {
+	return (a.email != "" && b.email != "" && a.email != b.email) ||
+		(a.phone != "" && b.phone != "" && a.phone != b.phone)
+}
+
+// normalizeEmail: chữ thường, bỏ phần "+tag"; Gmail bỏ qua dấu chấm ở phần tên
+func normalizeEmail(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok || local == "" || domain == "" {
+		return ""
+	}
+	if i := strings.IndexByte(local, '+'); i > 0 {
+		local = local[:i]
+	}
+	if domain == "gmail.com" || domain == "googlemail.com" {
+		local, domain = strings.ReplaceAll(local, ".", ""), "gmail.com"
+	}
+	return local + "@" + domain
+}
+
+// normalizePhone đưa số về dạng E.164 như lúc lưu; số không chuẩn hoá được (dữ liệu cũ) không được so trùng
+func normalizePhone(phone, region string) string {
+	if strings.TrimSpace(phone) == "" {
+		return ""
+	}
+	e164, err := utils.NormalizePhone(phone, region)
+	if err != nil {
+		return ""
+	}
+	return e164
+}
+
+// sortedNameTokens bỏ dấu họ tên và sắp các từ, để "Văn An Nguyễn" khớp "Nguyễn Văn An"
+func sortedNameTokens(name string) string {
+	tokens := strings.Fields(utils.FoldText(name))
+	sort.Strings(tokens)
+	return strings.Join(tokens, " ")
+}
+
+// nameBlockKey nhóm khách theo cặp (từ đầu, từ cuối) của họ tên, không phụ thuộc thứ tự,
+// để chỉ so tên từng đôi trong nhóm nhỏ thay vì toàn bộ collection
+func nameBlockKey(name string) string {
+	tokens := strings.Fields(utils.FoldText(name))
+	if len(tokens) == 0 {
+		return ""
+	}
+	first, last := tokens[0], tokens[len(tokens)-1]
+	if last < first {
+		first, last = last, first
+	}
+	return first + "|" + last
+}
+
+// nameSimilarity = 1 - khoảng cách Levenshtein / độ dài chuỗi dài hơn
+func nameSimilarity(a, b string) float64 {
+	if a == "" || b == "" {
+		return 0
+	}
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
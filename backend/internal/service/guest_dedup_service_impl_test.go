@@ -0,0 +1,78 @@
+package service_imple
+
+import (
+	"math"
+	"testing"
+
+	"event_manager/internal/domain/entity"
+)
+
+func TestNormalizeEmail(t *testing.T) {
+	tests := []struct {
+		email string
+		want  string
+	}{
+		{"  An.Nguyen@Example.COM ", "an.nguyen@example.com"},
+		{"an+events@example.com", "an@example.com"},
+		{"an.nguyen+vip@gmail.com", "annguyen@gmail.com"},
+		{"A.N.Nguyen@GoogleMail.com", "annguyen@gmail.com"},
+		{"+tag@example.com", "+tag@example.com"},
+		{"no-at-sign", ""},
+		{"@example.com", ""},
+		{"an@", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := normalizeEmail(tt.email); got != tt.want {
+			t.Errorf("normalizeEmail(%q) = %q, want %q", tt.email, got, tt.want)
+		}
+	}
+}
+
+func TestNameSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"nguyen van an", "nguyen van an", 1},
+		{"nguyen van an", "nguyen van anh", 1 - 1.0/14},
+		{"an", "binh", 0.25},
+		{"ab", "cd", 0},
+		{"tran thi", "tran thu", 1 - 1.0/8},
+		{"", "nguyen", 0},
+		{"nguyen", "", 0},
+		{"ệ", "e", 0},
+	}
+	for _, tt := range tests {
+		got := nameSimilarity(tt.a, tt.b)
+		if math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("nameSimilarity(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+		if back := nameSimilarity(tt.b, tt.a); math.Abs(back-got) > 1e-9 {
+			t.Errorf("nameSimilarity is not symmetric for %q, %q: %v != %v", tt.a, tt.b, got, back)
+		}
+	}
+}
+
+func TestDuplicateScore(t *testing.T) {
+	tests := []struct {
+		name    string
+		reasons []string
+		nameSim float64
+		want    float64
+	}{
+		{"name only", []string{entity.DuplicateByName}, 1, 0.3},
+		{"email", []string{entity.DuplicateByEmail}, 0, 0.5},
+		{"phone", []string{entity.DuplicateByPhone}, 0, 0.4},
+		{"email and similar name", []string{entity.DuplicateByEmail, entity.DuplicateByName}, 0.9, 0.77},
+		{"phone and partial name", []string{entity.DuplicateByPhone}, 0.5, 0.55},
+		{"capped at one", []string{entity.DuplicateByEmail, entity.DuplicateByPhone}, 1, 1},
+		{"rounded to three decimals", nil, 1.0 / 3, 0.1},
+		{"nothing", nil, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := duplicateScore(tt.reasons, tt.nameSim); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: duplicateScore = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
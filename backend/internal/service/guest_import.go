@@ -0,0 +1,449 @@
+package service_imple
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"os"
+	"strings"
+	"time"
+
+	"event_manager/internal/domain/entity"
+	service_interface "event_manager/internal/domain/service"
+	"event_manager/internal/models"
+	"event_manager/internal/sheet"
+	utils "event_manager/util"
+)
+
+const (
+	// guestImportBatch là số dòng giữa hai lần lưu tiến độ của job nền
+	guestImportBatch = 100
+	// guestImportTimeout giới hạn thời gian chạy của một job nền
+	guestImportTimeout = 30 * time.Minute
+	// guestImportStopped là lỗi ghi vào job bị dừng giữa chừng do app tắt
+	guestImportStopped = "import was interrupted by a server restart, upload the file again"
+)
+
+// Tên cột được tự nhận diện (so khớp sau khi bỏ dấu, chữ thường)
+var guestImportAliases = map[string][]string{
+	"full_name": {"full_name", "full name", "fullname", "name", "ho ten", "ho va ten", "ten", "ten khach", "khach moi"},
+	"email":     {"email", "e-mail", "mail", "thu dien tu"},
+	"phone":     {"phone", "phone number", "mobile", "so dien thoai", "sdt", "dien thoai"},
+}
+
+// guestImportColumns là vị trí các cột trong file, -1 nếu không có
+type guestImportColumns struct {
+	fullName, email, phone int
+}
+
+// 📥 ImportGuests đọc file CSV / XLSX theo luồng, tạo khách mới hoặc dùng lại khách đã có
+// (tìm theo email / SĐT qua FindByContact) rồi đăng ký vào sự kiện. Lỗi của từng dòng được
+// ghi vào báo cáo, không dừng cả lần nhập.
+func (s *GuestServiceImpl) ImportGuests(ctx context.Context, req entity.GuestImportRequest, r io.Reader) (*entity.GuestImportJob, error) {
+	if err := s.prepareImport(ctx, &req); err != nil {
+		return nil, err
+	}
+
+	job := newGuestImportJob(ctx, req)
+	job.Status = entity.GuestImportRunning
+	if err := s.runImport(ctx, req, r, job, nil); err != nil {
+		// Lỗi trước dòng dữ liệu đầu tiên (file hỏng, thiếu cột) thì chưa có gì được ghi
+		if job.Processed == 0 {
+			return nil, err
+		}
+		job.Error = err.Error()
+	}
+	finishGuestImport(job)
+	return job, nil
+}
+
+// 🕒 StartImport lưu tạm file rồi nhập trong nền; client theo dõi tiến độ qua GetImportJob.
+func (s *GuestServiceImpl) StartImport(ctx context.Context, req entity.GuestImportRequest, r io.Reader) (*entity.GuestImportJob, error) {
+	if s.importJobs == nil {
+		return nil, errors.New("guest import jobs are not configured")
+	}
+	if s.importStop.Err() != nil {
+		return nil, errors.New("server is shutting down, try again later")
+	}
+	if err := s.prepareImport(ctx, &req); err != nil {
+		return nil, err
+	}
+
+	tmp, err := os.CreateTemp("", "guest-import-*")
+	if err != nil {
+		return nil, fmt.Errorf("create temp file failed: %w", err)
+	}
+	path := tmp.Name()
+	_, err = io.Copy(tmp, r)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return nil, fmt.Errorf("store import file failed: %w", err)
+	}
+
+	// Kiểm tra tiêu đề ngay để trả lỗi file cho client thay vì job thất bại
+	total, err := countImportRows(req, path)
+	if err != nil {
+		_ = os.Remove(path)
+		return nil, err
+	}
+
+	job := newGuestImportJob(ctx, req)
+	job.Total = total
+	model := models.GuestImportJobEntityToModel(job)
+	if err := s.importJobs.Insert(ctx, model); err != nil {
+		_ = os.Remove(path)
+		return nil, fmt.Errorf("insert guest import job failed: %w", err)
+	}
+	job.ID, job.CreatedAt, job.UpdatedAt = model.ID, model.CreatedAt, model.UpdatedAt
+
+	// Job chạy tiếp sau khi request kết thúc nhưng vẫn giữ user để phân quyền và ghi lịch sử
+	s.importWG.Add(1)
+	go s.runImportJob(detachedContext(ctx), req, path, *job)
+	return job, nil
+}
+
+// 🧹 FailUnfinishedImports đánh dấu thất bại các job mà tiến trình trước chưa chạy xong (app chỉ chạy
+// một instance nên job pending / running lúc khởi động không còn goroutine nào xử lý)
+func (s *GuestServiceImpl) FailUnfinishedImports(ctx context.Context) (int64, error) {
+	if s.importJobs == nil {
+		return 0, nil
+	}
+	n, err := s.importJobs.FailUnfinished(ctx, guestImportStopped)
+	if err != nil {
+		return 0, fmt.Errorf("fail unfinished guest imports failed: %w", err)
+	}
+	return n, nil
+}
+
+// 🛑 StopImports huỷ các job nền đang chạy và chờ chúng lưu trạng thái cuối (tối đa đến khi ctx hết hạn)
+func (s *GuestServiceImpl) StopImports(ctx context.Context) {
+	s.cancelImport()
+
+	done := make(chan struct{})
+	go func() {
+		s.importWG.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+}
+
+// GetImportJob trả về tiến độ và báo cáo của job nhập khách.
+func (s *GuestServiceImpl) GetImportJob(ctx context.Context, jobID string) (*entity.GuestImportJob, error) {
+	jobID = strings.TrimSpace(jobID)
+	if jobID == "" {
+		return nil, errors.New("job id is required")
+	}
+	if s.importJobs == nil {
+		return nil, fmt.Errorf("import job %w", service_interface.ErrNotFound)
+	}
+
+	model, err := s.importJobs.FindByID(ctx, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("find guest import job failed: %w", err)
+	}
+	if model == nil {
+		return nil, fmt.Errorf("import job %w", service_interface.ErrNotFound)
+	}
+	if _, err := authorizeEventByID(ctx, s.eventRepo, model.EventID, entity.EventPermManageGuests); err != nil {
+		return nil, err
+	}
+	return models.GuestImportJobModelToEntity(model), nil
+}
+
+// prepareImport chuẩn hoá yêu cầu và kiểm tra quyền quản lý khách trên sự kiện đích
+func (s *GuestServiceImpl) prepareImport(ctx context.Context, req *entity.GuestImportRequest) error {
+	req.EventID = strings.TrimSpace(req.EventID)
+	if req.EventID == "" {
+		return errors.New("event id is required")
+	}
+	if req.Format != string(sheet.FormatCSV) && req.Format != string(sheet.FormatXLSX) {
+		return fmt.Errorf("%w: %s", service_interface.ErrInvalidImportFile, sheet.ErrUnsupportedFormat.Error())
+	}
+	if _, err := authorizeEventByID(ctx, s.eventRepo, req.EventID, entity.EventPermManageGuests); err != nil {
+		return err
+	}
+	return requireNoPayment(ctx, s.ticketRepo, req.EventID)
+}
+
+// runImportJob chạy job nền: lưu tiến độ sau mỗi guestImportBatch dòng và khi kết thúc
+func (s *GuestServiceImpl) runImportJob(ctx context.Context, req entity.GuestImportRequest, path string, job entity.GuestImportJob) {
+	defer s.importWG.Done()
+	defer os.Remove(path)
+
+	ctx, cancel := context.WithTimeout(ctx, guestImportTimeout)
+	defer cancel()
+	// App dừng thì huỷ job; lần lưu cuối bên dưới vẫn chạy và ghi job là failed
+	stop := context.AfterFunc(s.importStop, cancel)
+	defer stop()
+
+	flushed := 0
+	save := func() error {
+		model := models.GuestImportJobEntityToModel(&job)
+		// Lần lưu cuối vẫn phải chạy kể cả khi job đã quá thời gian
+		saveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+		defer cancel()
+		if err := s.importJobs.SaveProgress(saveCtx, model, model.Rows[flushed:]); err != nil {
+			return err
+		}
+		flushed = len(job.Rows)
+		return nil
+	}
+
+	job.Status = entity.GuestImportRunning
+	err := save()
+	if err == nil {
+		var f *os.File
+		if f, err = os.Open(path); err == nil {
+			err = s.runImport(ctx, req, f, &job, save)
+			_ = f.Close()
+		}
+	}
+	if err != nil {
+		job.Error = err.Error()
+		if s.importStop.Err() != nil {
+			job.Error = guestImportStopped
+		}
+	}
+	finishGuestImport(&job)
+	_ = save()
+}
+
+// runImport đọc từng dòng, xử lý và ghi kết quả vào job; progress (nếu có) được gọi sau mỗi lô dòng.
+func (s *GuestServiceImpl) runImport(ctx context.Context, req entity.GuestImportRequest, r io.Reader, job *entity.GuestImportJob, progress func() error) error {
+	rows, err := sheet.NewReader(sheet.Format(req.Format), r)
+	if err != nil {
+		return fmt.Errorf("%w: %s", service_interface.ErrInvalidImportFile, err.Error())
+	}
+	defer rows.Close()
+
+	cols, err := readImportHeader(rows, req.Columns)
+	if err != nil {
+		return err
+	}
+
+	for {
+		cells, err := rows.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("%w: row %d: %s", service_interface.ErrInvalidImportFile, rows.Line(), err.Error())
+		}
+		if blankRow(cells) {
+			continue
+		}
+		if job.Processed >= entity.MaxGuestImportRows {
+			return fmt.Errorf("%w: more than %d rows, the remaining rows were skipped", service_interface.ErrInvalidImportFile, entity.MaxGuestImportRows)
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		row := entity.GuestImportRow{
+			Row:      rows.Line(),
+			FullName: cell(cells, cols.fullName),
+			Email:    cell(cells, cols.email),
+			Phone:    cell(cells, cols.phone),
+		}
+		if err := s.importGuestRow(ctx, req.EventID, &row); err != nil {
+			row.Action, row.Error = entity.GuestImportError, err.Error()
+		}
+
+		job.Processed++
+		switch row.Action {
+		case entity.GuestImportCreated:
+			job.Created++
+		case entity.GuestImportReused:
+			job.Reused++
+		default:
+			job.Failed++
+		}
+		job.Rows = append(job.Rows, row)
+
+		if progress != nil && job.Processed%guestImportBatch == 0 {
+			if err := progress(); err != nil {
+				return fmt.Errorf("save import progress failed: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// importGuestRow kiểm tra một dòng rồi tạo khách mới hoặc đăng ký khách đã có vào sự kiện
+func (s *GuestServiceImpl) importGuestRow(ctx context.Context, eventID string, row *entity.GuestImportRow) error {
+	if err := validateImportRow(row, s.phoneRegion); err != nil {
+		return err
+	}
+
+	existing, err := s.FindByContact(ctx, row.Email, row.Phone)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		row.GuestID = existing.ID
+		if err := s.ensureGuestRegistration(ctx, existing.ID, eventID); err != nil {
+			return err
+		}
+		row.Action = entity.GuestImportReused
+		return nil
+	}
+
+	guest := &entity.Guest{FullName: row.FullName, Email: row.Email, Phone: row.Phone}
+	if err := s.Create(ctx, guest, eventID); err != nil {
+		return err
+	}
+	row.GuestID = guest.ID
+	row.Action = entity.GuestImportCreated
+	return nil
+}
+
+// validateImportRow kiểm tra họ tên, email và số điện thoại (theo vùng mặc định) của một dòng
+func validateImportRow(row *entity.GuestImportRow, phoneRegion string) error {
+	if row.FullName == "" {
+		return errors.New("full name is required")
+	}
+	if row.Email == "" && row.Phone == "" {
+		return errors.New("email or phone is required")
+	}
+	if row.Email != "" {
+		addr, err := mail.ParseAddress(row.Email)
+		if err != nil || addr.Address != row.Email {
+			return fmt.Errorf("invalid email %q", row.Email)
+		}
+	}
+	if row.Phone != "" {
+		if _, err := utils.NormalizePhone(row.Phone, phoneRegion); err != nil {
+			return fmt.Errorf("invalid phone %q", row.Phone)
+		}
+	}
+	return nil
+}
+
+// readImportHeader tìm dòng tiêu đề (dòng không rỗng đầu tiên) và vị trí các cột
+func readImportHeader(rows sheet.RowReader, mapping entity.GuestImportColumns) (guestImportColumns, error) {
+	for {
+		header, err := rows.Next()
+		if errors.Is(err, io.EOF) {
+			return guestImportColumns{}, fmt.Errorf("%w: file is empty", service_interface.ErrInvalidImportFile)
+		}
+		if err != nil {
+			return guestImportColumns{}, fmt.Errorf("%w: %s", service_interface.ErrInvalidImportFile, err.Error())
+		}
+		if blankRow(header) {
+			continue
+		}
+
+		cols := guestImportColumns{
+			fullName: findColumn(header, mapping.FullName, guestImportAliases["full_name"]),
+			email:    findColumn(header, mapping.Email, guestImportAliases["email"]),
+			phone:    findColumn(header, mapping.Phone, guestImportAliases["phone"]),
+		}
+		if cols.fullName < 0 {
+			return cols, fmt.Errorf("%w: missing full name column", service_interface.ErrInvalidImportFile)
+		}
+		if cols.email < 0 && cols.phone < 0 {
+			return cols, fmt.Errorf("%w: missing email or phone column", service_interface.ErrInvalidImportFile)
+		}
+		return cols, nil
+	}
+}
+
+// findColumn trả về vị trí cột có tiêu đề khớp tên được chỉ định, hoặc một trong các tên mặc định
+func findColumn(header []string, name string, aliases []string) int {
+	if name = utils.FoldText(name); name != "" {
+		aliases = []string{name}
+	}
+	for _, alias := range aliases {
+		for i, h := range header {
+			if utils.FoldText(h) == alias {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// countImportRows kiểm tra tiêu đề và đếm số dòng dữ liệu của file đã lưu tạm
+func countImportRows(req entity.GuestImportRequest, path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	rows, err := sheet.NewReader(sheet.Format(req.Format), f)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", service_interface.ErrInvalidImportFile, err.Error())
+	}
+	defer rows.Close()
+
+	if _, err := readImportHeader(rows, req.Columns); err != nil {
+		return 0, err
+	}
+	total := 0
+	for {
+		cells, err := rows.Next()
+		if errors.Is(err, io.EOF) {
+			return total, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("%w: %s", service_interface.ErrInvalidImportFile, err.Error())
+		}
+		if !blankRow(cells) {
+			total++
+		}
+	}
+}
+
+func newGuestImportJob(ctx context.Context, req entity.GuestImportRequest) *entity.GuestImportJob {
+	job := &entity.GuestImportJob{
+		EventID:   req.EventID,
+		FileName:  req.FileName,
+		Format:    req.Format,
+		Status:    entity.GuestImportPending,
+		Rows:      []entity.GuestImportRow{},
+		CreatedAt: time.Now(),
+	}
+	if user := utils.UserFromContext(ctx); user != nil {
+		job.CreatedBy = user.ID
+	}
+	job.UpdatedAt = job.CreatedAt
+	return job
+}
+
+func finishGuestImport(job *entity.GuestImportJob) {
+	now := time.Now()
+	job.Status = entity.GuestImportCompleted
+	if job.Error != "" {
+		job.Status = entity.GuestImportFailed
+	}
+	if job.Total < job.Processed {
+		job.Total = job.Processed
+	}
+	job.UpdatedAt = now
+	job.FinishedAt = &now
+}
+
+func cell(cells []string, i int) string {
+	if i < 0 || i >= len(cells) {
+		return ""
+	}
+	return strings.TrimSpace(cells[i])
+}
+
+func blankRow(cells []string) bool {
+	for _, c := range cells {
+		if strings.TrimSpace(c) != "" {
+			return false
+		}
+	}
+	return true
+}
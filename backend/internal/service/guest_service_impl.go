@@ -5,27 +5,50 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"event_manager/internal/domain/entity"
 	repository "event_manager/internal/domain/repository"
 	service_interface "event_manager/internal/domain/service"
 	"event_manager/internal/models"
+	utils "event_manager/util"
 )
 
 // GuestServiceImpl provides guest-domain operations backed by repositories.
 type GuestServiceImpl struct {
 	repo             repository.GuestRepository
 	registrationRepo repository.RegistrationRepository
+	eventRepo        repository.EventRepository
+	ticketRepo       repository.TicketTypeRepository
+	importJobs       repository.GuestImportJobRepository
+	phoneRegion      string // vùng mặc định khi chuẩn hoá số điện thoại không có mã quốc gia
+
+	// importStop bị huỷ khi app dừng để các job nhập nền kết thúc; importWG đếm các job đang chạy
+	importStop   context.Context
+	cancelImport context.CancelFunc
+	importWG     sync.WaitGroup
 }
 
 // NewGuestService wires dependencies into a GuestService implementation.
 func NewGuestService(
 	repo repository.GuestRepository,
 	registrationRepo repository.RegistrationRepository,
+	eventRepo repository.EventRepository,
+	ticketRepo repository.TicketTypeRepository,
+	importJobs repository.GuestImportJobRepository,
+	phoneRegion string,
 ) service_interface.GuestService {
+	importStop, cancelImport := context.WithCancel(context.Background())
 	return &GuestServiceImpl{
 		repo:             repo,
 		registrationRepo: registrationRepo,
+		eventRepo:        eventRepo,
+		ticketRepo:       ticketRepo,
+		importJobs:       importJobs,
+		phoneRegion:      phoneRegion,
+		importStop:       importStop,
+		cancelImport:     cancelImport,
 	}
 }
 
@@ -45,8 +68,19 @@ func (s *GuestServiceImpl) Create(ctx context.Context, guest *entity.Guest, even
 	if eventID == "" {
 		return errors.New("event id is required")
 	}
+	if _, err := authorizeEventByID(ctx, s.eventRepo, eventID, entity.EventPermManageGuests); err != nil {
+		return err
+	}
+	// Khách của sự kiện bán vé có phí được đăng ký qua đơn mua vé
+	if err := requireNoPayment(ctx, s.ticketRepo, eventID); err != nil {
+		return err
+	}
+	if err := s.normalizeGuestPhone(guest); err != nil {
+		return err
+	}
 
 	model := models.GuestEntityToModel(guest)
+	model.CreatedBy = actorFromContext(ctx)
 	guest.ID = model.ID
 
 	if err := s.repo.Insert(ctx, model); err != nil {
@@ -63,7 +97,7 @@ func (s *GuestServiceImpl) Create(ctx context.Context, guest *entity.Guest, even
 	return nil
 }
 
-// Update modifies an existing guest and optionally adjusts their registration's event.
+// Update modifies an existing guest and optionally registers them to one more event.
 func (s *GuestServiceImpl) Update(ctx context.Context, guest *entity.Guest, eventID string) error {
 	if guest == nil || strings.TrimSpace(guest.ID) == "" {
 		return errors.New("invalid guest for update")
@@ -74,7 +108,23 @@ func (s *GuestServiceImpl) Update(ctx context.Context, guest *entity.Guest, even
 		return fmt.Errorf("load guest failed: %w", err)
 	}
 	if existing == nil {
-		return errors.New("guest not found")
+		return fmt.Errorf("guest %w", service_interface.ErrNotFound)
+	}
+	if err := s.authorizeGuest(ctx, guest.ID); err != nil {
+		return err
+	}
+
+	eventID = strings.TrimSpace(eventID)
+	if eventID != "" {
+		if _, err := authorizeEventByID(ctx, s.eventRepo, eventID, entity.EventPermManageGuests); err != nil {
+			return err
+		}
+		if err := requireNoPayment(ctx, s.ticketRepo, eventID); err != nil {
+			return err
+		}
+	}
+	if err := s.normalizeGuestPhone(guest); err != nil {
+		return err
 	}
 
 	model := &models.GuestModel{
@@ -88,7 +138,6 @@ func (s *GuestServiceImpl) Update(ctx context.Context, guest *entity.Guest, even
 		return fmt.Errorf("update guest failed: %w", err)
 	}
 
-	eventID = strings.TrimSpace(eventID)
 	if eventID == "" || s.registrationRepo == nil {
 		return nil
 	}
@@ -100,44 +149,89 @@ func (s *GuestServiceImpl) Update(ctx context.Context, guest *entity.Guest, even
 	return nil
 }
 
-// ensureGuestRegistration either creates or updates the registration for the guest.
+// ensureGuestRegistration registers the guest to the event unless they already are;
+// registrations to other events are left untouched.
 func (s *GuestServiceImpl) ensureGuestRegistration(ctx context.Context, guestID, eventID string) error {
-	regs, err := s.registrationRepo.FindByGuest(ctx, guestID)
+	existing, err := s.registrationRepo.FindByEventAndGuest(ctx, eventID, guestID)
+	if err != nil {
+		return fmt.Errorf("find registration failed: %w", err)
+	}
+	if existing != nil {
+		return s.repo.AddEvent(ctx, guestID, eventID)
+	}
+	// Loại vé có phí có thể được thêm trong lúc đang import
+	if err := requireNoPayment(ctx, s.ticketRepo, eventID); err != nil {
+		return err
+	}
+
+	event, err := s.eventRepo.FindByID(ctx, eventID)
 	if err != nil {
-		return fmt.Errorf("find registration by guest failed: %w", err)
+		return fmt.Errorf("find event failed: %w", err)
 	}
 
-	if len(regs) == 0 {
-		regEntity := &entity.Registration{
-			EventID: eventID,
-			GuestID: guestID,
-			Status:  "pending",
+	// 🪑 Sự kiện giới hạn: hết chỗ thì khách vào danh sách chờ
+	seats := seatAllocator{eventRepo: s.eventRepo, registrationRepo: s.registrationRepo}
+	status, reserved, err := seats.allocate(ctx, event, entity.RegistrationPending)
+	if err != nil {
+		return err
+	}
+
+	reason := "registered"
+	if status == entity.RegistrationWaitlisted {
+		reason = "event is full"
+	}
+	now := time.Now()
+	regEntity := &entity.Registration{
+		EventID:   eventID,
+		GuestID:   guestID,
+		Status:    status,
+		CreatedAt: now,
+		History: []entity.RegistrationTransition{{
+			To:      status,
+			ActorID: actorFromContext(ctx),
+			Reason:  reason,
+			At:      now,
+		}},
+	}
+	regModel, err := models.RegistrationEntityToModel(regEntity)
+	if err != nil {
+		if reserved {
+			seats.rollback(ctx, event)
 		}
-		regModel, err := models.RegistrationEntityToModel(regEntity)
-		if err != nil {
-			return fmt.Errorf("map registration to model failed: %w", err)
+		return fmt.Errorf("map registration to model failed: %w", err)
+	}
+	if err := s.registrationRepo.Insert(ctx, regModel); err != nil {
+		if reserved {
+			seats.rollback(ctx, event)
 		}
-		if err := s.registrationRepo.Insert(ctx, regModel); err != nil {
+		// Một request song song đã đăng ký khách vào sự kiện này
+		if !errors.Is(err, repository.ErrDuplicate) {
 			return fmt.Errorf("insert registration failed: %w", err)
 		}
-		return nil
 	}
-
-	regModel := regs[0]
-	// Update event and ensure guest association remains consistent.
-	regModel.EventID = eventID
-	regModel.GuestID = guestID
-	if err := s.registrationRepo.Update(ctx, regModel); err != nil {
-		return fmt.Errorf("update registration failed: %w", err)
+	if err := s.repo.AddEvent(ctx, guestID, eventID); err != nil {
+		return fmt.Errorf("link guest to event failed: %w", err)
 	}
 	return nil
 }
 
+// authorizeGuest requires manage_guests on every event the guest is registered to,
+// so an organizer cannot modify guests that also belong to someone else's event.
+func (s *GuestServiceImpl) authorizeGuest(ctx context.Context, guestID string) error {
+	if s.registrationRepo == nil {
+		return nil
+	}
+	return authorizeGuestEvents(ctx, s.registrationRepo, s.eventRepo, s.repo, guestID)
+}
+
 // Delete removes a guest by identifier.
 func (s *GuestServiceImpl) Delete(ctx context.Context, guestID string) error {
 	if strings.TrimSpace(guestID) == "" {
 		return errors.New("guest id is required")
 	}
+	if err := s.authorizeGuest(ctx, guestID); err != nil {
+		return err
+	}
 	if err := s.repo.Delete(ctx, guestID); err != nil {
 		return fmt.Errorf("delete guest failed: %w", err)
 	}
@@ -160,30 +254,82 @@ func (s *GuestServiceImpl) GetByID(ctx context.Context, guestID string) (*entity
 	return models.GuestModelToEntity(model), nil
 }
 
-// List returns guests filtered optionally by keyword.
-func (s *GuestServiceImpl) List(ctx context.Context, keyword string) ([]*entity.Guest, error) {
-	modelsList, err := s.repo.FindAll(ctx)
+// Search returns one page of guests matching the query; the filtering runs in the repository.
+func (s *GuestServiceImpl) Search(ctx context.Context, q entity.GuestQuery) (*entity.GuestPage, error) {
+	q.Normalize()
+	q.EventID = strings.TrimSpace(q.EventID)
+
+	list, total, err := s.repo.Search(ctx, q)
 	if err != nil {
-		return nil, fmt.Errorf("list guests failed: %w", err)
+		return nil, fmt.Errorf("search guests failed: %w", err)
 	}
 
-	keyword = strings.ToLower(strings.TrimSpace(keyword))
-	result := make([]*entity.Guest, 0, len(modelsList))
-
-	for _, model := range modelsList {
+	page := &entity.GuestPage{
+		Guests:   make([]*entity.Guest, 0, len(list)),
+		Total:    total,
+		Page:     q.Page,
+		PageSize: q.PageSize,
+	}
+	for _, model := range list {
 		if model == nil {
 			continue
 		}
+		page.Guests = append(page.Guests, models.GuestModelToEntity(model))
+	}
+	return page, nil
+}
+
+// ListEvents returns every event the guest is registered to, ordered by start date.
+func (s *GuestServiceImpl) ListEvents(ctx context.Context, guestID string) ([]*entity.GuestEvent, error) {
+	guestID = strings.TrimSpace(guestID)
+	if guestID == "" {
+		return nil, errors.New("guest id is required")
+	}
+
+	guest, err := s.repo.FindByID(ctx, guestID)
+	if err != nil {
+		return nil, fmt.Errorf("find guest failed: %w", err)
+	}
+	if guest == nil {
+		return nil, fmt.Errorf("guest %w", service_interface.ErrNotFound)
+	}
+
+	regs, err := s.registrationRepo.FindByGuest(ctx, guestID)
+	if err != nil {
+		return nil, fmt.Errorf("find registration by guest failed: %w", err)
+	}
 
-		entityGuest := models.GuestModelToEntity(model)
-		if keyword != "" {
-			if !strings.Contains(strings.ToLower(entityGuest.FullName), keyword) &&
-				!strings.Contains(strings.ToLower(entityGuest.Email), keyword) &&
-				!strings.Contains(strings.ToLower(entityGuest.Phone), keyword) {
-				continue
-			}
+	regByEvent := make(map[string]*models.RegistrationModel, len(regs))
+	eventIDs := make([]string, 0, len(regs))
+	for _, reg := range regs {
+		if reg == nil {
+			continue
+		}
+		if _, ok := regByEvent[reg.EventID]; ok {
+			continue
 		}
-		result = append(result, entityGuest)
+		regByEvent[reg.EventID] = reg
+		eventIDs = append(eventIDs, reg.EventID)
+	}
+
+	events, err := s.eventRepo.FindByIDs(ctx, eventIDs)
+	if err != nil {
+		return nil, fmt.Errorf("find events failed: %w", err)
+	}
+
+	result := make([]*entity.GuestEvent, 0, len(events))
+	for _, event := range events {
+		if event == nil {
+			continue
+		}
+		reg, ok := regByEvent[event.ID]
+		if !ok {
+			continue
+		}
+		result = append(result, &entity.GuestEvent{
+			Event:        event.EventEntityToModel(),
+			Registration: *reg.RegistrationModelToEntity(),
+		})
 	}
 	return result, nil
 }
@@ -205,16 +351,32 @@ func (s *GuestServiceImpl) FindByContact(ctx context.Context, email, phone strin
 	if phone == "" {
 		return nil, nil
 	}
+	phone, err := utils.NormalizePhone(phone, s.phoneRegion)
+	if err != nil {
+		// Số không hợp lệ thì không thể thuộc về khách nào (SĐT đã lưu luôn ở dạng E.164)
+		return nil, nil
+	}
 
-	modelsList, err := s.repo.FindAll(ctx)
+	model, err := s.repo.FindByPhone(ctx, phone)
 	if err != nil {
 		return nil, fmt.Errorf("find guest by phone failed: %w", err)
 	}
+	if model == nil {
+		return nil, nil
+	}
+	return models.GuestModelToEntity(model), nil
+}
 
-	for _, model := range modelsList {
-		if model != nil && strings.EqualFold(model.Phone, phone) {
-			return models.GuestModelToEntity(model), nil
-		}
+// normalizeGuestPhone đưa số điện thoại của khách về dạng E.164 trước khi lưu
+func (s *GuestServiceImpl) normalizeGuestPhone(guest *entity.Guest) error {
+	guest.Phone = strings.TrimSpace(guest.Phone)
+	if guest.Phone == "" {
+		return nil
 	}
-	return nil, nil
+	phone, err := utils.NormalizePhone(guest.Phone, s.phoneRegion)
+	if err != nil {
+		return fmt.Errorf("%w %q", service_interface.ErrInvalidPhone, guest.Phone)
+	}
+	guest.Phone = phone
+	return nil
 }
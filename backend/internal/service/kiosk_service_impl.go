@@ -0,0 +1,395 @@
+package service_imple
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"event_manager/internal/domain/entity"
+	repository "event_manager/internal/domain/repository"
+	service_interface "event_manager/internal/domain/service"
+	"event_manager/internal/models"
+	utils "event_manager/util"
+)
+
+const (
+	// kioskTokenPrefix đứng trước phần random của token kiosk (xem utils.GenToken)
+	kioskTokenPrefix = "kiosk"
+	// kioskTouchEvery giới hạn số lần ghi last_seen_at của một kiosk
+	kioskTouchEvery = time.Minute
+	// kioskConfirmDigits là số chữ số cuối của SĐT khách dùng để xác nhận; kết quả tìm kiếm chỉ
+	// hiện 2 số cuối (utils.MaskPhone) nên vẫn còn 2 số phải tự biết
+	kioskConfirmDigits = 4
+	// kioskMaxConfirmFailures: xác nhận sai chừng này lần (trên mọi kiosk) thì đăng ký bị khoá tự
+	// check-in, khách phải check-in với nhân viên
+	kioskMaxConfirmFailures = 5
+)
+
+// KioskServiceImpl triển khai KioskService
+type KioskServiceImpl struct {
+	sessions         repository.KioskSessionRepository
+	audit            repository.KioskAuditRepository
+	eventRepo        repository.EventRepository
+	guestRepo        repository.GuestRepository
+	registrationRepo repository.RegistrationRepository
+	registrations    service_interface.RegistrationService
+}
+
+// NewKioskService wires dependencies into a KioskService implementation.
+func NewKioskService(
+	sessions repository.KioskSessionRepository,
+	audit repository.KioskAuditRepository,
+	eventRepo repository.EventRepository,
+	guestRepo repository.GuestRepository,
+	registrationRepo repository.RegistrationRepository,
+	registrations service_interface.RegistrationService,
+) service_interface.KioskService {
+	return &KioskServiceImpl{
+		sessions:         sessions,
+		audit:            audit,
+		eventRepo:        eventRepo,
+		guestRepo:        guestRepo,
+		registrationRepo: registrationRepo,
+		registrations:    registrations,
+	}
+}
+
+// CreateSession cấp token cho kiosk; người cấp cần quyền check-in trên sự kiện.
+func (s *KioskServiceImpl) CreateSession(ctx context.Context, eventID, name string, ttl time.Duration) (*entity.KioskSession, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, "", errors.New("kiosk name is required")
+	}
+	if _, err := authorizeEventByID(ctx, s.eventRepo, eventID, entity.EventPermCheckIn); err != nil {
+		return nil, "", err
+	}
+	if ttl <= 0 {
+		ttl = entity.DefaultKioskTTL
+	}
+	if ttl > entity.MaxKioskTTL {
+		ttl = entity.MaxKioskTTL
+	}
+
+	token, err := utils.GenToken(kioskTokenPrefix, 32)
+	if err != nil {
+		return nil, "", fmt.Errorf("generate kiosk token failed: %w", err)
+	}
+	now := time.Now()
+	m := &models.KioskSessionModel{
+		EventID:   strings.TrimSpace(eventID),
+		Name:      name,
+		TokenHash: utils.HashToken(token),
+		CreatedBy: actorFromContext(ctx),
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+	if err := s.sessions.Insert(ctx, m); err != nil {
+		return nil, "", fmt.Errorf("store kiosk session failed: %w", err)
+	}
+	return models.KioskSessionModelToEntity(m), token, nil
+}
+
+// ListSessions liệt kê kiosk của sự kiện
+func (s *KioskServiceImpl) ListSessions(ctx context.Context, eventID string) ([]*entity.KioskSession, error) {
+	event, err := authorizeEventByID(ctx, s.eventRepo, eventID, entity.EventPermCheckIn)
+	if err != nil {
+		return nil, err
+	}
+	list, err := s.sessions.FindByEvent(ctx, event.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list kiosk sessions failed: %w", err)
+	}
+	result := make([]*entity.KioskSession, 0, len(list))
+	for _, m := range list {
+		result = append(result, models.KioskSessionModelToEntity(m))
+	}
+	return result, nil
+}
+
+// RevokeSession thu hồi token kiosk
+func (s *KioskServiceImpl) RevokeSession(ctx context.Context, eventID, kioskID string) error {
+	session, err := s.findSession(ctx, eventID, kioskID)
+	if err != nil {
+		return err
+	}
+	if err := s.sessions.Revoke(ctx, session.ID.Hex(), time.Now()); err != nil {
+		return fmt.Errorf("revoke kiosk session failed: %w", err)
+	}
+	return nil
+}
+
+// Audit trả về nhật ký mới nhất của kiosk
+func (s *KioskServiceImpl) Audit(ctx context.Context, eventID, kioskID string, limit int) ([]*entity.KioskAuditEntry, error) {
+	session, err := s.findSession(ctx, eventID, kioskID)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = entity.DefaultKioskAuditSize
+	}
+	if limit > entity.MaxKioskAuditSize {
+		limit = entity.MaxKioskAuditSize
+	}
+
+	list, err := s.audit.FindByKiosk(ctx, session.ID.Hex(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("list kiosk audit failed: %w", err)
+	}
+	result := make([]*entity.KioskAuditEntry, 0, len(list))
+	for _, m := range list {
+		result = append(result, models.KioskAuditModelToEntity(m))
+	}
+	return result, nil
+}
+
+// findSession tải kiosk thuộc sự kiện sau khi kiểm tra quyền check-in của người gọi
+func (s *KioskServiceImpl) findSession(ctx context.Context, eventID, kioskID string) (*models.KioskSessionModel, error) {
+	if _, err := authorizeEventByID(ctx, s.eventRepo, eventID, entity.EventPermCheckIn); err != nil {
+		return nil, err
+	}
+	session, err := s.sessions.FindByID(ctx, strings.TrimSpace(kioskID))
+	if err != nil {
+		return nil, fmt.Errorf("find kiosk session failed: %w", err)
+	}
+	if session == nil || session.EventID != strings.TrimSpace(eventID) {
+		return nil, fmt.Errorf("kiosk %w", service_interface.ErrNotFound)
+	}
+	return session, nil
+}
+
+// 🔑 Authenticate tìm phiên kiosk còn hiệu lực theo token
+func (s *KioskServiceImpl) Authenticate(ctx context.Context, token string) (*entity.KioskSession, error) {
+	token = strings.TrimSpace(token)
+	if !strings.HasPrefix(token, kioskTokenPrefix+".") {
+		return nil, service_interface.ErrInvalidToken
+	}
+	m, err := s.sessions.FindByHash(ctx, utils.HashToken(token))
+	if err != nil {
+		return nil, fmt.Errorf("find kiosk session failed: %w", err)
+	}
+	if m == nil {
+		return nil, service_interface.ErrInvalidToken
+	}
+
+	session := models.KioskSessionModelToEntity(m)
+	now := time.Now()
+	if !session.IsActive(now) {
+		return nil, service_interface.ErrInvalidToken
+	}
+	if session.LastSeenAt == nil || now.Sub(*session.LastSeenAt) >= kioskTouchEvery {
+		_ = s.sessions.Touch(ctx, session.ID, now)
+		session.LastSeenAt = &now
+	}
+	return session, nil
+}
+
+// Event trả về sự kiện của kiosk
+func (s *KioskServiceImpl) Event(ctx context.Context) (*entity.Event, error) {
+	session, err := kioskFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	event, err := s.eventRepo.FindByID(ctx, session.EventID)
+	if err != nil {
+		return nil, fmt.Errorf("find event failed: %w", err)
+	}
+	if event == nil {
+		return nil, fmt.Errorf("event %w", service_interface.ErrNotFound)
+	}
+	e := event.EventEntityToModel()
+	return &e, nil
+}
+
+// 🔍 LookupGuests tìm khách đã đăng ký sự kiện của kiosk; đăng ký đã huỷ không hiện ra
+func (s *KioskServiceImpl) LookupGuests(ctx context.Context, query, clientIP string) (*entity.KioskLookupResult, error) {
+	session, err := kioskFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query = strings.TrimSpace(query)
+	entry := &entity.KioskAuditEntry{Action: entity.KioskAuditLookup, Query: query, ClientIP: clientIP}
+
+	if utf8.RuneCountInString(query) < entity.MinKioskQueryLength {
+		err := fmt.Errorf("%w: type at least %d characters", service_interface.ErrInvalidQuery, entity.MinKioskQueryLength)
+		s.record(ctx, session, entry, err)
+		return nil, err
+	}
+
+	guests, total, err := s.guestRepo.Search(ctx, entity.GuestQuery{
+		EventID:  session.EventID,
+		Keyword:  query,
+		Match:    entity.GuestMatchPrefix,
+		PageSize: entity.MaxKioskMatches,
+	})
+	if err != nil {
+		err = fmt.Errorf("search guests failed: %w", err)
+		s.record(ctx, session, entry, err)
+		return nil, err
+	}
+
+	result := &entity.KioskLookupResult{Matches: make([]*entity.KioskGuestMatch, 0, len(guests)), Total: total}
+	for _, g := range guests {
+		reg, err := s.registrationRepo.FindByEventAndGuest(ctx, session.EventID, g.ID)
+		if err != nil {
+			err = fmt.Errorf("find registration failed: %w", err)
+			s.record(ctx, session, entry, err)
+			return nil, err
+		}
+		if reg == nil || entity.ParseRegistrationStatus(reg.Status) == entity.RegistrationCancelled {
+			continue
+		}
+		result.Matches = append(result.Matches, kioskMatch(g, reg))
+	}
+
+	entry.Matches = len(result.Matches)
+	s.record(ctx, session, entry, nil)
+	return result, nil
+}
+
+// ✅ CheckIn cho khách tự check-in; confirm là email hoặc 4 số cuối SĐT của khách
+// để người khác không thể check-in hộ chỉ bằng cách tìm tên. Sai quá kioskMaxConfirmFailures lần
+// thì đăng ký bị khoá, giới hạn tần suất theo kiosk không đủ vì có thể thử trên nhiều kiosk.
+func (s *KioskServiceImpl) CheckIn(ctx context.Context, registrationID, confirm, clientIP string) (*entity.KioskGuestMatch, error) {
+	session, err := kioskFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	registrationID = strings.TrimSpace(registrationID)
+	entry := &entity.KioskAuditEntry{Action: entity.KioskAuditCheckIn, RegistrationID: registrationID, ClientIP: clientIP}
+
+	match, err := s.checkIn(ctx, session, registrationID, confirm)
+	s.record(ctx, session, entry, err)
+	return match, err
+}
+
+func (s *KioskServiceImpl) checkIn(ctx context.Context, session *entity.KioskSession, registrationID, confirm string) (*entity.KioskGuestMatch, error) {
+	if registrationID == "" {
+		return nil, errors.New("registration id is required")
+	}
+	reg, err := s.registrationRepo.FindByID(ctx, registrationID)
+	if err != nil {
+		return nil, fmt.Errorf("find registration failed: %w", err)
+	}
+	// Kiosk chỉ thấy đăng ký của sự kiện được gắn
+	if reg == nil || reg.EventID != session.EventID {
+		return nil, fmt.Errorf("registration %w", service_interface.ErrNotFound)
+	}
+	guest, err := s.guestRepo.FindByID(ctx, reg.GuestID)
+	if err != nil {
+		return nil, fmt.Errorf("find guest failed: %w", err)
+	}
+	if guest == nil {
+		return nil, fmt.Errorf("guest %w", service_interface.ErrNotFound)
+	}
+	if reg.KioskConfirmFailures >= kioskMaxConfirmFailures {
+		return nil, service_interface.ErrKioskLocked
+	}
+	if !kioskConfirmMatches(guest, confirm) {
+		failures, err := s.registrationRepo.AddKioskConfirmFailure(ctx, reg.ID.Hex())
+		if err != nil {
+			return nil, fmt.Errorf("record failed confirmation failed: %w", err)
+		}
+		if failures >= kioskMaxConfirmFailures {
+			return nil, service_interface.ErrKioskLocked
+		}
+		return nil, service_interface.ErrConfirmMismatch
+	}
+	if entity.ParseRegistrationStatus(reg.Status) == entity.RegistrationCheckedIn {
+		return nil, service_interface.ErrAlreadyCheckedIn
+	}
+
+	// Lời gọi không có user được RegistrationService coi là nội bộ; sự kiện đã được kiểm tra ở trên
+	updated, err := s.registrations.CheckIn(contextWithActor(ctx, "kiosk:"+session.ID), reg.ID.Hex())
+	if err != nil {
+		return nil, err
+	}
+	reg.Status, reg.CheckedIn = string(updated.Status), updated.CheckedIn
+	return kioskMatch(guest, reg), nil
+}
+
+// RecordRateLimited ghi nhật ký khi kiosk bị giới hạn tần suất
+func (s *KioskServiceImpl) RecordRateLimited(ctx context.Context, action, clientIP string) {
+	session := utils.KioskFromContext(ctx)
+	if session == nil {
+		return
+	}
+	s.record(ctx, session, &entity.KioskAuditEntry{
+		Action:   entity.KioskAuditRateLimited,
+		Message:  action,
+		ClientIP: clientIP,
+	}, nil)
+}
+
+// record ghi nhật ký của kiosk; lỗi ghi nhật ký không làm hỏng thao tác của khách
+func (s *KioskServiceImpl) record(ctx context.Context, session *entity.KioskSession, entry *entity.KioskAuditEntry, err error) {
+	entry.KioskID, entry.EventID, entry.At = session.ID, session.EventID, time.Now()
+	if entry.Result == "" {
+		entry.Result = entity.KioskResultOK
+	}
+	if err != nil {
+		entry.Result, entry.Message = entity.KioskResultRejected, err.Error()
+		if kioskInternalError(err) {
+			entry.Result = entity.KioskResultError
+		}
+	}
+	_ = s.audit.Insert(context.WithoutCancel(ctx), models.KioskAuditEntityToModel(entry))
+}
+
+func kioskFromContext(ctx context.Context) (*entity.KioskSession, error) {
+	session := utils.KioskFromContext(ctx)
+	if session == nil {
+		return nil, service_interface.ErrForbidden
+	}
+	return session, nil
+}
+
+// kioskInternalError phân biệt lỗi hệ thống với lỗi do khách (không tìm thấy, xác nhận sai, ...)
+func kioskInternalError(err error) bool {
+	for _, known := range []error{
+		service_interface.ErrInvalidQuery,
+		service_interface.ErrNotFound,
+		service_interface.ErrConfirmMismatch,
+		service_interface.ErrKioskLocked,
+		service_interface.ErrAlreadyCheckedIn,
+		service_interface.ErrInvalidTransition,
+		service_interface.ErrEventFull,
+	} {
+		if errors.Is(err, known) {
+			return false
+		}
+	}
+	return true
+}
+
+// kioskConfirmMatches so confirm với email (không phân biệt hoa thường) hoặc 4 số cuối SĐT của khách
+func kioskConfirmMatches(guest *models.GuestModel, confirm string) bool {
+	confirm = strings.TrimSpace(confirm)
+	if confirm == "" {
+		return false
+	}
+	if guest.Email != "" && strings.EqualFold(confirm, strings.TrimSpace(guest.Email)) {
+		return true
+	}
+	digits := utils.DigitsOnly(confirm)
+	phone := utils.DigitsOnly(guest.Phone)
+	return len(digits) == kioskConfirmDigits && len(digits) == len(confirm) && strings.HasSuffix(phone, digits)
+}
+
+func kioskMatch(g *models.GuestModel, reg *models.RegistrationModel) *entity.KioskGuestMatch {
+	m := &entity.KioskGuestMatch{
+		RegistrationID: reg.ID.Hex(),
+		Name:           utils.MaskName(g.FullName),
+		Status:         entity.ParseRegistrationStatus(reg.Status),
+		CheckedIn:      reg.CheckedIn,
+	}
+	if g.Email != "" {
+		m.Email = utils.MaskEmail(g.Email)
+	}
+	if g.Phone != "" {
+		m.Phone = utils.MaskPhone(g.Phone)
+	}
+	return m
+}
@@ -0,0 +1,301 @@
+package service_imple
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"event_manager/internal/domain/entity"
+	repository "event_manager/internal/domain/repository"
+	service_interface "event_manager/internal/domain/service"
+	"event_manager/internal/models"
+)
+
+// VenueBuffers là thời gian giữ địa điểm trước (setup) và sau (teardown) mỗi sự kiện
+type VenueBuffers struct {
+	Setup    time.Duration
+	Teardown time.Duration
+}
+
+// LocationServiceImpl triển khai LocationService
+type LocationServiceImpl struct {
+	repo      repository.LocationRepository
+	eventRepo repository.EventRepository
+	buffers   VenueBuffers
+}
+
+// ✅ Khởi tạo service
+func NewLocationService(
+	repo repository.LocationRepository,
+	eventRepo repository.EventRepository,
+	buffers VenueBuffers,
+) service_interface.LocationService {
+	if buffers.Setup < 0 {
+		buffers.Setup = 0
+	}
+	if buffers.Teardown < 0 {
+		buffers.Teardown = 0
+	}
+	return &LocationServiceImpl{
+		repo:      repo,
+		eventRepo: eventRepo,
+		buffers:   buffers,
+	}
+}
+
+// 🟢 Thêm địa điểm mới
+func (s *LocationServiceImpl) Create(ctx context.Context, l *entity.Location) error {
+	if err := validateLocation(l); err != nil {
+		return err
+	}
+
+	model := models.LocationEntityToModel(l)
+	if err := s.repo.Insert(ctx, model); err != nil {
+		if errors.Is(err, repository.ErrDuplicate) {
+			return service_interface.ErrDuplicateLocation
+		}
+		return fmt.Errorf("insert location failed: %w", err)
+	}
+	l.ID = model.ID.Hex()
+	l.Name = model.Name
+	return nil
+}
+
+// 🟡 Cập nhật địa điểm; đổi tên thì đồng bộ tên hiển thị trên các sự kiện
+func (s *LocationServiceImpl) Update(ctx context.Context, l *entity.Location) error {
+	if l == nil || strings.TrimSpace(l.ID) == "" {
+		return errors.New("invalid location")
+	}
+	if err := validateLocation(l); err != nil {
+		return err
+	}
+
+	old, err := s.repo.FindByID(ctx, l.ID)
+	if err != nil {
+		return fmt.Errorf("find location failed: %w", err)
+	}
+	if old == nil {
+		return fmt.Errorf("location %w", service_interface.ErrNotFound)
+	}
+
+	model := models.LocationEntityToModel(l)
+	if err := s.repo.Update(ctx, model); err != nil {
+		if errors.Is(err, repository.ErrDuplicate) {
+			return service_interface.ErrDuplicateLocation
+		}
+		return fmt.Errorf("update location failed: %w", err)
+	}
+
+	if old.Name != model.Name {
+		if err := s.eventRepo.RenameLocation(ctx, l.ID, model.Name); err != nil {
+			return fmt.Errorf("rename location on events failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// 🔴 Xoá địa điểm chưa được sự kiện nào sử dụng
+func (s *LocationServiceImpl) Delete(ctx context.Context, locationID string) error {
+	if _, err := s.GetByID(ctx, locationID); err != nil {
+		return err
+	}
+
+	used, err := s.eventRepo.CountByLocation(ctx, locationID)
+	if err != nil {
+		return fmt.Errorf("count events by location failed: %w", err)
+	}
+	if used > 0 {
+		return service_interface.ErrLocationInUse
+	}
+
+	if err := s.repo.Delete(ctx, locationID); err != nil {
+		return fmt.Errorf("delete location failed: %w", err)
+	}
+	return nil
+}
+
+// 🔍 Lấy địa điểm theo ID
+func (s *LocationServiceImpl) GetByID(ctx context.Context, locationID string) (*entity.Location, error) {
+	locationID = strings.TrimSpace(locationID)
+	if locationID == "" {
+		return nil, errors.New("location id is required")
+	}
+
+	model, err := s.repo.FindByID(ctx, locationID)
+	if err != nil {
+		return nil, fmt.Errorf("find location failed: %w", err)
+	}
+	if model == nil {
+		return nil, fmt.Errorf("location %w", service_interface.ErrNotFound)
+	}
+	return models.LocationModelToEntity(model), nil
+}
+
+// 📋 Danh sách địa điểm
+func (s *LocationServiceImpl) List(ctx context.Context) ([]*entity.Location, error) {
+	list, err := s.repo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list locations failed: %w", err)
+	}
+
+	result := make([]*entity.Location, 0, len(list))
+	for _, m := range list {
+		result = append(result, models.LocationModelToEntity(m))
+	}
+	return result, nil
+}
+
+// 🔎 Tìm theo tên đã chuẩn hoá, chưa có thì tạo địa điểm mới (sức chứa không giới hạn)
+func (s *LocationServiceImpl) Resolve(ctx context.Context, name string) (*entity.Location, error) {
+	key := models.LocationNameKey(name)
+	if key == "" {
+		return nil, errors.New("location name is required")
+	}
+
+	existing, err := s.repo.FindByNameKey(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("find location failed: %w", err)
+	}
+	if existing != nil {
+		return models.LocationModelToEntity(existing), nil
+	}
+
+	loc := &entity.Location{Name: strings.TrimSpace(name)}
+	err = s.Create(ctx, loc)
+	if errors.Is(err, service_interface.ErrDuplicateLocation) {
+		// Request song song vừa tạo cùng tên
+		existing, err = s.repo.FindByNameKey(ctx, key)
+		if err != nil || existing == nil {
+			return nil, fmt.Errorf("find location failed: %w", err)
+		}
+		return models.LocationModelToEntity(existing), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return loc, nil
+}
+
+// ⛔ Các sự kiện khác có khoảng bị chặn (kể cả buffer) giao với khoảng bị chặn của [start, end)
+func (s *LocationServiceImpl) FindConflicts(ctx context.Context, locationID string, start, end time.Time, excludeEventID string) ([]entity.VenueBooking, error) {
+	if strings.TrimSpace(locationID) == "" {
+		return nil, nil
+	}
+	if !end.After(start) {
+		return nil, errors.New("end date must be after start date")
+	}
+
+	// Hai sự kiện xung đột khi [start-setup, end+teardown) của chúng giao nhau
+	gap := s.buffers.Setup + s.buffers.Teardown
+	events, err := s.eventRepo.FindByLocationBetween(ctx, locationID, start.Add(-gap), end.Add(gap))
+	if err != nil {
+		return nil, fmt.Errorf("find events by location failed: %w", err)
+	}
+
+	conflicts := make([]entity.VenueBooking, 0)
+	for _, e := range events {
+		if e.ID == excludeEventID {
+			continue
+		}
+		conflicts = append(conflicts, s.booking(e))
+	}
+	return conflicts, nil
+}
+
+// 🔒 Khoá lịch đặt của địa điểm (chỉ có tác dụng trong transaction)
+func (s *LocationServiceImpl) LockBookings(ctx context.Context, locationID string) error {
+	if strings.TrimSpace(locationID) == "" {
+		return nil
+	}
+	if err := s.repo.LockBookings(ctx, locationID); err != nil {
+		return fmt.Errorf("lock location bookings failed: %w", err)
+	}
+	return nil
+}
+
+// 🗓️ Lịch bận / rảnh của địa điểm; khoảng rảnh là phần còn lại sau khi gộp các khoảng bị chặn
+func (s *LocationServiceImpl) Availability(ctx context.Context, locationID string, from, to time.Time) (*entity.VenueAvailability, error) {
+	if !to.After(from) {
+		return nil, fmt.Errorf("%w: 'to' must be after 'from'", service_interface.ErrInvalidQuery)
+	}
+	if _, err := s.GetByID(ctx, locationID); err != nil {
+		return nil, err
+	}
+
+	events, err := s.eventRepo.FindByLocationBetween(ctx, locationID, from.Add(-s.buffers.Teardown), to.Add(s.buffers.Setup))
+	if err != nil {
+		return nil, fmt.Errorf("find events by location failed: %w", err)
+	}
+
+	result := &entity.VenueAvailability{
+		LocationID:     locationID,
+		From:           from,
+		To:             to,
+		SetupBuffer:    s.buffers.Setup,
+		TeardownBuffer: s.buffers.Teardown,
+		Busy:           make([]entity.VenueBooking, 0, len(events)),
+		Free:           make([]entity.TimeSlot, 0),
+	}
+
+	cursor := from
+	for _, e := range events {
+		b := s.booking(e)
+		result.Busy = append(result.Busy, b)
+
+		if b.BlockedFrom.After(cursor) {
+			result.Free = append(result.Free, entity.TimeSlot{From: cursor, To: minTime(b.BlockedFrom, to)})
+		}
+		if b.BlockedTo.After(cursor) {
+			cursor = b.BlockedTo
+		}
+	}
+	if to.After(cursor) {
+		result.Free = append(result.Free, entity.TimeSlot{From: cursor, To: to})
+	}
+	return result, nil
+}
+
+func (s *LocationServiceImpl) booking(e *models.EventModel) entity.VenueBooking {
+	return entity.VenueBooking{
+		EventID:     e.ID,
+		EventName:   e.Name,
+		StartDate:   e.StartDate,
+		EndDate:     e.EndDate,
+		BlockedFrom: e.StartDate.Add(-s.buffers.Setup),
+		BlockedTo:   e.EndDate.Add(s.buffers.Teardown),
+	}
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+// ✅ Kiểm tra sức chứa khi thêm khách
+func (s *LocationServiceImpl) CheckCapacity(ctx context.Context, locationID string, expected int) (bool, error) {
+	if expected < 0 {
+		return false, errors.New("expected guests must not be negative")
+	}
+	loc, err := s.GetByID(ctx, locationID)
+	if err != nil {
+		return false, err
+	}
+	return loc.Fits(expected), nil
+}
+
+func validateLocation(l *entity.Location) error {
+	if l == nil {
+		return errors.New("location is nil")
+	}
+	if models.LocationNameKey(l.Name) == "" {
+		return errors.New("location name is required")
+	}
+	if l.Capacity < 0 {
+		return errors.New("capacity must not be negative")
+	}
+	return nil
+}
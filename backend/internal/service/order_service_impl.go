@@ -0,0 +1,477 @@
+package service_imple
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"event_manager/internal/domain/entity"
+	repository "event_manager/internal/domain/repository"
+	service_interface "event_manager/internal/domain/service"
+	"event_manager/internal/models"
+	"event_manager/internal/payment"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// defaultOrderHoldTTL là thời gian giữ vé chờ thanh toán khi không cấu hình
+	defaultOrderHoldTTL = 15 * time.Minute
+	// expireBatchSize là số đơn hết hạn xử lý mỗi lượt
+	expireBatchSize = 100
+)
+
+// OrderServiceImpl triển khai OrderService.
+// Đơn giữ một vé của loại vé và, với sự kiện giới hạn, một chỗ của sự kiện; khi thanh toán thành
+// công chỗ đó được chuyển sang đăng ký mới, khi đơn thất bại / hết hạn / bị huỷ thì cả hai được trả.
+type OrderServiceImpl struct {
+	repo             repository.OrderRepository
+	ticketRepo       repository.TicketTypeRepository
+	eventRepo        repository.EventRepository
+	registrationRepo repository.RegistrationRepository
+	guestRepo        repository.GuestRepository
+	seats            seatAllocator
+	provider         payment.Provider
+	holdTTL          time.Duration
+}
+
+// NewOrderService wires dependencies into an OrderService implementation; holdTTL <= 0 uses the default.
+// Without a provider only free tickets can be ordered.
+func NewOrderService(
+	repo repository.OrderRepository,
+	ticketRepo repository.TicketTypeRepository,
+	eventRepo repository.EventRepository,
+	registrationRepo repository.RegistrationRepository,
+	guestRepo repository.GuestRepository,
+	provider payment.Provider,
+	holdTTL time.Duration,
+) service_interface.OrderService {
+	if holdTTL <= 0 {
+		holdTTL = defaultOrderHoldTTL
+	}
+	return &OrderServiceImpl{
+		repo:             repo,
+		ticketRepo:       ticketRepo,
+		eventRepo:        eventRepo,
+		registrationRepo: registrationRepo,
+		guestRepo:        guestRepo,
+		seats:            seatAllocator{eventRepo: eventRepo, registrationRepo: registrationRepo},
+		provider:         provider,
+		holdTTL:          holdTTL,
+	}
+}
+
+// PlaceOrder giữ vé cho khách và tạo giao dịch thanh toán.
+func (s *OrderServiceImpl) PlaceOrder(ctx context.Context, eventID, ticketTypeID, guestID string) (*entity.Order, error) {
+	event, err := authorizeEventByID(ctx, s.eventRepo, eventID, entity.EventPermManageRegistrations)
+	if err != nil {
+		return nil, err
+	}
+	ticket, err := loadTicketType(ctx, s.ticketRepo, event.ID, ticketTypeID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	tt := ticket.TicketTypeModelToEntity()
+	if !tt.OnSale(now) {
+		return nil, service_interface.ErrNotOnSale
+	}
+	if tt.PriceAt(now) > 0 && s.provider == nil {
+		return nil, service_interface.ErrPaymentUnavailable
+	}
+
+	guestID = strings.TrimSpace(guestID)
+	if guestID == "" {
+		return nil, errors.New("guest id is required")
+	}
+	guest, err := s.guestRepo.FindByID(ctx, guestID)
+	if err != nil {
+		return nil, fmt.Errorf("find guest failed: %w", err)
+	}
+	if guest == nil {
+		return nil, fmt.Errorf("guest %w", service_interface.ErrNotFound)
+	}
+	existing, err := s.registrationRepo.FindByEventAndGuest(ctx, event.ID, guestID)
+	if err != nil {
+		return nil, fmt.Errorf("find registration failed: %w", err)
+	}
+	if existing != nil {
+		return nil, service_interface.ErrAlreadyRegistered
+	}
+
+	// 🎟️ Giữ vé; hết vé thì trả vé của các đơn đã quá hạn rồi thử lại một lần
+	ok, err := s.ticketRepo.Reserve(ctx, tt.ID)
+	if err == nil && !ok {
+		if _, err = s.expire(ctx, tt.ID); err == nil {
+			ok, err = s.ticketRepo.Reserve(ctx, tt.ID)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reserve ticket failed: %w", err)
+	}
+	if !ok {
+		return nil, service_interface.ErrSoldOut
+	}
+
+	// 🪑 Đơn trả tiền cho một chỗ chắc chắn nên không vào danh sách chờ
+	seatHeld := false
+	if event.IsLimited() {
+		ok, err := s.seats.reserve(ctx, event)
+		if err != nil || !ok {
+			_ = s.ticketRepo.Release(ctx, tt.ID)
+			if err != nil {
+				return nil, err
+			}
+			return nil, service_interface.ErrEventFull
+		}
+		seatHeld = true
+	}
+
+	order := &models.OrderModel{
+		ID:           primitive.NewObjectID(),
+		EventID:      event.ID,
+		TicketTypeID: tt.ID,
+		TicketName:   tt.Name,
+		GuestID:      guestID,
+		Amount:       tt.PriceAt(now),
+		Currency:     tt.Currency,
+		EarlyBird:    tt.IsEarlyBird(now),
+		Status:       string(entity.OrderPending),
+		ExpiresAt:    now.Add(s.holdTTL),
+		CreatedAt:    now,
+	}
+	if err := s.repo.Insert(ctx, order); err != nil {
+		_ = s.ticketRepo.Release(ctx, tt.ID)
+		if seatHeld {
+			s.seats.rollback(ctx, event)
+		}
+		if errors.Is(err, repository.ErrDuplicate) {
+			return nil, service_interface.ErrOrderPending
+		}
+		return nil, fmt.Errorf("insert order failed: %w", err)
+	}
+
+	// Vé miễn phí: xác nhận ngay, không qua cổng thanh toán
+	if order.Amount == 0 {
+		if err := s.confirm(ctx, order, "free ticket"); err != nil {
+			return nil, err
+		}
+		return order.OrderModelToEntity(), nil
+	}
+
+	pay, err := s.provider.CreatePayment(ctx, payment.Request{
+		OrderID:     order.ID.Hex(),
+		Amount:      order.Amount,
+		Currency:    order.Currency,
+		Description: fmt.Sprintf("%s - %s", event.Name, tt.Name),
+		ExpiresAt:   order.ExpiresAt,
+	})
+	if err != nil {
+		if _, closeErr := s.close(ctx, order, entity.OrderFailed, "create payment failed"); closeErr != nil {
+			log.Println("⚠️ Không trả được vé của đơn", order.ID.Hex(), ":", closeErr)
+		}
+		return nil, fmt.Errorf("create payment failed: %w", err)
+	}
+	if err := s.repo.SetPayment(ctx, order.ID.Hex(), s.provider.Name(), pay.ID, pay.CheckoutURL); err != nil {
+		return nil, fmt.Errorf("save payment failed: %w", err)
+	}
+	order.Provider = s.provider.Name()
+	order.PaymentID = pay.ID
+	order.CheckoutURL = pay.CheckoutURL
+	return order.OrderModelToEntity(), nil
+}
+
+// Cancel huỷ đơn chưa thanh toán.
+func (s *OrderServiceImpl) Cancel(ctx context.Context, eventID, orderID string) (*entity.Order, error) {
+	order, err := s.loadAuthorized(ctx, eventID, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if !entity.OrderStatus(order.Status).IsOpen() {
+		return nil, service_interface.ErrOrderClosed
+	}
+
+	ok, err := s.close(ctx, order, entity.OrderCancelled, "cancelled by organizer")
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, service_interface.ErrOrderClosed
+	}
+	s.cancelPayment(ctx, order)
+	return order.OrderModelToEntity(), nil
+}
+
+// GetByID trả về một đơn thuộc sự kiện.
+func (s *OrderServiceImpl) GetByID(ctx context.Context, eventID, orderID string) (*entity.Order, error) {
+	order, err := s.loadAuthorized(ctx, eventID, orderID)
+	if err != nil {
+		return nil, err
+	}
+	return order.OrderModelToEntity(), nil
+}
+
+// ListByEvent trả về các đơn của sự kiện.
+func (s *OrderServiceImpl) ListByEvent(ctx context.Context, eventID string, status entity.OrderStatus) ([]*entity.Order, error) {
+	event, err := authorizeEventByID(ctx, s.eventRepo, eventID, entity.EventPermManageRegistrations)
+	if err != nil {
+		return nil, err
+	}
+	list, err := s.repo.FindByEvent(ctx, event.ID, string(status))
+	if err != nil {
+		return nil, fmt.Errorf("list orders failed: %w", err)
+	}
+	result := make([]*entity.Order, 0, len(list))
+	for _, m := range list {
+		result = append(result, m.OrderModelToEntity())
+	}
+	return result, nil
+}
+
+// HandleWebhook áp dụng kết quả thanh toán; cổng thanh toán có thể gửi lại cùng một kết quả nhiều lần.
+func (s *OrderServiceImpl) HandleWebhook(ctx context.Context, header http.Header, body []byte) (*entity.Order, error) {
+	if s.provider == nil {
+		return nil, service_interface.ErrPaymentUnavailable
+	}
+	ev, err := s.provider.ParseWebhook(header, body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", service_interface.ErrInvalidWebhook, err)
+	}
+	order, err := s.repo.FindByPayment(ctx, s.provider.Name(), ev.PaymentID)
+	if err != nil {
+		return nil, fmt.Errorf("find order failed: %w", err)
+	}
+	if order == nil {
+		return nil, fmt.Errorf("order %w", service_interface.ErrNotFound)
+	}
+
+	ctx = contextWithActor(ctx, "payment:"+s.provider.Name())
+	status := entity.OrderStatus(order.Status)
+	switch ev.Status {
+	case payment.StatusSucceeded:
+		switch status {
+		case entity.OrderPending:
+			err = s.confirm(ctx, order, "payment "+ev.PaymentID+" succeeded")
+		case entity.OrderPaid, entity.OrderRefundDue:
+			// Đã xử lý trước đó
+		default:
+			// Tiền về sau khi đơn đã đóng: vé đã trả cho người khác nên chỉ có thể hoàn tiền
+			err = s.refundDue(ctx, order, status, fmt.Sprintf("payment received after the order was %s", status))
+		}
+	case payment.StatusFailed:
+		if status == entity.OrderPending {
+			reason := "payment failed"
+			if ev.Reason != "" {
+				reason += ": " + ev.Reason
+			}
+			_, err = s.close(ctx, order, entity.OrderFailed, reason)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return order.OrderModelToEntity(), nil
+}
+
+// ExpireReservations trả vé của các đơn hết thời gian giữ.
+func (s *OrderServiceImpl) ExpireReservations(ctx context.Context) (int, error) {
+	return s.expire(ctx, "")
+}
+
+// expire đóng các đơn quá hạn (của một loại vé, hoặc mọi loại vé khi ticketTypeID rỗng)
+func (s *OrderServiceImpl) expire(ctx context.Context, ticketTypeID string) (int, error) {
+	ctx = contextWithActor(ctx, systemActor)
+	total := 0
+	for {
+		list, err := s.repo.FindExpired(ctx, ticketTypeID, time.Now(), expireBatchSize)
+		if err != nil {
+			return total, fmt.Errorf("find expired orders failed: %w", err)
+		}
+		for _, order := range list {
+			ok, err := s.close(ctx, order, entity.OrderExpired, "reservation expired")
+			if err != nil {
+				return total, err
+			}
+			if ok {
+				total++
+				s.cancelPayment(ctx, order)
+			}
+		}
+		if len(list) < expireBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// confirm tạo đăng ký confirmed cho đơn đang chờ và đánh dấu đơn đã thanh toán. Đăng ký dùng lại
+// ID của đơn nên gọi lại sau lỗi giữa chừng không tạo đăng ký thứ hai.
+func (s *OrderServiceImpl) confirm(ctx context.Context, order *models.OrderModel, reason string) error {
+	now := time.Now()
+	registrationID := order.ID.Hex()
+
+	reg, err := s.registrationRepo.FindByID(ctx, registrationID)
+	if err != nil {
+		return fmt.Errorf("find registration failed: %w", err)
+	}
+	if reg == nil {
+		reg = &models.RegistrationModel{
+			ID:        order.ID,
+			EventID:   order.EventID,
+			GuestID:   order.GuestID,
+			Status:    string(entity.RegistrationConfirmed),
+			CreatedAt: now,
+			History: []models.RegistrationTransitionModel{{
+				To:      string(entity.RegistrationConfirmed),
+				ActorID: actorFromContext(ctx),
+				Reason:  reason,
+				At:      now,
+			}},
+		}
+		if err := s.registrationRepo.Insert(ctx, reg); err != nil {
+			if !errors.Is(err, repository.ErrDuplicate) {
+				return fmt.Errorf("insert registration failed: %w", err)
+			}
+			// Trùng do webhook khác vừa tạo đăng ký của đơn này thì tiếp tục, còn lại là khách
+			// đã được đăng ký bằng cách khác trong lúc chờ thanh toán
+			if again, findErr := s.registrationRepo.FindByID(ctx, registrationID); findErr != nil || again == nil {
+				return s.refundDue(ctx, order, entity.OrderPending, "guest was registered while the order was pending")
+			}
+		}
+		if err := s.guestRepo.AddEvent(ctx, order.GuestID, order.EventID); err != nil {
+			return fmt.Errorf("link guest to event failed: %w", err)
+		}
+	}
+
+	next := *order
+	next.Status = string(entity.OrderPaid)
+	next.RegistrationID = registrationID
+	next.PaidAt = &now
+	next.UpdatedAt = now
+	ok, err := s.repo.Transition(ctx, &next, string(entity.OrderPending))
+	if err != nil {
+		return fmt.Errorf("update order failed: %w", err)
+	}
+	if !ok {
+		return s.afterLostRace(ctx, order, registrationID)
+	}
+	*order = next
+
+	if err := s.ticketRepo.ConfirmSale(ctx, order.TicketTypeID); err != nil {
+		return fmt.Errorf("confirm ticket sale failed: %w", err)
+	}
+	return nil
+}
+
+// afterLostRace xử lý khi đơn không còn pending lúc xác nhận: webhook khác đã xác nhận thì thôi,
+// đơn đã bị đóng (hết hạn / huỷ) thì bỏ đăng ký vừa tạo và chuyển đơn sang chờ hoàn tiền.
+func (s *OrderServiceImpl) afterLostRace(ctx context.Context, order *models.OrderModel, registrationID string) error {
+	current, err := s.repo.FindByID(ctx, order.ID.Hex())
+	if err != nil {
+		return fmt.Errorf("find order failed: %w", err)
+	}
+	if current == nil {
+		return fmt.Errorf("order %w", service_interface.ErrNotFound)
+	}
+	*order = *current
+
+	status := entity.OrderStatus(current.Status)
+	if status == entity.OrderPaid || status == entity.OrderRefundDue {
+		return nil
+	}
+	if err := s.registrationRepo.Delete(ctx, registrationID); err != nil {
+		return fmt.Errorf("delete registration failed: %w", err)
+	}
+	if err := s.guestRepo.RemoveEvent(ctx, order.GuestID, order.EventID); err != nil {
+		return fmt.Errorf("unlink guest from event failed: %w", err)
+	}
+	return s.refundDue(ctx, order, status, fmt.Sprintf("payment received after the order was %s", status))
+}
+
+// refundDue chuyển đơn sang chờ hoàn tiền; đơn còn đang giữ vé thì trả vé
+func (s *OrderServiceImpl) refundDue(ctx context.Context, order *models.OrderModel, from entity.OrderStatus, reason string) error {
+	next := *order
+	next.Status = string(entity.OrderRefundDue)
+	next.Reason = reason
+	next.UpdatedAt = time.Now()
+	ok, err := s.repo.Transition(ctx, &next, string(from))
+	if err != nil {
+		return fmt.Errorf("update order failed: %w", err)
+	}
+	if !ok {
+		return service_interface.ErrOrderClosed
+	}
+	*order = next
+	log.Printf("⚠️ Đơn %s cần hoàn tiền: %s", order.ID.Hex(), reason)
+
+	if from == entity.OrderPending {
+		return s.releaseHold(ctx, order)
+	}
+	return nil
+}
+
+// close chuyển đơn đang chờ sang trạng thái kết thúc (failed / expired / cancelled) và trả vé.
+// Trả về false nếu đơn đã không còn pending.
+func (s *OrderServiceImpl) close(ctx context.Context, order *models.OrderModel, to entity.OrderStatus, reason string) (bool, error) {
+	next := *order
+	next.Status = string(to)
+	next.Reason = reason
+	next.UpdatedAt = time.Now()
+	ok, err := s.repo.Transition(ctx, &next, string(entity.OrderPending))
+	if err != nil {
+		return false, fmt.Errorf("update order failed: %w", err)
+	}
+	if !ok {
+		return false, nil
+	}
+	*order = next
+	return true, s.releaseHold(ctx, order)
+}
+
+// releaseHold trả vé và chỗ mà đơn đang giữ; chỗ trống được chuyển cho đăng ký waitlist cũ nhất
+func (s *OrderServiceImpl) releaseHold(ctx context.Context, order *models.OrderModel) error {
+	if err := s.ticketRepo.Release(ctx, order.TicketTypeID); err != nil {
+		return fmt.Errorf("release ticket failed: %w", err)
+	}
+	event, err := s.eventRepo.FindByID(ctx, order.EventID)
+	if err != nil {
+		return fmt.Errorf("find event failed: %w", err)
+	}
+	if _, err := s.seats.release(ctx, event); err != nil {
+		return err
+	}
+	return nil
+}
+
+// cancelPayment huỷ giao dịch ở cổng thanh toán để khách không trả tiền cho đơn đã đóng
+func (s *OrderServiceImpl) cancelPayment(ctx context.Context, order *models.OrderModel) {
+	if order.PaymentID == "" || s.provider == nil {
+		return
+	}
+	if err := s.provider.CancelPayment(ctx, order.PaymentID); err != nil {
+		log.Println("⚠️ Huỷ giao dịch", order.PaymentID, "thất bại:", err)
+	}
+}
+
+// loadAuthorized tìm đơn thuộc sự kiện và kiểm tra quyền quản lý đăng ký của user
+func (s *OrderServiceImpl) loadAuthorized(ctx context.Context, eventID, orderID string) (*models.OrderModel, error) {
+	event, err := authorizeEventByID(ctx, s.eventRepo, eventID, entity.EventPermManageRegistrations)
+	if err != nil {
+		return nil, err
+	}
+	orderID = strings.TrimSpace(orderID)
+	if orderID == "" {
+		return nil, errors.New("order id is required")
+	}
+	order, err := s.repo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("find order failed: %w", err)
+	}
+	if order == nil || order.EventID != event.ID {
+		return nil, fmt.Errorf("order %w", service_interface.ErrNotFound)
+	}
+	return order, nil
+}
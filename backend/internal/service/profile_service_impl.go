@@ -0,0 +1,346 @@
+package service_imple
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"event_manager/internal/domain/entity"
+	repository "event_manager/internal/domain/repository"
+	service_interface "event_manager/internal/domain/service"
+	"event_manager/internal/models"
+)
+
+// maxProfileLinks giới hạn số liên kết trên một hồ sơ
+const maxProfileLinks = 10
+
+// ProfileServiceImpl triển khai ProfileService
+type ProfileServiceImpl struct {
+	repo           repository.ProfileRepository
+	assignmentRepo repository.ProfileAssignmentRepository
+	eventRepo      repository.EventRepository
+	sessionRepo    repository.EventSessionRepository
+}
+
+// NewProfileService wires dependencies into a ProfileService implementation.
+func NewProfileService(
+	repo repository.ProfileRepository,
+	assignmentRepo repository.ProfileAssignmentRepository,
+	eventRepo repository.EventRepository,
+	sessionRepo repository.EventSessionRepository,
+) service_interface.ProfileService {
+	return &ProfileServiceImpl{
+		repo:           repo,
+		assignmentRepo: assignmentRepo,
+		eventRepo:      eventRepo,
+		sessionRepo:    sessionRepo,
+	}
+}
+
+// Create thêm hồ sơ diễn giả / nhà tài trợ.
+func (s *ProfileServiceImpl) Create(ctx context.Context, profile *entity.Profile) error {
+	if profile == nil {
+		return errors.New("profile is nil")
+	}
+	if err := validateProfile(profile); err != nil {
+		return err
+	}
+
+	profile.ID = ""
+	profile.PhotoURL = ""
+	profile.CreatedAt = time.Now()
+	profile.UpdatedAt = time.Time{}
+	model, err := models.ProfileEntityToModel(profile)
+	if err != nil {
+		return fmt.Errorf("map profile to model failed: %w", err)
+	}
+	if err := s.repo.Insert(ctx, model); err != nil {
+		return fmt.Errorf("insert profile failed: %w", err)
+	}
+	profile.ID = model.ID.Hex()
+	return nil
+}
+
+// Update sửa thông tin hồ sơ; loại hồ sơ giữ nguyên như lúc tạo.
+func (s *ProfileServiceImpl) Update(ctx context.Context, profile *entity.Profile) error {
+	if profile == nil {
+		return errors.New("profile is nil")
+	}
+	model, err := s.load(ctx, profile.ID)
+	if err != nil {
+		return err
+	}
+
+	if profile.Kind != "" && profile.Kind != entity.ProfileKind(model.Kind) {
+		return fmt.Errorf("%w: kind cannot be changed", service_interface.ErrInvalidProfile)
+	}
+	profile.Kind = entity.ProfileKind(model.Kind)
+	if err := validateProfile(profile); err != nil {
+		return err
+	}
+	update, err := models.ProfileEntityToModel(profile)
+	if err != nil {
+		return fmt.Errorf("map profile to model failed: %w", err)
+	}
+
+	model.Name = update.Name
+	model.Headline = update.Headline
+	model.Bio = update.Bio
+	model.Links = update.Links
+	model.Tier = update.Tier
+	model.UpdatedAt = time.Now()
+	if err := s.repo.Update(ctx, model); err != nil {
+		return fmt.Errorf("update profile failed: %w", err)
+	}
+
+	*profile = *model.ProfileModelToEntity()
+	return nil
+}
+
+// Delete xoá hồ sơ; các sự kiện đang gắn hồ sơ sẽ không còn hiển thị nó.
+func (s *ProfileServiceImpl) Delete(ctx context.Context, profileID string) error {
+	model, err := s.load(ctx, profileID)
+	if err != nil {
+		return err
+	}
+	if err := s.assignmentRepo.DeleteByProfile(ctx, model.ID.Hex()); err != nil {
+		return fmt.Errorf("unassign profile failed: %w", err)
+	}
+	if err := s.repo.Delete(ctx, model.ID.Hex()); err != nil {
+		return fmt.Errorf("delete profile failed: %w", err)
+	}
+	return nil
+}
+
+// GetByID trả về một hồ sơ.
+func (s *ProfileServiceImpl) GetByID(ctx context.Context, profileID string) (*entity.Profile, error) {
+	model, err := s.load(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+	return model.ProfileModelToEntity(), nil
+}
+
+// List trả về các hồ sơ theo loại.
+func (s *ProfileServiceImpl) List(ctx context.Context, kind entity.ProfileKind) ([]*entity.Profile, error) {
+	if kind != "" && !kind.IsValid() {
+		return nil, fmt.Errorf("%w: kind must be speaker or sponsor", service_interface.ErrInvalidQuery)
+	}
+	list, err := s.repo.FindAll(ctx, string(kind))
+	if err != nil {
+		return nil, fmt.Errorf("list profiles failed: %w", err)
+	}
+	result := make([]*entity.Profile, 0, len(list))
+	for _, m := range list {
+		result = append(result, m.ProfileModelToEntity())
+	}
+	return result, nil
+}
+
+// SetPhoto gán ảnh đã tải lên cho hồ sơ.
+func (s *ProfileServiceImpl) SetPhoto(ctx context.Context, profileID, photoURL string) (*entity.Profile, error) {
+	model, err := s.load(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+	photoURL = strings.TrimSpace(photoURL)
+	if photoURL == "" {
+		return nil, fmt.Errorf("%w: photo url is required", service_interface.ErrInvalidProfile)
+	}
+	if err := s.repo.SetPhoto(ctx, model.ID.Hex(), photoURL); err != nil {
+		return nil, fmt.Errorf("set profile photo failed: %w", err)
+	}
+	model.PhotoURL = photoURL
+	model.UpdatedAt = time.Now()
+	return model.ProfileModelToEntity(), nil
+}
+
+// Assign gắn hồ sơ vào sự kiện hoặc một phiên của sự kiện.
+func (s *ProfileServiceImpl) Assign(ctx context.Context, eventID, sessionID, profileID string) error {
+	event, sessionID, err := s.authorizeTarget(ctx, eventID, sessionID)
+	if err != nil {
+		return err
+	}
+	profile, err := s.load(ctx, profileID)
+	if err != nil {
+		return err
+	}
+
+	err = s.assignmentRepo.Insert(ctx, &models.ProfileAssignmentModel{
+		EventID:   event.ID,
+		SessionID: sessionID,
+		ProfileID: profile.ID.Hex(),
+		CreatedAt: time.Now(),
+	})
+	if err != nil && !errors.Is(err, repository.ErrDuplicate) {
+		return fmt.Errorf("assign profile failed: %w", err)
+	}
+	return nil
+}
+
+// Unassign gỡ hồ sơ khỏi sự kiện hoặc phiên.
+func (s *ProfileServiceImpl) Unassign(ctx context.Context, eventID, sessionID, profileID string) error {
+	event, sessionID, err := s.authorizeTarget(ctx, eventID, sessionID)
+	if err != nil {
+		return err
+	}
+	removed, err := s.assignmentRepo.Delete(ctx, event.ID, sessionID, strings.TrimSpace(profileID))
+	if err != nil {
+		return fmt.Errorf("unassign profile failed: %w", err)
+	}
+	if !removed {
+		return fmt.Errorf("profile assignment %w", service_interface.ErrNotFound)
+	}
+	return nil
+}
+
+// ListByEvent trả về hồ sơ gắn với sự kiện và các phiên của nó.
+func (s *ProfileServiceImpl) ListByEvent(ctx context.Context, eventID string) ([]*entity.EventProfile, error) {
+	eventID = strings.TrimSpace(eventID)
+	if eventID == "" {
+		return nil, errors.New("event id is required")
+	}
+	event, err := s.eventRepo.FindByID(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("find event failed: %w", err)
+	}
+	if event == nil {
+		return nil, fmt.Errorf("event %w", service_interface.ErrNotFound)
+	}
+
+	assignments, err := s.assignmentRepo.FindByEvent(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("list profile assignments failed: %w", err)
+	}
+	if len(assignments) == 0 {
+		return []*entity.EventProfile{}, nil
+	}
+
+	// Phiên đã xoá không còn trong chương trình nên bỏ qua liên kết tới nó
+	sessions, err := s.sessionRepo.FindByEvent(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("list sessions failed: %w", err)
+	}
+	liveSessions := make(map[string]bool, len(sessions))
+	for _, sess := range sessions {
+		liveSessions[sess.ID.Hex()] = true
+	}
+
+	byProfile := make(map[string]*entity.EventProfile)
+	profileIDs := make([]string, 0, len(assignments))
+	for _, a := range assignments {
+		if a.SessionID != "" && !liveSessions[a.SessionID] {
+			continue
+		}
+		ep, ok := byProfile[a.ProfileID]
+		if !ok {
+			ep = &entity.EventProfile{SessionIDs: []string{}}
+			byProfile[a.ProfileID] = ep
+			profileIDs = append(profileIDs, a.ProfileID)
+		}
+		if a.SessionID == "" {
+			ep.OnEvent = true
+		} else {
+			ep.SessionIDs = append(ep.SessionIDs, a.SessionID)
+		}
+	}
+
+	profiles, err := s.repo.FindByIDs(ctx, profileIDs)
+	if err != nil {
+		return nil, fmt.Errorf("find profiles failed: %w", err)
+	}
+	result := make([]*entity.EventProfile, 0, len(profiles))
+	for _, m := range profiles {
+		ep := byProfile[m.ID.Hex()]
+		ep.Profile = m.ProfileModelToEntity()
+		result = append(result, ep)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i].Profile, result[j].Profile
+		if a.Tier.Rank() != b.Tier.Rank() {
+			return a.Tier.Rank() < b.Tier.Rank()
+		}
+		return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+	})
+	return result, nil
+}
+
+// authorizeTarget kiểm tra quyền sửa sự kiện và phiên (nếu có) thuộc sự kiện đó
+func (s *ProfileServiceImpl) authorizeTarget(ctx context.Context, eventID, sessionID string) (*models.EventModel, string, error) {
+	event, err := authorizeEventByID(ctx, s.eventRepo, eventID, entity.EventPermEdit)
+	if err != nil {
+		return nil, "", err
+	}
+	sessionID = strings.TrimSpace(sessionID)
+	if sessionID == "" {
+		return event, "", nil
+	}
+	session, err := s.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		return nil, "", fmt.Errorf("find session failed: %w", err)
+	}
+	if session == nil || session.EventID != event.ID {
+		return nil, "", fmt.Errorf("session %w", service_interface.ErrNotFound)
+	}
+	return event, sessionID, nil
+}
+
+// load tìm hồ sơ theo ID
+func (s *ProfileServiceImpl) load(ctx context.Context, profileID string) (*models.ProfileModel, error) {
+	profileID = strings.TrimSpace(profileID)
+	if profileID == "" {
+		return nil, errors.New("profile id is required")
+	}
+	model, err := s.repo.FindByID(ctx, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("find profile failed: %w", err)
+	}
+	if model == nil {
+		return nil, fmt.Errorf("profile %w", service_interface.ErrNotFound)
+	}
+	return model, nil
+}
+
+// validateProfile chuẩn hoá và kiểm tra hồ sơ trước khi lưu
+func validateProfile(p *entity.Profile) error {
+	p.Name = strings.TrimSpace(p.Name)
+	p.Headline = strings.TrimSpace(p.Headline)
+	p.Bio = strings.TrimSpace(p.Bio)
+
+	if !p.Kind.IsValid() {
+		return fmt.Errorf("%w: kind must be speaker or sponsor", service_interface.ErrInvalidProfile)
+	}
+	if p.Name == "" {
+		return fmt.Errorf("%w: name is required", service_interface.ErrInvalidProfile)
+	}
+	switch p.Kind {
+	case entity.ProfileSponsor:
+		if !p.Tier.IsValid() {
+			return fmt.Errorf("%w: sponsor tier must be platinum, gold, silver, bronze or partner", service_interface.ErrInvalidProfile)
+		}
+	case entity.ProfileSpeaker:
+		if p.Tier != "" {
+			return fmt.Errorf("%w: only sponsors have a tier", service_interface.ErrInvalidProfile)
+		}
+	}
+
+	if len(p.Links) > maxProfileLinks {
+		return fmt.Errorf("%w: at most %d links are allowed", service_interface.ErrInvalidProfile, maxProfileLinks)
+	}
+	links := make([]entity.ProfileLink, 0, len(p.Links))
+	for _, l := range p.Links {
+		l.Label = strings.TrimSpace(l.Label)
+		l.URL = strings.TrimSpace(l.URL)
+		u, err := url.Parse(l.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: link %q must be an http(s) URL", service_interface.ErrInvalidProfile, l.URL)
+		}
+		links = append(links, l)
+	}
+	p.Links = links
+	return nil
+}
@@ -16,12 +16,39 @@ import (
 
 // RegistrationServiceImpl coordinates registration domain operations.
 type RegistrationServiceImpl struct {
-	repo repository.RegistrationRepository
+	repo      repository.RegistrationRepository
+	eventRepo repository.EventRepository
+	guestRepo repository.GuestRepository
+	// ticketRepo cho biết sự kiện có bán vé có phí hay không
+	ticketRepo repository.TicketTypeRepository
+	seats      seatAllocator
+	sessions   sessionSeats
+	syncOps    repository.CheckInSyncRepository
+	// ticketSecret ký vé QR, tách khỏi secret của access token
+	ticketSecret string
 }
 
 // NewRegistrationService constructs a RegistrationService backed by the repository.
-func NewRegistrationService(repo repository.RegistrationRepository) service_interface.RegistrationService {
-	return &RegistrationServiceImpl{repo: repo}
+func NewRegistrationService(
+	repo repository.RegistrationRepository,
+	eventRepo repository.EventRepository,
+	guestRepo repository.GuestRepository,
+	sessionRepo repository.EventSessionRepository,
+	sessionRegRepo repository.SessionRegistrationRepository,
+	syncOps repository.CheckInSyncRepository,
+	ticketRepo repository.TicketTypeRepository,
+	ticketSecret string,
+) service_interface.RegistrationService {
+	return &RegistrationServiceImpl{
+		repo:         repo,
+		eventRepo:    eventRepo,
+		guestRepo:    guestRepo,
+		ticketRepo:   ticketRepo,
+		seats:        seatAllocator{eventRepo: eventRepo, registrationRepo: repo},
+		sessions:     sessionSeats{sessionRepo: sessionRepo, sessionRegRepo: sessionRegRepo},
+		syncOps:      syncOps,
+		ticketSecret: ticketSecret,
+	}
 }
 
 // Register creates a new registration entity.
@@ -33,29 +60,114 @@ func (s *RegistrationServiceImpl) Register(ctx context.Context, registration *en
 		return errors.New("event id and guest id are required")
 	}
 
+	// Trạng thái ban đầu chỉ có thể là pending hoặc confirmed, waitlist do hệ thống quyết định
+	if registration.Status == "" {
+		registration.Status = entity.RegistrationPending
+	}
+	if registration.Status != entity.RegistrationPending && registration.Status != entity.RegistrationConfirmed {
+		return fmt.Errorf("%w: cannot register with status %s", service_interface.ErrInvalidTransition, registration.Status)
+	}
+
+	event, err := authorizeEventByID(ctx, s.eventRepo, registration.EventID, entity.EventPermManageRegistrations)
+	if err != nil {
+		return err
+	}
+	// 🎟️ Sự kiện bán vé có phí: khách đăng ký bằng cách đặt vé (OrderService.PlaceOrder)
+	if err := requireNoPayment(ctx, s.ticketRepo, event.ID); err != nil {
+		return err
+	}
+
 	if registration.ID == "" {
 		registration.ID = primitive.NewObjectID().Hex()
 	}
-	if registration.Status == "" {
-		registration.Status = "pending"
-	}
 	if registration.CreatedAt.IsZero() {
 		registration.CreatedAt = time.Now()
 	}
 
+	// 🪑 Sự kiện giới hạn: giữ chỗ nguyên tử, hết chỗ thì vào danh sách chờ
+	status, reserved, err := s.seats.allocate(ctx, event, registration.Status)
+	if err != nil {
+		return err
+	}
+	reason := "registered"
+	if status == entity.RegistrationWaitlisted {
+		reason = "event is full"
+	}
+	registration.Status = status
+	registration.History = []entity.RegistrationTransition{{
+		To:      status,
+		ActorID: actorFromContext(ctx),
+		Reason:  reason,
+		At:      registration.CreatedAt,
+	}}
+
 	model, err := models.RegistrationEntityToModel(registration)
 	if err != nil {
+		if reserved {
+			s.seats.rollback(ctx, event)
+		}
 		return fmt.Errorf("map registration to model failed: %w", err)
 	}
 
 	if err := s.repo.Insert(ctx, model); err != nil {
+		if reserved {
+			s.seats.rollback(ctx, event)
+		}
+		if errors.Is(err, repository.ErrDuplicate) {
+			return service_interface.ErrAlreadyRegistered
+		}
 		return fmt.Errorf("insert registration failed: %w", err)
 	}
+
+	// Đồng bộ danh sách sự kiện của khách để tìm kiếm theo sự kiện
+	if err := s.guestRepo.AddEvent(ctx, registration.GuestID, registration.EventID); err != nil {
+		return fmt.Errorf("link guest to event failed: %w", err)
+	}
 	return nil
 }
 
 // CheckIn marks a registration as attended.
 func (s *RegistrationServiceImpl) CheckIn(ctx context.Context, registrationID string) (*entity.Registration, error) {
+	return s.changeStatus(ctx, registrationID, entity.RegistrationCheckedIn, "", entity.EventPermCheckIn)
+}
+
+// CheckOut records that a checked-in guest left the venue; the guest may check in again later.
+func (s *RegistrationServiceImpl) CheckOut(ctx context.Context, registrationID string) (*entity.Registration, error) {
+	return s.changeStatus(ctx, registrationID, entity.RegistrationCheckedOut, "", entity.EventPermCheckIn)
+}
+
+// Cancel revokes a registration and returns the updated entity.
+func (s *RegistrationServiceImpl) Cancel(ctx context.Context, registrationID string) (*entity.Registration, error) {
+	return s.changeStatus(ctx, registrationID, entity.RegistrationCancelled, "", entity.EventPermManageRegistrations)
+}
+
+// ChangeStatus moves a registration to the given status if the transition is allowed.
+func (s *RegistrationServiceImpl) ChangeStatus(ctx context.Context, registrationID string, status entity.RegistrationStatus, reason string) (*entity.Registration, error) {
+	if !status.IsValid() {
+		return nil, fmt.Errorf("%w: unknown status %s", service_interface.ErrInvalidTransition, status)
+	}
+
+	perm := entity.EventPermManageRegistrations
+	if status == entity.RegistrationCheckedIn || status == entity.RegistrationCheckedOut {
+		perm = entity.EventPermCheckIn
+	}
+	return s.changeStatus(ctx, registrationID, status, reason, perm)
+}
+
+// History returns the recorded status transitions of a registration, oldest first.
+func (s *RegistrationServiceImpl) History(ctx context.Context, registrationID string) ([]entity.RegistrationTransition, error) {
+	reg, err := s.GetByID(ctx, registrationID)
+	if err != nil {
+		return nil, err
+	}
+	if reg == nil {
+		return nil, fmt.Errorf("registration %w", service_interface.ErrNotFound)
+	}
+	return reg.History, nil
+}
+
+// changeStatus loads the registration, authorizes the caller on its event and applies the transition.
+func (s *RegistrationServiceImpl) changeStatus(ctx context.Context, registrationID string, to entity.RegistrationStatus, reason string, perm entity.EventPermission) (*entity.Registration, error) {
 	if registrationID == "" {
 		return nil, errors.New("registration id is required")
 	}
@@ -65,43 +177,98 @@ func (s *RegistrationServiceImpl) CheckIn(ctx context.Context, registrationID st
 		return nil, fmt.Errorf("find registration failed: %w", err)
 	}
 	if model == nil {
-		return nil, errors.New("registration not found")
+		return nil, fmt.Errorf("registration %w", service_interface.ErrNotFound)
 	}
 
-	model.CheckedIn = true
-	if model.Status == "" || model.Status == "pending" {
-		model.Status = "checked_in"
+	event, err := authorizeEventByID(ctx, s.eventRepo, model.EventID, perm)
+	if err != nil {
+		return nil, err
 	}
-
-	if err := s.repo.Update(ctx, model); err != nil {
-		return nil, fmt.Errorf("update registration failed: %w", err)
+	// Sự kiện bán vé có phí: chỉ thanh toán thành công mới xác nhận được đăng ký
+	if to == entity.RegistrationConfirmed {
+		if err := requireNoPayment(ctx, s.ticketRepo, model.EventID); err != nil {
+			return nil, err
+		}
 	}
 
+	if err := s.transition(ctx, model, event, to, reason); err != nil {
+		return nil, err
+	}
 	return model.RegistrationModelToEntity(), nil
 }
 
-// Cancel revokes a registration and returns the updated entity.
-func (s *RegistrationServiceImpl) Cancel(ctx context.Context, registrationID string) (*entity.Registration, error) {
-	if registrationID == "" {
-		return nil, errors.New("registration id is required")
+// transition validates and atomically applies a status change, keeping the seat counter of
+// limited events in sync. The model is updated in place on success.
+func (s *RegistrationServiceImpl) transition(ctx context.Context, model *models.RegistrationModel, event *models.EventModel, to entity.RegistrationStatus, reason string) error {
+	from := entity.ParseRegistrationStatus(model.Status)
+	if from == to {
+		return nil
+	}
+	if !from.CanTransitionTo(to) {
+		return fmt.Errorf("%w: %s -> %s", service_interface.ErrInvalidTransition, from, to)
 	}
 
-	model, err := s.repo.FindByID(ctx, registrationID)
-	if err != nil {
-		return nil, fmt.Errorf("find registration failed: %w", err)
+	// 🪑 Rời danh sách chờ thì phải giữ được chỗ trước
+	reserved := false
+	if !from.HoldsSeat() && to.HoldsSeat() && event != nil && event.IsLimited() {
+		ok, err := s.seats.reserve(ctx, event)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return service_interface.ErrEventFull
+		}
+		reserved = true
 	}
-	if model == nil {
-		return nil, errors.New("registration not found")
+
+	checkedIn := to == entity.RegistrationCheckedIn || to == entity.RegistrationCheckedOut
+	entry := models.RegistrationTransitionModel{
+		From:    string(from),
+		To:      string(to),
+		ActorID: actorFromContext(ctx),
+		Reason:  reason,
+		At:      time.Now(),
 	}
 
-	model.Status = "cancelled"
-	model.CheckedIn = false
+	ok, err := s.repo.Transition(ctx, model.ID.Hex(), string(from), checkedIn, entry)
+	if err != nil || !ok {
+		if reserved {
+			s.seats.rollback(ctx, event)
+		}
+		if err != nil {
+			return fmt.Errorf("update registration failed: %w", err)
+		}
+		return service_interface.ErrStatusConflict
+	}
 
-	if err := s.repo.Update(ctx, model); err != nil {
-		return nil, fmt.Errorf("update registration failed: %w", err)
+	model.Status = string(to)
+	model.CheckedIn = checkedIn
+	model.History = append(model.History, entry)
+	switch to {
+	case entity.RegistrationCheckedIn:
+		model.Visits = append(model.Visits, models.RegistrationVisitModel{CheckedInAt: entry.At})
+	case entity.RegistrationCheckedOut:
+		if open := model.OpenVisit(); open != nil {
+			open.CheckedOutAt = &entry.At
+		}
 	}
 
-	return model.RegistrationModelToEntity(), nil
+	// 🪑 Trả chỗ: đăng ký waitlist cũ nhất được tự động đưa lên
+	if from.HoldsSeat() && !to.HoldsSeat() {
+		if _, err := s.seats.release(ctx, event); err != nil {
+			return err
+		}
+	}
+	// Huỷ đăng ký sự kiện thì huỷ luôn các phiên đã chọn và bỏ sự kiện khỏi hồ sơ khách
+	if to == entity.RegistrationCancelled {
+		if err := s.sessions.releaseAll(ctx, model.ID.Hex()); err != nil {
+			return err
+		}
+		if err := s.guestRepo.RemoveEvent(ctx, model.GuestID, model.EventID); err != nil {
+			return fmt.Errorf("unlink guest from event failed: %w", err)
+		}
+	}
+	return nil
 }
 
 // GetByID fetches registration detail.
@@ -133,6 +300,26 @@ func (s *RegistrationServiceImpl) ListByEvent(ctx context.Context, eventID strin
 	return mapRegistrationModels(modelsList), nil
 }
 
+// ExportGuests authorizes the caller on the event and streams its guest list into w.
+func (s *RegistrationServiceImpl) ExportGuests(ctx context.Context, eventID string, w service_interface.EventGuestWriter) error {
+	event, err := authorizeEventByID(ctx, s.eventRepo, eventID, entity.EventPermManageGuests)
+	if err != nil {
+		return err
+	}
+
+	e := event.EventEntityToModel()
+	if err := w.Begin(&e); err != nil {
+		return err
+	}
+	err = s.repo.StreamGuestsByEvent(ctx, event.ID, func(m *models.EventGuestModel) error {
+		return w.Write(m.EventGuestModelToEntity())
+	})
+	if err != nil {
+		return fmt.Errorf("export guests failed: %w", err)
+	}
+	return nil
+}
+
 // ListByGuest returns registrations created by the guest.
 func (s *RegistrationServiceImpl) ListByGuest(ctx context.Context, guestID string) ([]*entity.Registration, error) {
 	if guestID == "" {
@@ -0,0 +1,202 @@
+package service_imple
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"event_manager/internal/domain/entity"
+	service_interface "event_manager/internal/domain/service"
+	"event_manager/internal/models"
+)
+
+// 📶 SyncCheckIns áp dụng các thao tác check-in / huỷ mà thiết bị ghi lại khi mất mạng.
+//
+// Thao tác được áp dụng theo thứ tự (ClientTime, OpID) để kết quả không phụ thuộc thứ tự gửi,
+// rồi trả về theo thứ tự trong yêu cầu. Mỗi thao tác chỉ được áp dụng một lần theo (deviceID, OpID);
+// gửi lại nhận kết quả cũ. Quy tắc khi trạng thái trên server khác với lúc thiết bị thao tác:
+//   - huỷ trên server là trạng thái cuối: check-in offline bị bỏ (cancelled_on_server);
+//   - có mặt thắng huỷ: lệnh huỷ offline cho khách đã check-in bị bỏ (checked_in_on_server);
+//   - check-in đăng ký đang chờ cần giữ được chỗ, hết chỗ thì bị bỏ (event_full);
+//   - đăng ký đã ở trạng thái đích (vd check-in ở thiết bị khác) thì không làm gì (noop).
+func (s *RegistrationServiceImpl) SyncCheckIns(ctx context.Context, deviceID string, ops []entity.CheckInSyncOp) ([]entity.CheckInSyncResult, error) {
+	deviceID = strings.TrimSpace(deviceID)
+	if deviceID == "" {
+		return nil, fmt.Errorf("%w: device id is required", service_interface.ErrInvalidSync)
+	}
+	if len(ops) == 0 || len(ops) > entity.MaxCheckInSyncOps {
+		return nil, fmt.Errorf("%w: between 1 and %d operations are required", service_interface.ErrInvalidSync, entity.MaxCheckInSyncOps)
+	}
+
+	keys := make([]string, 0, len(ops))
+	for i := range ops {
+		ops[i].OpID = strings.TrimSpace(ops[i].OpID)
+		ops[i].RegistrationID = strings.TrimSpace(ops[i].RegistrationID)
+		if ops[i].OpID != "" {
+			keys = append(keys, models.CheckInSyncOpID(deviceID, ops[i].OpID))
+		}
+	}
+	synced, err := s.syncOps.FindByIDs(ctx, keys)
+	if err != nil {
+		return nil, fmt.Errorf("find synced operations failed: %w", err)
+	}
+	done := make(map[string]*entity.CheckInSyncResult, len(synced))
+	for _, m := range synced {
+		done[m.OpID] = m.CheckInSyncOpModelToEntity()
+	}
+
+	order := make([]int, len(ops))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		oa, ob := ops[order[a]], ops[order[b]]
+		if !oa.ClientTime.Equal(ob.ClientTime) {
+			return oa.ClientTime.Before(ob.ClientTime)
+		}
+		return oa.OpID < ob.OpID
+	})
+
+	results := make([]entity.CheckInSyncResult, len(ops))
+	events := map[string]*models.EventModel{}
+	for _, i := range order {
+		op := ops[i]
+		if prev, ok := done[op.OpID]; ok && op.OpID != "" {
+			res := *prev
+			res.Result = entity.CheckInSyncDuplicate
+			results[i] = res
+			continue
+		}
+
+		res := s.applySyncOp(ctx, deviceID, op, events)
+		res.SyncedAt = time.Now()
+		results[i] = res
+		if op.OpID == "" || res.Result == entity.CheckInSyncError {
+			continue
+		}
+		done[op.OpID] = &res
+
+		err := s.syncOps.Insert(ctx, &models.CheckInSyncOpModel{
+			ID:             models.CheckInSyncOpID(deviceID, op.OpID),
+			DeviceID:       deviceID,
+			OpID:           op.OpID,
+			RegistrationID: op.RegistrationID,
+			Action:         string(op.Action),
+			ClientTime:     op.ClientTime,
+			Result:         res.Result,
+			Conflict:       res.Conflict,
+			Status:         string(res.Status),
+			Message:        res.Message,
+			ActorID:        actorFromContext(ctx),
+			SyncedAt:       res.SyncedAt,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("record synced operation failed: %w", err)
+		}
+	}
+	return results, nil
+}
+
+// applySyncOp áp dụng một thao tác theo quy tắc của SyncCheckIns
+func (s *RegistrationServiceImpl) applySyncOp(ctx context.Context, deviceID string, op entity.CheckInSyncOp, events map[string]*models.EventModel) entity.CheckInSyncResult {
+	res := entity.CheckInSyncResult{OpID: op.OpID, RegistrationID: op.RegistrationID, Action: op.Action}
+	reject := func(msg string) entity.CheckInSyncResult {
+		res.Result, res.Message = entity.CheckInSyncRejected, msg
+		return res
+	}
+
+	switch {
+	case op.OpID == "":
+		return reject("op_id is required")
+	case op.RegistrationID == "":
+		return reject("registration_id is required")
+	case op.ClientTime.IsZero():
+		return reject("client_time is required")
+	case op.Action != entity.CheckInSyncCheckIn && op.Action != entity.CheckInSyncCancel:
+		return reject(fmt.Sprintf("unknown action %q", op.Action))
+	}
+
+	model, err := s.repo.FindByID(ctx, op.RegistrationID)
+	if err != nil {
+		res.Result, res.Message = entity.CheckInSyncError, err.Error()
+		return res
+	}
+	if model == nil {
+		return reject("registration not found")
+	}
+
+	event, ok := events[model.EventID]
+	if !ok {
+		event, err = s.eventRepo.FindByID(ctx, model.EventID)
+		if err != nil {
+			res.Result, res.Message = entity.CheckInSyncError, err.Error()
+			return res
+		}
+		events[model.EventID] = event
+	}
+	if event == nil {
+		return reject("event not found")
+	}
+	perm := entity.EventPermCheckIn
+	if op.Action == entity.CheckInSyncCancel {
+		perm = entity.EventPermManageRegistrations
+	}
+	if err := authorizeEvent(ctx, event, perm); err != nil {
+		return reject(err.Error())
+	}
+
+	status := entity.ParseRegistrationStatus(model.Status)
+	res.Status = status
+	conflict := func(code, msg string) entity.CheckInSyncResult {
+		res.Result, res.Conflict, res.Message = entity.CheckInSyncConflict, code, msg
+		return res
+	}
+
+	reason := fmt.Sprintf("offline %s on device %s at %s", op.Action, deviceID, op.ClientTime.UTC().Format(time.RFC3339))
+	var steps []entity.RegistrationStatus
+	switch op.Action {
+	case entity.CheckInSyncCheckIn:
+		switch status {
+		case entity.RegistrationCheckedIn:
+			res.Result = entity.CheckInSyncNoop
+			return res
+		case entity.RegistrationCancelled:
+			return conflict(entity.CheckInConflictCancelled, "registration was cancelled on the server")
+		case entity.RegistrationWaitlisted:
+			// Khách đã có mặt: xác nhận (giữ chỗ) rồi check-in
+			steps = []entity.RegistrationStatus{entity.RegistrationConfirmed, entity.RegistrationCheckedIn}
+		default:
+			steps = []entity.RegistrationStatus{entity.RegistrationCheckedIn}
+		}
+	case entity.CheckInSyncCancel:
+		switch status {
+		case entity.RegistrationCancelled:
+			res.Result = entity.CheckInSyncNoop
+			return res
+		case entity.RegistrationCheckedIn, entity.RegistrationCheckedOut:
+			return conflict(entity.CheckInConflictCheckedIn, "guest has already checked in")
+		default:
+			steps = []entity.RegistrationStatus{entity.RegistrationCancelled}
+		}
+	}
+
+	for _, to := range steps {
+		err := s.transition(ctx, model, event, to, reason)
+		res.Status = entity.ParseRegistrationStatus(model.Status)
+		switch {
+		case errors.Is(err, service_interface.ErrEventFull):
+			return conflict(entity.CheckInConflictEventFull, "registration is waitlisted and the event is full")
+		case errors.Is(err, service_interface.ErrInvalidTransition):
+			return reject(err.Error())
+		case err != nil:
+			// Gồm cả ErrStatusConflict: thiết bị gửi lại sẽ được xử lý theo trạng thái mới
+			res.Result, res.Message = entity.CheckInSyncError, err.Error()
+			return res
+		}
+	}
+	res.Result = entity.CheckInSyncApplied
+	return res
+}
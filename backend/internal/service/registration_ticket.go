@@ -0,0 +1,145 @@
+package service_imple
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"event_manager/internal/domain/entity"
+	service_interface "event_manager/internal/domain/service"
+	"event_manager/internal/models"
+	utils "event_manager/util"
+)
+
+// ticketExpiryGrace là thời gian vé còn hiệu lực sau khi sự kiện kết thúc (khách check-in muộn / quét bù)
+const ticketExpiryGrace = 24 * time.Hour
+
+// 🎫 Ticket ký vé cho đăng ký còn giữ chỗ; đăng ký đã huỷ hoặc đang chờ không có vé.
+func (s *RegistrationServiceImpl) Ticket(ctx context.Context, registrationID string) (string, error) {
+	model, event, err := s.ticketRegistration(ctx, registrationID)
+	if err != nil {
+		return "", err
+	}
+	return s.signTicket(model, event)
+}
+
+// 🔁 ReissueTicket tăng version vé của đăng ký (vé đã phát không quét được nữa) rồi ký vé mới,
+// dùng khi vé bị lộ hoặc gửi nhầm người.
+func (s *RegistrationServiceImpl) ReissueTicket(ctx context.Context, registrationID string) (string, error) {
+	model, event, err := s.ticketRegistration(ctx, registrationID)
+	if err != nil {
+		return "", err
+	}
+	model, err = s.repo.BumpTicketVersion(ctx, model.ID.Hex(), time.Now())
+	if err != nil {
+		return "", fmt.Errorf("bump ticket version failed: %w", err)
+	}
+	if model == nil {
+		return "", fmt.Errorf("registration %w", service_interface.ErrNotFound)
+	}
+	return s.signTicket(model, event)
+}
+
+// ticketRegistration load đăng ký cần vé, kiểm tra quyền và trạng thái giữ chỗ
+func (s *RegistrationServiceImpl) ticketRegistration(ctx context.Context, registrationID string) (*models.RegistrationModel, *models.EventModel, error) {
+	if strings.TrimSpace(registrationID) == "" {
+		return nil, nil, errors.New("registration id is required")
+	}
+
+	model, err := s.repo.FindByID(ctx, registrationID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("find registration failed: %w", err)
+	}
+	if model == nil {
+		return nil, nil, fmt.Errorf("registration %w", service_interface.ErrNotFound)
+	}
+	event, err := authorizeEventByID(ctx, s.eventRepo, model.EventID, entity.EventPermManageRegistrations)
+	if err != nil {
+		return nil, nil, err
+	}
+	if status := entity.ParseRegistrationStatus(model.Status); !status.HoldsSeat() {
+		return nil, nil, fmt.Errorf("%w: registration is %s", service_interface.ErrNoTicket, status)
+	}
+	return model, event, nil
+}
+
+// signTicket ký vé theo version hiện tại của đăng ký. iat là thời điểm cấp vé (lúc đăng ký hoặc lần
+// cấp lại gần nhất) để in lại / gửi lại không đổi mã; vé hết hạn sau khi sự kiện kết thúc.
+func (s *RegistrationServiceImpl) signTicket(model *models.RegistrationModel, event *models.EventModel) (string, error) {
+	claims := utils.TicketClaims{
+		RegistrationID: model.ID.Hex(),
+		EventID:        model.EventID,
+		Version:        model.TicketVersion,
+		IssuedAt:       model.CreatedAt,
+	}
+	if model.TicketIssuedAt != nil {
+		claims.IssuedAt = *model.TicketIssuedAt
+	}
+	if !event.EndDate.IsZero() {
+		claims.ExpiresAt = event.EndDate.Add(ticketExpiryGrace)
+	}
+	token, err := utils.GenTicketJWT(s.ticketSecret, claims)
+	if err != nil {
+		return "", fmt.Errorf("sign ticket failed: %w", err)
+	}
+	return token, nil
+}
+
+// 📷 ScanTicket kiểm tra chữ ký vé, vé phải thuộc sự kiện đang check-in, rồi check-in khách.
+func (s *RegistrationServiceImpl) ScanTicket(ctx context.Context, token, eventID string) (*entity.EventGuest, error) {
+	token, eventID = strings.TrimSpace(token), strings.TrimSpace(eventID)
+	if token == "" || eventID == "" {
+		return nil, errors.New("ticket token and event id are required")
+	}
+
+	claims, err := utils.ParseTicketJWT(s.ticketSecret, token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", service_interface.ErrInvalidTicket, err)
+	}
+	if claims.EventID != eventID {
+		return nil, fmt.Errorf("%w: ticket is for another event", service_interface.ErrInvalidTicket)
+	}
+
+	event, err := authorizeEventByID(ctx, s.eventRepo, eventID, entity.EventPermCheckIn)
+	if err != nil {
+		return nil, err
+	}
+
+	model, err := s.repo.FindByID(ctx, claims.RegistrationID)
+	if err != nil {
+		return nil, fmt.Errorf("find registration failed: %w", err)
+	}
+	if model == nil || model.EventID != eventID {
+		return nil, fmt.Errorf("registration %w", service_interface.ErrNotFound)
+	}
+	if claims.Version != model.TicketVersion {
+		return nil, fmt.Errorf("%w: ticket was reissued", service_interface.ErrInvalidTicket)
+	}
+
+	status := entity.ParseRegistrationStatus(model.Status)
+	if status == entity.RegistrationCheckedIn {
+		return nil, service_interface.ErrAlreadyCheckedIn
+	}
+	if !status.CanTransitionTo(entity.RegistrationCheckedIn) {
+		return nil, fmt.Errorf("%w: registration is %s", service_interface.ErrNoTicket, status)
+	}
+	if err := s.transition(ctx, model, event, entity.RegistrationCheckedIn, "ticket scan"); err != nil {
+		return nil, err
+	}
+
+	guest, err := s.guestRepo.FindByID(ctx, model.GuestID)
+	if err != nil {
+		return nil, fmt.Errorf("find guest failed: %w", err)
+	}
+	result := &entity.EventGuest{Registration: *model.RegistrationModelToEntity()}
+	if guest != nil {
+		result.Guest = *models.GuestModelToEntity(guest)
+	}
+	if n := len(model.History); n > 0 {
+		at := model.History[n-1].At
+		result.CheckedInAt = &at
+	}
+	return result, nil
+}
@@ -0,0 +1,226 @@
+package service_imple
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"event_manager/internal/domain/entity"
+	repository "event_manager/internal/domain/repository"
+	service_interface "event_manager/internal/domain/service"
+	"event_manager/internal/models"
+)
+
+// ReviewServiceImpl triển khai ReviewService
+type ReviewServiceImpl struct {
+	repo             repository.ReviewRepository
+	registrationRepo repository.RegistrationRepository
+	eventRepo        repository.EventRepository
+	guestRepo        repository.GuestRepository
+}
+
+// NewReviewService wires dependencies into a ReviewService implementation.
+func NewReviewService(
+	repo repository.ReviewRepository,
+	registrationRepo repository.RegistrationRepository,
+	eventRepo repository.EventRepository,
+	guestRepo repository.GuestRepository,
+) service_interface.ReviewService {
+	return &ReviewServiceImpl{
+		repo:             repo,
+		registrationRepo: registrationRepo,
+		eventRepo:        eventRepo,
+		guestRepo:        guestRepo,
+	}
+}
+
+// Create ghi nhận đánh giá; khách phải có đăng ký đã check-in vào sự kiện.
+func (s *ReviewServiceImpl) Create(ctx context.Context, review *entity.Review) error {
+	if review == nil {
+		return errors.New("review is nil")
+	}
+	review.EventID = strings.TrimSpace(review.EventID)
+	review.GuestID = strings.TrimSpace(review.GuestID)
+	review.Comment = strings.TrimSpace(review.Comment)
+	if review.GuestID == "" {
+		return errors.New("guest id is required")
+	}
+	if err := validateRating(review.Rating); err != nil {
+		return err
+	}
+
+	if _, err := authorizeEventByID(ctx, s.eventRepo, review.EventID, entity.EventPermManageGuests); err != nil {
+		return err
+	}
+
+	reg, err := s.registrationRepo.FindByEventAndGuest(ctx, review.EventID, review.GuestID)
+	if err != nil {
+		return fmt.Errorf("find registration failed: %w", err)
+	}
+	if reg == nil || !hasAttended(reg) {
+		return service_interface.ErrNotCheckedIn
+	}
+
+	review.CreatedAt = time.Now()
+	review.UpdatedAt = time.Time{}
+	model, err := models.ReviewEntityToModel(review)
+	if err != nil {
+		return fmt.Errorf("map review to model failed: %w", err)
+	}
+	if err := s.repo.Insert(ctx, model); err != nil {
+		if errors.Is(err, repository.ErrDuplicate) {
+			return service_interface.ErrAlreadyReviewed
+		}
+		return fmt.Errorf("insert review failed: %w", err)
+	}
+	review.ID = model.ID.Hex()
+	return nil
+}
+
+// Update sửa điểm và nhận xét; khách và sự kiện của đánh giá không đổi.
+func (s *ReviewServiceImpl) Update(ctx context.Context, review *entity.Review) error {
+	if review == nil {
+		return errors.New("review is nil")
+	}
+	if err := validateRating(review.Rating); err != nil {
+		return err
+	}
+
+	model, err := s.load(ctx, review.EventID, review.ID)
+	if err != nil {
+		return err
+	}
+	if _, err := authorizeEventByID(ctx, s.eventRepo, review.EventID, entity.EventPermManageGuests); err != nil {
+		return err
+	}
+
+	model.Rating = review.Rating
+	model.Comment = strings.TrimSpace(review.Comment)
+	model.UpdatedAt = time.Now()
+	if err := s.repo.Update(ctx, model); err != nil {
+		return fmt.Errorf("update review failed: %w", err)
+	}
+
+	*review = *model.ReviewModelToEntity()
+	return nil
+}
+
+// Delete xoá một đánh giá thuộc sự kiện.
+func (s *ReviewServiceImpl) Delete(ctx context.Context, eventID, reviewID string) error {
+	if _, err := s.load(ctx, eventID, reviewID); err != nil {
+		return err
+	}
+	if _, err := authorizeEventByID(ctx, s.eventRepo, eventID, entity.EventPermManageGuests); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, reviewID); err != nil {
+		return fmt.Errorf("delete review failed: %w", err)
+	}
+	return nil
+}
+
+// GetByID trả về một đánh giá thuộc sự kiện, kèm tên khách.
+func (s *ReviewServiceImpl) GetByID(ctx context.Context, eventID, reviewID string) (*entity.Review, error) {
+	model, err := s.load(ctx, eventID, reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	review := model.ReviewModelToEntity()
+	if err := s.attachGuestNames(ctx, []*entity.Review{review}); err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+// ListByEvent trả về các đánh giá của sự kiện và điểm trung bình.
+func (s *ReviewServiceImpl) ListByEvent(ctx context.Context, eventID string) (*entity.ReviewSummary, error) {
+	eventID = strings.TrimSpace(eventID)
+	if eventID == "" {
+		return nil, errors.New("event id is required")
+	}
+
+	event, err := s.eventRepo.FindByID(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("find event failed: %w", err)
+	}
+	if event == nil {
+		return nil, fmt.Errorf("event %w", service_interface.ErrNotFound)
+	}
+
+	list, err := s.repo.FindByEvent(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("list reviews failed: %w", err)
+	}
+	avg, err := s.repo.AverageRating(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("average rating failed: %w", err)
+	}
+
+	summary := &entity.ReviewSummary{
+		Reviews:       make([]*entity.Review, 0, len(list)),
+		AverageRating: avg,
+		Count:         len(list),
+	}
+	for _, m := range list {
+		summary.Reviews = append(summary.Reviews, m.ReviewModelToEntity())
+	}
+	if err := s.attachGuestNames(ctx, summary.Reviews); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// load tìm đánh giá và đảm bảo nó thuộc đúng sự kiện trên đường dẫn
+func (s *ReviewServiceImpl) load(ctx context.Context, eventID, reviewID string) (*models.ReviewModel, error) {
+	eventID = strings.TrimSpace(eventID)
+	reviewID = strings.TrimSpace(reviewID)
+	if eventID == "" || reviewID == "" {
+		return nil, errors.New("event id and review id are required")
+	}
+
+	model, err := s.repo.FindByID(ctx, reviewID)
+	if err != nil {
+		return nil, fmt.Errorf("find review failed: %w", err)
+	}
+	if model == nil || model.EventID != eventID {
+		return nil, fmt.Errorf("review %w", service_interface.ErrNotFound)
+	}
+	return model, nil
+}
+
+// attachGuestNames điền tên khách để hiển thị cùng đánh giá
+func (s *ReviewServiceImpl) attachGuestNames(ctx context.Context, reviews []*entity.Review) error {
+	ids := make([]string, 0, len(reviews))
+	for _, r := range reviews {
+		ids = append(ids, r.GuestID)
+	}
+
+	guests, err := s.guestRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("find guests failed: %w", err)
+	}
+	names := make(map[string]string, len(guests))
+	for _, g := range guests {
+		names[g.ID] = g.FullName
+	}
+	for _, r := range reviews {
+		r.GuestName = names[r.GuestID]
+	}
+	return nil
+}
+
+func validateRating(rating float64) error {
+	if rating < entity.MinReviewRating || rating > entity.MaxReviewRating {
+		return service_interface.ErrInvalidRating
+	}
+	return nil
+}
+
+// hasAttended cho biết khách đã check-in vào sự kiện (kể cả đã check-out)
+func hasAttended(reg *models.RegistrationModel) bool {
+	status := entity.ParseRegistrationStatus(reg.Status)
+	return reg.CheckedIn || status == entity.RegistrationCheckedIn || status == entity.RegistrationCheckedOut
+}
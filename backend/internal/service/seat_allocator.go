@@ -0,0 +1,159 @@
+package service_imple
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"event_manager/internal/domain/entity"
+	repository "event_manager/internal/domain/repository"
+	"event_manager/internal/models"
+)
+
+// seatAllocator giữ / trả chỗ cho sự kiện giới hạn (MaxGuests) và quản lý danh sách chờ.
+// Bộ đếm seats_taken nằm trên document của sự kiện nên việc giữ chỗ là một lệnh
+// update nguyên tử, không phụ thuộc số request đồng thời.
+type seatAllocator struct {
+	eventRepo        repository.EventRepository
+	registrationRepo repository.RegistrationRepository
+}
+
+// allocate trả về trạng thái cho đăng ký mới: giữ nguyên requested nếu còn chỗ,
+// "waitlisted" nếu sự kiện đã đầy. reserved = true nghĩa là đã giữ một chỗ,
+// caller phải gọi rollback nếu lưu đăng ký thất bại.
+func (a seatAllocator) allocate(ctx context.Context, event *models.EventModel, requested entity.RegistrationStatus) (status entity.RegistrationStatus, reserved bool, err error) {
+	if event == nil || !event.IsLimited() {
+		return requested, false, nil
+	}
+
+	ok, err := a.reserve(ctx, event)
+	if err != nil {
+		return "", false, err
+	}
+	if !ok {
+		return entity.RegistrationWaitlisted, false, nil
+	}
+	return requested, true, nil
+}
+
+// reserve giữ một chỗ của sự kiện giới hạn; trả về false nếu sự kiện đã đầy
+func (a seatAllocator) reserve(ctx context.Context, event *models.EventModel) (bool, error) {
+	// Sự kiện tạo trước khi có bộ đếm: khởi tạo từ số đăng ký hiện có (no-op nếu đã có)
+	if event.SeatsTaken == 0 {
+		taken, err := a.registrationRepo.CountHoldingSeat(ctx, event.ID)
+		if err != nil {
+			return false, fmt.Errorf("count registrations failed: %w", err)
+		}
+		if err := a.eventRepo.InitSeatCounter(ctx, event.ID, taken); err != nil {
+			return false, fmt.Errorf("init seat counter failed: %w", err)
+		}
+	}
+
+	ok, err := a.eventRepo.ReserveSeat(ctx, event.ID)
+	if err != nil {
+		return false, fmt.Errorf("reserve seat failed: %w", err)
+	}
+	return ok, nil
+}
+
+// rollback trả lại chỗ đã giữ bởi allocate
+func (a seatAllocator) rollback(ctx context.Context, event *models.EventModel) {
+	if event == nil {
+		return
+	}
+	_ = a.eventRepo.ReleaseSeat(ctx, event.ID)
+}
+
+// release được gọi khi một đăng ký đang giữ chỗ bị huỷ: chỗ trống được chuyển
+// ngay cho đăng ký waitlist cũ nhất, nếu không có ai chờ thì trả chỗ về sự kiện.
+// Trả về đăng ký được đưa lên (nil nếu danh sách chờ trống).
+func (a seatAllocator) release(ctx context.Context, event *models.EventModel) (*models.RegistrationModel, error) {
+	if event == nil || !event.IsLimited() {
+		return nil, nil
+	}
+
+	promoted, err := a.registrationRepo.PromoteOldestWaitlisted(ctx, event.ID, waitlistPromotion())
+	if err != nil {
+		return nil, fmt.Errorf("promote waitlisted registration failed: %w", err)
+	}
+	if promoted != nil {
+		return promoted, nil
+	}
+
+	if err := a.eventRepo.ReleaseSeat(ctx, event.ID); err != nil {
+		return nil, fmt.Errorf("release seat failed: %w", err)
+	}
+	return nil, nil
+}
+
+// fill được gọi sau khi sự kiện có thêm chỗ (tăng max_guests hoặc bỏ giới hạn): đưa đăng ký waitlist
+// cũ nhất lên cho tới khi hết chỗ trống hoặc hết người chờ, giống release nhưng cho nhiều chỗ.
+// Sự kiện giới hạn giữ từng chỗ trên bộ đếm trước khi đưa lên; sự kiện mở đưa lên toàn bộ danh sách chờ.
+func (a seatAllocator) fill(ctx context.Context, event *models.EventModel) ([]*models.RegistrationModel, error) {
+	var promoted []*models.RegistrationModel
+	for {
+		reserved := false
+		if event.IsLimited() {
+			ok, err := a.eventRepo.ReserveSeat(ctx, event.ID)
+			if err != nil {
+				return promoted, fmt.Errorf("reserve seat failed: %w", err)
+			}
+			if !ok {
+				return promoted, nil
+			}
+			reserved = true
+		}
+
+		reg, err := a.registrationRepo.PromoteOldestWaitlisted(ctx, event.ID, waitlistPromotion())
+		if err != nil || reg == nil {
+			if reserved {
+				a.rollback(ctx, event)
+			}
+			if err != nil {
+				return promoted, fmt.Errorf("promote waitlisted registration failed: %w", err)
+			}
+			return promoted, nil
+		}
+		promoted = append(promoted, reg)
+	}
+}
+
+// waitlistPromotion là bản ghi lịch sử khi đăng ký được đưa lên từ danh sách chờ
+func waitlistPromotion() models.RegistrationTransitionModel {
+	return models.RegistrationTransitionModel{
+		From:    string(entity.RegistrationWaitlisted),
+		To:      string(entity.RegistrationPending),
+		ActorID: systemActor,
+		Reason:  "promoted from waitlist",
+		At:      time.Now(),
+	}
+}
+
+// sessionSeats huỷ các đăng ký phiên của một đăng ký sự kiện và trả chỗ cho từng phiên;
+// dùng khi đăng ký sự kiện bị huỷ hoặc bị bỏ lúc gộp khách trùng.
+type sessionSeats struct {
+	sessionRepo    repository.EventSessionRepository
+	sessionRegRepo repository.SessionRegistrationRepository
+}
+
+func (a sessionSeats) releaseAll(ctx context.Context, registrationID string) error {
+	regs, err := a.sessionRegRepo.FindByRegistration(ctx, registrationID)
+	if err != nil {
+		return fmt.Errorf("find session registrations failed: %w", err)
+	}
+	now := time.Now()
+	for _, reg := range regs {
+		ok, err := a.sessionRegRepo.Cancel(ctx, reg.ID.Hex(), now)
+		if err != nil {
+			return fmt.Errorf("cancel session registration failed: %w", err)
+		}
+		// Bản ghi đã bị huỷ bởi request khác thì chỗ cũng đã được trả
+		if !ok {
+			continue
+		}
+		if err := a.sessionRepo.ReleaseSeat(ctx, reg.SessionID); err != nil {
+			return fmt.Errorf("release session seat failed: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+package service_imple
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"event_manager/internal/domain/entity"
+	repository "event_manager/internal/domain/repository"
+	service_interface "event_manager/internal/domain/service"
+	"event_manager/internal/models"
+)
+
+// TicketServiceImpl triển khai TicketService
+type TicketServiceImpl struct {
+	repo      repository.TicketTypeRepository
+	orderRepo repository.OrderRepository
+	eventRepo repository.EventRepository
+}
+
+// NewTicketService wires dependencies into a TicketService implementation.
+func NewTicketService(
+	repo repository.TicketTypeRepository,
+	orderRepo repository.OrderRepository,
+	eventRepo repository.EventRepository,
+) service_interface.TicketService {
+	return &TicketServiceImpl{
+		repo:      repo,
+		orderRepo: orderRepo,
+		eventRepo: eventRepo,
+	}
+}
+
+// CreateType thêm loại vé cho sự kiện.
+func (s *TicketServiceImpl) CreateType(ctx context.Context, ticketType *entity.TicketType) error {
+	if ticketType == nil {
+		return errors.New("ticket type is nil")
+	}
+	event, err := authorizeEventByID(ctx, s.eventRepo, strings.TrimSpace(ticketType.EventID), entity.EventPermEdit)
+	if err != nil {
+		return err
+	}
+	ticketType.EventID = event.ID
+	if err := validateTicketType(ticketType); err != nil {
+		return err
+	}
+
+	ticketType.ID = ""
+	ticketType.Sold = 0
+	ticketType.Reserved = 0
+	ticketType.CreatedAt = time.Now()
+	ticketType.UpdatedAt = time.Time{}
+	model, err := models.TicketTypeEntityToModel(ticketType)
+	if err != nil {
+		return fmt.Errorf("map ticket type to model failed: %w", err)
+	}
+	if err := s.repo.Insert(ctx, model); err != nil {
+		return fmt.Errorf("insert ticket type failed: %w", err)
+	}
+	ticketType.ID = model.ID.Hex()
+	return nil
+}
+
+// UpdateType sửa loại vé; bộ đếm vé đã bán / đang giữ không đổi.
+func (s *TicketServiceImpl) UpdateType(ctx context.Context, ticketType *entity.TicketType) error {
+	if ticketType == nil {
+		return errors.New("ticket type is nil")
+	}
+	model, err := s.load(ctx, ticketType.EventID, ticketType.ID)
+	if err != nil {
+		return err
+	}
+	if _, err := authorizeEventByID(ctx, s.eventRepo, model.EventID, entity.EventPermEdit); err != nil {
+		return err
+	}
+
+	ticketType.EventID = model.EventID
+	if err := validateTicketType(ticketType); err != nil {
+		return err
+	}
+	// Đơn đã tạo lưu số tiền theo tiền tệ cũ
+	if ticketType.Currency != model.Currency && model.Sold+model.Reserved > 0 {
+		return fmt.Errorf("%w: currency cannot change once tickets are sold or reserved", service_interface.ErrInvalidTicketType)
+	}
+
+	model.Name = ticketType.Name
+	model.Description = ticketType.Description
+	model.Currency = ticketType.Currency
+	model.Price = ticketType.Price
+	model.EarlyBirdPrice = ticketType.EarlyBirdPrice
+	model.EarlyBirdUntil = ticketType.EarlyBirdUntil
+	model.Quantity = ticketType.Quantity
+	model.SaleStart = ticketType.SaleStart
+	model.SaleEnd = ticketType.SaleEnd
+	model.UpdatedAt = time.Now()
+	ok, err := s.repo.Update(ctx, model)
+	if err != nil {
+		return fmt.Errorf("update ticket type failed: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("%w: quantity is below the tickets already sold or reserved", service_interface.ErrInvalidTicketType)
+	}
+
+	updated, err := s.load(ctx, model.EventID, model.ID.Hex())
+	if err != nil {
+		return err
+	}
+	*ticketType = *updated.TicketTypeModelToEntity()
+	return nil
+}
+
+// DeleteType xoá loại vé; loại vé đã có đơn (kể cả đơn đã huỷ) được giữ lại để đối soát.
+func (s *TicketServiceImpl) DeleteType(ctx context.Context, eventID, ticketTypeID string) error {
+	model, err := s.load(ctx, eventID, ticketTypeID)
+	if err != nil {
+		return err
+	}
+	if _, err := authorizeEventByID(ctx, s.eventRepo, model.EventID, entity.EventPermEdit); err != nil {
+		return err
+	}
+
+	orders, err := s.orderRepo.CountByTicketType(ctx, ticketTypeID)
+	if err != nil {
+		return fmt.Errorf("count orders failed: %w", err)
+	}
+	if orders > 0 {
+		return fmt.Errorf("%w (%d orders)", service_interface.ErrTicketTypeInUse, orders)
+	}
+	if err := s.repo.Delete(ctx, ticketTypeID); err != nil {
+		return fmt.Errorf("delete ticket type failed: %w", err)
+	}
+	return nil
+}
+
+// GetType trả về một loại vé thuộc sự kiện.
+func (s *TicketServiceImpl) GetType(ctx context.Context, eventID, ticketTypeID string) (*entity.TicketType, error) {
+	model, err := s.load(ctx, eventID, ticketTypeID)
+	if err != nil {
+		return nil, err
+	}
+	return model.TicketTypeModelToEntity(), nil
+}
+
+// ListTypes trả về các loại vé của sự kiện.
+func (s *TicketServiceImpl) ListTypes(ctx context.Context, eventID string) ([]*entity.TicketType, error) {
+	eventID = strings.TrimSpace(eventID)
+	if eventID == "" {
+		return nil, errors.New("event id is required")
+	}
+	event, err := s.eventRepo.FindByID(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("find event failed: %w", err)
+	}
+	if event == nil {
+		return nil, fmt.Errorf("event %w", service_interface.ErrNotFound)
+	}
+
+	list, err := s.repo.FindByEvent(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("list ticket types failed: %w", err)
+	}
+	result := make([]*entity.TicketType, 0, len(list))
+	for _, m := range list {
+		result = append(result, m.TicketTypeModelToEntity())
+	}
+	return result, nil
+}
+
+// load tìm loại vé và đảm bảo nó thuộc đúng sự kiện trên đường dẫn
+func (s *TicketServiceImpl) load(ctx context.Context, eventID, ticketTypeID string) (*models.TicketTypeModel, error) {
+	return loadTicketType(ctx, s.repo, eventID, ticketTypeID)
+}
+
+func loadTicketType(ctx context.Context, repo repository.TicketTypeRepository, eventID, ticketTypeID string) (*models.TicketTypeModel, error) {
+	eventID = strings.TrimSpace(eventID)
+	ticketTypeID = strings.TrimSpace(ticketTypeID)
+	if eventID == "" || ticketTypeID == "" {
+		return nil, errors.New("event id and ticket type id are required")
+	}
+
+	model, err := repo.FindByID(ctx, ticketTypeID)
+	if err != nil {
+		return nil, fmt.Errorf("find ticket type failed: %w", err)
+	}
+	if model == nil || model.EventID != eventID {
+		return nil, fmt.Errorf("ticket type %w", service_interface.ErrNotFound)
+	}
+	return model, nil
+}
+
+// requireNoPayment chặn đăng ký / xác nhận trực tiếp vào sự kiện bán vé có phí: đăng ký của các sự
+// kiện này chỉ được tạo (ở trạng thái confirmed) khi đơn mua vé thanh toán thành công
+func requireNoPayment(ctx context.Context, repo repository.TicketTypeRepository, eventID string) error {
+	if repo == nil {
+		return nil
+	}
+	paid, err := repo.HasPaidTypes(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("check ticket types failed: %w", err)
+	}
+	if paid {
+		return service_interface.ErrPaymentRequired
+	}
+	return nil
+}
+
+// validateTicketType chuẩn hoá và kiểm tra loại vé trước khi lưu
+func validateTicketType(t *entity.TicketType) error {
+	t.Name = strings.TrimSpace(t.Name)
+	t.Description = strings.TrimSpace(t.Description)
+	t.Currency = strings.ToUpper(strings.TrimSpace(t.Currency))
+
+	if t.Name == "" {
+		return fmt.Errorf("%w: name is required", service_interface.ErrInvalidTicketType)
+	}
+	if len(t.Currency) != 3 || strings.Trim(t.Currency, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return fmt.Errorf("%w: currency must be a 3-letter ISO 4217 code", service_interface.ErrInvalidTicketType)
+	}
+	if t.Price < 0 {
+		return fmt.Errorf("%w: price must not be negative", service_interface.ErrInvalidTicketType)
+	}
+	if t.Quantity < 0 {
+		return fmt.Errorf("%w: quantity must not be negative", service_interface.ErrInvalidTicketType)
+	}
+	if t.SaleStart != nil && t.SaleEnd != nil && !t.SaleEnd.After(*t.SaleStart) {
+		return fmt.Errorf("%w: sale end must be after sale start", service_interface.ErrInvalidTicketType)
+	}
+
+	if t.EarlyBirdUntil == nil {
+		t.EarlyBirdPrice = 0
+		return nil
+	}
+	if t.EarlyBirdPrice < 0 || t.EarlyBirdPrice >= t.Price {
+		return fmt.Errorf("%w: early-bird price must be below the regular price", service_interface.ErrInvalidTicketType)
+	}
+	if t.SaleStart != nil && !t.EarlyBirdUntil.After(*t.SaleStart) {
+		return fmt.Errorf("%w: early-bird deadline must be after sale start", service_interface.ErrInvalidTicketType)
+	}
+	return nil
+}
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"event_manager/internal/domain/entity"
 	repository "event_manager/internal/domain/repository"
+	service_interface "event_manager/internal/domain/service"
 	"event_manager/internal/models"
+	utils "event_manager/util"
 	"fmt"
 	"time"
 )
@@ -21,9 +23,9 @@ func NewUserService(repo repository.UserRepository) *UserServiceImpl {
 }
 
 // ➕ CreateUser – nghiệp vụ tạo mới user
-func (s *UserServiceImpl) CreateUser(ctx context.Context, username, email, fullName string) error {
+func (s *UserServiceImpl) CreateUser(ctx context.Context, username, email, fullName, password string) error {
 	// Kiểm tra đầu vào
-	if username == "" || email == "" || fullName == "" {
+	if username == "" || email == "" || fullName == "" || password == "" {
 		return errors.New("missing required fields")
 	}
 
@@ -36,15 +38,25 @@ func (s *UserServiceImpl) CreateUser(ctx context.Context, username, email, fullN
 		return fmt.Errorf("username '%s' already exists", username)
 	}
 
+	// Băm mật khẩu trước khi lưu, không bao giờ lưu plain text
+	passwordHash, err := utils.HashPassword(password)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	// Đăng ký công khai luôn là viewer; admin đầu tiên được cấp qua `go run ./cmd/migrate -task promote-admin`
+	role := entity.RoleViewer
+
 	// Tạo entity (logic thuần domain)
 	user := &entity.User{
-		Username:  username,
-		Email:     email,
-		FullName:  fullName,
-		Status:    "active",
-		Role:      "user",
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		Username:     username,
+		Email:        email,
+		FullName:     fullName,
+		PasswordHash: passwordHash,
+		Status:       "active",
+		Role:         string(role),
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
 	}
 
 	// Map sang model để lưu Mongo
@@ -164,3 +176,40 @@ func (s *UserServiceImpl) ListUsers(ctx context.Context, limit, offset int) ([]*
 
 	return users, nil
 }
+
+// 🛡️ ChangeRole – nghiệp vụ đổi vai trò user
+func (s *UserServiceImpl) ChangeRole(ctx context.Context, id string, role entity.Role) error {
+	if id == "" {
+		return errors.New("missing user id")
+	}
+	if !role.IsValid() {
+		return service_interface.ErrInvalidRole
+	}
+
+	m, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("find user error: %w", err)
+	}
+	if m == nil {
+		return errors.New("user not found")
+	}
+
+	// Không cho hạ quyền admin cuối cùng, tránh hệ thống không còn ai quản trị
+	if m.Role == string(entity.RoleAdmin) && role != entity.RoleAdmin {
+		admins, err := s.repo.CountByRole(ctx, string(entity.RoleAdmin))
+		if err != nil {
+			return fmt.Errorf("count admins error: %w", err)
+		}
+		if admins <= 1 {
+			return errors.New("cannot demote the last admin")
+		}
+	}
+
+	m.Role = string(role)
+	m.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(ctx, m); err != nil {
+		return fmt.Errorf("failed to change role: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+// Package sheet streams rows out of CSV and XLSX uploads.
+package sheet
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Format is the file type of an upload.
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+// ErrUnsupportedFormat is returned for file types other than CSV and XLSX.
+var ErrUnsupportedFormat = errors.New("unsupported file format, expected csv or xlsx")
+
+// FormatFromFilename guesses the format from the file extension.
+func FormatFromFilename(name string) (Format, error) {
+	switch strings.ToLower(strings.TrimPrefix(filepath.Ext(name), ".")) {
+	case "csv", "txt":
+		return FormatCSV, nil
+	case "xlsx", "xlsm":
+		return FormatXLSX, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+// RowReader returns one row at a time; Next returns io.EOF after the last row.
+type RowReader interface {
+	Next() ([]string, error)
+	// Line is the 1-based line (CSV) or row number (XLSX) of the last row returned by Next.
+	Line() int
+	Close() error
+}
+
+// NewReader opens a row reader for the given format. CSV is streamed from r; XLSX is a zip
+// archive, so the workbook is opened first and its first sheet is then streamed row by row.
+func NewReader(format Format, r io.Reader) (RowReader, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVReader(r)
+	case FormatXLSX:
+		return newXLSXReader(r)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+type csvReader struct {
+	r    *csv.Reader
+	line int
+}
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// sniffSize is how much of the file is inspected to pick the delimiter.
+const sniffSize = 4096
+
+// newCSVReader strips the UTF-8 BOM Excel writes and detects ";" as delimiter (Excel with
+// a comma decimal separator, as in the vi-VN locale, exports CSV that way).
+func newCSVReader(r io.Reader) (*csvReader, error) {
+	br := bufio.NewReader(r)
+	if head, _ := br.Peek(len(utf8BOM)); bytes.Equal(head, utf8BOM) {
+		_, _ = br.Discard(len(utf8BOM))
+	}
+
+	// Peek still returns what is available when the file is shorter than sniffSize
+	header, _ := br.Peek(sniffSize)
+	if i := bytes.IndexByte(header, '\n'); i >= 0 {
+		header = header[:i]
+	}
+
+	cr := csv.NewReader(br)
+	if bytes.Count(header, []byte{';'}) > bytes.Count(header, []byte{','}) {
+		cr.Comma = ';'
+	}
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	return &csvReader{r: cr}, nil
+}
+
+func (c *csvReader) Next() ([]string, error) {
+	row, err := c.r.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		c.line++
+		return nil, fmt.Errorf("read csv: %w", err)
+	}
+	// csv.Reader skips blank lines, so the position comes from the reader itself
+	c.line, _ = c.r.FieldPos(0)
+	return row, nil
+}
+
+func (c *csvReader) Line() int { return c.line }
+
+func (c *csvReader) Close() error { return nil }
+
+type xlsxReader struct {
+	file *excelize.File
+	rows *excelize.Rows
+	line int
+}
+
+func newXLSXReader(r io.Reader) (*xlsxReader, error) {
+	f, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("open xlsx: %w", err)
+	}
+	sheets := f.GetSheetList()
+	if len(sheets) == 0 {
+		_ = f.Close()
+		return nil, errors.New("xlsx has no sheet")
+	}
+	name := f.GetSheetName(f.GetActiveSheetIndex())
+	if name == "" {
+		name = sheets[0]
+	}
+	rows, err := f.Rows(name)
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("read xlsx sheet %q: %w", name, err)
+	}
+	return &xlsxReader{file: f, rows: rows}, nil
+}
+
+func (x *xlsxReader) Next() ([]string, error) {
+	if !x.rows.Next() {
+		if err := x.rows.Error(); err != nil {
+			return nil, fmt.Errorf("read xlsx: %w", err)
+		}
+		return nil, io.EOF
+	}
+	x.line++
+	return x.rows.Columns()
+}
+
+func (x *xlsxReader) Line() int { return x.line }
+
+func (x *xlsxReader) Close() error {
+	err := x.rows.Close()
+	if cerr := x.file.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"context"
+
+	"event_manager/internal/domain/entity"
+)
+
+type authUserKey struct{}
+
+// ContextWithUser gắn user đã xác thực vào context của request
+func ContextWithUser(ctx context.Context, user *entity.User) context.Context {
+	return context.WithValue(ctx, authUserKey{}, user)
+}
+
+// UserFromContext lấy user đã xác thực (nil nếu request chưa đăng nhập)
+func UserFromContext(ctx context.Context) *entity.User {
+	if ctx == nil {
+		return nil
+	}
+	user, _ := ctx.Value(authUserKey{}).(*entity.User)
+	return user
+}
+
+type kioskSessionKey struct{}
+
+// ContextWithKiosk gắn phiên kiosk đã xác thực vào context của request
+func ContextWithKiosk(ctx context.Context, kiosk *entity.KioskSession) context.Context {
+	return context.WithValue(ctx, kioskSessionKey{}, kiosk)
+}
+
+// KioskFromContext lấy phiên kiosk đã xác thực (nil nếu request không đến từ kiosk)
+func KioskFromContext(ctx context.Context) *entity.KioskSession {
+	if ctx == nil {
+		return nil
+	}
+	kiosk, _ := ctx.Value(kioskSessionKey{}).(*entity.KioskSession)
+	return kiosk
+}
@@ -3,6 +3,7 @@ package utils
 import (
 	"crypto/rand"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"time"
 
@@ -22,16 +23,36 @@ func GenToken(secret string, length int) (string, error) {
 	return token, nil
 }
 
-func GenJWT(secret string, userID int) (string, error) {
+// GenJWT ký access token HS256 cho userID, hết hạn sau ttl
+func GenJWT(secret string, userID string, ttl time.Duration) (string, error) {
+	now := time.Now()
 	claims := jwt.MapClaims{
 		"sub": userID,              // subject = userID
 		"jti": uuid.New().String(), // unique token ID
 		// "device_id": deviceID,            // gắn thông tin thiết bị
-		// "roles":     roles,                                // vai trò/quyền
-		"iat": time.Now().Unix(),                    // issued at
-		"exp": time.Now().Add(time.Hour * 1).Unix(), // hết hạn sau 1h
+		"iat": now.Unix(),          // issued at
+		"exp": now.Add(ttl).Unix(), // hết hạn sau ttl
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString([]byte(secret))
 }
+
+// ParseJWT kiểm tra chữ ký + hạn dùng của access token và trả về userID (sub)
+func ParseJWT(secret string, tokenString string) (string, error) {
+	token, err := jwt.Parse(tokenString, func(t *jwt.Token) (interface{}, error) {
+		return []byte(secret), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return "", err
+	}
+
+	sub, err := token.Claims.GetSubject()
+	if err != nil {
+		return "", err
+	}
+	if sub == "" {
+		return "", errors.New("token has no subject")
+	}
+	return sub, nil
+}
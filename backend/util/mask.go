@@ -0,0 +1,45 @@
+package utils
+
+import "strings"
+
+// MaskName giữ chữ cái đầu của mỗi từ: "Nguyễn Văn An" -> "N***** V** A*"
+func MaskName(name string) string {
+	words := strings.Fields(name)
+	for i, w := range words {
+		r := []rune(w)
+		words[i] = string(r[0]) + strings.Repeat("*", len(r)-1)
+	}
+	return strings.Join(words, " ")
+}
+
+// MaskEmail giữ 2 ký tự đầu của tên, ký tự đầu của tên miền và đuôi: "nguyenvana@gmail.com" -> "ng********@g****.com"
+func MaskEmail(email string) string {
+	local, domain, ok := strings.Cut(strings.TrimSpace(email), "@")
+	if !ok {
+		return maskTail(email, 2)
+	}
+	host, tld := domain, ""
+	if i := strings.LastIndexByte(domain, '.'); i > 0 {
+		host, tld = domain[:i], domain[i:]
+	}
+	return maskTail(local, 2) + "@" + maskTail(host, 1) + tld
+}
+
+// MaskPhone chỉ giữ 2 số cuối ở dạng quốc nội: "+84901234567" -> "********67".
+// Kiosk xác nhận bằng 4 số cuối nên không được hiện nhiều hơn 2 số
+func MaskPhone(phone string) string {
+	digits := PhoneSearchKey(phone)
+	if len(digits) <= 2 {
+		return strings.Repeat("*", len(digits))
+	}
+	return strings.Repeat("*", len(digits)-2) + digits[len(digits)-2:]
+}
+
+// maskTail giữ keep ký tự đầu, thay phần còn lại bằng "*"
+func maskTail(s string, keep int) string {
+	r := []rune(s)
+	if len(r) <= keep {
+		keep = len(r) / 2
+	}
+	return string(r[:keep]) + strings.Repeat("*", len(r)-keep)
+}
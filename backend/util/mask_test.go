@@ -0,0 +1,22 @@
+package utils
+
+import "testing"
+
+func TestMaskPhone(t *testing.T) {
+	tests := []struct {
+		phone string
+		want  string
+	}{
+		{"+84901234567", "********67"},
+		{"0901 234 567", "********67"},
+		{"+14155552671", "********71"},
+		{"12", "**"},
+		{"7", "*"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := MaskPhone(tt.phone); got != tt.want {
+			t.Errorf("MaskPhone(%q) = %q, want %q", tt.phone, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nyaruka/phonenumbers"
+)
+
+// ErrInvalidPhone được trả về khi không chuẩn hoá được số điện thoại.
+var ErrInvalidPhone = errors.New("invalid phone number")
+
+// PhoneRegion kiểm tra mã vùng mặc định (ISO 3166-1, vd "VN") dùng cho số không có mã quốc gia.
+func PhoneRegion(region string) (string, error) {
+	region = strings.ToUpper(strings.TrimSpace(region))
+	if phonenumbers.GetCountryCodeForRegion(region) == 0 {
+		return "", fmt.Errorf("unknown phone region %q", region)
+	}
+	return region, nil
+}
+
+// NormalizePhone đưa số điện thoại về dạng E.164; số không có "+" được hiểu theo region.
+// "0901 234 567", "+84 901 234 567" và "0084901234567" -> "+84901234567"
+func NormalizePhone(raw, region string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if strings.HasPrefix(raw, "00") {
+		raw = "+" + raw[2:]
+	}
+	num, err := phonenumbers.Parse(raw, region)
+	if err != nil || !phonenumbers.IsPossibleNumber(num) {
+		return "", fmt.Errorf("%w %q", ErrInvalidPhone, raw)
+	}
+	return phonenumbers.Format(num, phonenumbers.E164), nil
+}
+
+// PhoneSearchKey là các chữ số của số điện thoại ở dạng quốc nội ("+84901234567" -> "0901234567"),
+// để tìm theo tiền tố giống cách người dùng thường gõ; số chưa chuẩn hoá chỉ giữ chữ số.
+func PhoneSearchKey(phone string) string {
+	if strings.HasPrefix(phone, "+") {
+		if num, err := phonenumbers.Parse(phone, ""); err == nil {
+			return DigitsOnly(phonenumbers.Format(num, phonenumbers.NATIONAL))
+		}
+	}
+	return DigitsOnly(phone)
+}
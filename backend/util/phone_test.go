@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		raw     string
+		region  string
+		want    string
+		wantErr bool
+	}{
+		{"0901 234 567", "VN", "+84901234567", false},
+		{"+84 901 234 567", "VN", "+84901234567", false},
+		{"0084901234567", "VN", "+84901234567", false},
+		{"  0901-234-567 ", "VN", "+84901234567", false},
+		{"+84901234567", "US", "+84901234567", false},
+		{"(415) 555-2671", "US", "+14155552671", false},
+		{"12", "VN", "", true},
+		{"abc", "VN", "", true},
+		{"", "VN", "", true},
+	}
+	for _, tt := range tests {
+		got, err := NormalizePhone(tt.raw, tt.region)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidPhone) {
+				t.Errorf("NormalizePhone(%q, %q) error = %v, want ErrInvalidPhone", tt.raw, tt.region, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("NormalizePhone(%q, %q) = %q, %v, want %q", tt.raw, tt.region, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ticketTokenType phân biệt vé với các JWT khác ký cùng thuật toán
+const ticketTokenType = "ticket"
+
+// TicketClaims là nội dung của vé điện tử gắn với một đăng ký
+type TicketClaims struct {
+	RegistrationID string
+	EventID        string
+	Version        int // phải khớp ticket_version của đăng ký lúc quét
+	IssuedAt       time.Time
+	ExpiresAt      time.Time // zero: không hết hạn
+}
+
+// GenTicketJWT ký vé HS256. Hiệu lực còn phụ thuộc trạng thái và version vé của đăng ký lúc quét.
+// Claim được giữ ngắn để mã QR nhỏ, dễ quét; version 0 (vé cũ) không ghi "ver".
+func GenTicketJWT(secret string, c TicketClaims) (string, error) {
+	claims := jwt.MapClaims{
+		"typ": ticketTokenType,
+		"sub": c.RegistrationID,
+		"evt": c.EventID,
+		"iat": c.IssuedAt.Unix(),
+	}
+	if c.Version != 0 {
+		claims["ver"] = c.Version
+	}
+	if !c.ExpiresAt.IsZero() {
+		claims["exp"] = c.ExpiresAt.Unix()
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(secret))
+}
+
+// ParseTicketJWT kiểm tra chữ ký và hạn dùng của vé rồi trả về nội dung
+func ParseTicketJWT(secret string, tokenString string) (*TicketClaims, error) {
+	token, err := jwt.Parse(tokenString, func(t *jwt.Token) (interface{}, error) {
+		return []byte(secret), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithIssuedAt())
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || claims["typ"] != ticketTokenType {
+		return nil, errors.New("token is not a ticket")
+	}
+	sub, _ := claims.GetSubject()
+	evt, _ := claims["evt"].(string)
+	if sub == "" || evt == "" {
+		return nil, errors.New("ticket has no registration or event")
+	}
+	c := &TicketClaims{RegistrationID: sub, EventID: evt}
+	if ver, ok := claims["ver"].(float64); ok {
+		c.Version = int(ver)
+	}
+	if iat, err := claims.GetIssuedAt(); err == nil && iat != nil {
+		c.IssuedAt = iat.Time
+	}
+	if exp, err := claims.GetExpirationTime(); err == nil && exp != nil {
+		c.ExpiresAt = exp.Time
+	}
+	return c, nil
+}
@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestTicketJWTRoundTrip(t *testing.T) {
+	const secret = "ticket-secret-for-tests-0123456789"
+	issued := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	tests := []struct {
+		name   string
+		claims TicketClaims
+	}{
+		{"legacy ticket", TicketClaims{RegistrationID: "r1", EventID: "e1", IssuedAt: issued}},
+		{"reissued ticket", TicketClaims{RegistrationID: "r2", EventID: "e2", Version: 3, IssuedAt: issued}},
+		{"expiring ticket", TicketClaims{RegistrationID: "r3", EventID: "e3", Version: 1, IssuedAt: issued, ExpiresAt: issued.Add(48 * time.Hour)}},
+	}
+	for _, tt := range tests {
+		token, err := GenTicketJWT(secret, tt.claims)
+		if err != nil {
+			t.Fatalf("%s: sign: %v", tt.name, err)
+		}
+		got, err := ParseTicketJWT(secret, token)
+		if err != nil {
+			t.Fatalf("%s: parse: %v", tt.name, err)
+		}
+		if !got.IssuedAt.Equal(tt.claims.IssuedAt) || !got.ExpiresAt.Equal(tt.claims.ExpiresAt) {
+			t.Errorf("%s: times = %v / %v, want %v / %v", tt.name, got.IssuedAt, got.ExpiresAt, tt.claims.IssuedAt, tt.claims.ExpiresAt)
+		}
+		got.IssuedAt, got.ExpiresAt = tt.claims.IssuedAt, tt.claims.ExpiresAt
+		if *got != tt.claims {
+			t.Errorf("%s: got %+v, want %+v", tt.name, *got, tt.claims)
+		}
+	}
+}
+
+func TestParseTicketJWTRejects(t *testing.T) {
+	const secret = "ticket-secret-for-tests-0123456789"
+	now := time.Now()
+	valid := TicketClaims{RegistrationID: "r1", EventID: "e1", IssuedAt: now.Add(-time.Hour)}
+
+	sign := func(c TicketClaims, key string) string {
+		token, err := GenTicketJWT(key, c)
+		if err != nil {
+			t.Fatalf("sign: %v", err)
+		}
+		return token
+	}
+	expired := valid
+	expired.ExpiresAt = now.Add(-time.Minute)
+	notTicket, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"typ": "access", "sub": "r1", "evt": "e1", "iat": now.Unix(),
+	}).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("sign access token: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"wrong secret", sign(valid, "another-secret")},
+		{"expired", sign(expired, secret)},
+		{"not a ticket", notTicket},
+		{"missing event", sign(TicketClaims{RegistrationID: "r1", IssuedAt: valid.IssuedAt}, secret)},
+		{"garbage", "not-a-jwt"},
+	}
+	for _, tt := range tests {
+		if _, err := ParseTicketJWT(secret, tt.token); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+class ReviewModel {
+  final String id;
+  final String eventId;
+  final String guestId;
+  final String guestName;
+  final double rating;
+  final String comment;
+  final DateTime createdAt;
+
+  const ReviewModel({
+    required this.id,
+    required this.eventId,
+    required this.guestId,
+    required this.guestName,
+    required this.rating,
+    required this.comment,
+    required this.createdAt,
+  });
+
+  factory ReviewModel.fromJson(Map<String, dynamic> json) {
+    return ReviewModel(
+      id: (json['id'] ?? '').toString(),
+      eventId: (json['event_id'] ?? '').toString(),
+      guestId: (json['guest_id'] ?? '').toString(),
+      guestName: (json['guest_name'] ?? '').toString(),
+      rating: (json['rating'] as num?)?.toDouble() ?? 0,
+      comment: (json['comment'] ?? '').toString(),
+      createdAt:
+          DateTime.tryParse(json['created_at']?.toString() ?? '')?.toLocal() ??
+          DateTime.now(),
+    );
+  }
+}
+
+class ReviewSummaryModel {
+  final double averageRating;
+  final int count;
+  final List<ReviewModel> reviews;
+
+  const ReviewSummaryModel({
+    required this.averageRating,
+    required this.count,
+    required this.reviews,
+  });
+
+  static const empty = ReviewSummaryModel(
+    averageRating: 0,
+    count: 0,
+    reviews: [],
+  );
+
+  factory ReviewSummaryModel.fromJson(Map<String, dynamic> json) {
+    final raw = json['reviews'];
+    return ReviewSummaryModel(
+      averageRating: (json['average_rating'] as num?)?.toDouble() ?? 0,
+      count: (json['count'] as num?)?.toInt() ?? 0,
+      reviews: raw is List
+          ? raw
+                .whereType<Map>()
+                .map((e) => ReviewModel.fromJson(Map<String, dynamic>.from(e)))
+                .toList()
+          : const [],
+    );
+  }
+}
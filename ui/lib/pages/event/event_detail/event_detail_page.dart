@@ -20,7 +20,10 @@ import 'widgets/share_event_sheet.dart';
 class EventDetailPage extends StatefulWidget {
   final EventModel event;
 
-  const EventDetailPage({super.key, required this.event});
+  /// Guest a review is written for, when the page is opened from a guest.
+  final String? guestId;
+
+  const EventDetailPage({super.key, required this.event, this.guestId});
 
   @override
   State<EventDetailPage> createState() => _EventDetailPageState();
@@ -253,7 +256,12 @@ class _EventDetailPageState extends State<EventDetailPage> {
               const SizedBox(height: 28),
               EventGallery(gallery: gallery),
               const SizedBox(height: 28),
-              EventReviews(status: status, eventColor: Colors.blueAccent),
+              EventReviews(
+                eventId: e.id,
+                status: status,
+                eventColor: Colors.blueAccent,
+                guestId: widget.guestId,
+              ),
               const SizedBox(height: 40),
             ],
           ),
@@ -1,17 +1,31 @@
 import 'package:flutter/material.dart';
+import 'package:provider/provider.dart';
+
 import '../../../../../l10n/app_localizations.dart';
+import '../../../../models/guest_model.dart';
+import '../../../../models/review_model.dart';
+import '../../../../providers/auth_provider.dart';
+import '../../../../services/guest_api_service.dart';
+import '../../../../services/review_api_service.dart';
 
 import 'rating_stars.dart';
 import 'review_item.dart';
 
 class EventReviews extends StatefulWidget {
+  final String eventId;
   final String status;
   final Color eventColor;
 
+  /// Guest the review is submitted for. When null the organizer picks the
+  /// guest in the form; the API rejects guests who have not checked in.
+  final String? guestId;
+
   const EventReviews({
     super.key,
+    required this.eventId,
     required this.status,
     required this.eventColor,
+    this.guestId,
   });
 
   @override
@@ -23,33 +37,79 @@ class _EventReviewsState extends State<EventReviews> {
   double _newRating = 0;
   bool _showAllReviews = false;
 
-  // Mock data - in a real app, this would come from an API and be localized
-  final List<Map<String, dynamic>> _reviews = [
-    {
-      "name": "Nguyễn Văn A",
-      "rating": 4.5,
-      "comment": "Không gian tổ chức chuyên nghiệp, rất ấn tượng!",
-      "date": DateTime(2025, 10, 12),
-    },
-    {
-      "name": "Trần Thị B",
-      "rating": 5.0,
-      "comment": "Mọi thứ tuyệt vời, mong có thêm nhiều sự kiện như thế này.",
-      "date": DateTime(2025, 10, 13),
-    },
-    {
-      "name": "Lê Văn C",
-      "rating": 3.5,
-      "comment": "Ổn nhưng phần âm thanh hơi nhỏ.",
-      "date": DateTime(2025, 10, 11),
-    },
-    {
-      "name": "Phạm Duy",
-      "rating": 4.0,
-      "comment": "Rất vui và ý nghĩa!",
-      "date": DateTime(2025, 10, 10),
-    },
-  ];
+  late final ReviewApiService _api;
+  ReviewSummaryModel _summary = ReviewSummaryModel.empty;
+  bool _submitting = false;
+
+  List<GuestModel> _guests = const [];
+  String? _selectedGuestId;
+
+  @override
+  void initState() {
+    super.initState();
+    _api = ReviewApiService(
+      accessToken: context.read<AuthProvider>().accessToken,
+    );
+    _selectedGuestId = widget.guestId;
+    _loadReviews();
+    if (widget.guestId == null) _loadGuests();
+  }
+
+  @override
+  void dispose() {
+    _commentController.dispose();
+    super.dispose();
+  }
+
+  Future<void> _loadReviews() async {
+    try {
+      final summary = await _api.getReviews(widget.eventId);
+      if (!mounted) return;
+      setState(() => _summary = summary);
+    } catch (_) {
+      // Keep the section empty when reviews cannot be loaded (e.g. offline).
+    }
+  }
+
+  Future<void> _loadGuests() async {
+    try {
+      final checkedIn = await _api.getCheckedInGuestIds(widget.eventId);
+      if (checkedIn.isEmpty) return;
+      final guests = await GuestApiService().getGuests();
+      if (!mounted) return;
+      setState(() {
+        _guests = guests.where((g) => checkedIn.contains(g.id)).toList();
+      });
+    } catch (_) {
+      // Without the guest list the form stays hidden.
+    }
+  }
+
+  Future<void> _submitReview() async {
+    final guestId = _selectedGuestId;
+    if (guestId == null || _newRating < 1 || _submitting) return;
+
+    setState(() => _submitting = true);
+    try {
+      await _api.createReview(
+        eventId: widget.eventId,
+        guestId: guestId,
+        rating: _newRating,
+        comment: _commentController.text.trim(),
+      );
+      _commentController.clear();
+      _newRating = 0;
+      await _loadReviews();
+    } catch (e) {
+      if (mounted) {
+        ScaffoldMessenger.of(
+          context,
+        ).showSnackBar(SnackBar(content: Text(e.toString())));
+      }
+    } finally {
+      if (mounted) setState(() => _submitting = false);
+    }
+  }
 
   @override
   Widget build(BuildContext context) {
@@ -57,13 +117,13 @@ class _EventReviewsState extends State<EventReviews> {
     final color = Theme.of(context).colorScheme;
     final text = Theme.of(context).textTheme;
 
-    final avg = _reviews.isNotEmpty
-        ? _reviews.map((r) => r["rating"]).reduce((a, b) => a + b) /
-              _reviews.length
-        : 0.0;
-    final visible = _showAllReviews ? _reviews : _reviews.take(3).toList();
+    final reviews = _summary.reviews;
+    final avg = _summary.averageRating;
+    final visible = _showAllReviews ? reviews : reviews.take(3).toList();
 
-    final isReviewFormVisible = widget.status == l10n.completed;
+    final isReviewFormVisible =
+        widget.status == l10n.completed &&
+        (widget.guestId != null || _guests.isNotEmpty);
 
     return Column(
       crossAxisAlignment: CrossAxisAlignment.start,
@@ -93,7 +153,7 @@ class _EventReviewsState extends State<EventReviews> {
                   ),
                   const SizedBox(width: 6),
                   Text(
-                    l10n.xReviews(_reviews.length),
+                    l10n.xReviews(_summary.count),
                     style: text.bodyMedium?.copyWith(
                       color: color.onSurfaceVariant,
                     ),
@@ -104,10 +164,17 @@ class _EventReviewsState extends State<EventReviews> {
               ...visible.map(
                 (r) => Padding(
                   padding: const EdgeInsets.only(bottom: 12),
-                  child: ReviewItem(review: r),
+                  child: ReviewItem(
+                    review: {
+                      "name": r.guestName,
+                      "rating": r.rating,
+                      "comment": r.comment,
+                      "date": r.createdAt,
+                    },
+                  ),
                 ),
               ),
-              if (!_showAllReviews && _reviews.length > 3)
+              if (!_showAllReviews && reviews.length > 3)
                 Align(
                   alignment: Alignment.center,
                   child: TextButton(
@@ -122,6 +189,27 @@ class _EventReviewsState extends State<EventReviews> {
                   style: text.titleSmall?.copyWith(fontWeight: FontWeight.w600),
                 ),
                 const SizedBox(height: 8),
+                if (widget.guestId == null) ...[
+                  DropdownButtonFormField<String>(
+                    value: _selectedGuestId,
+                    decoration: InputDecoration(
+                      labelText: l10n.guest,
+                      border: OutlineInputBorder(
+                        borderRadius: BorderRadius.circular(12),
+                      ),
+                    ),
+                    items: _guests
+                        .map(
+                          (g) => DropdownMenuItem(
+                            value: g.id,
+                            child: Text(g.fullName),
+                          ),
+                        )
+                        .toList(),
+                    onChanged: (id) => setState(() => _selectedGuestId = id),
+                  ),
+                  const SizedBox(height: 8),
+                ],
                 RatingStars(
                   rating: _newRating,
                   onRate: (r) => setState(() => _newRating = r),
@@ -141,7 +229,9 @@ class _EventReviewsState extends State<EventReviews> {
                 Align(
                   alignment: Alignment.centerRight,
                   child: FilledButton(
-                    onPressed: () {},
+                    onPressed: _submitting || _selectedGuestId == null
+                        ? null
+                        : _submitReview,
                     child: Text(l10n.submitReview),
                   ),
                 ),
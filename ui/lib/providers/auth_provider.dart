@@ -3,28 +3,41 @@ import 'package:shared_preferences/shared_preferences.dart';
 
 class AuthProvider with ChangeNotifier {
   bool _isLoggedIn = false;
+  String? _accessToken;
   final SharedPreferences _prefs;
 
   bool get isLoggedIn => _isLoggedIn;
 
+  /// Bearer token sent to endpoints behind the API's auth middleware.
+  String? get accessToken => _accessToken;
+
   AuthProvider(this._prefs) {
     _loadLoginState();
   }
 
   void _loadLoginState() {
     _isLoggedIn = _prefs.getBool('isLoggedIn') ?? false;
+    _accessToken = _prefs.getString('accessToken');
     notifyListeners();
   }
 
-  Future<void> login() async {
+  Future<void> login({String? accessToken}) async {
     _isLoggedIn = true;
+    _accessToken = accessToken;
     await _prefs.setBool('isLoggedIn', true);
+    if (accessToken != null) {
+      await _prefs.setString('accessToken', accessToken);
+    } else {
+      await _prefs.remove('accessToken');
+    }
     notifyListeners();
   }
 
   Future<void> logout() async {
     _isLoggedIn = false;
+    _accessToken = null;
     await _prefs.setBool('isLoggedIn', false);
+    await _prefs.remove('accessToken');
     notifyListeners();
   }
 }
@@ -0,0 +1,95 @@
+import 'package:dio/dio.dart';
+import 'package:pretty_dio_logger/pretty_dio_logger.dart';
+
+import '../models/review_model.dart';
+
+class ReviewApiService {
+  final Dio _dio;
+
+  /// [accessToken] is sent as `Authorization: Bearer` since every review
+  /// route sits behind the API's auth middleware.
+  ReviewApiService({Dio? dio, String? accessToken})
+    : _dio =
+          dio ??
+                Dio(
+                  BaseOptions(
+                    baseUrl: 'http://10.0.2.2:8080/api/v1',
+                    connectTimeout: const Duration(seconds: 8),
+                    receiveTimeout: const Duration(seconds: 8),
+                    responseType: ResponseType.json,
+                  ),
+                )
+            ..interceptors.add(
+              PrettyDioLogger(
+                requestHeader: true,
+                requestBody: true,
+                responseHeader: false,
+                responseBody: true,
+                error: true,
+                compact: true,
+                maxWidth: 90,
+              ),
+            ) {
+    if (accessToken != null && accessToken.isNotEmpty) {
+      _dio.options.headers['Authorization'] = 'Bearer $accessToken';
+    }
+  }
+
+  Future<ReviewSummaryModel> getReviews(String eventId) async {
+    try {
+      final response = await _dio.get('/events/$eventId/reviews');
+      final data = response.data?['data'];
+      if (data is! Map) return ReviewSummaryModel.empty;
+      return ReviewSummaryModel.fromJson(Map<String, dynamic>.from(data));
+    } on DioException catch (e) {
+      final message = e.response?.data?['error'] ?? e.message ?? 'Unknown';
+      throw Exception('Failed to load reviews: $message');
+    }
+  }
+
+  /// IDs of guests who checked in to [eventId], the only ones allowed to review.
+  Future<Set<String>> getCheckedInGuestIds(String eventId) async {
+    try {
+      final response = await _dio.get(
+        '/registrations/',
+        queryParameters: {'event_id': eventId},
+      );
+      final data = response.data?['data'];
+      if (data is! List) return const {};
+      return data
+          .whereType<Map>()
+          .where((r) => r['checked_in'] == true)
+          .map((r) => r['guest_id'].toString())
+          .toSet();
+    } on DioException catch (e) {
+      final message = e.response?.data?['error'] ?? e.message ?? 'Unknown';
+      throw Exception('Failed to load registrations: $message');
+    }
+  }
+
+  Future<ReviewModel> createReview({
+    required String eventId,
+    required String guestId,
+    required double rating,
+    String? comment,
+  }) async {
+    try {
+      final response = await _dio.post(
+        '/events/$eventId/reviews',
+        data: {
+          'guest_id': guestId,
+          'rating': rating,
+          'comment': comment ?? '',
+        },
+      );
+      final data = response.data?['data'];
+      if (data is! Map) {
+        throw const FormatException('Invalid response from server');
+      }
+      return ReviewModel.fromJson(Map<String, dynamic>.from(data));
+    } on DioException catch (e) {
+      final message = e.response?.data?['error'] ?? e.message ?? 'Unknown';
+      throw Exception('Failed to submit review: $message');
+    }
+  }
+}